@@ -2,34 +2,64 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
 	"net"
+	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
-	"sync"
+	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 
 	"github.com/jyogi-web/2025_Ptera/backend/pkg/ai"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/authn"
 	"github.com/jyogi-web/2025_Ptera/backend/pkg/battle"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/card"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/cardqr"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/circle"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/collection"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/fairness"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/gacha"
 	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
 	"github.com/jyogi-web/2025_Ptera/backend/pkg/infra"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/moderation"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/quota"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/render"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/storage"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/trade"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/user"
 )
 
 const (
-	defaultPort = 50051
+	defaultPort       = 50051
+	defaultStorageDir = "data/images"
+	defaultRenderDir  = "data/render-cache"
 )
 
 type server struct {
 	ptera.UnimplementedPteraServiceServer
-	mu        sync.RWMutex
-	users     map[string]*ptera.User
-	aiService *ai.GeminiService
+	aiService       ai.CardCompleter
+	imageLoader     *ai.ImageLoader
+	imageStore      storage.Store
+	suggestionCache *ai.SuggestionCache
+	authenticator   *authn.Authenticator
+	quotaService    *quota.Service
+	moderator       *moderation.Moderator
+	cardService     *card.Service
+	renderService   *render.Service
+	cardQRService   *cardqr.Service
+	logger          *slog.Logger
 }
 
 func main() {
@@ -49,17 +79,76 @@ func main() {
 }
 
 func run(logger *slog.Logger) error {
+	// Create AI Service (AI_PROVIDER=gemini|offline, offline when GEMINI_API_KEY is not set)
 	apiKey := os.Getenv("GEMINI_API_KEY")
-	if apiKey == "" {
-		return fmt.Errorf("GEMINI_API_KEY environment variable is not set")
+	aiProvider := os.Getenv("AI_PROVIDER")
+	aiService, err := ai.NewCardCompleter(context.Background(), aiProvider, apiKey)
+	if err != nil {
+		return fmt.Errorf("failed to create ai service: %w", err)
+	}
+	defer aiService.Close()
+	if _, ok := aiService.(*ai.OfflineService); ok {
+		logger.Warn("using the offline AI provider; card completion is rule-based")
+	}
+
+	// Files are served over HTTP on the gRPC port, at URLs under PUBLIC_BASE_URL (the address clients reach the server at)
+	publicBaseURL := strings.TrimSuffix(os.Getenv("PUBLIC_BASE_URL"), "/")
+	if publicBaseURL == "" {
+		return fmt.Errorf("PUBLIC_BASE_URL is required (e.g. http://localhost:%d for local development)", defaultPort)
+	}
+	if u, err := url.Parse(publicBaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid PUBLIC_BASE_URL: %q", publicBaseURL)
+	}
+
+	// Create Image Storage (local filesystem, served over HTTP)
+	storageDir := os.Getenv("STORAGE_LOCAL_DIR")
+	if storageDir == "" {
+		storageDir = defaultStorageDir
+	}
+	storagePublicURL := os.Getenv("STORAGE_PUBLIC_URL")
+	if storagePublicURL == "" {
+		storagePublicURL = publicBaseURL + "/images"
+	}
+	imageStore, err := storage.NewLocalStore(storageDir, storagePublicURL)
+	if err != nil {
+		return fmt.Errorf("failed to create image storage: %w", err)
 	}
 
-	// Create Gemini Service
-	geminiService, err := ai.NewGeminiService(context.Background(), apiKey)
+	// Create Image Loader (uploaded images or allowed storage hosts, normalized before AI analysis)
+	imageHosts, err := ai.ParseImageHosts(os.Getenv("IMAGE_ALLOWED_HOSTS"))
+	if err != nil {
+		return fmt.Errorf("invalid IMAGE_ALLOWED_HOSTS: %w", err)
+	}
+	imageFetcher := ai.NewImageFetcher(imageHosts)
+	imageMaxEdge := ai.DefaultImageMaxEdge
+	if maxEdge := os.Getenv("IMAGE_MAX_EDGE"); maxEdge != "" {
+		parsed, err := strconv.Atoi(maxEdge)
+		if err != nil || parsed <= 0 {
+			return fmt.Errorf("invalid IMAGE_MAX_EDGE: %q", maxEdge)
+		}
+		imageMaxEdge = parsed
+	}
+	imageLoader := ai.NewImageLoader(imageFetcher, imageStore, ai.NewImagePipeline(imageMaxEdge))
+
+	// Create Moderator (model safety ratings + Japanese blocklist, checked before and after generation)
+	moderationPolicy := moderation.DefaultPolicy()
+	if threshold := os.Getenv("MODERATION_THRESHOLD"); threshold != "" {
+		moderationPolicy.Threshold = threshold
+	}
+	if os.Getenv("MODERATION_DEFAULT_BLOCKLIST") == "false" {
+		moderationPolicy.DefaultBlocklist = false
+	}
+	if path := os.Getenv("MODERATION_BLOCKLIST_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read MODERATION_BLOCKLIST_FILE: %w", err)
+		}
+		moderationPolicy.Blocklist = moderation.ParseBlocklist(string(data))
+	}
+	moderator, err := moderation.NewModerator(moderationPolicy)
 	if err != nil {
-		return fmt.Errorf("failed to create gemini service: %w", err)
+		return fmt.Errorf("invalid moderation policy: %w", err)
 	}
-	defer geminiService.Close()
 
 	// Create Firestore Client
 	firestoreClient, err := infra.NewFirestoreClient(context.Background())
@@ -68,11 +157,154 @@ func run(logger *slog.Logger) error {
 	}
 	defer firestoreClient.Close()
 
+	// Create Suggestion Cache (AI_CACHE_STORE=memory|firestore, memory by default)
+	var suggestionStore ai.SuggestionStore
+	switch cacheStore := os.Getenv("AI_CACHE_STORE"); cacheStore {
+	case "", "memory":
+		suggestionStore = ai.NewMemorySuggestionStore(0)
+	case "firestore":
+		suggestionStore = ai.NewFirestoreSuggestionStore(firestoreClient)
+	default:
+		return fmt.Errorf("invalid AI_CACHE_STORE: %q", cacheStore)
+	}
+	cacheTTL := ai.DefaultSuggestionCacheTTL
+	if ttl := os.Getenv("AI_CACHE_TTL"); ttl != "" {
+		parsed, err := time.ParseDuration(ttl)
+		if err != nil || parsed <= 0 {
+			return fmt.Errorf("invalid AI_CACHE_TTL: %q", ttl)
+		}
+		cacheTTL = parsed
+	}
+	suggestionCache := ai.NewSuggestionCache(suggestionStore, cacheTTL)
+
+	userRepo := user.NewRepository(firestoreClient)
+
+	// Create Collection Repository (the only writer of user_cards, enforces trade locks)
+	userCardRepo := collection.NewRepository(firestoreClient)
+
+	// Create Authenticator (callers are identified by their Firebase ID token)
+	authClient, err := infra.NewAuthClient(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to create firebase auth client: %w", err)
+	}
+	authenticator := authn.NewAuthenticator(authClient)
+
+	// Create Quota Service (AI budgets per user and circle, admin RPCs for ADMIN_USER_IDS)
+	quotaConfig := quota.DefaultConfig()
+	if limits := os.Getenv("AI_QUOTA_LIMITS"); limits != "" {
+		parsed, err := quota.ParseLimits(limits, quotaConfig)
+		if err != nil {
+			return fmt.Errorf("invalid AI_QUOTA_LIMITS: %w", err)
+		}
+		quotaConfig = parsed
+	}
+	quotaRepo := quota.NewRepository(firestoreClient)
+	quotaService := quota.NewService(logger, quotaRepo, userRepo, quotaConfig, quota.ParseAdminIDs(os.Getenv("ADMIN_USER_IDS")), authenticator)
+
 	// Create Battle Service
+	fairnessRepo := fairness.NewRepository(firestoreClient)
 	battleRepo := battle.NewRepository(firestoreClient)
-	cardRepo := battle.NewCardRepository(firestoreClient)
+	cardRepo := battle.NewCardRepository(firestoreClient, userCardRepo)
 	enableMockFallback := os.Getenv("ENABLE_MOCK_FALLBACK") == "true"
-	battleService := battle.NewService(logger, battleRepo, cardRepo, enableMockFallback)
+	// Battles with commentary use templates, narrated by AI in the background when AI_COMMENTARY_ENABLED=true
+	// (counted against the AI quota of the circle that started the battle)
+	var commentator battle.Commentator
+	if os.Getenv("AI_COMMENTARY_ENABLED") == "true" {
+		commentator = aiService
+	}
+	battleService := battle.NewService(logger, battleRepo, cardRepo, fairnessRepo, userRepo, commentator, quotaService, moderator, enableMockFallback)
+
+	// Create Gacha Service
+	gachaConfig := gacha.DefaultConfig()
+	if weights := os.Getenv("GACHA_RARITY_WEIGHTS"); weights != "" {
+		parsed, err := gacha.ParseRarityWeights(weights)
+		if err != nil {
+			return fmt.Errorf("invalid GACHA_RARITY_WEIGHTS: %w", err)
+		}
+		gachaConfig.Weights = parsed
+	}
+	if threshold := os.Getenv("GACHA_PITY_THRESHOLD"); threshold != "" {
+		parsed, err := strconv.Atoi(threshold)
+		if err != nil || parsed < 0 {
+			return fmt.Errorf("invalid GACHA_PITY_THRESHOLD: %q", threshold)
+		}
+		gachaConfig.PityThreshold = parsed
+	}
+	gachaRepo := gacha.NewRepository(firestoreClient, userCardRepo)
+	gachaService := gacha.NewService(logger, gachaRepo, cardRepo, userRepo, fairnessRepo, gachaConfig)
+
+	// Create Fairness Service (commit-reveal verification of battle and gacha rolls)
+	fairnessService := fairness.NewService(logger, fairnessRepo, map[string]fairness.Verifier{
+		fairness.PurposeBattle: battleService,
+		fairness.PurposeGacha:  gachaService,
+	})
+
+	// Create Card Service (CRUD part of PteraService)
+	// AI-generated flavor texts are optional (AI_FLAVOR_ENABLED=true)
+	var flavorGenerator card.FlavorGenerator
+	if os.Getenv("AI_FLAVOR_ENABLED") == "true" {
+		flavorGenerator = aiService
+	}
+	cardService := card.NewService(logger, cardRepo, userRepo, flavorGenerator, quotaService, imageStore, moderator)
+
+	// Create Render Service (shareable card PNGs)
+	// Rendered cards are cached in their own directory: unlike uploads they are replaced when the card is edited,
+	// so they must not be served under /images with an immutable Cache-Control
+	renderDir := os.Getenv("RENDER_CACHE_DIR")
+	if renderDir == "" {
+		renderDir = defaultRenderDir
+	}
+	renderCache, err := storage.NewLocalStore(renderDir, "")
+	if err != nil {
+		return fmt.Errorf("failed to create render cache: %w", err)
+	}
+	cardImagePublicURL := os.Getenv("CARD_IMAGE_PUBLIC_URL")
+	if cardImagePublicURL == "" {
+		cardImagePublicURL = publicBaseURL + "/cards"
+	}
+	renderService := render.NewService(logger, cardRepo, renderCache, imageLoader, cardImagePublicURL)
+
+	// Create Card QR Service (signed, expiring card tokens for QRメンコ)
+	// CARD_QR_SECRET is required; a random per-process key is only allowed with CARD_QR_RANDOM_KEY=true
+	// (local development), since issued QR codes stop working on restart and differ between instances
+	cardQRKey := []byte(os.Getenv("CARD_QR_SECRET"))
+	if len(cardQRKey) == 0 {
+		if os.Getenv("CARD_QR_RANDOM_KEY") != "true" {
+			return fmt.Errorf("CARD_QR_SECRET is required (set CARD_QR_RANDOM_KEY=true to use a random key for local development)")
+		}
+		logger.Error("CARD_QR_SECRET is not set, using a random key: card QR codes are invalidated on restart and are not shared between instances")
+		cardQRKey, err = cardqr.GenerateKey()
+		if err != nil {
+			return err
+		}
+	}
+	cardQRTTL := cardqr.DefaultTTL
+	if ttl := os.Getenv("CARD_QR_TTL"); ttl != "" {
+		parsed, err := time.ParseDuration(ttl)
+		if err != nil || parsed <= 0 {
+			return fmt.Errorf("invalid CARD_QR_TTL: %q", ttl)
+		}
+		cardQRTTL = parsed
+	}
+	cardQRSigner, err := cardqr.NewSigner(cardQRKey, cardQRTTL)
+	if err != nil {
+		return fmt.Errorf("invalid CARD_QR_SECRET: %w", err)
+	}
+	cardQRService := cardqr.NewService(logger, cardRepo, userCardRepo, cardQRSigner)
+
+	// Create Trade Service (pending trades are expired in the background)
+	tradeRepo := trade.NewRepository(firestoreClient, userCardRepo)
+	tradeService := trade.NewService(logger, tradeRepo)
+	expiryCtx, stopExpiry := context.WithCancel(context.Background())
+	defer stopExpiry()
+	go tradeService.RunExpiry(expiryCtx, trade.ExpiryInterval)
+
+	// Create Circle Service
+	circleRepo := circle.NewRepository(firestoreClient)
+	circleService := circle.NewService(logger, circleRepo, userRepo)
+
+	// Create User Service
+	userService := user.NewService(logger, userRepo, authenticator)
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -88,63 +320,246 @@ func run(logger *slog.Logger) error {
 
 	// Register Ptera Service (Existing)
 	ptera.RegisterPteraServiceServer(grpcServer, &server{
-		users:     make(map[string]*ptera.User),
-		aiService: geminiService,
+		aiService:       aiService,
+		imageLoader:     imageLoader,
+		imageStore:      imageStore,
+		suggestionCache: suggestionCache,
+		authenticator:   authenticator,
+		quotaService:    quotaService,
+		moderator:       moderator,
+		cardService:     cardService,
+		renderService:   renderService,
+		cardQRService:   cardQRService,
+		logger:          logger,
 	})
 
 	// Register Battle Service (New)
 	ptera.RegisterBattleServiceServer(grpcServer, battleService)
 
+	// Register Gacha Service
+	ptera.RegisterGachaServiceServer(grpcServer, gachaService)
+
+	// Register Fairness Service
+	ptera.RegisterFairnessServiceServer(grpcServer, fairnessService)
+
+	// Register Trade Service
+	ptera.RegisterTradeServiceServer(grpcServer, tradeService)
+
+	// Register Circle Service
+	ptera.RegisterCircleServiceServer(grpcServer, circleService)
+
+	// Register User Service
+	ptera.RegisterUserServiceServer(grpcServer, userService)
+
+	// Register Quota Service
+	ptera.RegisterQuotaServiceServer(grpcServer, quotaService)
+
 	reflection.Register(grpcServer)
 
+	// Files that browsers load directly (uploaded images and shareable card images) are served on the same port,
+	// since Cloud Run exposes only PORT. gRPC needs HTTP/2, which clients speak without TLS (h2c).
+	mux := http.NewServeMux()
+	mux.Handle("/images/", http.StripPrefix("/images/", imageStore))
+	mux.Handle("/cards/", http.StripPrefix("/cards", renderService))
+	httpServer := newHTTPServer(grpcServer, mux)
+
+	serveErr := make(chan error, 1)
 	go func() {
 		logger.Info("server listening", "address", lis.Addr())
-		if err := grpcServer.Serve(lis); err != nil {
-			logger.Error("failed to serve", "error", err)
-			return
-		}
+		serveErr <- httpServer.Serve(lis)
 	}()
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	select {
+	case <-quit:
+	case err := <-serveErr:
+		return fmt.Errorf("failed to serve: %w", err)
+	}
 
 	log.Println("shutting down server...")
-	grpcServer.GracefulStop()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		logger.Error("failed to shut down server", "error", err)
+	}
+	grpcServer.Stop()
 	log.Println("server stopped")
 
 	return nil
 }
 
-// CompleteCard implementation (Moved from original file content, kept minimal/correct)
+// newHTTPServer serves gRPC and HTTP/1.1 or h2c requests for h on one listener
+func newHTTPServer(grpcServer *grpc.Server, h http.Handler) *http.Server {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+	return &http.Server{
+		Handler:           grpcOrHTTP(grpcServer, h),
+		Protocols:         protocols,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// grpcOrHTTP sends gRPC requests to grpcServer and all other requests to h
+func grpcOrHTTP(grpcServer, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			grpcServer.ServeHTTP(w, r)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// CompleteCard suggests card fields with AI. AI failures are reported with success=false and error_message.
+// Results are cached by image and input fields unless force_refresh is set.
+// Calls that reach the model count against the AI quota of the caller (from their ID token) and their circle.
+// The input fields and the generated answer are moderated; flagged answers are returned without their fields.
 func (s *server) CompleteCard(ctx context.Context, req *ptera.CompleteCardRequest) (*ptera.CompleteCardResponse, error) {
-	// Basic delegation to AI service
-	// In real code, validation and error handling should be here
-	// Re-implementing based on original file content to avoid regression
+	return s.completeCard(ctx, req, nil)
+}
 
-	if req.ImageUrl == "" {
-		// We need status package
-		// return nil, status.Error(codes.InvalidArgument, "image_url is required")
-		// Just return error for brevity in this overwrite if imports are tricky, but I added imports.
-		return nil, fmt.Errorf("image_url is required")
+// StreamCompleteCard is CompleteCard sending each generated field as soon as it is parsed from the partial answer.
+// Fields that hit the blocklist are held back; the last message is the complete, validated and moderated result.
+func (s *server) StreamCompleteCard(req *ptera.CompleteCardRequest, stream ptera.PteraService_StreamCompleteCardServer) error {
+	var sendErr error
+	onField := func(field, value string) {
+		if sendErr != nil {
+			return
+		}
+		if reasons := s.moderator.CheckText(moderation.StageOutput, moderation.Field{Name: field, Value: value}); len(reasons) > 0 {
+			return
+		}
+		sendErr = stream.Send(&ptera.StreamCompleteCardResponse{
+			Event: &ptera.StreamCompleteCardResponse_Field{
+				Field: &ptera.CardFieldUpdate{Field: field, Value: value},
+			},
+		})
 	}
 
-	suggestions, err := s.aiService.AnalyzeCardImage(
-		ctx,
-		req.GetImageUrl(),
-		req.GetName(),
-		req.GetFaculty(),
-		req.GetDepartment(),
-		req.GetGrade(),
-		req.GetPosition(),
-		req.GetHobby(),
-		req.GetDescription(),
-	)
+	resp, err := s.completeCard(stream.Context(), req, onField)
+	if err != nil {
+		return err
+	}
+	if sendErr != nil {
+		return sendErr
+	}
+	return stream.Send(&ptera.StreamCompleteCardResponse{
+		Event: &ptera.StreamCompleteCardResponse_Result{Result: resp},
+	})
+}
+
+// completeCard implements CompleteCard; onField, if set, receives the fields while the answer is generated
+func (s *server) completeCard(ctx context.Context, req *ptera.CompleteCardRequest, onField ai.FieldFunc) (*ptera.CompleteCardResponse, error) {
+	userID, err := s.authenticator.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.ImageUrl == "" && req.GetImageId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "image_url or image_id is required")
+	}
+
+	image, err := s.imageLoader.Load(ctx, req.ImageUrl, req.GetImageId())
+	if errors.Is(err, ai.ErrInvalidImage) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid image: %v", err)
+	}
 	if err != nil {
-		log.Printf("failed to analyze card image: %v", err)
-		return nil, fmt.Errorf("failed to analyze image: %w", err)
+		s.logger.Error("failed to load card image", "error", err)
+		return nil, status.Errorf(codes.Unavailable, "failed to load image: %v", err)
 	}
 
+	opts, err := ai.ParsePromptOptions(req.Locale, req.Tone)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	input := ai.CompletionInput{
+		Name:        req.GetName(),
+		Faculty:     req.GetFaculty(),
+		Department:  req.GetDepartment(),
+		Grade:       req.GetGrade(),
+		Position:    req.GetPosition(),
+		Hobby:       req.GetHobby(),
+		Description: req.GetDescription(),
+	}
+	if reasons := s.moderator.CheckInput(input); len(reasons) > 0 {
+		return flaggedResponse(reasons), nil
+	}
+
+	promptVersion := s.aiService.PromptVersion(opts)
+	cacheKey := s.suggestionCache.Key(promptVersion, image, input)
+	if !req.ForceRefresh {
+		suggestions, err := s.suggestionCache.Get(ctx, cacheKey)
+		if err == nil {
+			// The policy may have changed since the answer was cached
+			if reasons := s.moderator.CheckSuggestions(suggestions); len(reasons) > 0 {
+				return flaggedResponse(reasons), nil
+			}
+			return completeCardResponse(suggestions, promptVersion, true), nil
+		}
+		if !errors.Is(err, ai.ErrCacheMiss) {
+			s.logger.Warn("failed to read suggestion cache", "error", err)
+		}
+	}
+
+	reservation, err := s.quotaService.Reserve(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var suggestions *ai.CardSuggestions
+	if onField != nil {
+		suggestions, err = s.aiService.AnalyzeCardImageStream(ctx, image, input, opts, onField)
+	} else {
+		suggestions, err = s.aiService.AnalyzeCardImage(ctx, image, input, opts)
+	}
+	if err != nil {
+		// Invalid or blocked answers still consumed tokens
+		s.quotaService.RecordTokens(ctx, reservation, ai.ConsumedTokens(err))
+	}
+	var blocked *ai.BlockedError
+	if errors.As(err, &blocked) {
+		s.logger.Warn("card image blocked by the model", "user_id", userID, "stage", blocked.Stage, "reason", blocked.Reason)
+		return flaggedResponse(s.moderator.CheckBlocked(blocked)), nil
+	}
+	if err != nil {
+		s.logger.Error("failed to analyze card image", "error", err)
+		message := "AI補完に失敗しました"
+		if errors.Is(err, ai.ErrInvalidSuggestions) {
+			message = "AIが正しい形式で回答できませんでした。もう一度お試しください"
+		}
+		return &ptera.CompleteCardResponse{
+			Success:      false,
+			ErrorMessage: &message,
+		}, nil
+	}
+
+	s.quotaService.RecordTokens(ctx, reservation, suggestions.TotalTokens)
+
+	if reasons := s.moderator.CheckSuggestions(suggestions); len(reasons) > 0 {
+		s.logger.Warn("generated suggestions flagged", "user_id", userID, "reasons", len(reasons))
+		return flaggedResponse(reasons), nil
+	}
+
+	if err := s.suggestionCache.Set(ctx, cacheKey, suggestions); err != nil {
+		s.logger.Warn("failed to cache suggestions", "error", err)
+	}
+	return completeCardResponse(suggestions, promptVersion, false), nil
+}
+
+// flaggedResponse reports a flagged image or answer without its fields, so that it cannot be saved as is
+func flaggedResponse(reasons []moderation.Reason) *ptera.CompleteCardResponse {
+	message := "不適切な内容が含まれている可能性があるため、補完結果を表示できません"
+	return &ptera.CompleteCardResponse{
+		Success:           false,
+		ErrorMessage:      &message,
+		Flagged:           true,
+		ModerationReasons: moderation.ReasonsToProto(reasons),
+	}
+}
+
+func completeCardResponse(suggestions *ai.CardSuggestions, promptVersion string, cached bool) *ptera.CompleteCardResponse {
 	return &ptera.CompleteCardResponse{
 		Name:        suggestions.Name,
 		Faculty:     suggestions.Faculty,
@@ -153,6 +568,58 @@ func (s *server) CompleteCard(ctx context.Context, req *ptera.CompleteCardReques
 		Position:    suggestions.Position,
 		Hobby:       suggestions.Hobby,
 		Description: suggestions.Description,
-		Success:     true,
-	}, nil
+		StatProfile: battle.NormalizeStatProfile(&ptera.StatProfile{
+			Type:      suggestions.StatProfile.Type,
+			Intensity: suggestions.StatProfile.Intensity,
+			Reason:    suggestions.StatProfile.Reason,
+		}),
+		Success:       true,
+		Cached:        cached,
+		PromptVersion: promptVersion,
+	}
+}
+
+func (s *server) UploadCardImage(stream ptera.PteraService_UploadCardImageServer) error {
+	return s.cardService.UploadCardImage(stream)
+}
+
+// Card CRUD is implemented in the card package
+func (s *server) CreateCard(ctx context.Context, req *ptera.CreateCardRequest) (*ptera.Card, error) {
+	return s.cardService.CreateCard(ctx, req)
+}
+
+func (s *server) GetCard(ctx context.Context, req *ptera.GetCardRequest) (*ptera.Card, error) {
+	return s.cardService.GetCard(ctx, req)
+}
+
+func (s *server) UpdateCard(ctx context.Context, req *ptera.UpdateCardRequest) (*ptera.Card, error) {
+	return s.cardService.UpdateCard(ctx, req)
+}
+
+func (s *server) DeleteCard(ctx context.Context, req *ptera.DeleteCardRequest) (*ptera.DeleteCardResponse, error) {
+	return s.cardService.DeleteCard(ctx, req)
+}
+
+func (s *server) ListCards(ctx context.Context, req *ptera.ListCardsRequest) (*ptera.ListCardsResponse, error) {
+	return s.cardService.ListCards(ctx, req)
+}
+
+func (s *server) SetFavoriteCard(ctx context.Context, req *ptera.SetFavoriteCardRequest) (*ptera.User, error) {
+	return s.cardService.SetFavoriteCard(ctx, req)
+}
+
+func (s *server) ListFavoriteCards(ctx context.Context, req *ptera.ListFavoriteCardsRequest) (*ptera.ListFavoriteCardsResponse, error) {
+	return s.cardService.ListFavoriteCards(ctx, req)
+}
+
+func (s *server) RenderCard(ctx context.Context, req *ptera.RenderCardRequest) (*ptera.RenderCardResponse, error) {
+	return s.renderService.RenderCard(ctx, req)
+}
+
+func (s *server) IssueCardQR(ctx context.Context, req *ptera.IssueCardQRRequest) (*ptera.IssueCardQRResponse, error) {
+	return s.cardQRService.IssueCardQR(ctx, req)
+}
+
+func (s *server) ResolveCardQR(ctx context.Context, req *ptera.ResolveCardQRRequest) (*ptera.Card, error) {
+	return s.cardQRService.ResolveCardQR(ctx, req)
 }
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/ai"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/storage"
+)
+
+// maxConcurrentDrafts limits the parallel AnalyzeCardImage calls of one DetectMembers request
+const maxConcurrentDrafts = 4
+
+// quotaExhaustedMessage is the draft error of the members left over when the AI quota runs out
+const quotaExhaustedMessage = "AIの利用上限に達したため補完できませんでした"
+
+// DetectMembers finds the people in a group photo, crops each face server-side and returns one card draft per person.
+// The detection and every draft count as one call each against the AI quota of the caller. Once the budget runs out,
+// the remaining members are returned with their crop but without a draft.
+func (s *server) DetectMembers(ctx context.Context, req *ptera.DetectMembersRequest) (*ptera.DetectMembersResponse, error) {
+	userID, err := s.authenticator.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.ImageUrl == "" && req.GetImageId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "image_url or image_id is required")
+	}
+
+	image, err := s.imageLoader.Load(ctx, req.ImageUrl, req.GetImageId())
+	if errors.Is(err, ai.ErrInvalidImage) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid image: %v", err)
+	}
+	if err != nil {
+		s.logger.Error("failed to load group photo", "error", err)
+		return nil, status.Errorf(codes.Unavailable, "failed to load image: %v", err)
+	}
+
+	opts, err := ai.ParsePromptOptions(req.Locale, req.Tone)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	reservation, err := s.quotaService.Reserve(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	detection, err := s.aiService.DetectMembers(ctx, image)
+	if err != nil {
+		s.quotaService.RecordTokens(ctx, reservation, ai.ConsumedTokens(err))
+	}
+	if errors.Is(err, ai.ErrContentBlocked) {
+		message := "不適切な画像と判定されたため、人物を検出できません"
+		return &ptera.DetectMembersResponse{Success: false, ErrorMessage: &message}, nil
+	}
+	if err != nil {
+		s.logger.Error("failed to detect members", "error", err)
+		message := "人物の検出に失敗しました"
+		return &ptera.DetectMembersResponse{Success: false, ErrorMessage: &message}, nil
+	}
+	s.quotaService.RecordTokens(ctx, reservation, detection.TotalTokens)
+	if len(detection.Boxes) == 0 {
+		message := "写真から人物が見つかりませんでした"
+		return &ptera.DetectMembersResponse{Success: false, ErrorMessage: &message}, nil
+	}
+
+	members := make([]*ptera.MemberDraft, len(detection.Boxes))
+	sem := make(chan struct{}, maxConcurrentDrafts)
+	var exhausted atomic.Bool
+	var wg sync.WaitGroup
+	for i, box := range detection.Boxes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			members[i] = s.memberDraft(ctx, userID, image, box, opts, &exhausted)
+		}()
+	}
+	wg.Wait()
+
+	return &ptera.DetectMembersResponse{
+		Members: members,
+		Success: true,
+	}, nil
+}
+
+// memberDraft crops one person, stores the crop and suggests the card fields from it, reserving one AI call.
+// Failures are reported in the draft so that the other members are still returned. exhausted is set once
+// the user's quota runs out, so that the remaining members skip the model.
+func (s *server) memberDraft(ctx context.Context, userID string, image *ai.Image, box ai.BoundingBox, opts ai.PromptOptions, exhausted *atomic.Bool) *ptera.MemberDraft {
+	member := &ptera.MemberDraft{
+		Box: &ptera.BoundingBox{
+			XMin: float32(box.XMin),
+			YMin: float32(box.YMin),
+			XMax: float32(box.XMax),
+			YMax: float32(box.YMax),
+		},
+	}
+	fail := func(message string) *ptera.MemberDraft {
+		member.Draft = &ptera.CompleteCardResponse{Success: false, ErrorMessage: &message}
+		return member
+	}
+
+	crop, err := ai.CropMember(image, box)
+	if err != nil {
+		s.logger.Error("failed to crop member", "box", box, "error", err)
+		return fail("画像の切り抜きに失敗しました")
+	}
+
+	sum := sha256.Sum256(crop.Data)
+	imageID := hex.EncodeToString(sum[:]) + storage.ExtensionFor(crop.MIMEType)
+	imageURL, err := s.imageStore.Put(ctx, imageID, &storage.Object{Data: crop.Data, ContentType: crop.MIMEType})
+	if err != nil {
+		s.logger.Error("failed to store member crop", "error", err)
+		return fail("切り抜いた画像の保存に失敗しました")
+	}
+	member.ImageId = imageID
+	member.ImageUrl = imageURL
+
+	if exhausted.Load() {
+		return fail(quotaExhaustedMessage)
+	}
+	reservation, err := s.quotaService.Reserve(ctx, userID)
+	if status.Code(err) == codes.ResourceExhausted {
+		exhausted.Store(true)
+		return fail(quotaExhaustedMessage)
+	}
+	if err != nil {
+		s.logger.Error("failed to reserve ai quota", "user_id", userID, "error", err)
+		return fail("AI補完に失敗しました")
+	}
+
+	suggestions, err := s.aiService.AnalyzeCardImage(ctx, crop, ai.CompletionInput{}, opts)
+	if err != nil {
+		s.quotaService.RecordTokens(ctx, reservation, ai.ConsumedTokens(err))
+	}
+	var blocked *ai.BlockedError
+	if errors.As(err, &blocked) {
+		member.Draft = flaggedResponse(s.moderator.CheckBlocked(blocked))
+		return member
+	}
+	if err != nil {
+		s.logger.Error("failed to analyze member crop", "image_id", imageID, "error", err)
+		return fail("AI補完に失敗しました")
+	}
+	s.quotaService.RecordTokens(ctx, reservation, suggestions.TotalTokens)
+	if reasons := s.moderator.CheckSuggestions(suggestions); len(reasons) > 0 {
+		member.Draft = flaggedResponse(reasons)
+		return member
+	}
+	member.Draft = completeCardResponse(suggestions, s.aiService.PromptVersion(opts), false)
+	return member
+}
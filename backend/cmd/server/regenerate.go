@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/ai"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/moderation"
+)
+
+// RegenerateField returns alternatives for one field of the card being edited, with the other fields held fixed.
+// Each call reaches the model (no cache, since the point is new choices) and counts against the AI quota of the caller.
+// Alternatives that hit the moderation are dropped; the response is flagged only if none is left.
+func (s *server) RegenerateField(ctx context.Context, req *ptera.RegenerateFieldRequest) (*ptera.RegenerateFieldResponse, error) {
+	userID, err := s.authenticator.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !ai.IsRegenerableField(req.FieldName) {
+		return nil, status.Errorf(codes.InvalidArgument, "field_name %q cannot be regenerated", req.FieldName)
+	}
+	n := int(req.N)
+	if n == 0 {
+		n = ai.DefaultFieldAlternatives
+	}
+	if n < 1 || n > ai.MaxFieldAlternatives {
+		return nil, status.Errorf(codes.InvalidArgument, "n must be between 1 and %d", ai.MaxFieldAlternatives)
+	}
+	opts, err := ai.ParsePromptOptions(req.Locale, req.Style)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	fields := req.GetFields()
+	input := ai.CompletionInput{
+		Name:        fields.GetName(),
+		Faculty:     fields.GetFaculty(),
+		Department:  fields.GetDepartment(),
+		Grade:       fields.GetGrade(),
+		Position:    fields.GetPosition(),
+		Hobby:       fields.GetHobby(),
+		Description: fields.GetDescription(),
+	}
+	if reasons := s.moderator.CheckInput(input); len(reasons) > 0 {
+		return regenerateFlaggedResponse(reasons), nil
+	}
+
+	reservation, err := s.quotaService.Reserve(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	alternatives, err := s.aiService.RegenerateField(ctx, input, req.FieldName, n, opts)
+	if err != nil {
+		// Invalid or blocked answers still consumed tokens
+		s.quotaService.RecordTokens(ctx, reservation, ai.ConsumedTokens(err))
+	}
+	var blocked *ai.BlockedError
+	if errors.As(err, &blocked) {
+		s.logger.Warn("field regeneration blocked by the model", "user_id", userID, "stage", blocked.Stage, "reason", blocked.Reason)
+		return regenerateFlaggedResponse(s.moderator.CheckBlocked(blocked)), nil
+	}
+	if err != nil {
+		s.logger.Error("failed to regenerate field", "field", req.FieldName, "error", err)
+		message := "候補の生成に失敗しました"
+		if errors.Is(err, ai.ErrInvalidSuggestions) {
+			message = "AIが正しい形式で回答できませんでした。もう一度お試しください"
+		}
+		return &ptera.RegenerateFieldResponse{Success: false, ErrorMessage: &message}, nil
+	}
+
+	s.quotaService.RecordTokens(ctx, reservation, alternatives.TotalTokens)
+
+	if reasons := s.moderator.CheckRatings(alternatives.SafetyRatings); len(reasons) > 0 {
+		return regenerateFlaggedResponse(reasons), nil
+	}
+	var values []string
+	var reasons []moderation.Reason
+	for _, v := range alternatives.Values {
+		if r := s.moderator.CheckText(moderation.StageOutput, moderation.Field{Name: req.FieldName, Value: v}); len(r) > 0 {
+			reasons = append(reasons, r...)
+			continue
+		}
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return regenerateFlaggedResponse(reasons), nil
+	}
+
+	return &ptera.RegenerateFieldResponse{
+		Values:        values,
+		Success:       true,
+		PromptVersion: s.aiService.PromptVersion(opts),
+	}, nil
+}
+
+func regenerateFlaggedResponse(reasons []moderation.Reason) *ptera.RegenerateFieldResponse {
+	message := "不適切な内容が含まれている可能性があるため、候補を表示できません"
+	return &ptera.RegenerateFieldResponse{
+		Success:           false,
+		ErrorMessage:      &message,
+		Flagged:           true,
+		ModerationReasons: moderation.ReasonsToProto(reasons),
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/battle"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/circle"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/fairness"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/gacha"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/quota"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/trade"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/user"
+)
+
+// emptyStream is a server stream without messages
+type emptyStream struct {
+	ctx context.Context
+}
+
+func (s emptyStream) SetHeader(metadata.MD) error  { return nil }
+func (s emptyStream) SendHeader(metadata.MD) error { return nil }
+func (s emptyStream) SetTrailer(metadata.MD)       {}
+func (s emptyStream) Context() context.Context     { return s.ctx }
+func (s emptyStream) SendMsg(any) error            { return nil }
+func (s emptyStream) RecvMsg(any) error            { return io.EOF }
+
+// TestHandlersImplemented calls every RPC with an empty request and fails if it answers Unimplemented,
+// i.e. if the handler is missing and the embedded Unimplemented server answers instead.
+// The services have no dependencies here, so implemented handlers may fail or panic in any other way.
+func TestHandlersImplemented(t *testing.T) {
+	services := []struct {
+		desc *grpc.ServiceDesc
+		srv  any
+	}{
+		{&ptera.PteraService_ServiceDesc, &server{}},
+		{&ptera.BattleService_ServiceDesc, &battle.Service{}},
+		{&ptera.GachaService_ServiceDesc, &gacha.Service{}},
+		{&ptera.FairnessService_ServiceDesc, &fairness.Service{}},
+		{&ptera.TradeService_ServiceDesc, &trade.Service{}},
+		{&ptera.CircleService_ServiceDesc, &circle.Service{}},
+		{&ptera.UserService_ServiceDesc, &user.Service{}},
+		{&ptera.QuotaService_ServiceDesc, &quota.Service{}},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, svc := range services {
+		for _, m := range svc.desc.Methods {
+			t.Run(svc.desc.ServiceName+"/"+m.MethodName, func(t *testing.T) {
+				err := callHandler(func() error {
+					_, err := m.Handler(svc.srv, ctx, func(any) error { return nil }, nil)
+					return err
+				})
+				if status.Code(err) == codes.Unimplemented {
+					t.Errorf("%s is not implemented: %v", m.MethodName, err)
+				}
+			})
+		}
+		for _, st := range svc.desc.Streams {
+			t.Run(svc.desc.ServiceName+"/"+st.StreamName, func(t *testing.T) {
+				err := callHandler(func() error {
+					return st.Handler(svc.srv, emptyStream{ctx: ctx})
+				})
+				if status.Code(err) == codes.Unimplemented {
+					t.Errorf("%s is not implemented: %v", st.StreamName, err)
+				}
+			})
+		}
+	}
+}
+
+// callHandler runs a handler, treating a panic (a missing dependency) as implemented
+func callHandler(call func() error) (err error) {
+	defer func() {
+		if recover() != nil {
+			err = nil
+		}
+	}()
+	return call()
+}
+
+func TestGRPCOrHTTP(t *testing.T) {
+	var got string
+	handler := grpcOrHTTP(
+		http.HandlerFunc(func(http.ResponseWriter, *http.Request) { got = "grpc" }),
+		http.HandlerFunc(func(http.ResponseWriter, *http.Request) { got = "http" }),
+	)
+
+	tests := []struct {
+		name        string
+		protoMajor  int
+		contentType string
+		want        string
+	}{
+		{"grpc", 2, "application/grpc", "grpc"},
+		{"grpc+proto", 2, "application/grpc+proto", "grpc"},
+		{"image over http2", 2, "", "http"},
+		{"image over http1", 1, "", "http"},
+		{"grpc content type over http1", 1, "application/grpc", "http"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = ""
+			r := httptest.NewRequest(http.MethodGet, "/images/abc.jpg", nil)
+			r.ProtoMajor = tt.protoMajor
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+			if got != tt.want {
+				t.Errorf("served by %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSharedPort serves gRPC and files on one listener, as on Cloud Run
+func TestSharedPort(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	healthgrpc.RegisterHealthServer(grpcServer, health.NewServer())
+	mux := http.NewServeMux()
+	mux.HandleFunc("/images/", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "image")
+	})
+	httpServer := newHTTPServer(grpcServer, mux)
+	go httpServer.Serve(lis)
+	defer httpServer.Close()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to create grpc client: %v", err)
+	}
+	defer conn.Close()
+	resp, err := healthgrpc.NewHealthClient(conn).Check(context.Background(), &healthgrpc.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("grpc Check() error = %v", err)
+	}
+	if resp.Status != healthgrpc.HealthCheckResponse_SERVING {
+		t.Errorf("grpc Check() status = %v", resp.Status)
+	}
+
+	httpResp, err := http.Get("http://" + lis.Addr().String() + "/images/abc.jpg")
+	if err != nil {
+		t.Fatalf("http GET error = %v", err)
+	}
+	defer httpResp.Body.Close()
+	body, _ := io.ReadAll(httpResp.Body)
+	if httpResp.StatusCode != http.StatusOK || string(body) != "image" {
+		t.Errorf("http GET = %d %q, want 200 %q", httpResp.StatusCode, body, "image")
+	}
+}
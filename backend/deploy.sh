@@ -32,6 +32,16 @@ if [ -z "$FIREBASE_SERVICE_ACCOUNT_KEY" ]; then
     exit 1
 fi
 
+if [ -z "$PUBLIC_BASE_URL" ]; then
+    echo "Error: PUBLIC_BASE_URL is not set in .env.local (the Cloud Run service URL)"
+    exit 1
+fi
+
+if [ -z "$CARD_QR_SECRET" ]; then
+    echo "Error: CARD_QR_SECRET is not set in .env.local"
+    exit 1
+fi
+
 # Escape logic removed - using env-vars-file approach for safety
 
 echo "Deploying to Project: ${PROJECT_ID}, Region: ${REGION}"
@@ -49,6 +59,8 @@ cat <<EOF > env_vars.yaml
 GEMINI_API_KEY: "${GEMINI_API_KEY}"
 FIREBASE_SERVICE_ACCOUNT_KEY: '${FIREBASE_SERVICE_ACCOUNT_KEY}'
 GOOGLE_CLOUD_PROJECT: "${PROJECT_ID}"
+CARD_QR_SECRET: "${CARD_QR_SECRET}"
+PUBLIC_BASE_URL: "${PUBLIC_BASE_URL}"
 EOF
 
 # 4. Deploy to Cloud Run
@@ -0,0 +1,153 @@
+package ai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultSuggestionCacheTTL    = 24 * time.Hour
+	defaultMemoryCacheMaxEntries = 1000
+)
+
+// ErrCacheMiss is returned by a SuggestionStore when there is no live entry for the key
+var ErrCacheMiss = errors.New("suggestion cache miss")
+
+// SuggestionStore stores card suggestions by cache key
+type SuggestionStore interface {
+	// Get returns the suggestions of key, or ErrCacheMiss if there are none or they have expired
+	Get(ctx context.Context, key string) (*CardSuggestions, error)
+	Set(ctx context.Context, key string, suggestions *CardSuggestions, expiresAt time.Time) error
+}
+
+// SuggestionCache caches CompleteCard results, so that the same photo with the same fields
+// returns the same suggestions without calling the model again
+type SuggestionCache struct {
+	store SuggestionStore
+	ttl   time.Duration
+}
+
+// NewSuggestionCache creates a cache. ttl <= 0 uses DefaultSuggestionCacheTTL.
+func NewSuggestionCache(store SuggestionStore, ttl time.Duration) *SuggestionCache {
+	if ttl <= 0 {
+		ttl = DefaultSuggestionCacheTTL
+	}
+	return &SuggestionCache{
+		store: store,
+		ttl:   ttl,
+	}
+}
+
+// Key derives the cache key from the SHA-256 of the normalized image, the normalized input fields
+// and the prompt version (including locale and tone), so changing the prompt invalidates the previous answers
+func (c *SuggestionCache) Key(promptVersion string, image *Image, input CompletionInput) string {
+	imageSum := sha256.Sum256(image.Data)
+	material, _ := json.Marshal(struct {
+		PromptVersion string          `json:"v"`
+		Image         string          `json:"image"`
+		Input         CompletionInput `json:"input"`
+	}{
+		PromptVersion: promptVersion,
+		Image:         hex.EncodeToString(imageSum[:]),
+		Input:         input.normalize(),
+	})
+	sum := sha256.Sum256(material)
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *SuggestionCache) Get(ctx context.Context, key string) (*CardSuggestions, error) {
+	return c.store.Get(ctx, key)
+}
+
+func (c *SuggestionCache) Set(ctx context.Context, key string, suggestions *CardSuggestions) error {
+	return c.store.Set(ctx, key, suggestions, time.Now().Add(c.ttl))
+}
+
+// normalize trims the fields and collapses runs of whitespace (including full-width spaces)
+func (in CompletionInput) normalize() CompletionInput {
+	clean := func(s string) string {
+		return strings.Join(strings.Fields(s), " ")
+	}
+	return CompletionInput{
+		Name:        clean(in.Name),
+		Faculty:     clean(in.Faculty),
+		Department:  clean(in.Department),
+		Grade:       in.Grade,
+		Position:    clean(in.Position),
+		Hobby:       clean(in.Hobby),
+		Description: clean(in.Description),
+	}
+}
+
+// MemorySuggestionStore keeps suggestions in process memory (the default store).
+// When it is full, expired entries are dropped first, then arbitrary ones.
+type MemorySuggestionStore struct {
+	mu         sync.Mutex
+	entries    map[string]memorySuggestion
+	maxEntries int
+}
+
+type memorySuggestion struct {
+	suggestions CardSuggestions
+	expiresAt   time.Time
+}
+
+// NewMemorySuggestionStore creates a store. maxEntries <= 0 uses the default of 1000.
+func NewMemorySuggestionStore(maxEntries int) *MemorySuggestionStore {
+	if maxEntries <= 0 {
+		maxEntries = defaultMemoryCacheMaxEntries
+	}
+	return &MemorySuggestionStore{
+		entries:    make(map[string]memorySuggestion),
+		maxEntries: maxEntries,
+	}
+}
+
+func (s *MemorySuggestionStore) Get(ctx context.Context, key string) (*CardSuggestions, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(s.entries, key)
+		return nil, ErrCacheMiss
+	}
+	suggestions := entry.suggestions
+	return &suggestions, nil
+}
+
+func (s *MemorySuggestionStore) Set(ctx context.Context, key string, suggestions *CardSuggestions, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.entries[key]; !exists && len(s.entries) >= s.maxEntries {
+		s.evict()
+	}
+	s.entries[key] = memorySuggestion{suggestions: *suggestions, expiresAt: expiresAt}
+	return nil
+}
+
+// evict makes room for one entry. Callers must hold s.mu.
+func (s *MemorySuggestionStore) evict() {
+	now := time.Now()
+	for key, entry := range s.entries {
+		if now.After(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+	for key := range s.entries {
+		if len(s.entries) < s.maxEntries {
+			return
+		}
+		delete(s.entries, key)
+	}
+}
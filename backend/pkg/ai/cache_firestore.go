@@ -0,0 +1,66 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CollectionSuggestionCache holds cached CompleteCard results (document ID is the cache key).
+// A Firestore TTL policy on expiresAt removes expired documents; Get also ignores them until then.
+const CollectionSuggestionCache = "ai_suggestion_cache"
+
+// FirestoreSuggestionStore shares cached suggestions between server instances
+type FirestoreSuggestionStore struct {
+	client *firestore.Client
+}
+
+func NewFirestoreSuggestionStore(client *firestore.Client) *FirestoreSuggestionStore {
+	return &FirestoreSuggestionStore{client: client}
+}
+
+func (s *FirestoreSuggestionStore) Get(ctx context.Context, key string) (*CardSuggestions, error) {
+	doc, err := s.client.Collection(CollectionSuggestionCache).Doc(key).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrCacheMiss
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached suggestions: %w", err)
+	}
+
+	data := doc.Data()
+	expiresAt, _ := data["expiresAt"].(time.Time)
+	if time.Now().After(expiresAt) {
+		return nil, ErrCacheMiss
+	}
+	raw, _ := data["suggestions"].(string)
+
+	var suggestions CardSuggestions
+	if err := json.Unmarshal([]byte(raw), &suggestions); err != nil {
+		// A document written by an incompatible version is treated as missing
+		return nil, ErrCacheMiss
+	}
+	return &suggestions, nil
+}
+
+func (s *FirestoreSuggestionStore) Set(ctx context.Context, key string, suggestions *CardSuggestions, expiresAt time.Time) error {
+	raw, err := json.Marshal(suggestions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal suggestions: %w", err)
+	}
+
+	_, err = s.client.Collection(CollectionSuggestionCache).Doc(key).Set(ctx, map[string]interface{}{
+		"suggestions": string(raw),
+		"expiresAt":   expiresAt,
+		"createdAt":   firestore.ServerTimestamp,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to cache suggestions: %w", err)
+	}
+	return nil
+}
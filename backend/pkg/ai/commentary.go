@@ -0,0 +1,91 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/genai"
+)
+
+const (
+	BattleActionAttack  = "attack"
+	BattleActionRetreat = "retreat"
+
+	maxCommentaryLength         = 120
+	commentarySystemInstruction = `You are the play-by-play announcer of a card battle between Japanese university clubs. Each card is a club member. Given what happened in one turn, write a short and lively commentary in Japanese, one or two sentences and at most 80 characters, like a sports announcer. Use the card names, and when it fits, their hobby or flavor text. Keep the facts (damage, knock-outs, the winner) exactly as given. Do not insult anyone. Return ONLY the commentary, without quotes.`
+)
+
+// CommentaryCard is the part of a card the commentary can mention
+type CommentaryCard struct {
+	Name   string
+	Hobby  string
+	Flavor string
+}
+
+// BattleTurn describes what happened in one turn of a battle
+type BattleTurn struct {
+	Action         string // BattleActionAttack or BattleActionRetreat
+	Circle         string // circle taking the action
+	OpponentCircle string
+	Card           CommentaryCard // attacking card, or the card sent in on a retreat
+	Target         CommentaryCard // defending card, or the card withdrawn on a retreat
+	Damage         int32
+	KnockOut       bool // the defending card was knocked out
+	Win            bool // the circle taking the action won the battle
+}
+
+// GenerateCommentary writes a short Japanese play-by-play of the turn
+func (s *GeminiService) GenerateCommentary(ctx context.Context, turn BattleTurn) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Action: %s\n", turn.Action)
+	fmt.Fprintf(&b, "Circle: %s\n", turn.Circle)
+	fmt.Fprintf(&b, "Opponent circle: %s\n", turn.OpponentCircle)
+	if turn.Action == BattleActionRetreat {
+		fmt.Fprintf(&b, "Card sent in: %s (hobby: %s, flavor: %s)\n", turn.Card.Name, turn.Card.Hobby, turn.Card.Flavor)
+		fmt.Fprintf(&b, "Card withdrawn: %s\n", turn.Target.Name)
+	} else {
+		fmt.Fprintf(&b, "Attacker: %s (hobby: %s, flavor: %s)\n", turn.Card.Name, turn.Card.Hobby, turn.Card.Flavor)
+		fmt.Fprintf(&b, "Defender: %s (hobby: %s, flavor: %s)\n", turn.Target.Name, turn.Target.Hobby, turn.Target.Flavor)
+		fmt.Fprintf(&b, "Damage: %d\n", turn.Damage)
+		fmt.Fprintf(&b, "Defender knocked out: %t\n", turn.KnockOut)
+		fmt.Fprintf(&b, "Attacking circle won the battle: %t\n", turn.Win)
+	}
+
+	contents := []*genai.Content{
+		{
+			Parts: []*genai.Part{{Text: b.String()}},
+		},
+	}
+
+	config := &genai.GenerateContentConfig{
+		SystemInstruction: &genai.Content{
+			Parts: []*genai.Part{
+				{Text: commentarySystemInstruction},
+			},
+		},
+	}
+
+	genResp, err := s.client.Models.GenerateContent(ctx, "gemini-2.5-flash", contents, config)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate content: %w", err)
+	}
+	if _, err := responseSafety(genResp); err != nil {
+		return "", err
+	}
+
+	commentary := strings.TrimSpace(genResp.Text())
+	commentary = strings.Trim(commentary, "\"'「」『』")
+	commentary = strings.Join(strings.Fields(commentary), " ")
+	if commentary == "" {
+		return "", fmt.Errorf("no commentary generated")
+	}
+	if utf8.RuneCountInString(commentary) > maxCommentaryLength {
+		return "", fmt.Errorf("generated commentary is too long: %q", commentary)
+	}
+	if !containsJapanese(commentary) {
+		return "", fmt.Errorf("generated commentary is not in Japanese: %q", commentary)
+	}
+	return commentary, nil
+}
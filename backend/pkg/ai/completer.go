@@ -0,0 +1,70 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	ProviderGemini  = "gemini"
+	ProviderOffline = "offline"
+)
+
+// CompletionInput holds the card fields the user already filled in (empty when unknown)
+type CompletionInput struct {
+	Name        string
+	Faculty     string
+	Department  string
+	Grade       int32
+	Position    string
+	Hobby       string
+	Description string
+}
+
+// CardCompleter completes card profiles with AI
+type CardCompleter interface {
+	// AnalyzeCardImage suggests card fields from the photo (see ImageLoader) and the fields already filled in
+	AnalyzeCardImage(ctx context.Context, image *Image, input CompletionInput, opts PromptOptions) (*CardSuggestions, error)
+	// AnalyzeCardImageStream is AnalyzeCardImage reporting each field to onField as soon as it is generated
+	AnalyzeCardImageStream(ctx context.Context, image *Image, input CompletionInput, opts PromptOptions, onField FieldFunc) (*CardSuggestions, error)
+	// RegenerateField writes up to n alternatives for one field of the input, keeping the other fields as context
+	RegenerateField(ctx context.Context, input CompletionInput, field string, n int, opts PromptOptions) (*FieldAlternatives, error)
+	// DetectMembers returns the face region of every person in a group photo
+	DetectMembers(ctx context.Context, image *Image) (*MemberDetection, error)
+	// GenerateFlavor generates a one-line flavor text for a card from its profile
+	GenerateFlavor(ctx context.Context, name, position, hobby, description string, grade int32) (string, error)
+	// GenerateCommentary writes a short Japanese play-by-play of one battle turn
+	GenerateCommentary(ctx context.Context, turn BattleTurn) (string, error)
+	// PromptVersion identifies the provider, prompt, locale and tone behind the suggestions.
+	// It is part of the cache key and recorded on the card.
+	PromptVersion(opts PromptOptions) string
+	Close() error
+}
+
+var (
+	_ CardCompleter = (*GeminiService)(nil)
+	_ CardCompleter = (*OfflineService)(nil)
+)
+
+// NewCardCompleter creates the completer of the given provider.
+// An empty provider selects Gemini when an API key is set, and the offline completer otherwise.
+func NewCardCompleter(ctx context.Context, provider, apiKey string) (CardCompleter, error) {
+	if provider == "" {
+		provider = ProviderOffline
+		if apiKey != "" {
+			provider = ProviderGemini
+		}
+	}
+
+	switch provider {
+	case ProviderGemini:
+		if apiKey == "" {
+			return nil, fmt.Errorf("GEMINI_API_KEY is required for the %s provider", ProviderGemini)
+		}
+		return NewGeminiService(ctx, apiKey)
+	case ProviderOffline:
+		return NewOfflineService(), nil
+	default:
+		return nil, fmt.Errorf("unknown AI provider: %s", provider)
+	}
+}
@@ -0,0 +1,122 @@
+package ai
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// testTIFF builds a TIFF header whose IFD0 holds a dummy tag followed by the orientation tag
+func testTIFF(order binary.ByteOrder, orientation uint16) []byte {
+	tiff := make([]byte, 8+2+2*12)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 2)
+
+	// ImageWidth, to check that other tags are skipped
+	order.PutUint16(tiff[10:], 0x0100)
+	order.PutUint16(tiff[12:], 3)
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], 640)
+
+	order.PutUint16(tiff[22:], exifOrientationTag)
+	order.PutUint16(tiff[24:], 3) // SHORT
+	order.PutUint32(tiff[26:], 1)
+	order.PutUint16(tiff[30:], orientation)
+	return tiff
+}
+
+// testSegment builds a JPEG marker segment
+func testSegment(marker byte, payload []byte) []byte {
+	seg := []byte{0xFF, marker, 0, 0}
+	binary.BigEndian.PutUint16(seg[2:], uint16(len(payload)+2))
+	return append(seg, payload...)
+}
+
+// testJPEG builds the start of a JPEG from its segments, followed by the start of the image data
+func testJPEG(segments ...[]byte) []byte {
+	data := []byte{0xFF, 0xD8}
+	for _, s := range segments {
+		data = append(data, s...)
+	}
+	return append(data, 0xFF, 0xDA, 0x00, 0x02)
+}
+
+func exifSegment(tiff []byte) []byte {
+	return testSegment(0xE1, append([]byte("Exif\x00\x00"), tiff...))
+}
+
+func TestTIFFOrientation(t *testing.T) {
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		for orientation := 1; orientation <= 8; orientation++ {
+			if got := tiffOrientation(testTIFF(order, uint16(orientation))); got != orientation {
+				t.Errorf("%v orientation %d: got %d", order, orientation, got)
+			}
+		}
+	}
+
+	valid := testTIFF(binary.BigEndian, 6)
+	badOrder := append([]byte("XX"), valid[2:]...)
+	badIFD := append([]byte{}, valid...)
+	binary.BigEndian.PutUint32(badIFD[4:], 1000)
+	tooManyEntries := append([]byte{}, valid...)
+	binary.BigEndian.PutUint16(tooManyEntries[8:], 50)
+	binary.BigEndian.PutUint16(tooManyEntries[22:], 0x0101) // no orientation among the entries that exist
+
+	tests := []struct {
+		name string
+		tiff []byte
+	}{
+		{"empty", nil},
+		{"short header", valid[:6]},
+		{"unknown byte order", badOrder},
+		{"IFD offset out of range", badIFD},
+		{"entry count past the end", tooManyEntries},
+		{"truncated entry", valid[:len(valid)-4]},
+		{"orientation 0", testTIFF(binary.LittleEndian, 0)},
+		{"orientation 9", testTIFF(binary.LittleEndian, 9)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tiffOrientation(tt.tiff); got != 1 {
+				t.Errorf("tiffOrientation() = %d, want 1", got)
+			}
+		})
+	}
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	exif := exifSegment(testTIFF(binary.LittleEndian, 6))
+	app0 := testSegment(0xE0, []byte("JFIF\x00\x01\x01"))
+	full := testJPEG(exif)
+
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{"exif", full, 6},
+		{"exif after APP0", testJPEG(app0, exif), 6},
+		{"big endian exif", testJPEG(exifSegment(testTIFF(binary.BigEndian, 8))), 8},
+		{"no exif", testJPEG(app0), 1},
+		{"APP1 that is not exif", testJPEG(testSegment(0xE1, []byte("http://ns.adobe.com/xap/1.0/\x00"))), 1},
+		{"exif after the image data", append(testJPEG(app0), exif...), 1},
+		{"not a JPEG", []byte("\x89PNG\r\n\x1a\n"), 1},
+		{"only SOI", []byte{0xFF, 0xD8}, 1},
+		{"truncated segment header", full[:5], 1},
+		{"truncated segment", full[:len(exif)], 1},
+		{"segment size below 2", testJPEG([]byte{0xFF, 0xE1, 0x00, 0x01}), 1},
+		{"garbage instead of a marker", []byte{0xFF, 0xD8, 0x00, 0x00, 0x00, 0x00}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jpegOrientation(tt.data); got != tt.want {
+				t.Errorf("jpegOrientation() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	imageDownloadTimeout = 30 * time.Second
+	maxImageSize         = 10 * 1024 * 1024 // 10MB
+	maxImageRedirects    = 3
+)
+
+// DefaultImageHosts are the storage hosts images may be fetched from when none are configured
+var DefaultImageHosts = []string{
+	"firebasestorage.googleapis.com",
+	"storage.googleapis.com",
+}
+
+// ErrInvalidImage is wrapped by every error caused by the image URL or its content (not by the network)
+var ErrInvalidImage = errors.New("invalid image")
+
+var (
+	ErrImageURLInvalid     = fmt.Errorf("%w: image_url must be an https URL", ErrInvalidImage)
+	ErrImageHostNotAllowed = fmt.Errorf("%w: image host is not allowed", ErrInvalidImage)
+	ErrImageAddressBlocked = fmt.Errorf("%w: image host resolves to a blocked address", ErrInvalidImage)
+	ErrImageTooManyRedirs  = fmt.Errorf("%w: too many redirects", ErrInvalidImage)
+	ErrImageNotImage       = fmt.Errorf("%w: content is not a supported image", ErrInvalidImage)
+	ErrImageTooLarge       = fmt.Errorf("%w: image is too large", ErrInvalidImage)
+	ErrImageStatus         = fmt.Errorf("%w: image could not be downloaded", ErrInvalidImage)
+)
+
+// ErrImageUnavailable is returned when the storage host fails (5xx); unlike ErrInvalidImage, retrying may help
+var ErrImageUnavailable = errors.New("image storage is unavailable")
+
+// allowedImageTypes are the content types accepted from the storage hosts
+var allowedImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// carrierGradeNAT (100.64.0.0/10) is not covered by netip.Addr.IsPrivate
+var carrierGradeNAT = netip.MustParsePrefix("100.64.0.0/10")
+
+// ImageFetcher downloads card images without letting the client reach internal addresses.
+// Only https URLs on the allowed hosts are fetched, and every connection (including redirects)
+// is checked against the resolved IP address, so DNS tricks cannot point it at private networks.
+type ImageFetcher struct {
+	allowedHosts []string
+	client       *http.Client
+	maxSize      int64
+}
+
+// NewImageFetcher creates a fetcher for the given hosts (see ParseImageHosts).
+// A host starting with "*." matches its subdomains.
+func NewImageFetcher(allowedHosts []string) *ImageFetcher {
+	if len(allowedHosts) == 0 {
+		allowedHosts = DefaultImageHosts
+	}
+
+	f := &ImageFetcher{
+		allowedHosts: allowedHosts,
+		maxSize:      maxImageSize,
+	}
+
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: blockInternalAddress,
+	}
+	f.client = &http.Client{
+		Timeout: imageDownloadTimeout,
+		Transport: &http.Transport{
+			Proxy:               nil, // a proxy would hide the destination address from the dialer
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > maxImageRedirects {
+				return ErrImageTooManyRedirs
+			}
+			return f.checkURL(req.URL)
+		},
+	}
+	return f
+}
+
+// ParseImageHosts parses a comma-separated host list (e.g. IMAGE_ALLOWED_HOSTS).
+// A wildcard is only allowed as a whole leftmost label ("*.example.com"), so that
+// "*example.com" cannot be mistaken for a pattern that also matches evilexample.com.
+func ParseImageHosts(s string) ([]string, error) {
+	var hosts []string
+	for _, h := range strings.Split(s, ",") {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if h == "" {
+			continue
+		}
+		domain := strings.TrimPrefix(h, "*.")
+		if domain == "" || strings.Contains(domain, "*") || strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") {
+			return nil, fmt.Errorf("invalid image host %q (use example.com or *.example.com)", h)
+		}
+		hosts = append(hosts, h)
+	}
+	return hosts, nil
+}
+
+// Fetch downloads the image and returns its data and MIME type
+func (f *ImageFetcher) Fetch(ctx context.Context, imageURL string) ([]byte, string, error) {
+	u, err := url.Parse(imageURL)
+	if err != nil {
+		return nil, "", ErrImageURLInvalid
+	}
+	if err := f.checkURL(u); err != nil {
+		return nil, "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "image/*")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		// Errors of CheckRedirect and the dialer are wrapped in *url.Error
+		if errors.Is(err, ErrInvalidImage) {
+			return nil, "", err
+		}
+		return nil, "", fmt.Errorf("failed to fetch image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return nil, "", fmt.Errorf("%w: status code %d", ErrImageUnavailable, resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("%w: status code %d", ErrImageStatus, resp.StatusCode)
+	}
+
+	headerType := strings.ToLower(strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0]))
+	if headerType != "" && headerType != "application/octet-stream" && !allowedImageTypes[headerType] {
+		return nil, "", fmt.Errorf("%w: content type %s", ErrImageNotImage, headerType)
+	}
+	if resp.ContentLength > f.maxSize {
+		return nil, "", ErrImageTooLarge
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, f.maxSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read image data: %w", err)
+	}
+	if int64(len(data)) > f.maxSize {
+		return nil, "", ErrImageTooLarge
+	}
+
+	// The header may lie, so the content itself must be an image too
+	mimeType := http.DetectContentType(data)
+	if !allowedImageTypes[mimeType] {
+		return nil, "", fmt.Errorf("%w: detected %s", ErrImageNotImage, mimeType)
+	}
+	return data, mimeType, nil
+}
+
+// checkURL validates the scheme, port and host of a URL (including redirect targets)
+func (f *ImageFetcher) checkURL(u *url.URL) error {
+	if u.Scheme != "https" || u.Host == "" || u.User != nil {
+		return ErrImageURLInvalid
+	}
+	if port := u.Port(); port != "" && port != "443" {
+		return ErrImageURLInvalid
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range f.allowedHosts {
+		if host == allowed {
+			return nil
+		}
+		// "*.example.com" matches on a label boundary: a.example.com, but not example.com or evilexample.com
+		if domain, ok := strings.CutPrefix(allowed, "*."); ok && strings.HasSuffix(host, "."+domain) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrImageHostNotAllowed, host)
+}
+
+// blockInternalAddress is the dialer hook that runs after DNS resolution, right before connecting
+func blockInternalAddress(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrImageAddressBlocked, address)
+	}
+	if isBlockedAddress(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrImageAddressBlocked, addrPort.Addr())
+	}
+	return nil
+}
+
+func isBlockedAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() ||
+		carrierGradeNAT.Contains(addr)
+}
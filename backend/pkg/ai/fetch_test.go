@@ -0,0 +1,101 @@
+package ai
+
+import (
+	"errors"
+	"net/netip"
+	"net/url"
+	"slices"
+	"testing"
+)
+
+func TestParseImageHosts(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{in: "", want: nil},
+		{in: " Storage.GoogleAPIs.com , *.example.com,", want: []string{"storage.googleapis.com", "*.example.com"}},
+		{in: "*example.com", wantErr: true},
+		{in: "*", wantErr: true},
+		{in: "*.", wantErr: true},
+		{in: "a.*.example.com", wantErr: true},
+		{in: ".example.com", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseImageHosts(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseImageHosts(%q) err = %v, wantErr %t", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("ParseImageHosts(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestImageFetcherCheckURL(t *testing.T) {
+	f := NewImageFetcher([]string{"storage.googleapis.com", "*.example.com"})
+	tests := []struct {
+		url     string
+		wantErr error
+	}{
+		{url: "https://storage.googleapis.com/bucket/a.jpg"},
+		{url: "https://cdn.example.com/a.jpg"},
+		{url: "https://a.b.example.com/a.jpg"},
+		{url: "https://CDN.Example.com:443/a.jpg"},
+		{url: "https://example.com/a.jpg", wantErr: ErrImageHostNotAllowed},
+		{url: "https://evilexample.com/a.jpg", wantErr: ErrImageHostNotAllowed},
+		{url: "https://storage.googleapis.com.evil.com/a.jpg", wantErr: ErrImageHostNotAllowed},
+		{url: "http://storage.googleapis.com/a.jpg", wantErr: ErrImageURLInvalid},
+		{url: "https://storage.googleapis.com:8443/a.jpg", wantErr: ErrImageURLInvalid},
+		{url: "https://user@storage.googleapis.com/a.jpg", wantErr: ErrImageURLInvalid},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = f.checkURL(u)
+		if tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+			t.Errorf("checkURL(%s) = %v, want %v", tt.url, err, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidImage) {
+			t.Errorf("checkURL(%s) = %v, want ErrInvalidImage", tt.url, err)
+		}
+	}
+}
+
+func TestImageErrorsClassification(t *testing.T) {
+	// Storage failures may be retried, so they must not be reported as an invalid image
+	if errors.Is(ErrImageUnavailable, ErrInvalidImage) {
+		t.Error("ErrImageUnavailable wraps ErrInvalidImage")
+	}
+	if !errors.Is(ErrImageStatus, ErrInvalidImage) {
+		t.Error("ErrImageStatus does not wrap ErrInvalidImage")
+	}
+}
+
+func TestIsBlockedAddress(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "142.250.196.128", want: false},
+		{addr: "2404:6800:4004:81a::2010", want: false},
+		{addr: "127.0.0.1", want: true},
+		{addr: "10.1.2.3", want: true},
+		{addr: "192.168.0.1", want: true},
+		{addr: "169.254.169.254", want: true},
+		{addr: "100.64.0.1", want: true},
+		{addr: "::1", want: true},
+		{addr: "::ffff:127.0.0.1", want: true},
+		{addr: "fd00::1", want: true},
+		{addr: "0.0.0.0", want: true},
+	}
+	for _, tt := range tests {
+		if got := isBlockedAddress(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("isBlockedAddress(%s) = %t, want %t", tt.addr, got, tt.want)
+		}
+	}
+}
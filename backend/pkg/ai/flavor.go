@@ -0,0 +1,56 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/genai"
+)
+
+const (
+	maxFlavorLength         = 60
+	flavorSystemInstruction = `You write the flavor text of a trading card for a member of a Japanese university club. Given the member's profile, write ONE short, witty and good-natured line (like the italic text on a trading card) in Japanese, at most 40 characters. Do not insult the person and do not mention appearance. Return ONLY the line, without quotes.`
+)
+
+// GenerateFlavor generates a one-line flavor text for a card from its profile
+func (s *GeminiService) GenerateFlavor(ctx context.Context, name, position, hobby, description string, grade int32) (string, error) {
+	promptText := fmt.Sprintf(`
+				Name: %s
+				Grade: %d
+				Position: %s
+				Hobby: %s
+				Description: %s
+			`, name, grade, position, hobby, description)
+
+	contents := []*genai.Content{
+		{
+			Parts: []*genai.Part{{Text: promptText}},
+		},
+	}
+
+	config := &genai.GenerateContentConfig{
+		SystemInstruction: &genai.Content{
+			Parts: []*genai.Part{
+				{Text: flavorSystemInstruction},
+			},
+		},
+	}
+
+	genResp, err := s.client.Models.GenerateContent(ctx, "gemini-2.5-flash", contents, config)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate content: %w", err)
+	}
+
+	flavor := strings.TrimSpace(genResp.Text())
+	flavor = strings.Trim(flavor, "\"'「」『』")
+	flavor = strings.TrimSpace(strings.SplitN(flavor, "\n", 2)[0])
+	if flavor == "" {
+		return "", fmt.Errorf("no flavor generated")
+	}
+	if utf8.RuneCountInString(flavor) > maxFlavorLength {
+		return "", fmt.Errorf("generated flavor is too long: %q", flavor)
+	}
+	return flavor, nil
+}
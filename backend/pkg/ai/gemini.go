@@ -3,23 +3,32 @@ package ai
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"strings"
-	"time"
 
 	"google.golang.org/genai"
 )
 
 type CardSuggestions struct {
-	Name        string `json:"name"`
-	Faculty     string `json:"faculty"`
-	Department  string `json:"department"`
-	Grade       int32  `json:"grade"`
-	Position    string `json:"position"`
-	Hobby       string `json:"hobby"`
-	Description string `json:"description"`
+	Name        string      `json:"name"`
+	Faculty     string      `json:"faculty"`
+	Department  string      `json:"department"`
+	Grade       int32       `json:"grade"`
+	Position    string      `json:"position"`
+	Hobby       string      `json:"hobby"`
+	Description string      `json:"description"`
+	StatProfile StatProfile `json:"stat_profile"`
+	TotalTokens int64       `json:"-"` // tokens consumed by the model, including repair retries (0 when offline)
+	// SafetyRatings of the accepted answer, checked by the moderation stage (empty when offline)
+	SafetyRatings []SafetyRating `json:"-"`
+}
+
+// StatProfile is the battle stat tendency proposed from the photo and profile
+type StatProfile struct {
+	Type      string  `json:"type"`      // balanced, tank, attacker or speedster
+	Intensity float32 `json:"intensity"` // 0.0 - 1.0
+	Reason    string  `json:"reason"`
 }
 
 type GeminiService struct {
@@ -27,9 +36,7 @@ type GeminiService struct {
 }
 
 const (
-	imageDownloadTimeout = 30 * time.Second
-	maxImageSize         = 10 * 1024 * 1024 // 10MB
-	systemInstruction    = `You are an AI assistant that analyzes photos of people to create a profile card. You will be provided with an image and potentially some existing information (Name, Faculty, Department, Grade, Position, Hobby, Description). Your task is to generate values for these fields. If a field is already provided, you can either use it as is, or refine it to be more interesting/funny if appropriate, but prefer keeping the core meaning. If a field is missing, generate a creative, slightly biased or opinionated, and interesting value based on the person's appearance in the photo. The 'Description' should be a short, witty bio. Return ONLY a JSON object with keys: name, faculty, department, grade (integer), position, hobby, description. All string values must be in Japanese.`
+	maxRepairAttempts = 2
 )
 
 func NewGeminiService(ctx context.Context, apiKey string) (*GeminiService, error) {
@@ -45,64 +52,28 @@ func NewGeminiService(ctx context.Context, apiKey string) (*GeminiService, error
 	}, nil
 }
 
-func (s *GeminiService) AnalyzeCardImage(ctx context.Context, imageURL string, currentName, currentFaculty, currentDepartment string, currentGrade int32, currentPosition, currentHobby, currentDescription string) (*CardSuggestions, error) {
-	// 画像をダウンロード
-	client := &http.Client{
-		Timeout: imageDownloadTimeout,
-	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch image: %w", err)
-	}
-	defer resp.Body.Close()
+func (s *GeminiService) AnalyzeCardImage(ctx context.Context, image *Image, input CompletionInput, opts PromptOptions) (*CardSuggestions, error) {
+	return s.analyzeCardImage(ctx, image, input, opts, nil)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch image: status code %d", resp.StatusCode)
-	}
+// AnalyzeCardImageStream streams the first answer and reports each field as soon as it is complete.
+// Repair retries, if needed, are not streamed; the returned suggestions are always validated.
+func (s *GeminiService) AnalyzeCardImageStream(ctx context.Context, image *Image, input CompletionInput, opts PromptOptions, onField FieldFunc) (*CardSuggestions, error) {
+	return s.analyzeCardImage(ctx, image, input, opts, onField)
+}
 
-	limitedReader := io.LimitReader(resp.Body, maxImageSize)
-	imageData, err := io.ReadAll(limitedReader)
+func (s *GeminiService) analyzeCardImage(ctx context.Context, image *Image, input CompletionInput, opts PromptOptions, onField FieldFunc) (*CardSuggestions, error) {
+	prompt, err := renderCardPrompt(CardPromptVersion, opts, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read image data: %w", err)
-	}
-	if len(imageData) == maxImageSize {
-		return nil, fmt.Errorf("image size exceeds maximum allowed size of %d bytes", maxImageSize)
-	}
-
-	// MIMEタイプを特定
-	detectedMime := http.DetectContentType(imageData)
-	var mimeType string
-	if strings.HasPrefix(detectedMime, "image/") {
-		mimeType = detectedMime
-	} else {
-		headerMime := resp.Header.Get("Content-Type")
-		headerMime = strings.TrimSpace(headerMime)
-		mimeType = headerMime
+		return nil, err
 	}
-	if mimeType == "" || !strings.HasPrefix(mimeType, "image/") {
-		mimeType = "image/jpeg"
-	}
-
-	promptText := fmt.Sprintf(`
-				Name: %s
-				Faculty: %s
-				Department: %s
-				Grade: %d
-				Position: %s
-				Hobby: %s
-				Description: %s
-			`, currentName, currentFaculty, currentDepartment, currentGrade, currentPosition, currentHobby, currentDescription)
 
 	parts := []*genai.Part{
 		{InlineData: &genai.Blob{
-			MIMEType: mimeType,
-			Data:     imageData,
+			MIMEType: image.MIMEType,
+			Data:     image.Data,
 		}},
-		{Text: promptText},
+		{Text: prompt.user},
 	}
 
 	contents := []*genai.Content{
@@ -114,41 +85,151 @@ func (s *GeminiService) AnalyzeCardImage(ctx context.Context, imageURL string, c
 	config := &genai.GenerateContentConfig{
 		SystemInstruction: &genai.Content{
 			Parts: []*genai.Part{
-				{Text: systemInstruction},
+				{Text: prompt.system},
 			},
 		},
 		ResponseMIMEType: "application/json",
+		ResponseSchema:   cardSuggestionsSchema,
 	}
 
+	// Retry with a repair prompt while the answer does not pass validation
+	var lastErr error
+	var totalTokens int64
+	for attempt := 0; attempt <= maxRepairAttempts; attempt++ {
+		var gen *generation
+		var err error
+		if attempt == 0 && onField != nil {
+			gen, err = s.generateStream(ctx, contents, config, onField)
+		} else {
+			gen, err = s.generate(ctx, contents, config)
+		}
+		if err != nil {
+			return nil, withTokens(err, totalTokens+ConsumedTokens(err))
+		}
+		totalTokens += gen.tokens
+
+		var suggestions CardSuggestions
+		if err := json.Unmarshal([]byte(gen.text), &suggestions); err != nil {
+			lastErr = fmt.Errorf("%w: failed to unmarshal response: %v", ErrInvalidSuggestions, err)
+		} else if err := suggestions.Validate(opts.Locale, input); err != nil {
+			lastErr = err
+		} else {
+			suggestions.TotalTokens = totalTokens
+			suggestions.SafetyRatings = gen.ratings
+			return &suggestions, nil
+		}
+
+		repair, err := renderRepairPrompt(CardPromptVersion, opts, lastErr)
+		if err != nil {
+			return nil, withTokens(err, totalTokens)
+		}
+		contents = append(contents,
+			&genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{{Text: gen.text}}},
+			&genai.Content{Role: genai.RoleUser, Parts: []*genai.Part{{Text: repair}}},
+		)
+	}
+
+	return nil, withTokens(lastErr, totalTokens)
+}
+
+// tokenError is a failure after the model has answered, carrying the tokens consumed until then
+type tokenError struct {
+	err    error
+	tokens int64
+}
+
+func (e *tokenError) Error() string {
+	return e.err.Error()
+}
+
+func (e *tokenError) Unwrap() error {
+	return e.err
+}
+
+// withTokens attaches the tokens consumed by a failed call to err
+func withTokens(err error, tokens int64) error {
+	if tokens <= 0 {
+		return err
+	}
+	return &tokenError{err: err, tokens: tokens}
+}
+
+// ConsumedTokens returns the tokens a failed call consumed before failing (e.g. invalid or blocked answers),
+// so that they can still be counted against the quota. 0 if the model was not reached.
+func ConsumedTokens(err error) int64 {
+	var e *tokenError
+	if errors.As(err, &e) {
+		return e.tokens
+	}
+	return 0
+}
+
+// generation is the text of one model answer with its usage and safety ratings
+type generation struct {
+	text    string
+	tokens  int64
+	ratings []SafetyRating
+}
+
+func (s *GeminiService) generate(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig) (*generation, error) {
 	// modelはgemini-2.5-flashを使用する
 	genResp, err := s.client.Models.GenerateContent(ctx, "gemini-2.5-flash", contents, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate content: %w", err)
 	}
 
-	if len(genResp.Candidates) == 0 || genResp.Candidates[0].Content == nil {
-		return nil, fmt.Errorf("no content generated")
+	gen := &generation{text: genResp.Text()}
+	if genResp.UsageMetadata != nil {
+		gen.tokens = int64(genResp.UsageMetadata.TotalTokenCount)
 	}
+	if gen.ratings, err = responseSafety(genResp); err != nil {
+		return nil, withTokens(err, gen.tokens)
+	}
+	if gen.text == "" {
+		return nil, withTokens(fmt.Errorf("no content generated"), gen.tokens)
+	}
+	return gen, nil
+}
 
-	var suggestions CardSuggestions
-	for _, part := range genResp.Candidates[0].Content.Parts {
-		if part.Text != "" {
-			jsonStr := part.Text
-			jsonStr = strings.TrimPrefix(jsonStr, "```json")
-			jsonStr = strings.TrimPrefix(jsonStr, "```")
-			jsonStr = strings.TrimSuffix(jsonStr, "```")
-
-			if err := json.Unmarshal([]byte(jsonStr), &suggestions); err != nil {
-				// 純粋なJSONの場合に備えて、標準的なアンマーシャルを試みる
-				if err2 := json.Unmarshal([]byte(part.Text), &suggestions); err2 != nil {
-					return nil, fmt.Errorf("failed to unmarshal response: %w, text: %s", err, part.Text)
-				}
-			}
-			return &suggestions, nil
+// generateStream is generate with the streaming API, calling onField for each field completed by a chunk
+func (s *GeminiService) generateStream(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig, onField FieldFunc) (*generation, error) {
+	gen := &generation{}
+	var text strings.Builder
+	emitted := 0
+	for genResp, err := range s.client.Models.GenerateContentStream(ctx, "gemini-2.5-flash", contents, config) {
+		if err != nil {
+			return nil, withTokens(fmt.Errorf("failed to generate content: %w", err), gen.tokens)
+		}
+		// Usage is cumulative, so the last chunk carries the total
+		if genResp.UsageMetadata != nil {
+			gen.tokens = int64(genResp.UsageMetadata.TotalTokenCount)
+		}
+		ratings, err := responseSafety(genResp)
+		if err != nil {
+			return nil, withTokens(err, gen.tokens)
 		}
+		if len(ratings) > 0 {
+			gen.ratings = ratings
+		}
+
+		text.WriteString(genResp.Text())
+		fields := partialFields(text.String())
+		for _, f := range fields[emitted:] {
+			onField(f.name, f.value)
+		}
+		emitted = len(fields)
 	}
 
-	return nil, fmt.Errorf("no text part in response")
+	gen.text = text.String()
+	if gen.text == "" {
+		return nil, withTokens(fmt.Errorf("no content generated"), gen.tokens)
+	}
+	return gen, nil
+}
+
+// PromptVersion is e.g. "card-v3/ja/funny"; add a new template directory when the schema or model changes as well
+func (s *GeminiService) PromptVersion(opts PromptOptions) string {
+	return opts.Version(CardPromptVersion)
 }
 
 func (s *GeminiService) Close() error {
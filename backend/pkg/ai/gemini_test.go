@@ -0,0 +1,39 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestConsumedTokens(t *testing.T) {
+	blocked := &BlockedError{Stage: SafetyStageOutput, Reason: "SAFETY"}
+	tests := []struct {
+		name string
+		err  error
+		want int64
+	}{
+		{"plain error", errors.New("network"), 0},
+		{"no tokens", withTokens(ErrInvalidSuggestions, 0), 0},
+		{"invalid answer", withTokens(ErrInvalidSuggestions, 120), 120},
+		{"wrapped", fmt.Errorf("failed: %w", withTokens(blocked, 80)), 80},
+		{"outermost total wins", withTokens(withTokens(blocked, 80), 200), 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConsumedTokens(tt.err); got != tt.want {
+				t.Errorf("ConsumedTokens() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	// The attached tokens must not hide the cause
+	err := withTokens(blocked, 80)
+	var got *BlockedError
+	if !errors.As(err, &got) || !errors.Is(err, ErrContentBlocked) {
+		t.Errorf("withTokens() hides the blocked error: %v", err)
+	}
+	if err.Error() != blocked.Error() {
+		t.Errorf("Error() = %q, want %q", err.Error(), blocked.Error())
+	}
+}
@@ -0,0 +1,177 @@
+package ai
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+
+	// Register the decoders accepted by the pipeline
+	_ "image/gif"
+	_ "image/png"
+)
+
+const (
+	DefaultImageMaxEdge = 1024
+	imageJPEGQuality    = 85
+	maxImagePixels      = 40_000_000 // reject decompression bombs before decoding
+	memberCropMargin    = 0.4        // margin around a detected face, relative to the face size
+)
+
+// ImagePipeline normalizes images before they are sent to the model.
+// Images are decoded (JPEG, PNG or GIF), rotated according to the EXIF orientation,
+// downsized to maxEdge and re-encoded as JPEG, which also drops EXIF and location metadata.
+type ImagePipeline struct {
+	maxEdge int
+}
+
+// NewImagePipeline creates a pipeline. maxEdge <= 0 uses DefaultImageMaxEdge.
+func NewImagePipeline(maxEdge int) *ImagePipeline {
+	if maxEdge <= 0 {
+		maxEdge = DefaultImageMaxEdge
+	}
+	return &ImagePipeline{maxEdge: maxEdge}
+}
+
+// Process returns the normalized image and its MIME type
+func (p *ImagePipeline) Process(data []byte) ([]byte, string, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrImageNotImage, err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxImagePixels {
+		return nil, "", fmt.Errorf("%w: %dx%d pixels", ErrImageTooLarge, config.Width, config.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrImageNotImage, err)
+	}
+
+	// Flatten onto white, since JPEG has no alpha channel
+	img := image.NewRGBA(src.Bounds())
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(img, img.Bounds(), src, src.Bounds().Min, draw.Over)
+
+	if format == "jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+	img = downsize(img, p.maxEdge)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: imageJPEGQuality}); err != nil {
+		return nil, "", fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), "image/jpeg", nil
+}
+
+// CropMember cuts the region of a detected person out of a normalized image,
+// widening the face box so that the hair and shoulders are included
+func CropMember(img *Image, box BoundingBox) (*Image, error) {
+	src, _, err := image.Decode(bytes.NewReader(img.Data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrImageNotImage, err)
+	}
+
+	b := src.Bounds()
+	w, h := float64(b.Dx()), float64(b.Dy())
+	marginX := (box.XMax - box.XMin) * memberCropMargin
+	marginY := (box.YMax - box.YMin) * memberCropMargin
+	rect := image.Rect(
+		b.Min.X+int(max(box.XMin-marginX, 0)*w),
+		b.Min.Y+int(max(box.YMin-marginY, 0)*h),
+		b.Min.X+int(min(box.XMax+marginX, 1)*w),
+		b.Min.Y+int(min(box.YMax+marginY, 1)*h),
+	).Intersect(b)
+	if rect.Empty() {
+		return nil, fmt.Errorf("crop region is empty: %+v", box)
+	}
+
+	cropped := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	draw.Draw(cropped, cropped.Bounds(), src, rect.Min, draw.Src)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, cropped, &jpeg.Options{Quality: imageJPEGQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return &Image{Data: buf.Bytes(), MIMEType: "image/jpeg"}, nil
+}
+
+// applyOrientation rotates/flips the image so that it is displayed upright (EXIF orientation 1-8)
+func applyOrientation(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		// 5-8 swap width and height
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // flip horizontal
+				dx, dy = w-1-x, y
+			case 3: // rotate 180
+				dx, dy = w-1-x, h-1-y
+			case 4: // flip vertical
+				dx, dy = x, h-1-y
+			case 5: // transpose
+				dx, dy = y, x
+			case 6: // rotate 90 clockwise
+				dx, dy = h-1-y, x
+			case 7: // transverse
+				dx, dy = h-1-y, w-1-x
+			case 8: // rotate 90 counter-clockwise
+				dx, dy = y, w-1-x
+			}
+			dst.SetRGBA(dx, dy, src.RGBAAt(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+// downsize scales the image so that its longer edge is at most maxEdge, averaging the covered source pixels
+func downsize(src *image.RGBA, maxEdge int) *image.RGBA {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= maxEdge && h <= maxEdge {
+		return src
+	}
+
+	dw, dh := maxEdge, h*maxEdge/w
+	if h > w {
+		dw, dh = w*maxEdge/h, maxEdge
+	}
+	dw, dh = max(dw, 1), max(dh, 1)
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for dy := 0; dy < dh; dy++ {
+		sy0, sy1 := dy*h/dh, max((dy+1)*h/dh, dy*h/dh+1)
+		for dx := 0; dx < dw; dx++ {
+			sx0, sx1 := dx*w/dw, max((dx+1)*w/dw, dx*w/dw+1)
+
+			var r, g, bl, a, n uint32
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					c := src.RGBAAt(b.Min.X+sx, b.Min.Y+sy)
+					r += uint32(c.R)
+					g += uint32(c.G)
+					bl += uint32(c.B)
+					a += uint32(c.A)
+					n++
+				}
+			}
+			dst.SetRGBA(dx, dy, color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(bl / n), A: uint8(a / n)})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,158 @@
+package ai
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+var (
+	red  = color.RGBA{R: 255, A: 255}
+	blue = color.RGBA{B: 255, A: 255}
+)
+
+// markedImage is a 4x2 image with a red top-left and a blue top-right pixel
+func markedImage() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	img.SetRGBA(0, 0, red)
+	img.SetRGBA(3, 0, blue)
+	return img
+}
+
+func TestApplyOrientation(t *testing.T) {
+	tests := []struct {
+		orientation int
+		w, h        int
+		red, blue   image.Point // where the top-left and top-right pixels end up
+	}{
+		{1, 4, 2, image.Pt(0, 0), image.Pt(3, 0)},
+		{2, 4, 2, image.Pt(3, 0), image.Pt(0, 0)}, // flip horizontal
+		{3, 4, 2, image.Pt(3, 1), image.Pt(0, 1)}, // rotate 180
+		{4, 4, 2, image.Pt(0, 1), image.Pt(3, 1)}, // flip vertical
+		{5, 2, 4, image.Pt(0, 0), image.Pt(0, 3)}, // transpose
+		{6, 2, 4, image.Pt(1, 0), image.Pt(1, 3)}, // rotate 90 clockwise
+		{7, 2, 4, image.Pt(1, 3), image.Pt(1, 0)}, // transverse
+		{8, 2, 4, image.Pt(0, 3), image.Pt(0, 0)}, // rotate 90 counter-clockwise
+		{0, 4, 2, image.Pt(0, 0), image.Pt(3, 0)}, // invalid values are ignored
+		{9, 4, 2, image.Pt(0, 0), image.Pt(3, 0)},
+	}
+	for _, tt := range tests {
+		got := applyOrientation(markedImage(), tt.orientation)
+		if got.Bounds().Dx() != tt.w || got.Bounds().Dy() != tt.h {
+			t.Errorf("orientation %d: size %v, want %dx%d", tt.orientation, got.Bounds().Size(), tt.w, tt.h)
+			continue
+		}
+		if c := got.RGBAAt(tt.red.X, tt.red.Y); c != red {
+			t.Errorf("orientation %d: pixel at %v = %v, want red", tt.orientation, tt.red, c)
+		}
+		if c := got.RGBAAt(tt.blue.X, tt.blue.Y); c != blue {
+			t.Errorf("orientation %d: pixel at %v = %v, want blue", tt.orientation, tt.blue, c)
+		}
+	}
+}
+
+func TestDownsize(t *testing.T) {
+	tests := []struct {
+		name         string
+		w, h         int
+		maxEdge      int
+		wantW, wantH int
+	}{
+		{"small enough", 100, 50, 100, 100, 50},
+		{"landscape", 200, 100, 50, 50, 25},
+		{"portrait", 100, 400, 100, 25, 100},
+		{"extreme aspect ratio", 1000, 2, 10, 10, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := downsize(image.NewRGBA(image.Rect(0, 0, tt.w, tt.h)), tt.maxEdge)
+			if got.Bounds().Dx() != tt.wantW || got.Bounds().Dy() != tt.wantH {
+				t.Errorf("downsize() = %v, want %dx%d", got.Bounds().Size(), tt.wantW, tt.wantH)
+			}
+		})
+	}
+
+	t.Run("averages pixels", func(t *testing.T) {
+		img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+		img.SetRGBA(0, 0, color.RGBA{R: 200, A: 255})
+		img.SetRGBA(1, 1, color.RGBA{R: 200, A: 255})
+		img.SetRGBA(1, 0, color.RGBA{A: 255})
+		img.SetRGBA(0, 1, color.RGBA{A: 255})
+		if got := downsize(img, 1).RGBAAt(0, 0); got != (color.RGBA{R: 100, A: 255}) {
+			t.Errorf("downsize() pixel = %v, want R=100", got)
+		}
+	})
+}
+
+func TestImagePipelineProcess(t *testing.T) {
+	t.Run("rotates by the exif orientation", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 40, 20)), nil); err != nil {
+			t.Fatal(err)
+		}
+		data := append([]byte{0xFF, 0xD8}, exifSegment(testTIFF(binary.LittleEndian, 6))...)
+		data = append(data, buf.Bytes()[2:]...)
+
+		out, mimeType, err := NewImagePipeline(0).Process(data)
+		if err != nil {
+			t.Fatalf("Process() error = %v", err)
+		}
+		config, err := jpeg.DecodeConfig(bytes.NewReader(out))
+		if err != nil {
+			t.Fatalf("output is not a JPEG: %v", err)
+		}
+		if mimeType != "image/jpeg" || config.Width != 20 || config.Height != 40 {
+			t.Errorf("Process() = %s %dx%d, want image/jpeg 20x40", mimeType, config.Width, config.Height)
+		}
+	})
+
+	t.Run("downsizes to the max edge", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 300, 150))); err != nil {
+			t.Fatal(err)
+		}
+		out, _, err := NewImagePipeline(100).Process(buf.Bytes())
+		if err != nil {
+			t.Fatalf("Process() error = %v", err)
+		}
+		config, err := jpeg.DecodeConfig(bytes.NewReader(out))
+		if err != nil || config.Width != 100 || config.Height != 50 {
+			t.Errorf("Process() = %dx%d (%v), want 100x50", config.Width, config.Height, err)
+		}
+	})
+
+	t.Run("rejects oversized images before decoding", func(t *testing.T) {
+		_, _, err := NewImagePipeline(0).Process(pngHeader(t, 20000, 20000))
+		if !errors.Is(err, ErrImageTooLarge) {
+			t.Errorf("Process() error = %v, want %v", err, ErrImageTooLarge)
+		}
+	})
+
+	t.Run("rejects other content", func(t *testing.T) {
+		_, _, err := NewImagePipeline(0).Process([]byte("<html></html>"))
+		if !errors.Is(err, ErrImageNotImage) {
+			t.Errorf("Process() error = %v, want %v", err, ErrImageNotImage)
+		}
+	})
+}
+
+// pngHeader returns a PNG that claims to be w x h pixels, without the pixel data
+func pngHeader(t *testing.T, w, h uint32) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	// Signature (8 bytes), then the IHDR chunk: length (4), type (4), width, height, ... and its CRC
+	binary.BigEndian.PutUint32(data[16:], w)
+	binary.BigEndian.PutUint32(data[20:], h)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
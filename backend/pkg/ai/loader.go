@@ -0,0 +1,66 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/storage"
+)
+
+var ErrImageNotFound = fmt.Errorf("%w: uploaded image not found", ErrInvalidImage)
+
+// Image is a normalized image ready to be sent to the model
+type Image struct {
+	Data     []byte
+	MIMEType string
+}
+
+// ImageLoader loads the image of a CompleteCard request, either uploaded with UploadCardImage
+// or fetched from an allowed storage host, and normalizes it
+type ImageLoader struct {
+	fetcher  *ImageFetcher
+	store    storage.Store
+	pipeline *ImagePipeline
+}
+
+func NewImageLoader(fetcher *ImageFetcher, store storage.Store, pipeline *ImagePipeline) *ImageLoader {
+	return &ImageLoader{
+		fetcher:  fetcher,
+		store:    store,
+		pipeline: pipeline,
+	}
+}
+
+// Load returns the image of imageID if set, or of imageURL
+func (l *ImageLoader) Load(ctx context.Context, imageURL, imageID string) (*Image, error) {
+	// Images in our own storage are read directly instead of over HTTP
+	if imageID == "" {
+		imageID, _ = l.store.KeyFromURL(imageURL)
+	}
+
+	var data []byte
+	if imageID != "" {
+		obj, err := l.store.Get(ctx, imageID)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrImageNotFound, imageID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load uploaded image: %w", err)
+		}
+		data = obj.Data
+	} else {
+		fetched, _, err := l.fetcher.Fetch(ctx, imageURL)
+		if err != nil {
+			return nil, err
+		}
+		data = fetched
+	}
+
+	// 縮小・向き補正・メタデータ除去してから送る
+	normalized, mimeType, err := l.pipeline.Process(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Image{Data: normalized, MIMEType: mimeType}, nil
+}
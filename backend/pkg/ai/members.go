@@ -0,0 +1,137 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"google.golang.org/genai"
+)
+
+const (
+	MaxDetectedMembers      = 10
+	minMemberBoxSize        = 0.02 // boxes smaller than 2% of the image edge are noise
+	memberBoxScale          = 1000 // Gemini returns box_2d normalized to 0-1000
+	detectSystemInstruction = `You detect the members in a photo of a Japanese university club. For every clearly visible real person (ignore people in posters, screens or reflections), return the bounding box of their face, as "box_2d": [ymin, xmin, ymax, xmax] normalized to 0-1000. Return at most 10 people, ordered from left to right. Return ONLY a JSON array of objects with the key box_2d.`
+)
+
+// BoundingBox is a region of the image with coordinates normalized to 0..1
+type BoundingBox struct {
+	XMin float64
+	YMin float64
+	XMax float64
+	YMax float64
+}
+
+// MemberDetection is the result of DetectMembers
+type MemberDetection struct {
+	Boxes       []BoundingBox // ordered from left to right
+	TotalTokens int64
+}
+
+// memberBoxesSchema is the structured-output schema of the detected faces
+var memberBoxesSchema = &genai.Schema{
+	Type:     genai.TypeArray,
+	MaxItems: genai.Ptr[int64](MaxDetectedMembers),
+	Items: &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"box_2d": {
+				Type:     genai.TypeArray,
+				Items:    &genai.Schema{Type: genai.TypeInteger},
+				MinItems: genai.Ptr[int64](4),
+				MaxItems: genai.Ptr[int64](4),
+			},
+		},
+		Required: []string{"box_2d"},
+	},
+}
+
+// DetectMembers returns the face region of every person in a group photo
+func (s *GeminiService) DetectMembers(ctx context.Context, image *Image) (*MemberDetection, error) {
+	contents := []*genai.Content{
+		{
+			Parts: []*genai.Part{
+				{InlineData: &genai.Blob{
+					MIMEType: image.MIMEType,
+					Data:     image.Data,
+				}},
+				{Text: "Detect the faces of the people in this photo."},
+			},
+		},
+	}
+
+	config := &genai.GenerateContentConfig{
+		SystemInstruction: &genai.Content{
+			Parts: []*genai.Part{
+				{Text: detectSystemInstruction},
+			},
+		},
+		ResponseMIMEType: "application/json",
+		ResponseSchema:   memberBoxesSchema,
+	}
+
+	genResp, err := s.client.Models.GenerateContent(ctx, "gemini-2.5-flash", contents, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate content: %w", err)
+	}
+	var tokens int64
+	if genResp.UsageMetadata != nil {
+		tokens = int64(genResp.UsageMetadata.TotalTokenCount)
+	}
+	if _, err := responseSafety(genResp); err != nil {
+		return nil, withTokens(err, tokens)
+	}
+
+	var detected []struct {
+		Box2D []float64 `json:"box_2d"`
+	}
+	if err := json.Unmarshal([]byte(genResp.Text()), &detected); err != nil {
+		return nil, withTokens(fmt.Errorf("%w: failed to unmarshal detected members: %v", ErrInvalidSuggestions, err), tokens)
+	}
+
+	var boxes []BoundingBox
+	for _, d := range detected {
+		if len(d.Box2D) != 4 {
+			continue
+		}
+		boxes = append(boxes, BoundingBox{
+			YMin: d.Box2D[0] / memberBoxScale,
+			XMin: d.Box2D[1] / memberBoxScale,
+			YMax: d.Box2D[2] / memberBoxScale,
+			XMax: d.Box2D[3] / memberBoxScale,
+		})
+	}
+
+	return &MemberDetection{Boxes: normalizeBoxes(boxes), TotalTokens: tokens}, nil
+}
+
+// normalizeBoxes clamps the boxes to the image, drops degenerate ones and orders them from left to right
+func normalizeBoxes(boxes []BoundingBox) []BoundingBox {
+	clamp := func(v float64) float64 {
+		return min(max(v, 0), 1)
+	}
+
+	var result []BoundingBox
+	for _, b := range boxes {
+		b = BoundingBox{
+			XMin: clamp(min(b.XMin, b.XMax)),
+			YMin: clamp(min(b.YMin, b.YMax)),
+			XMax: clamp(max(b.XMin, b.XMax)),
+			YMax: clamp(max(b.YMin, b.YMax)),
+		}
+		if b.XMax-b.XMin < minMemberBoxSize || b.YMax-b.YMin < minMemberBoxSize {
+			continue
+		}
+		result = append(result, b)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].XMin < result[j].XMin
+	})
+	if len(result) > MaxDetectedMembers {
+		result = result[:MaxDetectedMembers]
+	}
+	return result
+}
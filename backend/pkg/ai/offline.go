@@ -0,0 +1,270 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// OfflineService is a deterministic rule-based CardCompleter that needs no network or API key.
+// The same input always produces the same suggestions, so it can be used for local development and tests.
+type OfflineService struct{}
+
+func NewOfflineService() *OfflineService {
+	return &OfflineService{}
+}
+
+// offlineFixtures holds the fixture texts of one locale
+type offlineFixtures struct {
+	names          []string
+	faculties      []string
+	departments    []string
+	positions      []string
+	hobbies        []string
+	descriptions   []string
+	balancedReason string
+}
+
+var (
+	offlineNames        = []string{"部室の主", "謎の新入生", "伝説の先輩", "期待のルーキー", "癒やし担当", "ムードメーカー"}
+	offlineFaculties    = []string{"工学部", "理学部", "経済学部", "文学部", "情報学部", "教育学部"}
+	offlineDepartments  = []string{"情報工学科", "機械工学科", "数学科", "経済学科", "日本文学科", "教育学科"}
+	offlinePositions    = []string{"部員", "代表", "副代表", "会計", "広報", "書記"}
+	offlineHobbies      = []string{"ゲーム", "カラオケ", "筋トレ", "読書", "ラーメン巡り", "プログラミング", "昼寝"}
+	offlineDescriptions = []string{
+		"部室にいる時間が一番長い。",
+		"いつも笑顔でみんなを和ませる。",
+		"締め切り前だけ本気を出す。",
+		"差し入れのセンスが光る。",
+		"誰よりも早く集合場所に着く。",
+	}
+	offlineFlavors = []string{
+		"今日も部室の平和を守っている。",
+		"その笑顔の裏に締め切りが迫る。",
+		"気づけばいつも輪の中心にいる。",
+		"本気を出すのはいつも明日から。",
+	}
+)
+
+var offlineFixturesByLocale = map[string]offlineFixtures{
+	LocaleJa: {
+		names:          offlineNames,
+		faculties:      offlineFaculties,
+		departments:    offlineDepartments,
+		positions:      offlinePositions,
+		hobbies:        offlineHobbies,
+		descriptions:   offlineDescriptions,
+		balancedReason: "バランスの取れた万能タイプに見えるから。",
+	},
+	LocaleEn: {
+		names:       []string{"Club Room Regular", "Mystery Freshman", "Legendary Senior", "Rising Rookie", "Chief of Good Vibes", "Mood Maker"},
+		faculties:   []string{"Faculty of Engineering", "Faculty of Science", "Faculty of Economics", "Faculty of Letters", "Faculty of Informatics", "Faculty of Education"},
+		departments: []string{"Computer Science", "Mechanical Engineering", "Mathematics", "Economics", "Japanese Literature", "Education"},
+		positions:   []string{"Member", "President", "Vice President", "Treasurer", "PR Lead", "Secretary"},
+		hobbies:     []string{"Gaming", "Karaoke", "Weight training", "Reading", "Ramen hunting", "Programming", "Napping"},
+		descriptions: []string{
+			"Spends more time in the club room than anyone.",
+			"Always smiling and putting everyone at ease.",
+			"Only gets serious right before a deadline.",
+			"Known for excellent taste in snacks.",
+			"Always the first to arrive at the meeting point.",
+		},
+		balancedReason: "Looks like a well-rounded all-rounder.",
+	},
+}
+
+func (f offlineFixtures) forField(field string) []string {
+	switch field {
+	case "name":
+		return f.names
+	case "faculty":
+		return f.faculties
+	case "department":
+		return f.departments
+	case "position":
+		return f.positions
+	case "hobby":
+		return f.hobbies
+	case "description":
+		return f.descriptions
+	}
+	return nil
+}
+
+// offlineProfileRules maps hobby keywords to a stat profile
+var offlineProfileRules = []struct {
+	keywords    []string
+	profileType string
+	reason      string
+}{
+	{[]string{"筋トレ", "ラグビー", "相撲", "柔道"}, "tank", "鍛えた体でどんな攻撃も受け止めそうだから。"},
+	{[]string{"サッカー", "バスケ", "テニス", "野球", "格闘", "ボクシング"}, "attacker", "勝負どころで強烈な一撃を放ちそうだから。"},
+	{[]string{"ランニング", "陸上", "ダンス", "ゲーム", "自転車"}, "speedster", "身軽で手数の多さが武器になりそうだから。"},
+	{[]string{"weight training", "rugby", "sumo", "judo"}, "tank", "Looks trained enough to take any hit."},
+	{[]string{"soccer", "football", "basketball", "tennis", "baseball", "boxing"}, "attacker", "Seems ready to land a big hit when it counts."},
+	{[]string{"running", "track", "dance", "gaming", "cycling"}, "speedster", "Light on their feet with plenty of moves."},
+}
+
+// AnalyzeCardImage keeps the given fields and fills the missing ones from fixtures chosen by hashing the input.
+// The fixtures follow the locale; the tone is ignored.
+func (s *OfflineService) AnalyzeCardImage(ctx context.Context, image *Image, input CompletionInput, opts PromptOptions) (*CardSuggestions, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	fixtures, ok := offlineFixturesByLocale[opts.Locale]
+	if !ok {
+		fixtures = offlineFixturesByLocale[LocaleJa]
+	}
+	seed := offlineSeed(string(image.Data), input.Name)
+
+	suggestions := &CardSuggestions{
+		Name:        orPick(input.Name, fixtures.names, seed, 0),
+		Faculty:     orPick(input.Faculty, fixtures.faculties, seed, 1),
+		Department:  orPick(input.Department, fixtures.departments, seed, 2),
+		Grade:       input.Grade,
+		Position:    orPick(input.Position, fixtures.positions, seed, 3),
+		Hobby:       orPick(input.Hobby, fixtures.hobbies, seed, 4),
+		Description: orPick(input.Description, fixtures.descriptions, seed, 5),
+	}
+	if suggestions.Grade < 1 || suggestions.Grade > 4 {
+		suggestions.Grade = int32(seed%4) + 1
+	}
+	suggestions.StatProfile = offlineStatProfile(suggestions.Hobby, fixtures.balancedReason, seed)
+
+	return suggestions, nil
+}
+
+// AnalyzeCardImageStream reports the fields of AnalyzeCardImage one by one, in the order of the JSON answer
+func (s *OfflineService) AnalyzeCardImageStream(ctx context.Context, image *Image, input CompletionInput, opts PromptOptions, onField FieldFunc) (*CardSuggestions, error) {
+	suggestions, err := s.AnalyzeCardImage(ctx, image, input, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	onField("name", suggestions.Name)
+	onField("faculty", suggestions.Faculty)
+	onField("department", suggestions.Department)
+	onField("grade", strconv.Itoa(int(suggestions.Grade)))
+	onField("position", suggestions.Position)
+	onField("hobby", suggestions.Hobby)
+	onField("description", suggestions.Description)
+	onField("stat_profile.type", suggestions.StatProfile.Type)
+	onField("stat_profile.intensity", strconv.FormatFloat(float64(suggestions.StatProfile.Intensity), 'f', -1, 32))
+	onField("stat_profile.reason", suggestions.StatProfile.Reason)
+	return suggestions, nil
+}
+
+// RegenerateField picks n fixtures of the field other than the current value, starting at a position chosen by hashing the input
+func (s *OfflineService) RegenerateField(ctx context.Context, input CompletionInput, field string, n int, opts PromptOptions) (*FieldAlternatives, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if _, ok := regenerableFields[field]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedField, field)
+	}
+
+	fixtures, ok := offlineFixturesByLocale[opts.Locale]
+	if !ok {
+		fixtures = offlineFixturesByLocale[LocaleJa]
+	}
+	candidates := fixtures.forField(field)
+	seed := offlineSeed(input.Name, input.Position, input.Hobby, input.Description, field)
+
+	rotated := make([]string, len(candidates))
+	for i := range candidates {
+		rotated[i] = candidates[(int(seed%uint64(len(candidates)))+i)%len(candidates)]
+	}
+	values := validAlternatives(rotated, field, fieldValue(input, field), min(max(n, 1), MaxFieldAlternatives), opts.Locale)
+	return &FieldAlternatives{Values: values}, nil
+}
+
+// DetectMembers splits the photo into one to four columns chosen by hashing the image,
+// with a face-sized box in the upper part of each column
+func (s *OfflineService) DetectMembers(ctx context.Context, image *Image) (*MemberDetection, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	count := int(offlineSeed(string(image.Data))%4) + 1
+	width := 1 / float64(count)
+	var boxes []BoundingBox
+	for i := 0; i < count; i++ {
+		left := float64(i) * width
+		boxes = append(boxes, BoundingBox{
+			XMin: left + width*0.2,
+			YMin: 0.15,
+			XMax: left + width*0.8,
+			YMax: 0.15 + min(width*0.6, 0.5),
+		})
+	}
+	return &MemberDetection{Boxes: boxes}, nil
+}
+
+// GenerateFlavor picks a fixed flavor text by hashing the profile
+func (s *OfflineService) GenerateFlavor(ctx context.Context, name, position, hobby, description string, grade int32) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	seed := offlineSeed(name, position, hobby, description)
+	return offlineFlavors[seed%uint64(len(offlineFlavors))], nil
+}
+
+// GenerateCommentary states the facts of the turn in a fixed sentence
+func (s *OfflineService) GenerateCommentary(ctx context.Context, turn BattleTurn) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if turn.Action == BattleActionRetreat {
+		return fmt.Sprintf("%sは%sを下げて%sを投入！", turn.Circle, turn.Target.Name, turn.Card.Name), nil
+	}
+
+	commentary := fmt.Sprintf("%sの%sが%sに%dダメージ！", turn.Circle, turn.Card.Name, turn.Target.Name, turn.Damage)
+	if turn.KnockOut {
+		commentary += fmt.Sprintf("%sはダウン！", turn.Target.Name)
+	}
+	if turn.Win {
+		commentary += fmt.Sprintf("%sの勝利！", turn.Circle)
+	}
+	return commentary, nil
+}
+
+// PromptVersion is e.g. "offline-v2/ja"; the fixtures do not depend on the tone
+func (s *OfflineService) PromptVersion(opts PromptOptions) string {
+	return "offline-v2/" + opts.Locale
+}
+
+func (s *OfflineService) Close() error {
+	return nil
+}
+
+func offlineStatProfile(hobby, balancedReason string, seed uint64) StatProfile {
+	hobby = strings.ToLower(hobby)
+	for _, rule := range offlineProfileRules {
+		for _, keyword := range rule.keywords {
+			if strings.Contains(hobby, keyword) {
+				return StatProfile{Type: rule.profileType, Intensity: 0.5, Reason: rule.reason}
+			}
+		}
+	}
+	return StatProfile{Type: "balanced", Intensity: float32(seed%5) / 10, Reason: balancedReason}
+}
+
+func offlineSeed(values ...string) uint64 {
+	h := fnv.New64a()
+	for _, v := range values {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
+
+// orPick returns current if it is set, or a fixture chosen by the seed (each field uses different bits of it)
+func orPick(current string, fixtures []string, seed uint64, field uint) string {
+	if strings.TrimSpace(current) != "" {
+		return current
+	}
+	return fixtures[(seed>>(field*8))%uint64(len(fixtures))]
+}
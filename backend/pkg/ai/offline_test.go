@@ -0,0 +1,218 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+var testImage = &Image{Data: []byte("not really a jpeg"), MIMEType: "image/jpeg"}
+
+func TestOfflineAnalyzeCardImage(t *testing.T) {
+	tests := []struct {
+		name  string
+		input CompletionInput
+		opts  PromptOptions
+		check func(t *testing.T, got *CardSuggestions)
+	}{
+		{
+			name:  "fills every field",
+			input: CompletionInput{},
+			opts:  PromptOptions{Locale: LocaleJa},
+			check: func(t *testing.T, got *CardSuggestions) {
+				for field, value := range map[string]string{
+					"name": got.Name, "faculty": got.Faculty, "department": got.Department,
+					"position": got.Position, "hobby": got.Hobby, "description": got.Description,
+				} {
+					if !slices.Contains(offlineFixturesByLocale[LocaleJa].forField(field), value) {
+						t.Errorf("%s = %q, want a ja fixture", field, value)
+					}
+				}
+			},
+		},
+		{
+			name:  "keeps the given fields",
+			input: CompletionInput{Name: "山田太郎", Grade: 3, Position: "代表", Hobby: "筋トレ"},
+			opts:  PromptOptions{Locale: LocaleJa},
+			check: func(t *testing.T, got *CardSuggestions) {
+				if got.Name != "山田太郎" || got.Grade != 3 || got.Position != "代表" || got.Hobby != "筋トレ" {
+					t.Errorf("got %+v, want the input fields kept", got)
+				}
+				if got.StatProfile.Type != "tank" {
+					t.Errorf("stat profile = %q, want tank for 筋トレ", got.StatProfile.Type)
+				}
+			},
+		},
+		{
+			name:  "english fixtures",
+			input: CompletionInput{Hobby: "Weight training"},
+			opts:  PromptOptions{Locale: LocaleEn},
+			check: func(t *testing.T, got *CardSuggestions) {
+				if !slices.Contains(offlineFixturesByLocale[LocaleEn].names, got.Name) {
+					t.Errorf("name = %q, want an en fixture", got.Name)
+				}
+				if got.StatProfile.Type != "tank" {
+					t.Errorf("stat profile = %q, want tank for weight training", got.StatProfile.Type)
+				}
+			},
+		},
+		{
+			name:  "unknown locale falls back to ja",
+			input: CompletionInput{},
+			opts:  PromptOptions{Locale: "fr"},
+			check: func(t *testing.T, got *CardSuggestions) {
+				if !slices.Contains(offlineNames, got.Name) {
+					t.Errorf("name = %q, want a ja fixture", got.Name)
+				}
+			},
+		},
+	}
+	s := NewOfflineService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.AnalyzeCardImage(context.Background(), testImage, tt.input, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Grade < 1 || got.Grade > 4 {
+				t.Errorf("grade = %d, want 1-4", got.Grade)
+			}
+			if got.StatProfile.Intensity < 0 || got.StatProfile.Intensity > 1 {
+				t.Errorf("intensity = %v, want 0-1", got.StatProfile.Intensity)
+			}
+			tt.check(t, got)
+
+			again, _ := s.AnalyzeCardImage(context.Background(), testImage, tt.input, tt.opts)
+			if !reflect.DeepEqual(got, again) {
+				t.Errorf("suggestions differ for the same input: %+v, %+v", got, again)
+			}
+		})
+	}
+}
+
+func TestOfflineAnalyzeCardImageStream(t *testing.T) {
+	s := NewOfflineService()
+	fields := map[string]string{}
+	var order []string
+	got, err := s.AnalyzeCardImageStream(context.Background(), testImage, CompletionInput{}, PromptOptions{Locale: LocaleJa}, func(field, value string) {
+		fields[field] = value
+		order = append(order, field)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"name", "faculty", "department", "grade", "position", "hobby", "description",
+		"stat_profile.type", "stat_profile.intensity", "stat_profile.reason"}
+	if !slices.Equal(order, want) {
+		t.Errorf("fields reported in %v, want %v", order, want)
+	}
+	if fields["name"] != got.Name || fields["description"] != got.Description || fields["stat_profile.type"] != got.StatProfile.Type {
+		t.Errorf("streamed fields %v do not match the result %+v", fields, got)
+	}
+}
+
+func TestOfflineRegenerateField(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   CompletionInput
+		field   string
+		n       int
+		want    int
+		wantErr error
+	}{
+		{name: "hobby", input: CompletionInput{Hobby: "ゲーム"}, field: "hobby", n: 3, want: 3},
+		{name: "n is capped", field: "position", n: 100, want: MaxFieldAlternatives},
+		{name: "n is at least one", field: "description", n: 0, want: 1},
+		{name: "grade is not regenerable", field: "grade", n: 3, wantErr: ErrUnsupportedField},
+	}
+	s := NewOfflineService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.RegenerateField(context.Background(), tt.input, tt.field, tt.n, PromptOptions{Locale: LocaleJa})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Values) != tt.want {
+				t.Errorf("got %d values %v, want %d", len(got.Values), got.Values, tt.want)
+			}
+			if current := fieldValue(tt.input, tt.field); current != "" && slices.Contains(got.Values, current) {
+				t.Errorf("values %v contain the current value %q", got.Values, current)
+			}
+		})
+	}
+}
+
+func TestOfflineDetectMembers(t *testing.T) {
+	s := NewOfflineService()
+	for _, data := range []string{"a", "b", "group photo", "another photo"} {
+		got, err := s.DetectMembers(context.Background(), &Image{Data: []byte(data)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got.Boxes) < 1 || len(got.Boxes) > 4 {
+			t.Errorf("%q: %d boxes, want 1-4", data, len(got.Boxes))
+		}
+		for _, b := range got.Boxes {
+			if b.XMin < 0 || b.YMin < 0 || b.XMax > 1 || b.YMax > 1 || b.XMin >= b.XMax || b.YMin >= b.YMax {
+				t.Errorf("%q: box %+v is not inside the photo", data, b)
+			}
+		}
+	}
+}
+
+func TestOfflineCanceled(t *testing.T) {
+	s := NewOfflineService()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := s.AnalyzeCardImage(ctx, testImage, CompletionInput{}, PromptOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("AnalyzeCardImage err = %v, want context.Canceled", err)
+	}
+	if _, err := s.DetectMembers(ctx, testImage); !errors.Is(err, context.Canceled) {
+		t.Errorf("DetectMembers err = %v, want context.Canceled", err)
+	}
+	if _, err := s.GenerateFlavor(ctx, "name", "", "", "", 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("GenerateFlavor err = %v, want context.Canceled", err)
+	}
+}
+
+func TestNewCardCompleter(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		apiKey   string
+		offline  bool
+		wantErr  bool
+	}{
+		{name: "default without api key", offline: true},
+		{name: "offline", provider: ProviderOffline, apiKey: "key", offline: true},
+		{name: "gemini without api key", provider: ProviderGemini, wantErr: true},
+		{name: "unknown provider", provider: "openai", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCardCompleter(context.Background(), tt.provider, tt.apiKey)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %T, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := got.(*OfflineService); ok != tt.offline {
+				t.Errorf("got %T", got)
+			}
+		})
+	}
+}
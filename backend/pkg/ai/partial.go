@@ -0,0 +1,68 @@
+package ai
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// FieldFunc receives a field of a streamed answer as soon as it is complete.
+// Nested fields are named with a dot (e.g. "stat_profile.type"), and numbers are formatted as strings.
+type FieldFunc func(field, value string)
+
+type partialField struct {
+	name  string
+	value string
+}
+
+// partialFields returns the scalar fields that are complete in a possibly truncated JSON object, in document order.
+// A number at the very end of the text is left out, since more digits may follow.
+func partialFields(doc string) []partialField {
+	dec := json.NewDecoder(strings.NewReader(doc))
+	dec.UseNumber()
+
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil
+	}
+	var fields []partialField
+	walkPartialObject(dec, len(strings.TrimRight(doc, " \t\r\n")), "", &fields)
+	return fields
+}
+
+// walkPartialObject reads the members of an object until it ends or the text runs out
+func walkPartialObject(dec *json.Decoder, end int, prefix string, fields *[]partialField) bool {
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return false
+		}
+		key, ok := keyTok.(string)
+		if !ok {
+			return false
+		}
+
+		tok, err := dec.Token()
+		if err != nil {
+			return false
+		}
+		switch v := tok.(type) {
+		case json.Delim:
+			if v != '{' || !walkPartialObject(dec, end, prefix+key+".", fields) {
+				return false
+			}
+			if _, err := dec.Token(); err != nil { // closing '}'
+				return false
+			}
+		case string:
+			*fields = append(*fields, partialField{name: prefix + key, value: v})
+		case json.Number:
+			if int(dec.InputOffset()) >= end {
+				return false
+			}
+			*fields = append(*fields, partialField{name: prefix + key, value: v.String()})
+		case bool:
+			*fields = append(*fields, partialField{name: prefix + key, value: strconv.FormatBool(v)})
+		}
+	}
+	return true
+}
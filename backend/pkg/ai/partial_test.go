@@ -0,0 +1,82 @@
+package ai
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestPartialFields(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want []partialField
+	}{
+		{name: "empty", doc: "", want: nil},
+		{name: "not an object", doc: `["a"]`, want: nil},
+		{name: "open object", doc: `{`, want: nil},
+		{name: "key without value", doc: `{"name": `, want: nil},
+		{name: "truncated string", doc: `{"name": "山田`, want: nil},
+		{
+			name: "complete string",
+			doc:  `{"name": "山田太郎", "faculty": "工`,
+			want: []partialField{{name: "name", value: "山田太郎"}},
+		},
+		{
+			name: "number at the end may continue",
+			doc:  `{"name": "a", "grade": 1`,
+			want: []partialField{{name: "name", value: "a"}},
+		},
+		{
+			name: "number followed by more text",
+			doc:  `{"name": "a", "grade": 12, `,
+			want: []partialField{{name: "name", value: "a"}, {name: "grade", value: "12"}},
+		},
+		{
+			name: "nested object in progress",
+			doc:  `{"hobby": "x", "stat_profile": {"type": "tank", "intensity": 0.5, "reason": "鍛`,
+			want: []partialField{
+				{name: "hobby", value: "x"},
+				{name: "stat_profile.type", value: "tank"},
+				{name: "stat_profile.intensity", value: "0.5"},
+			},
+		},
+		{
+			name: "complete document with escapes and a bool",
+			doc:  "{\"description\": \"say \\\"hi\\\"\\n\", \"ok\": true, \"stat_profile\": {\"type\": \"tank\"}}\n",
+			want: []partialField{
+				{name: "description", value: "say \"hi\"\n"},
+				{name: "ok", value: "true"},
+				{name: "stat_profile.type", value: "tank"},
+			},
+		},
+		{
+			name: "stops at an array",
+			doc:  `{"name": "a", "tags": ["x"], "hobby": "b"}`,
+			want: []partialField{{name: "name", value: "a"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := partialFields(tt.doc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("partialFields(%q) = %v, want %v", tt.doc, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPartialFieldsGrowing feeds a document chunk by chunk, as the stream does, and checks that fields only ever get added
+func TestPartialFieldsGrowing(t *testing.T) {
+	doc := `{"name": "山田太郎", "grade": 3, "position": "代表", "stat_profile": {"type": "tank", "intensity": 0.75, "reason": "強そう"}}`
+	var previous []partialField
+	for i := 1; i <= len(doc); i++ {
+		got := partialFields(doc[:i])
+		if len(got) < len(previous) || !slices.Equal(got[:len(previous)], previous) {
+			t.Fatalf("at %d: fields %v do not extend %v", i, got, previous)
+		}
+		previous = got
+	}
+	if len(previous) != 6 {
+		t.Errorf("got %d fields from the whole document, want 6: %v", len(previous), previous)
+	}
+}
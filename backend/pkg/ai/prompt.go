@@ -0,0 +1,156 @@
+package ai
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"text/template"
+)
+
+const (
+	LocaleJa = "ja"
+	LocaleEn = "en"
+
+	ToneFunny   = "funny"
+	ToneFormal  = "formal"
+	ToneNeutral = "neutral"
+
+	// CardPromptVersion is the prompt template set used for card generation (a directory under prompts/).
+	// Add a new directory instead of editing a released one, so generations stay comparable.
+	CardPromptVersion = "card-v3"
+)
+
+// ErrInvalidPromptOptions is returned for an unknown locale or tone
+var ErrInvalidPromptOptions = errors.New("invalid prompt options")
+
+//go:embed prompts
+var promptFS embed.FS
+
+// cardPrompts holds the parsed template set of each prompt version.
+// Each set has system_<locale>.tmpl, user.tmpl and repair.tmpl, and field_<locale>.tmpl for RegenerateField.
+var cardPrompts = mustLoadPrompts()
+
+// PromptOptions selects the language and tone of the generated card
+type PromptOptions struct {
+	Locale string // LocaleJa or LocaleEn
+	Tone   string // ToneFunny, ToneFormal or ToneNeutral
+}
+
+// ParsePromptOptions validates a locale and tone, defaulting to Japanese and funny
+func ParsePromptOptions(locale, tone string) (PromptOptions, error) {
+	opts := PromptOptions{
+		Locale: strings.ToLower(strings.TrimSpace(locale)),
+		Tone:   strings.ToLower(strings.TrimSpace(tone)),
+	}
+	if opts.Locale == "" {
+		opts.Locale = LocaleJa
+	}
+	if opts.Tone == "" {
+		opts.Tone = ToneFunny
+	}
+
+	if opts.Locale != LocaleJa && opts.Locale != LocaleEn {
+		return PromptOptions{}, fmt.Errorf("%w: unknown locale %q", ErrInvalidPromptOptions, locale)
+	}
+	if opts.Tone != ToneFunny && opts.Tone != ToneFormal && opts.Tone != ToneNeutral {
+		return PromptOptions{}, fmt.Errorf("%w: unknown tone %q", ErrInvalidPromptOptions, tone)
+	}
+	return opts, nil
+}
+
+// Version returns the identifier recorded with the generated card, e.g. "card-v3/ja/funny"
+func (o PromptOptions) Version(promptVersion string) string {
+	return promptVersion + "/" + o.Locale + "/" + o.Tone
+}
+
+// promptData is the data available to the templates
+type promptData struct {
+	Locale   string
+	Tone     string
+	Input    CompletionInput
+	Error    string
+	Field    string // field to regenerate
+	N        int    // number of alternatives
+	MinGrade int
+	MaxGrade int
+}
+
+// cardPrompt is a rendered card generation prompt
+type cardPrompt struct {
+	system string
+	user   string
+}
+
+func renderCardPrompt(version string, opts PromptOptions, input CompletionInput) (*cardPrompt, error) {
+	data := promptData{Locale: opts.Locale, Tone: opts.Tone, Input: input, MinGrade: minGrade, MaxGrade: maxGrade}
+
+	system, err := renderPrompt(version, "system_"+opts.Locale+".tmpl", data)
+	if err != nil {
+		return nil, err
+	}
+	user, err := renderPrompt(version, "user.tmpl", data)
+	if err != nil {
+		return nil, err
+	}
+	return &cardPrompt{system: system, user: user}, nil
+}
+
+func renderFieldPrompt(version string, opts PromptOptions, input CompletionInput, field string, n int) (*cardPrompt, error) {
+	data := promptData{Locale: opts.Locale, Tone: opts.Tone, Input: input, Field: field, N: n, MinGrade: minGrade, MaxGrade: maxGrade}
+
+	system, err := renderPrompt(version, "field_"+opts.Locale+".tmpl", data)
+	if err != nil {
+		return nil, err
+	}
+	user, err := renderPrompt(version, "user.tmpl", data)
+	if err != nil {
+		return nil, err
+	}
+	return &cardPrompt{system: system, user: user}, nil
+}
+
+func renderRepairPrompt(version string, opts PromptOptions, cause error) (string, error) {
+	return renderPrompt(version, "repair.tmpl", promptData{
+		Locale:   opts.Locale,
+		Tone:     opts.Tone,
+		Error:    cause.Error(),
+		MinGrade: minGrade,
+		MaxGrade: maxGrade,
+	})
+}
+
+func renderPrompt(version, name string, data promptData) (string, error) {
+	set, ok := cardPrompts[version]
+	if !ok {
+		return "", fmt.Errorf("unknown prompt version: %s", version)
+	}
+	var buf bytes.Buffer
+	if err := set.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt %s/%s: %w", version, name, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// mustLoadPrompts parses every version directory under prompts/. The templates are embedded,
+// so a parse error is a programming error.
+func mustLoadPrompts() map[string]*template.Template {
+	entries, err := fs.ReadDir(promptFS, "prompts")
+	if err != nil {
+		panic(err)
+	}
+
+	sets := make(map[string]*template.Template)
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		sets[e.Name()] = template.Must(template.New(e.Name()).Option("missingkey=error").ParseFS(promptFS, "prompts/"+e.Name()+"/*.tmpl"))
+	}
+	if _, ok := sets[CardPromptVersion]; !ok {
+		panic("missing prompt templates for " + CardPromptVersion)
+	}
+	return sets
+}
@@ -0,0 +1,109 @@
+package ai
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParsePromptOptions(t *testing.T) {
+	tests := []struct {
+		locale, tone string
+		want         PromptOptions
+		wantErr      bool
+	}{
+		{want: PromptOptions{Locale: LocaleJa, Tone: ToneFunny}},
+		{locale: " EN ", tone: "Formal", want: PromptOptions{Locale: LocaleEn, Tone: ToneFormal}},
+		{locale: "ja", tone: "neutral", want: PromptOptions{Locale: LocaleJa, Tone: ToneNeutral}},
+		{locale: "fr", wantErr: true},
+		{tone: "angry", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePromptOptions(tt.locale, tt.tone)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidPromptOptions) {
+				t.Errorf("ParsePromptOptions(%q, %q) err = %v, want ErrInvalidPromptOptions", tt.locale, tt.tone, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParsePromptOptions(%q, %q) = %+v, %v, want %+v", tt.locale, tt.tone, got, err, tt.want)
+		}
+	}
+
+	if got := (PromptOptions{Locale: LocaleEn, Tone: ToneFormal}).Version(CardPromptVersion); got != CardPromptVersion+"/en/formal" {
+		t.Errorf("Version() = %q", got)
+	}
+}
+
+// TestRenderCardPrompt renders every locale and tone of the current templates
+func TestRenderCardPrompt(t *testing.T) {
+	toneLines := map[string]map[string]string{
+		LocaleJa: {ToneFunny: "ユーモア", ToneFormal: "丁寧", ToneNeutral: "中立的"},
+		LocaleEn: {ToneFunny: "humorous", ToneFormal: "polite", ToneNeutral: "neutral style"},
+	}
+	input := CompletionInput{Name: "山田太郎", Grade: 2, Hobby: "Python"}
+	for locale, tones := range toneLines {
+		for tone, line := range tones {
+			opts := PromptOptions{Locale: locale, Tone: tone}
+			t.Run(opts.Version(CardPromptVersion), func(t *testing.T) {
+				card, err := renderCardPrompt(CardPromptVersion, opts, input)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.Contains(card.system, line) {
+					t.Errorf("system prompt does not contain %q:\n%s", line, card.system)
+				}
+				for _, other := range tones {
+					if other != line && strings.Contains(card.system, other) {
+						t.Errorf("system prompt for %s also contains %q", tone, other)
+					}
+				}
+				if strings.Contains(card.system, "{{") || strings.Contains(card.system, "<no value>") {
+					t.Errorf("system prompt is not fully rendered:\n%s", card.system)
+				}
+				for _, want := range []string{"Name: 山田太郎", "Grade: 2", "Hobby: Python", "Faculty: \n"} {
+					if !strings.Contains(card.user+"\n", want) {
+						t.Errorf("user prompt does not contain %q:\n%s", want, card.user)
+					}
+				}
+
+				field, err := renderFieldPrompt(CardPromptVersion, opts, input, "hobby", 3)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.Contains(field.system, "hobby") || !strings.Contains(field.system, "3") {
+					t.Errorf("field prompt does not name the field and count:\n%s", field.system)
+				}
+			})
+		}
+	}
+}
+
+func TestRenderRepairPrompt(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{locale: LocaleJa, want: "Japanese"},
+		{locale: LocaleEn, want: "English"},
+	}
+	for _, tt := range tests {
+		got, err := renderRepairPrompt(CardPromptVersion, PromptOptions{Locale: tt.locale, Tone: ToneFunny}, errors.New("grade must be between 1 and 4"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(got, "grade must be between 1 and 4") || !strings.Contains(got, tt.want) {
+			t.Errorf("repair prompt for %s = %q", tt.locale, got)
+		}
+	}
+}
+
+func TestRenderPromptUnknownVersion(t *testing.T) {
+	if _, err := renderCardPrompt("card-v0", PromptOptions{Locale: LocaleJa, Tone: ToneFunny}, CompletionInput{}); err == nil {
+		t.Error("renderCardPrompt succeeded for an unknown version")
+	}
+	if _, err := renderCardPrompt(CardPromptVersion, PromptOptions{Locale: "fr", Tone: ToneFunny}, CompletionInput{}); err == nil {
+		t.Error("renderCardPrompt succeeded for a locale without templates")
+	}
+}
@@ -0,0 +1,141 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+const (
+	MaxFieldAlternatives     = 5
+	DefaultFieldAlternatives = 3
+)
+
+// ErrUnsupportedField is returned by RegenerateField for a field it cannot rewrite
+var ErrUnsupportedField = errors.New("unsupported field")
+
+// regenerableFields holds the length limit of each field RegenerateField can rewrite.
+// The grade is a number, so there is nothing to choose from.
+var regenerableFields = map[string]int{
+	"name":        maxNameLength,
+	"faculty":     maxFacultyLength,
+	"department":  maxDepartmentLength,
+	"position":    maxPositionLength,
+	"hobby":       maxHobbyLength,
+	"description": maxDescriptionLength,
+}
+
+// IsRegenerableField reports whether RegenerateField can rewrite the field
+func IsRegenerableField(field string) bool {
+	_, ok := regenerableFields[field]
+	return ok
+}
+
+// FieldAlternatives is the result of RegenerateField
+type FieldAlternatives struct {
+	Values        []string // distinct, valid and different from the current value
+	TotalTokens   int64
+	SafetyRatings []SafetyRating
+}
+
+// RegenerateField writes up to n alternatives for one field, keeping the other fields as context.
+// Invalid alternatives are dropped rather than repaired, so fewer than n may be returned.
+func (s *GeminiService) RegenerateField(ctx context.Context, input CompletionInput, field string, n int, opts PromptOptions) (*FieldAlternatives, error) {
+	maxLength, ok := regenerableFields[field]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedField, field)
+	}
+	n = min(max(n, 1), MaxFieldAlternatives)
+
+	prompt, err := renderFieldPrompt(CardPromptVersion, opts, input, field, n)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := []*genai.Content{
+		{
+			Parts: []*genai.Part{{Text: prompt.user}},
+		},
+	}
+	config := &genai.GenerateContentConfig{
+		SystemInstruction: &genai.Content{
+			Parts: []*genai.Part{{Text: prompt.system}},
+		},
+		ResponseMIMEType: "application/json",
+		ResponseSchema: &genai.Schema{
+			Type: genai.TypeObject,
+			Properties: map[string]*genai.Schema{
+				"values": {
+					Type:     genai.TypeArray,
+					Items:    stringSchema(field, int64(maxLength)),
+					MinItems: genai.Ptr[int64](1),
+					MaxItems: genai.Ptr(int64(n)),
+				},
+			},
+			Required: []string{"values"},
+		},
+	}
+
+	gen, err := s.generate(ctx, contents, config)
+	if err != nil {
+		return nil, err
+	}
+
+	var answer struct {
+		Values []string `json:"values"`
+	}
+	if err := json.Unmarshal([]byte(gen.text), &answer); err != nil {
+		return nil, withTokens(fmt.Errorf("%w: failed to unmarshal alternatives: %v", ErrInvalidSuggestions, err), gen.tokens)
+	}
+
+	values := validAlternatives(answer.Values, field, fieldValue(input, field), n, opts.Locale)
+	if len(values) == 0 {
+		return nil, withTokens(fmt.Errorf("%w: no valid alternative for %s", ErrInvalidSuggestions, field), gen.tokens)
+	}
+	return &FieldAlternatives{Values: values, TotalTokens: gen.tokens, SafetyRatings: gen.ratings}, nil
+}
+
+// validAlternatives trims the values and keeps the first n that pass the same checks as CardSuggestions.Validate,
+// dropping duplicates and the current value
+func validAlternatives(values []string, field, current string, n int, locale string) []string {
+	maxLength := regenerableFields[field]
+	// Only the description is a sentence; the other fields may be written in Latin letters (see CardSuggestions.Validate)
+	japanese := locale != LocaleEn && field == "description"
+
+	seen := map[string]bool{strings.TrimSpace(current): true}
+	var result []string
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if seen[v] || len(checkText(nil, field, v, maxLength, japanese)) > 0 {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+		if len(result) == n {
+			break
+		}
+	}
+	return result
+}
+
+func fieldValue(input CompletionInput, field string) string {
+	switch field {
+	case "name":
+		return input.Name
+	case "faculty":
+		return input.Faculty
+	case "department":
+		return input.Department
+	case "position":
+		return input.Position
+	case "hobby":
+		return input.Hobby
+	case "description":
+		return input.Description
+	}
+	return ""
+}
@@ -0,0 +1,84 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/genai"
+)
+
+const (
+	SafetyStageInput  = "input"  // the photo and prompt
+	SafetyStageOutput = "output" // the generated answer
+)
+
+// ErrContentBlocked is wrapped by BlockedError
+var ErrContentBlocked = errors.New("content was blocked by the model's safety filters")
+
+// SafetyRating is the model's harm assessment of the input or of the generated answer
+type SafetyRating struct {
+	Stage       string // SafetyStageInput or SafetyStageOutput
+	Category    string // e.g. HARM_CATEGORY_HARASSMENT
+	Probability string // NEGLIGIBLE, LOW, MEDIUM or HIGH
+	Blocked     bool
+}
+
+// BlockedError is returned when the model refuses the input or stops its answer for safety reasons
+type BlockedError struct {
+	Stage   string
+	Reason  string // block or finish reason reported by the model
+	Ratings []SafetyRating
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("%s: %s %s", ErrContentBlocked, e.Stage, e.Reason)
+}
+
+func (e *BlockedError) Unwrap() error {
+	return ErrContentBlocked
+}
+
+// safetyFinishReasons are the finish reasons meaning the answer was cut for safety
+var safetyFinishReasons = map[genai.FinishReason]bool{
+	genai.FinishReasonSafety:            true,
+	genai.FinishReasonBlocklist:         true,
+	genai.FinishReasonProhibitedContent: true,
+	genai.FinishReasonSPII:              true,
+	genai.FinishReasonImageSafety:       true,
+}
+
+// responseSafety returns the input and output safety ratings of a response,
+// or a *BlockedError if the prompt or the answer was blocked
+func responseSafety(resp *genai.GenerateContentResponse) ([]SafetyRating, error) {
+	var ratings []SafetyRating
+	if feedback := resp.PromptFeedback; feedback != nil {
+		ratings = appendRatings(ratings, SafetyStageInput, feedback.SafetyRatings)
+		if feedback.BlockReason != "" {
+			return nil, &BlockedError{Stage: SafetyStageInput, Reason: string(feedback.BlockReason), Ratings: ratings}
+		}
+	}
+
+	if len(resp.Candidates) > 0 {
+		candidate := resp.Candidates[0]
+		ratings = appendRatings(ratings, SafetyStageOutput, candidate.SafetyRatings)
+		if safetyFinishReasons[candidate.FinishReason] {
+			return nil, &BlockedError{Stage: SafetyStageOutput, Reason: string(candidate.FinishReason), Ratings: ratings}
+		}
+	}
+	return ratings, nil
+}
+
+func appendRatings(ratings []SafetyRating, stage string, src []*genai.SafetyRating) []SafetyRating {
+	for _, r := range src {
+		if r == nil {
+			continue
+		}
+		ratings = append(ratings, SafetyRating{
+			Stage:       stage,
+			Category:    string(r.Category),
+			Probability: string(r.Probability),
+			Blocked:     r.Blocked,
+		})
+	}
+	return ratings
+}
@@ -0,0 +1,110 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/genai"
+)
+
+const (
+	minGrade             = 1
+	maxGrade             = 4
+	maxNameLength        = 50
+	maxFacultyLength     = 50
+	maxDepartmentLength  = 50
+	maxPositionLength    = 30
+	maxHobbyLength       = 100
+	maxDescriptionLength = 500
+	maxReasonLength      = 100
+)
+
+// ErrInvalidSuggestions is returned when the model keeps answering with invalid fields after the repair retries
+var ErrInvalidSuggestions = errors.New("generated suggestions are invalid")
+
+// cardSuggestionsSchema is the structured-output schema of CardSuggestions
+var cardSuggestionsSchema = &genai.Schema{
+	Type: genai.TypeObject,
+	Properties: map[string]*genai.Schema{
+		"name":        stringSchema("名前", maxNameLength),
+		"faculty":     stringSchema("学部", maxFacultyLength),
+		"department":  stringSchema("学科", maxDepartmentLength),
+		"grade":       {Type: genai.TypeInteger, Description: "学年", Minimum: genai.Ptr[float64](minGrade), Maximum: genai.Ptr[float64](maxGrade)},
+		"position":    stringSchema("役職", maxPositionLength),
+		"hobby":       stringSchema("趣味", maxHobbyLength),
+		"description": stringSchema("短くてユーモアのある紹介文", maxDescriptionLength),
+		"stat_profile": {
+			Type: genai.TypeObject,
+			Properties: map[string]*genai.Schema{
+				"type":      {Type: genai.TypeString, Enum: []string{"balanced", "tank", "attacker", "speedster"}},
+				"intensity": {Type: genai.TypeNumber, Minimum: genai.Ptr[float64](0), Maximum: genai.Ptr[float64](1)},
+				"reason":    stringSchema("そのタイプを選んだ理由 (一文)", maxReasonLength),
+			},
+			Required:         []string{"type", "intensity", "reason"},
+			PropertyOrdering: []string{"type", "intensity", "reason"},
+		},
+	},
+	Required:         []string{"name", "faculty", "department", "grade", "position", "hobby", "description", "stat_profile"},
+	PropertyOrdering: []string{"name", "faculty", "department", "grade", "position", "hobby", "description", "stat_profile"},
+}
+
+func stringSchema(description string, maxLength int64) *genai.Schema {
+	return &genai.Schema{
+		Type:        genai.TypeString,
+		Description: description,
+		MinLength:   genai.Ptr[int64](1),
+		MaxLength:   genai.Ptr(maxLength),
+	}
+}
+
+// Validate checks the fields the schema cannot express: length in characters, and Japanese text for LocaleJa.
+// Short fields may be proper nouns in Latin letters ("Python", "K-POP"), so only the sentences must contain
+// Japanese, and the description not when it was copied from the input.
+func (c *CardSuggestions) Validate(locale string, input CompletionInput) error {
+	var errs []string
+	japanese := locale != LocaleEn
+
+	if c.Grade < minGrade || c.Grade > maxGrade {
+		errs = append(errs, fmt.Sprintf("grade must be between %d and %d", minGrade, maxGrade))
+	}
+
+	errs = checkText(errs, "name", c.Name, maxNameLength, false)
+	errs = checkText(errs, "faculty", c.Faculty, maxFacultyLength, false)
+	errs = checkText(errs, "department", c.Department, maxDepartmentLength, false)
+	errs = checkText(errs, "position", c.Position, maxPositionLength, false)
+	errs = checkText(errs, "hobby", c.Hobby, maxHobbyLength, false)
+	copied := strings.TrimSpace(c.Description) == strings.TrimSpace(input.Description)
+	errs = checkText(errs, "description", c.Description, maxDescriptionLength, japanese && !copied)
+	errs = checkText(errs, "stat_profile.reason", c.StatProfile.Reason, maxReasonLength, japanese)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidSuggestions, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func checkText(errs []string, field, value string, maxLength int, japanese bool) []string {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "":
+		return append(errs, field+" is empty")
+	case utf8.RuneCountInString(value) > maxLength:
+		return append(errs, fmt.Sprintf("%s must be at most %d characters", field, maxLength))
+	case japanese && !containsJapanese(value):
+		return append(errs, field+" must be written in Japanese")
+	}
+	return errs
+}
+
+// containsJapanese reports whether s contains hiragana, katakana or kanji
+func containsJapanese(s string) bool {
+	for _, r := range s {
+		if unicode.In(r, unicode.Hiragana, unicode.Katakana, unicode.Han) {
+			return true
+		}
+	}
+	return false
+}
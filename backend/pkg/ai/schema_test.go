@@ -0,0 +1,81 @@
+package ai
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func validSuggestions() CardSuggestions {
+	return CardSuggestions{
+		Name:        "山田太郎",
+		Faculty:     "工学部",
+		Department:  "情報工学科",
+		Grade:       2,
+		Position:    "部員",
+		Hobby:       "ゲーム",
+		Description: "部室にいる時間が一番長い。",
+		StatProfile: StatProfile{Type: "balanced", Intensity: 0.3, Reason: "バランスが良さそうだから。"},
+	}
+}
+
+func TestCardSuggestionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *CardSuggestions)
+		input   CompletionInput
+		locale  string
+		wantErr bool
+	}{
+		{name: "valid", modify: func(c *CardSuggestions) {}, locale: LocaleJa},
+		{name: "latin hobby", modify: func(c *CardSuggestions) { c.Hobby = "Python" }, locale: LocaleJa},
+		{name: "latin hobby and position", modify: func(c *CardSuggestions) { c.Hobby, c.Position = "K-POP", "PM" }, locale: LocaleJa},
+		{name: "romaji name", modify: func(c *CardSuggestions) { c.Name = "Taro Yamada" }, locale: LocaleJa},
+		{
+			name:    "english description written by the model",
+			modify:  func(c *CardSuggestions) { c.Description = "Always in the club room." },
+			locale:  LocaleJa,
+			wantErr: true,
+		},
+		{
+			name:   "english description copied from the input",
+			modify: func(c *CardSuggestions) { c.Description = "Always in the club room." },
+			input:  CompletionInput{Description: " Always in the club room. "},
+			locale: LocaleJa,
+		},
+		{
+			name:    "english reason",
+			modify:  func(c *CardSuggestions) { c.StatProfile.Reason = "Looks balanced." },
+			locale:  LocaleJa,
+			wantErr: true,
+		},
+		{
+			name: "english locale",
+			modify: func(c *CardSuggestions) {
+				c.Description, c.StatProfile.Reason = "Always in the club room.", "Looks balanced."
+			},
+			locale: LocaleEn,
+		},
+		{name: "grade out of range", modify: func(c *CardSuggestions) { c.Grade = 5 }, locale: LocaleJa, wantErr: true},
+		{name: "empty field", modify: func(c *CardSuggestions) { c.Faculty = " " }, locale: LocaleJa, wantErr: true},
+		{
+			name:    "too long",
+			modify:  func(c *CardSuggestions) { c.Description = strings.Repeat("あ", maxDescriptionLength+1) },
+			locale:  LocaleJa,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validSuggestions()
+			tt.modify(&c)
+			err := c.Validate(tt.locale, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidSuggestions) {
+				t.Errorf("Validate() = %v, want ErrInvalidSuggestions", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package authn
+
+import (
+	"context"
+	"strings"
+
+	"firebase.google.com/go/v4/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// IDTokenVerifier verifies the Firebase ID token of the caller (auth.Client)
+type IDTokenVerifier interface {
+	VerifyIDToken(ctx context.Context, idToken string) (*auth.Token, error)
+}
+
+// Authenticator identifies callers by the Firebase ID token sent as "authorization: Bearer <token>" metadata
+type Authenticator struct {
+	verifier IDTokenVerifier // nil rejects every call
+}
+
+func NewAuthenticator(verifier IDTokenVerifier) *Authenticator {
+	return &Authenticator{verifier: verifier}
+}
+
+// UserID verifies the caller's ID token and returns their user ID.
+// Errors are gRPC statuses: Unauthenticated, or Unavailable when no verifier is configured.
+func (a *Authenticator) UserID(ctx context.Context) (string, error) {
+	if a.verifier == nil {
+		return "", status.Error(codes.Unavailable, "authentication is not configured")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	var idToken string
+	if values := md.Get("authorization"); len(values) > 0 {
+		idToken, _ = strings.CutPrefix(values[0], "Bearer ")
+	}
+	if idToken == "" {
+		return "", status.Error(codes.Unauthenticated, "an ID token is required")
+	}
+
+	token, err := a.verifier.VerifyIDToken(ctx, idToken)
+	if err != nil {
+		return "", status.Errorf(codes.Unauthenticated, "invalid ID token: %v", err)
+	}
+	return token.UID, nil
+}
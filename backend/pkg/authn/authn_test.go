@@ -0,0 +1,56 @@
+package authn
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"firebase.google.com/go/v4/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeVerifier accepts the ID tokens in its map, returning the mapped user ID
+type fakeVerifier map[string]string
+
+func (f fakeVerifier) VerifyIDToken(ctx context.Context, idToken string) (*auth.Token, error) {
+	uid, ok := f[idToken]
+	if !ok {
+		return nil, errors.New("token is invalid")
+	}
+	return &auth.Token{UID: uid}, nil
+}
+
+func TestUserID(t *testing.T) {
+	verifier := fakeVerifier{"user-token": "user"}
+	tests := []struct {
+		name     string
+		verifier IDTokenVerifier
+		header   string
+		want     codes.Code
+	}{
+		{name: "valid token", verifier: verifier, header: "Bearer user-token", want: codes.OK},
+		{name: "invalid token", verifier: verifier, header: "Bearer forged", want: codes.Unauthenticated},
+		{name: "no token", verifier: verifier, want: codes.Unauthenticated},
+		{name: "empty bearer token", verifier: verifier, header: "Bearer ", want: codes.Unauthenticated},
+		{name: "no verifier", header: "Bearer user-token", want: codes.Unavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAuthenticator(tt.verifier)
+			ctx := context.Background()
+			if tt.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.header))
+			}
+
+			uid, err := a.UserID(ctx)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("UserID() = %v, want %v", err, tt.want)
+			}
+			if err == nil && uid != "user" {
+				t.Errorf("uid = %q, want user", uid)
+			}
+		})
+	}
+}
@@ -2,18 +2,27 @@ package battle
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"cloud.google.com/go/firestore"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/collection"
 	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
-	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
 	CollectionCards   = "cards"
 	CollectionCircles = "circles"
+
+	maxCircleCards = 20
+	// maxCardScanBatches bounds how many batches ListCards reads to fill the limit when most cards are graduated
+	maxCardScanBatches = 10
 )
 
+var errCircleRequired = errors.New("circle ID is required")
+
 // GetCircleName retrieves the name of a circle from Firestore
 func (r *CardRepository) GetCircleName(ctx context.Context, circleID string) (string, error) {
 	doc, err := r.client.Collection(CollectionCircles).Doc(circleID).Get(ctx)
@@ -28,69 +37,140 @@ func (r *CardRepository) GetCircleName(ctx context.Context, circleID string) (st
 }
 
 type CardRepository struct {
-	client *firestore.Client
+	client    *firestore.Client
+	userCards *collection.Repository
 }
 
-func NewCardRepository(client *firestore.Client) *CardRepository {
-	return &CardRepository{client: client}
+func NewCardRepository(client *firestore.Client, userCards *collection.Repository) *CardRepository {
+	return &CardRepository{client: client, userCards: userCards}
+}
+
+// GetCircleCards retrieves cards for a given circle from Firestore.
+// Graduated (expired) cards are excluded unless includeGraduated is set.
+func (r *CardRepository) GetCircleCards(ctx context.Context, circleID string, includeGraduated bool) ([]*ptera.Card, error) {
+	// ListCards reads the public pool (every card) for an empty circle ID
+	if circleID == "" {
+		return nil, errCircleRequired
+	}
+	cards, err := r.ListCards(ctx, circleID, includeGraduated, maxCircleCards)
+	if err != nil {
+		return nil, err
+	}
+
+	// If no cards found, return error
+	if len(cards) == 0 {
+		return nil, fmt.Errorf("no active cards found for circle %s (include_graduated=%t)", circleID, includeGraduated)
+	}
+
+	return cards, nil
 }
 
-// GetCircleCards retrieves cards for a given circle from Firestore
-func (r *CardRepository) GetCircleCards(ctx context.Context, circleID string) ([]*ptera.Card, error) {
-	// Query cards where circleId == circleID
-	iter := r.client.Collection(CollectionCards).
-		Where("circleId", "==", circleID).
-		Limit(20). // Limit to 20 cards
-		Documents(ctx)
+// ListCards retrieves up to limit cards from Firestore, filtered by circle when circleID is not empty.
+// Graduated (expired) cards are excluded unless includeGraduated is set.
+func (r *CardRepository) ListCards(ctx context.Context, circleID string, includeGraduated bool, limit int) ([]*ptera.Card, error) {
+	query := r.client.Collection(CollectionCards).OrderBy(firestore.DocumentID, firestore.Asc)
+	if circleID != "" {
+		// Query cards where circleId == circleID
+		query = query.Where("circleId", "==", circleID)
+	}
 
+	// expiryDate may be missing on older cards, so graduation is filtered here instead of in the query.
+	// Cards are read in batches of limit until enough active cards are found, so the query is always bounded.
+	now := time.Now()
 	var cards []*ptera.Card
-	for {
-		doc, err := iter.Next()
-		if err == iterator.Done {
-			break
+	var last *firestore.DocumentSnapshot
+	for batch := 0; batch < maxCardScanBatches && len(cards) < limit; batch++ {
+		page := query.Limit(limit)
+		if last != nil {
+			page = page.StartAfter(last)
 		}
+		docs, err := page.Documents(ctx).GetAll()
 		if err != nil {
-			return nil, fmt.Errorf("failed to iterate cards: %w", err)
+			return nil, fmt.Errorf("failed to get cards: %w", err)
 		}
 
-		data := doc.Data()
-
-		// Map Firestore document to proto Card
-		card := &ptera.Card{
-			Id:          doc.Ref.ID,
-			Name:        getStringField(data, "name"),
-			Grade:       int32(getIntField(data, "grade")),
-			Position:    getStringField(data, "position"),
-			Hobby:       getStringField(data, "hobby"),
-			Description: getStringField(data, "description"),
-			ImageUrl:    getStringField(data, "imageUrl"),
-			CreatorId:   getStringField(data, "creatorId"),
+		for _, doc := range docs {
+			card := cardFromDocument(doc, now)
+			if card.Graduated && !includeGraduated {
+				continue
+			}
+			if len(cards) < limit {
+				cards = append(cards, card)
+			}
 		}
-
-		// Set optional fields (pointers)
-		if circleId := getStringField(data, "circleId"); circleId != "" {
-			card.CircleId = &circleId
-		}
-		if affiliatedGroup := getStringField(data, "affiliatedGroup"); affiliatedGroup != "" {
-			card.AffiliatedGroup = &affiliatedGroup
+		if len(docs) < limit {
+			break
 		}
+		last = docs[len(docs)-1]
+	}
 
-		// Generate battle stats based on card ID and grade
-		battleStats := GenerateBattleStats(card.Id, card.Grade)
-		card.MaxHp = battleStats.MaxHp
-		card.Attack = battleStats.Attack
-		card.Flavor = battleStats.Flavor
-		card.CurrentHp = battleStats.MaxHp // Initialize current HP to max
+	return cards, nil
+}
 
-		cards = append(cards, card)
+// cardFromDocument maps a Firestore card document to a proto Card with battle stats
+func cardFromDocument(doc *firestore.DocumentSnapshot, now time.Time) *ptera.Card {
+	data := doc.Data()
+
+	// Map Firestore document to proto Card
+	card := &ptera.Card{
+		Id:          doc.Ref.ID,
+		Name:        getStringField(data, "name"),
+		Grade:       int32(getIntField(data, "grade")),
+		Position:    getStringField(data, "position"),
+		Hobby:       getStringField(data, "hobby"),
+		Description: getStringField(data, "description"),
+		ImageUrl:    getStringField(data, "imageUrl"),
+		CreatorId:   getStringField(data, "creatorId"),
 	}
 
-	// If no cards found, return error
-	if len(cards) == 0 {
-		return nil, fmt.Errorf("no cards found for circle %s", circleID)
+	// Set optional fields (pointers)
+	if circleId := getStringField(data, "circleId"); circleId != "" {
+		card.CircleId = &circleId
+	}
+	if affiliatedGroup := getStringField(data, "affiliatedGroup"); affiliatedGroup != "" {
+		card.AffiliatedGroup = &affiliatedGroup
 	}
 
-	return cards, nil
+	// Timestamps (expiryDate falls back to the graduation date, same as the frontend)
+	createdAt := getTimeField(data, "createdAt")
+	if createdAt.IsZero() {
+		createdAt = now
+	}
+	card.CreatedAt = timestamppb.New(createdAt)
+
+	expiryDate := getTimeField(data, "expiryDate")
+	if expiryDate.IsZero() {
+		expiryDate = GraduationDate(card.Grade, createdAt)
+	}
+	card.ExpiryDate = timestamppb.New(expiryDate)
+	card.Graduated = IsGraduated(expiryDate, now)
+
+	card.StatProfile = statProfileFromDocument(data)
+	card.PromptVersion = getStringField(data, "promptVersion")
+
+	// Use battle stats derived at write time, or generate them for older cards
+	battleStats := GenerateCardStats(card)
+	card.MaxHp = battleStats.MaxHp
+	card.Attack = battleStats.Attack
+	if maxHp := getIntField(data, "maxHp"); maxHp > 0 {
+		card.MaxHp = int32(maxHp)
+	}
+	if attack := getIntField(data, "attack"); attack > 0 {
+		card.Attack = int32(attack)
+	}
+
+	// Flavor: AI-generated (cached) > stored > derived from the card
+	card.Flavor = GenerateFlavor(card)
+	if flavor := getStringField(data, "flavor"); flavor != "" && flavor != LegacyFlavor {
+		card.Flavor = flavor
+	}
+	if aiFlavor := getStringField(data, "aiFlavor"); aiFlavor != "" {
+		card.Flavor = aiFlavor
+		card.FlavorAiGenerated = true
+	}
+	card.CurrentHp = card.MaxHp // Initialize current HP to max
+
+	return card
 }
 
 // Helper functions to safely extract fields from Firestore data
@@ -116,3 +196,13 @@ func getIntField(data map[string]interface{}, field string) int {
 	}
 	return 0
 }
+
+func getTimeField(data map[string]interface{}, field string) time.Time {
+	if val, ok := data[field]; ok {
+		// Firestore returns Timestamp as time.Time
+		if t, ok := val.(time.Time); ok {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,321 @@
+package battle
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrCardNotFound     = errors.New("card not found")
+	ErrInvalidPageToken = errors.New("invalid page token")
+)
+
+// CardFilter narrows down ListCardsPage
+type CardFilter struct {
+	CircleID         string
+	CreatorID        string
+	IncludeGraduated bool
+}
+
+// GetCard retrieves a single card from Firestore
+func (r *CardRepository) GetCard(ctx context.Context, cardID string) (*ptera.Card, error) {
+	doc, err := r.client.Collection(CollectionCards).Doc(cardID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrCardNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card: %w", err)
+	}
+	return cardFromDocument(doc, time.Now()), nil
+}
+
+// GetCards retrieves cards by ID, keeping the given order. Cards that do not exist are skipped.
+func (r *CardRepository) GetCards(ctx context.Context, cardIDs []string) ([]*ptera.Card, error) {
+	if len(cardIDs) == 0 {
+		return nil, nil
+	}
+
+	refs := make([]*firestore.DocumentRef, len(cardIDs))
+	for i, id := range cardIDs {
+		refs[i] = r.client.Collection(CollectionCards).Doc(id)
+	}
+
+	docs, err := r.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cards: %w", err)
+	}
+
+	now := time.Now()
+	cards := make([]*ptera.Card, 0, len(docs))
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
+		cards = append(cards, cardFromDocument(doc, now))
+	}
+	return cards, nil
+}
+
+// CreateCard saves a new card. Battle stats are derived from the new card ID and grade.
+func (r *CardRepository) CreateCard(ctx context.Context, card *ptera.Card) (*ptera.Card, error) {
+	ref := r.client.Collection(CollectionCards).NewDoc()
+	card.Id = ref.ID
+
+	stats := GenerateCardStats(card)
+	card.MaxHp = stats.MaxHp
+	card.Attack = stats.Attack
+	card.Flavor = GenerateFlavor(card)
+
+	data := cardToDocument(card)
+	data["flavor"] = card.Flavor
+	data["createdAt"] = card.CreatedAt.AsTime()
+	data["creatorId"] = card.CreatorId
+
+	if _, err := ref.Create(ctx, data); err != nil {
+		return nil, fmt.Errorf("failed to create card: %w", err)
+	}
+
+	return r.GetCard(ctx, card.Id)
+}
+
+// UpdateCard reads the card in a transaction, lets apply validate and modify it, and saves the result.
+// creatorId and createdAt are never changed.
+func (r *CardRepository) UpdateCard(ctx context.Context, cardID string, apply func(current *ptera.Card) error) (*ptera.Card, error) {
+	ref := r.client.Collection(CollectionCards).Doc(cardID)
+
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return ErrCardNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get card: %w", err)
+		}
+
+		card := cardFromDocument(doc, time.Now())
+		grade, position, hobby := card.Grade, card.Position, card.Hobby
+		profile := card.StatProfile
+		if err := apply(card); err != nil {
+			return err
+		}
+
+		// Stats depend on grade and stat profile, so regenerate them when they change
+		if card.Grade != grade || !proto.Equal(card.StatProfile, profile) {
+			stats := GenerateCardStats(card)
+			card.MaxHp = stats.MaxHp
+			card.Attack = stats.Attack
+		}
+
+		data := cardToDocument(card)
+		// The derived flavor follows the profile (an AI-generated one is kept in aiFlavor)
+		if card.Grade != grade || card.Position != position || card.Hobby != hobby {
+			data["flavor"] = GenerateFlavor(card)
+		}
+
+		return tx.Set(ref, data, firestore.MergeAll)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetCard(ctx, cardID)
+}
+
+// SetAIFlavor caches an AI-generated flavor on the card. An existing one is never overwritten.
+func (r *CardRepository) SetAIFlavor(ctx context.Context, cardID, flavor string) error {
+	ref := r.client.Collection(CollectionCards).Doc(cardID)
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return ErrCardNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get card: %w", err)
+		}
+		if getStringField(doc.Data(), "aiFlavor") != "" {
+			return nil
+		}
+
+		return tx.Update(ref, []firestore.Update{
+			{Path: "aiFlavor", Value: flavor},
+			{Path: "aiFlavorRetryAt", Value: firestore.Delete},
+			{Path: "aiFlavorFailures", Value: firestore.Delete},
+		})
+	})
+}
+
+// ClaimAIFlavor marks the AI flavor of the card as being generated until now+AIFlavorLease,
+// so that one instance generates it at a time. Returns false when the card already has an
+// AI flavor, or another generation is running or backing off after a failure.
+func (r *CardRepository) ClaimAIFlavor(ctx context.Context, cardID string, now time.Time) (bool, error) {
+	ref := r.client.Collection(CollectionCards).Doc(cardID)
+
+	var claimed bool
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		claimed = false
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return ErrCardNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get card: %w", err)
+		}
+		data := doc.Data()
+		if getStringField(data, "aiFlavor") != "" || now.Before(getTimeField(data, "aiFlavorRetryAt")) {
+			return nil
+		}
+
+		claimed = true
+		return tx.Update(ref, []firestore.Update{
+			{Path: "aiFlavorRetryAt", Value: now.Add(AIFlavorLease)},
+		})
+	})
+	if err != nil {
+		return false, err
+	}
+	return claimed, nil
+}
+
+// FailAIFlavor records a failed AI flavor generation and backs off the next one (see AIFlavorBackoff)
+func (r *CardRepository) FailAIFlavor(ctx context.Context, cardID string, now time.Time) error {
+	ref := r.client.Collection(CollectionCards).Doc(cardID)
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return ErrCardNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get card: %w", err)
+		}
+
+		failures := getIntField(doc.Data(), "aiFlavorFailures") + 1
+		return tx.Update(ref, []firestore.Update{
+			{Path: "aiFlavorFailures", Value: failures},
+			{Path: "aiFlavorRetryAt", Value: now.Add(AIFlavorBackoff(failures))},
+		})
+	})
+}
+
+// DeleteCard deletes the card after check approves it, in a transaction, along with the creator's own copies.
+// A card another user has in their collection (collection.ErrCardOwned) or with a copy locked by a pending trade
+// (collection.ErrCardLocked) cannot be deleted.
+func (r *CardRepository) DeleteCard(ctx context.Context, cardID string, check func(current *ptera.Card) error) error {
+	ref := r.client.Collection(CollectionCards).Doc(cardID)
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return ErrCardNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get card: %w", err)
+		}
+
+		now := time.Now()
+		card := cardFromDocument(doc, now)
+		if err := check(card); err != nil {
+			return err
+		}
+		if err := r.userCards.RemoveCard(tx, cardID, card.CreatorId, now); err != nil {
+			return err
+		}
+		return tx.Delete(ref)
+	})
+}
+
+// ListCardsPage lists cards ordered by creation date (newest first).
+// The returned token is empty when there are no more cards.
+func (r *CardRepository) ListCardsPage(ctx context.Context, filter CardFilter, pageSize int, pageToken string) ([]*ptera.Card, string, error) {
+	query := r.client.Collection(CollectionCards).Query
+	if filter.CircleID != "" {
+		query = query.Where("circleId", "==", filter.CircleID)
+	}
+	if filter.CreatorID != "" {
+		query = query.Where("creatorId", "==", filter.CreatorID)
+	}
+	query = query.OrderBy("createdAt", firestore.Desc)
+
+	if pageToken != "" {
+		lastID, err := base64.RawURLEncoding.DecodeString(pageToken)
+		if err != nil {
+			return nil, "", ErrInvalidPageToken
+		}
+		lastDoc, err := r.client.Collection(CollectionCards).Doc(string(lastID)).Get(ctx)
+		if err != nil {
+			return nil, "", ErrInvalidPageToken
+		}
+		query = query.StartAfter(lastDoc)
+	}
+
+	// Graduated cards are filtered after reading, so keep reading until the page is full,
+	// up to maxCardScanBatches pages of documents (a shorter page then still has a next page token)
+	scanLimit := pageSize * maxCardScanBatches
+	iter := query.Limit(scanLimit).Documents(ctx)
+	defer iter.Stop()
+
+	now := time.Now()
+	var cards []*ptera.Card
+	var lastID string
+	scanned := 0
+	for len(cards) < pageSize {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			if scanned < scanLimit {
+				return cards, "", nil
+			}
+			break
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to iterate cards: %w", err)
+		}
+		lastID = doc.Ref.ID
+		scanned++
+
+		card := cardFromDocument(doc, now)
+		if card.Graduated && !filter.IncludeGraduated {
+			continue
+		}
+		cards = append(cards, card)
+	}
+
+	return cards, base64.RawURLEncoding.EncodeToString([]byte(lastID)), nil
+}
+
+// cardToDocument maps the editable and derived fields of a card to Firestore fields
+func cardToDocument(card *ptera.Card) map[string]interface{} {
+	data := map[string]interface{}{
+		"name":        card.Name,
+		"grade":       card.Grade,
+		"position":    card.Position,
+		"hobby":       card.Hobby,
+		"description": card.Description,
+		"imageUrl":    card.ImageUrl,
+		"circleId":    card.GetCircleId(),
+		"expiryDate":  card.ExpiryDate.AsTime(),
+		"maxHp":       card.MaxHp,
+		"attack":      card.Attack,
+		"updatedAt":   firestore.ServerTimestamp,
+	}
+	if card.AffiliatedGroup != nil {
+		data["affiliatedGroup"] = card.GetAffiliatedGroup()
+	}
+	if card.StatProfile != nil {
+		data["statProfile"] = statProfileToDocument(card.StatProfile)
+	}
+	if card.PromptVersion != "" {
+		data["promptVersion"] = card.PromptVersion
+	}
+	return data
+}
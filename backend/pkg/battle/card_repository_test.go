@@ -0,0 +1,31 @@
+package battle
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+)
+
+// TestCircleRequired checks that a battle never builds a deck from the public pool
+func TestCircleRequired(t *testing.T) {
+	ctx := context.Background()
+
+	if _, err := (&CardRepository{}).GetCircleCards(ctx, "", false); !errors.Is(err, errCircleRequired) {
+		t.Errorf("GetCircleCards(\"\") error = %v, want %v", err, errCircleRequired)
+	}
+
+	s := &Service{}
+	for _, req := range []*ptera.StartBattleRequest{
+		{OpponentCircleId: "circle2"},
+		{MyCircleId: "circle1"},
+	} {
+		if _, err := s.StartBattle(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("StartBattle(%v) = %v, want %v", req, err, codes.InvalidArgument)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package battle
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/ai"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/moderation"
+)
+
+const (
+	commentaryGenerationTimeout = 15 * time.Second
+	// maxConcurrentCommentaries bounds the AI commentaries generated in the background
+	maxConcurrentCommentaries = 8
+)
+
+// Commentator narrates battle turns with AI
+type Commentator interface {
+	GenerateCommentary(ctx context.Context, turn ai.BattleTurn) (string, error)
+}
+
+// 実況テンプレート (%[1]s = サークル, %[2]s = カード, %[3]s = 相手のカード, %[4]d = ダメージ, %[5]s = 趣味)
+var attackCommentaries = []string{
+	"%[1]sの%[2]s、仕掛けた！%[3]sに%[4]dダメージ！",
+	"%[2]sの攻撃が決まった！%[3]s、%[4]dダメージを受ける！",
+	"さあ%[1]sの攻撃だ！%[2]sの一撃で%[3]sに%[4]dダメージ！",
+}
+
+var hobbyAttackCommentaries = []string{
+	"%[5]sで鍛えた%[2]sの一撃！%[3]sに%[4]dダメージ！",
+	"%[5]s仕込みの動きが光る！%[2]sが%[3]sに%[4]dダメージ！",
+}
+
+var knockOutCommentaries = []string{
+	"%s、ここでダウン！",
+	"%s、たまらず倒れた！",
+	"%sは力尽きた！",
+}
+
+var winCommentaries = []string{
+	"勝負あり！%sの勝利です！",
+	"試合終了！制したのは%s！",
+}
+
+// (%[1]s = サークル, %[2]s = 投入するカード, %[3]s = 下げるカード)
+var retreatCommentaries = []string{
+	"%[1]s、%[3]sを下げて%[2]sを投入！",
+	"ここで選手交代！%[1]sは%[2]sに望みを託す！",
+	"%[3]sがベンチへ。代わって%[2]sが前に出る！",
+}
+
+// startCommentary is the first entry of a battle with commentary
+func startCommentary(state *ptera.BattleState) *ptera.BattleCommentary {
+	text := fmt.Sprintf("%s対%s、試合開始！", state.PlayerMe.CircleName, state.PlayerOpponent.CircleName)
+	if len(state.PlayerMe.Deck) > 0 && len(state.PlayerOpponent.Deck) > 0 {
+		text += fmt.Sprintf("先鋒は%sと%sだ！", state.PlayerMe.Deck[0].Name, state.PlayerOpponent.Deck[0].Name)
+	}
+	return &ptera.BattleCommentary{Index: 0, Text: text}
+}
+
+// templateCommentary writes the commentary of the turn from templates.
+// It is saved right away, and replaced when the AI commentary is ready.
+func templateCommentary(battleID string, index int32, turn ai.BattleTurn) string {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "commentary:%s:%d", battleID, index)
+	sum := h.Sum64()
+	// Each choice uses different bits of the hash
+	pick := func(texts []string, part uint) string {
+		return texts[(sum>>(part*8))%uint64(len(texts))]
+	}
+
+	if turn.Action == ai.BattleActionRetreat {
+		return fmt.Sprintf(pick(retreatCommentaries, 0), turn.Circle, turn.Card.Name, turn.Target.Name)
+	}
+
+	templates := attackCommentaries
+	if hobby := strings.TrimSpace(turn.Card.Hobby); hobby != "" && (sum>>8)%3 != 0 {
+		templates = hobbyAttackCommentaries
+	}
+	text := fmt.Sprintf(pick(templates, 0), turn.Circle, turn.Card.Name, turn.Target.Name, turn.Damage, strings.TrimSpace(turn.Card.Hobby))
+	if turn.KnockOut {
+		text += fmt.Sprintf(pick(knockOutCommentaries, 2), turn.Target.Name)
+	}
+	if turn.Win {
+		text += fmt.Sprintf(pick(winCommentaries, 3), turn.Circle)
+	}
+	return text
+}
+
+// commentTurn prepends the template commentary of the turn, if the battle has commentary, and returns its index
+func (s *Service) commentTurn(state *ptera.BattleState, turn ai.BattleTurn) int32 {
+	if !state.CommentaryEnabled {
+		return -1
+	}
+	index := int32(len(state.Commentary))
+	text := templateCommentary(state.BattleId, index, turn)
+	state.Commentary = append([]*ptera.BattleCommentary{{Index: index, Text: text}}, state.Commentary...)
+	return index
+}
+
+func commentaryCard(card *ptera.Card) ai.CommentaryCard {
+	return ai.CommentaryCard{Name: card.Name, Hobby: card.Hobby, Flavor: card.Flavor}
+}
+
+// narrateTurn replaces the commentary of the turn with AI in the background, after the battle was saved.
+// Each commentary counts as one AI call of the circle that started the battle. Turns never wait for the model;
+// the template commentary stays when all maxConcurrentCommentaries are busy, the quota is exhausted, or on any failure.
+func (s *Service) narrateTurn(state *ptera.BattleState, index int32, turn ai.BattleTurn) {
+	if s.commentator == nil || index < 0 {
+		return
+	}
+	select {
+	case s.commentarySlots <- struct{}{}:
+	default:
+		return
+	}
+	battleID, circleID := state.BattleId, state.PlayerMe.CircleId
+
+	go func() {
+		defer func() { <-s.commentarySlots }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), commentaryGenerationTimeout)
+		defer cancel()
+
+		if _, err := s.quotaService.ReserveCircle(ctx, circleID); err != nil {
+			s.logger.Warn("commentary not reserved", "battle_id", battleID, "circle_id", circleID, "error", err)
+			return
+		}
+		text, err := s.commentator.GenerateCommentary(ctx, turn)
+		if err != nil {
+			s.logger.Warn("failed to generate commentary", "battle_id", battleID, "index", index, "error", err)
+			return
+		}
+		if reasons := s.moderator.CheckText(moderation.StageOutput, moderation.Field{Name: "commentary", Value: text}); len(reasons) > 0 {
+			s.logger.Warn("generated commentary flagged", "battle_id", battleID, "word", reasons[0].Detail)
+			return
+		}
+		if err := s.repo.SetAICommentary(ctx, battleID, index, text); err != nil {
+			s.logger.Warn("failed to save commentary", "battle_id", battleID, "index", index, "error", err)
+		}
+	}()
+}
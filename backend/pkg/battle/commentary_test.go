@@ -0,0 +1,52 @@
+package battle
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/ai"
+)
+
+func TestTemplateCommentary(t *testing.T) {
+	attacker := ai.CommentaryCard{Name: "山田", Hobby: "筋トレ"}
+	defender := ai.CommentaryCard{Name: "佐藤"}
+	tests := []struct {
+		name string
+		turn ai.BattleTurn
+		want []string
+	}{
+		{
+			name: "attack",
+			turn: ai.BattleTurn{Action: ai.BattleActionAttack, Circle: "囲碁部", Card: attacker, Target: defender, Damage: 12},
+			want: []string{"佐藤", "12"},
+		},
+		{
+			name: "knock-out and win",
+			turn: ai.BattleTurn{Action: ai.BattleActionAttack, Circle: "囲碁部", Card: attacker, Target: defender, Damage: 30, KnockOut: true, Win: true},
+			want: []string{"佐藤", "30", "囲碁部"},
+		},
+		{
+			name: "retreat",
+			turn: ai.BattleTurn{Action: ai.BattleActionRetreat, Circle: "囲碁部", Card: attacker, Target: defender},
+			want: []string{"山田"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for index := int32(1); index <= 8; index++ {
+				text := templateCommentary("battle-1", index, tt.turn)
+				for _, want := range tt.want {
+					if !strings.Contains(text, want) {
+						t.Errorf("commentary %d = %q, want it to contain %q", index, text, want)
+					}
+				}
+				if strings.Contains(text, "%!") {
+					t.Errorf("commentary %d = %q is badly formatted", index, text)
+				}
+				if again := templateCommentary("battle-1", index, tt.turn); again != text {
+					t.Errorf("commentary %d = %q, then %q", index, text, again)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package battle
+
+import (
+	"hash/fnv"
+	"strings"
+	"time"
+
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+)
+
+// LegacyFlavor is the placeholder that was stored on cards before flavor texts were generated
+const LegacyFlavor = "今日も元気にお布団から出られない。"
+
+const (
+	// AIFlavorLease is how long a claimed AI flavor generation keeps other instances from starting one
+	AIFlavorLease = 2 * time.Minute
+
+	aiFlavorBaseBackoff = 10 * time.Minute
+	aiFlavorMaxBackoff  = 24 * time.Hour
+)
+
+// AIFlavorBackoff returns the wait before retrying the AI flavor of a card after its n-th failure.
+// It doubles from 10 minutes up to a day.
+func AIFlavorBackoff(failures int) time.Duration {
+	backoff := aiFlavorBaseBackoff
+	for i := 1; i < failures && backoff < aiFlavorMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, aiFlavorMaxBackoff)
+}
+
+// flavorCategory is a group of flavor texts chosen when one of the keywords appears in the field
+type flavorCategory struct {
+	keywords []string
+	texts    []string
+}
+
+// 役職ごとのフレーバーテキスト
+var positionFlavors = []flavorCategory{
+	{
+		keywords: []string{"代表", "部長", "会長", "リーダー", "主将", "キャプテン"},
+		texts: []string{
+			"集合時間を決めた本人が一番遅れてくる。",
+			"「自由参加です」と言いつつ、出欠は全部覚えている。",
+			"サークルの予算と自分の財布の区別が曖昧になりがち。",
+			"新歓の挨拶だけで三回泣いた。",
+			"決断は早いが、決めたことはだいたい忘れる。",
+			"部室の鍵を持っている。それだけで偉い。",
+			"困ったときは「とりあえず飲み会で決めよう」。",
+		},
+	},
+	{
+		keywords: []string{"副"},
+		texts: []string{
+			"代表の言葉を翻訳するのが主な仕事。",
+			"影の実力者。表には出ない。出たくない。",
+			"代表が休むと急に輝き出す。",
+			"議事録のない会議を記憶だけで回している。",
+			"「それ、先週も言いましたよね」が口癖。",
+		},
+	},
+	{
+		keywords: []string{"会計", "財務"},
+		texts: []string{
+			"1円のズレで三日眠れない。",
+			"レシートを見ると反射的に受け取ってしまう。",
+			"部費の催促だけは誰よりも情熱的。",
+			"電卓を叩く音でサークルの財政状況がわかる。",
+			"飲み会の割り勘を暗算で終わらせる。",
+		},
+	},
+	{
+		keywords: []string{"書記", "庶務", "事務"},
+		texts: []string{
+			"議事録の誤字は見逃さないが、内容は覚えていない。",
+			"共有フォルダの整理だけが生きがい。",
+			"スケジュール調整ツールを三つ使い分けている。",
+			"誰も読まない連絡事項を今日も書き続ける。",
+		},
+	},
+	{
+		keywords: []string{"広報", "SNS", "宣伝"},
+		texts: []string{
+			"ハッシュタグの付け方に一家言ある。",
+			"部活の写真には必ず自分が写っていない。",
+			"フォロワーを増やすためなら多少の誇張もいとわない。",
+			"「映え」を求めて活動場所を変えようとする。",
+			"新歓ビラのフォント選びに一週間かけた。",
+		},
+	},
+	{
+		keywords: []string{"新入", "新人", "部員", "メンバー", "一般"},
+		texts: []string{
+			"まだ先輩の名前を半分しか覚えていない。",
+			"とりあえず全部のイベントに顔を出している。",
+			"気づけば幽霊部員から抜け出していた。",
+			"飲み会の店選びを任されて震えている。",
+			"いつか役職につく日を夢見て、今日も部室のゴミを捨てる。",
+		},
+	},
+}
+
+// 趣味ごとのフレーバーテキスト
+var hobbyFlavors = []flavorCategory{
+	{
+		keywords: []string{"ゲーム", "game", "eスポーツ", "FPS", "RPG"},
+		texts: []string{
+			"「あと1戦だけ」が朝まで続く。",
+			"ログインボーナスのために生活リズムが決まっている。",
+			"リアルでもコマンド入力で回避しようとする。",
+			"積みゲーの数は単位の数より多い。",
+			"ガチャの確率について語らせると長い。",
+		},
+	},
+	{
+		keywords: []string{"音楽", "バンド", "ギター", "ピアノ", "カラオケ", "歌", "ライブ", "ドラム"},
+		texts: []string{
+			"講義中も脳内ではずっとライブ中。",
+			"カラオケの十八番は誰も知らない曲。",
+			"機材にかけたお金の話は聞かないであげてほしい。",
+			"リズムに乗りすぎて課題の締め切りを踏み外す。",
+			"推しのライブのためなら単位も投げ出す覚悟がある。",
+		},
+	},
+	{
+		keywords: []string{"サッカー", "野球", "バスケ", "テニス", "バレー", "ランニング", "筋トレ", "スポーツ", "水泳", "陸上"},
+		texts: []string{
+			"階段を見ると駆け上がらずにはいられない。",
+			"プロテインの味に異常に詳しい。",
+			"筋肉痛を勲章だと思っている。",
+			"体力だけなら卒業まで持つ自信がある。",
+			"雨の日は目に見えて元気がない。",
+		},
+	},
+	{
+		keywords: []string{"読書", "本", "小説", "漫画", "マンガ"},
+		texts: []string{
+			"積読タワーが部屋の耐震性を脅かしている。",
+			"名言で会話しようとして滑る。",
+			"レポートより先に読書感想文が書ける。",
+			"図書館の返却期限とだけは真剣に向き合っている。",
+		},
+	},
+	{
+		keywords: []string{"アニメ", "オタク", "推し", "声優", "アイドル"},
+		texts: []string{
+			"今期の覇権アニメを三本挙げられる。",
+			"推しの誕生日は自分の誕生日より大事。",
+			"グッズの置き場所がついにベッドまで侵食した。",
+			"深夜アニメのために一限を犠牲にしている。",
+		},
+	},
+	{
+		keywords: []string{"料理", "カフェ", "グルメ", "食べ", "ラーメン", "スイーツ", "お菓子"},
+		texts: []string{
+			"学食のメニューを全制覇した。",
+			"美味しい店の情報網はサークル随一。",
+			"差し入れのクオリティで一目置かれている。",
+			"ラーメン一杯で一日の予定が決まる。",
+			"料理の腕は確かだが、洗い物はしない。",
+		},
+	},
+	{
+		keywords: []string{"プログラミング", "開発", "コード", "エンジニア", "ハッカソン", "競プロ", "PC", "パソコン"},
+		texts: []string{
+			"「自分の環境では動く」が口癖。",
+			"バグを直したら別のバグが生まれた。",
+			"ハッカソンの徹夜明けが一番いい顔をしている。",
+			"キーボードの打鍵音で機嫌がわかる。",
+			"エラーメッセージを読む前に再起動する。",
+			"コミットメッセージは「fix」しか書かない。",
+		},
+	},
+	{
+		keywords: []string{"旅行", "旅", "カメラ", "写真", "散歩", "ドライブ"},
+		texts: []string{
+			"連休のたびにどこかへ消える。",
+			"お土産のセンスだけは信頼されている。",
+			"写真フォルダが風景で埋め尽くされている。",
+			"方向音痴なのに地図は見ない主義。",
+		},
+	},
+	{
+		keywords: []string{"寝", "睡眠", "昼寝", "ゴロゴロ"},
+		texts: []string{
+			"今日も元気にお布団から出られない。",
+			"どこでも三秒で眠れるのが特技。",
+			"講義室の一番後ろは指定席。",
+			"目覚ましを五つかけても起きられない。",
+		},
+	},
+}
+
+// 学年ごとのフレーバーテキスト (インデックス = 学年 - 1)
+var gradeFlavors = [][]string{
+	{
+		"キャンパスで迷子になるのもまだ許される。",
+		"履修登録で人生最大の選択を迫られた。",
+		"一人暮らしの自炊はまだ三日坊主。",
+		"サークルの新歓で食べ歩いていたら春が終わった。",
+	},
+	{
+		"後輩ができて少しだけ背筋が伸びた。",
+		"中だるみという言葉を全身で体現している。",
+		"楽単の情報だけは誰よりも早い。",
+		"サークル運営の裏側を知ってしまった。",
+	},
+	{
+		"就活という言葉から全力で目を逸らしている。",
+		"研究室選びとサークルの引退時期に揺れている。",
+		"後輩に頼られるのが嬉しくて仕方ない。",
+		"インターンのESを書きながら部室に入り浸る。",
+	},
+	{
+		"卒論の進捗は聞かないでほしい。",
+		"後輩たちに伝説だけを残して去ろうとしている。",
+		"卒業までのカウントダウンが始まった。",
+		"OB・OGになっても部室に来るつもりでいる。",
+		"内定先の話になると急に遠い目をする。",
+	},
+}
+
+// どの条件にも当てはまらないときのフレーバーテキスト
+var genericFlavors = []string{
+	"今日も元気にお布団から出られない。",
+	"部室に来る理由は主にWi-Fi。",
+	"謎の人脈を持っている。",
+	"締め切り前夜に本気を出すタイプ。",
+	"どのグループLINEにも必ずいる。",
+	"サークルの雰囲気を陰で支えている。",
+	"なぜかいつもお菓子を持っている。",
+	"集合写真では必ず端にいる。",
+	"誰とでもすぐ打ち解けるが、名前は覚えていない。",
+	"一限の出席率は低いが、飲み会の出席率は高い。",
+}
+
+// GenerateFlavor selects a flavor text deterministically from the card ID.
+// The category is chosen among the ones matching the position, hobby and grade of the card, and the generic one.
+func GenerateFlavor(card *ptera.Card) string {
+	var candidates [][]string
+	if texts := matchFlavors(positionFlavors, card.Position); texts != nil {
+		candidates = append(candidates, texts)
+	}
+	if texts := matchFlavors(hobbyFlavors, card.Hobby); texts != nil {
+		candidates = append(candidates, texts)
+	}
+	if card.Grade >= 1 && int(card.Grade) <= len(gradeFlavors) {
+		candidates = append(candidates, gradeFlavors[card.Grade-1])
+	}
+	candidates = append(candidates, genericFlavors)
+
+	// Salted so that the choice is independent of the stats derived from the same ID
+	h := fnv.New64a()
+	h.Write([]byte("flavor:" + card.Id))
+	sum := h.Sum64()
+
+	texts := candidates[sum%uint64(len(candidates))]
+	return texts[(sum/uint64(len(candidates)))%uint64(len(texts))]
+}
+
+// matchFlavors returns the texts of the first category whose keyword appears in value
+func matchFlavors(categories []flavorCategory, value string) []string {
+	if value == "" {
+		return nil
+	}
+	lower := strings.ToLower(value)
+	for _, c := range categories {
+		for _, keyword := range c.keywords {
+			if strings.Contains(lower, strings.ToLower(keyword)) {
+				return c.texts
+			}
+		}
+	}
+	return nil
+}
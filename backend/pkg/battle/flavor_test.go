@@ -0,0 +1,38 @@
+package battle
+
+import (
+	"testing"
+	"time"
+
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+)
+
+func TestGenerateFlavorDeterministic(t *testing.T) {
+	card := &ptera.Card{Id: "card-1", Position: "会計", Hobby: "ゲーム", Grade: 2}
+	first := GenerateFlavor(card)
+	if first == "" || first == LegacyFlavor {
+		t.Fatalf("GenerateFlavor = %q, want a pooled flavor", first)
+	}
+	if again := GenerateFlavor(card); again != first {
+		t.Errorf("GenerateFlavor = %q, then %q for the same card", first, again)
+	}
+}
+
+func TestAIFlavorBackoff(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{failures: 1, want: 10 * time.Minute},
+		{failures: 2, want: 20 * time.Minute},
+		{failures: 4, want: 80 * time.Minute},
+		{failures: 8, want: 1280 * time.Minute},
+		{failures: 9, want: 24 * time.Hour},
+		{failures: 100, want: 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := AIFlavorBackoff(tt.failures); got != tt.want {
+			t.Errorf("AIFlavorBackoff(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
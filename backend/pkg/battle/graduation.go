@@ -0,0 +1,34 @@
+package battle
+
+import (
+	"time"
+)
+
+// GraduationDate calculates the default expiry date (March 31st of the graduation year)
+// for a card of the given grade. Mirrors calculateGraduationDate on the frontend.
+func GraduationDate(grade int32, createdAt time.Time) time.Time {
+	if grade < 1 || grade > 4 {
+		// Fallback: 4 years after creation
+		return createdAt.AddDate(4, 0, 0)
+	}
+
+	// 4月以降なら今年度、3月以前なら前年度
+	academicYear := createdAt.Year()
+	if createdAt.Month() < time.April {
+		academicYear--
+	}
+
+	// 4年生: +1, 3年生: +2, 2年生: +3, 1年生: +4
+	graduationYear := academicYear + int(5-grade)
+
+	return time.Date(graduationYear, time.March, 31, 0, 0, 0, 0, createdAt.Location())
+}
+
+// IsGraduated reports whether a card with the given expiry date has graduated (expired) at now.
+// A zero expiry date is treated as not graduated.
+func IsGraduated(expiryDate, now time.Time) bool {
+	if expiryDate.IsZero() {
+		return false
+	}
+	return !expiryDate.After(now)
+}
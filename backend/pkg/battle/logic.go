@@ -3,7 +3,9 @@ package battle
 import (
 	"fmt"
 	"hash/fnv"
+	"math"
 	"math/rand"
+	"sort"
 	"time"
 
 	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
@@ -34,12 +36,16 @@ func GenerateBattleStats(cardID string, grade int32) *ptera.Card {
 		Id:     cardID,
 		MaxHp:  maxHp,
 		Attack: attack,
-		Flavor: "今日も元気にお布団から出られない。", // TODO: 現時点では固定、決定論的にランダム化することも可能
+		Flavor: GenerateFlavor(&ptera.Card{Id: cardID, Grade: grade}),
 	}
 }
 
-// BuildDeck selects 5 random cards from the source pool
-func BuildDeck(cards []*ptera.Card) []*ptera.Card {
+// favoriteAttackBonus is the morale bonus (ratio of attack) of a 推しメン card
+const favoriteAttackBonus = 0.1
+
+// BuildDeck selects 5 random cards from the source pool.
+// Cards in favoriteIDs (推しメン of the circle members) are guaranteed a slot and lead the deck with a morale bonus.
+func BuildDeck(cards []*ptera.Card, favoriteIDs []string) []*ptera.Card {
 	// Copy to avoid modifying original
 	shuffled := make([]*ptera.Card, len(cards))
 	copy(shuffled, cards)
@@ -50,6 +56,15 @@ func BuildDeck(cards []*ptera.Card) []*ptera.Card {
 		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
 	})
 
+	// Move favorites to the front so that they are always picked
+	favorites := make(map[string]bool, len(favoriteIDs))
+	for _, id := range favoriteIDs {
+		favorites[id] = true
+	}
+	sort.SliceStable(shuffled, func(i, j int) bool {
+		return favorites[shuffled[i].Id] && !favorites[shuffled[j].Id]
+	})
+
 	// Select up to 5
 	deckSize := 5
 	if len(shuffled) < 5 {
@@ -57,6 +72,12 @@ func BuildDeck(cards []*ptera.Card) []*ptera.Card {
 	}
 	deck := shuffled[:deckSize]
 
+	for _, card := range deck {
+		if favorites[card.Id] {
+			applyFavoriteBonus(card)
+		}
+	}
+
 	// Fill with dummy cards if needed (though user said maybe just average cards)
 	// For now, if < 5, we fill with dummy
 	for len(deck) < 5 {
@@ -66,6 +87,12 @@ func BuildDeck(cards []*ptera.Card) []*ptera.Card {
 	return deck
 }
 
+// applyFavoriteBonus boosts the attack of a 推しメン card (士気ボーナス)
+func applyFavoriteBonus(card *ptera.Card) {
+	card.Favorite = true
+	card.Attack += int32(math.Round(float64(card.Attack) * favoriteAttackBonus))
+}
+
 func createDummyCard() *ptera.Card {
 	id := fmt.Sprintf("dummy-%d", time.Now().UnixNano())
 	return &ptera.Card{
@@ -78,16 +105,34 @@ func createDummyCard() *ptera.Card {
 	}
 }
 
+// Rng is the source of damage rolls (fairness.Roller for committed battles)
+type Rng interface {
+	Float64() float64
+}
+
+// nextRollIndex returns the index the next damage roll starts at: where the previous roll stopped.
+// A roll may consume more than one index, so the indices are not the positions in rolls.
+// Rolls recorded without NextRollIndex consumed exactly one index.
+func nextRollIndex(rolls []*ptera.DamageRoll) int64 {
+	if len(rolls) == 0 {
+		return 0
+	}
+	last := rolls[len(rolls)-1]
+	if last.NextRollIndex > 0 {
+		return last.NextRollIndex
+	}
+	return last.RollIndex + 1
+}
+
 // CalculateDamage with 0.9 - 1.1 variance
-func CalculateDamage(attacker *ptera.Card) int32 {
-	// Non-deterministic for damage (battle excitement) or deterministic?
-	// User req said: "0.9 + rand.Float64()*0.2" so dynamic.
-	variance := 0.9 + rand.Float64()*0.2
+func CalculateDamage(attacker *ptera.Card, rng Rng) int32 {
+	// Dynamic for battle excitement, but derived from the committed seed so it can be verified
+	variance := 0.9 + rng.Float64()*0.2
 	return int32(float64(attacker.Attack) * variance)
 }
 
 // ExecuteEnemyTurn processes the opponent's move (Simple AI: Attack active)
-func ExecuteEnemyTurn(state *ptera.BattleState) *ptera.BattleState {
+func ExecuteEnemyTurn(state *ptera.BattleState, rng Rng) *ptera.BattleState {
 	if state.WinnerId != "" {
 		return state
 	}
@@ -96,7 +141,7 @@ func ExecuteEnemyTurn(state *ptera.BattleState) *ptera.BattleState {
 	defender := state.PlayerMe.Deck[0]
 
 	// Calculate Damage
-	damage := CalculateDamage(attacker)
+	damage := CalculateDamage(attacker, rng)
 
 	// Apply Damage logic (need to update HP which is inside Card struct... wait,
 	// proto types generated in Go might need helper to clone or modify properly if we want immutability,
@@ -0,0 +1,102 @@
+package battle
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/fairness"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+)
+
+func TestBuildDeckFavorites(t *testing.T) {
+	tests := []struct {
+		name        string
+		poolSize    int
+		favoriteIDs []string
+		wantLead    []string
+	}{
+		{name: "no favorites", poolSize: 20},
+		{name: "one favorite", poolSize: 20, favoriteIDs: []string{"card-19"}, wantLead: []string{"card-19"}},
+		{name: "favorite at the end of the pool", poolSize: 21, favoriteIDs: []string{"card-20"}, wantLead: []string{"card-20"}},
+		{name: "favorite not in the pool", poolSize: 20, favoriteIDs: []string{"other"}},
+		{name: "small pool", poolSize: 2, favoriteIDs: []string{"card-1"}, wantLead: []string{"card-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := make([]*ptera.Card, tt.poolSize)
+			for i := range pool {
+				pool[i] = &ptera.Card{Id: fmt.Sprintf("card-%d", i), Attack: 100}
+			}
+
+			deck := BuildDeck(pool, tt.favoriteIDs)
+			if len(deck) != 5 {
+				t.Fatalf("len(deck) = %d, want 5", len(deck))
+			}
+			for i, id := range tt.wantLead {
+				if deck[i].Id != id {
+					t.Errorf("deck[%d] = %s, want favorite %s", i, deck[i].Id, id)
+				}
+				if !deck[i].Favorite || deck[i].Attack != 110 {
+					t.Errorf("deck[%d] favorite = %t, attack = %d, want true, 110", i, deck[i].Favorite, deck[i].Attack)
+				}
+			}
+			for _, card := range deck[len(tt.wantLead):] {
+				if card.Favorite {
+					t.Errorf("%s marked favorite", card.Id)
+				}
+			}
+		})
+	}
+}
+
+func TestNextRollIndex(t *testing.T) {
+	tests := []struct {
+		name  string
+		rolls []*ptera.DamageRoll
+		want  int64
+	}{
+		{"first roll", nil, 0},
+		{"after a single-index roll", []*ptera.DamageRoll{{RollIndex: 0, NextRollIndex: 1}}, 1},
+		{"after a roll that used several indices", []*ptera.DamageRoll{{RollIndex: 0, NextRollIndex: 1}, {RollIndex: 1, NextRollIndex: 4}}, 4},
+		{"rolls recorded without the next index", []*ptera.DamageRoll{{RollIndex: 0}, {RollIndex: 1}}, 2},
+		{"next index recorded after older rolls", []*ptera.DamageRoll{{RollIndex: 0}, {RollIndex: 1, NextRollIndex: 3}}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextRollIndex(tt.rolls); got != tt.want {
+				t.Errorf("nextRollIndex() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRecordedRollsReproducible records draws that may use several indices each, the way Attack does,
+// and checks that a verifier starting a roller at each RollIndex gets the same values from disjoint indices
+func TestRecordedRollsReproducible(t *testing.T) {
+	seed := []byte("a server seed of thirty-two byte")
+	const n = 3 << 61 // rejects about a quarter of the rolls
+
+	var rolls []*ptera.DamageRoll
+	var values []int
+	for range 50 {
+		start := nextRollIndex(rolls)
+		roller := fairness.NewRoller(seed, "nonce", start)
+		values = append(values, roller.Intn(n))
+		rolls = append(rolls, &ptera.DamageRoll{RollIndex: start, NextRollIndex: roller.Index()})
+	}
+
+	var used int64
+	for i, roll := range rolls {
+		if roll.RollIndex != used {
+			t.Fatalf("roll %d starts at index %d, want %d", i, roll.RollIndex, used)
+		}
+		verifier := fairness.NewRoller(seed, "nonce", roll.RollIndex)
+		if got := verifier.Intn(n); got != values[i] {
+			t.Errorf("roll %d recomputed as %d, recorded %d", i, got, values[i])
+		}
+		used = verifier.Index()
+	}
+	if used == int64(len(rolls)) {
+		t.Error("expected some draws to use more than one index")
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	"cloud.google.com/go/firestore"
 	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
@@ -22,21 +24,31 @@ func NewRepository(client *firestore.Client) *Repository {
 	return &Repository{client: client}
 }
 
-// SaveBattle saves the battle state to Firestore
+// SaveBattle saves the battle state to Firestore.
+// Commentary narrated by AI since the state was read is kept, so a turn never overwrites it with the template text.
 func (r *Repository) SaveBattle(ctx context.Context, battle *ptera.BattleState) error {
-	// Convert protobuf to JSON with proper field naming (camelCase)
-	jsonBytes, err := protojson.Marshal(battle)
-	if err != nil {
-		return fmt.Errorf("failed to marshal battle to JSON: %w", err)
-	}
-
-	// Convert JSON to map for Firestore
-	var battleMap map[string]interface{}
-	if err := json.Unmarshal(jsonBytes, &battleMap); err != nil {
-		return fmt.Errorf("failed to unmarshal JSON to map: %w", err)
-	}
+	ref := r.client.Collection(CollectionBattles).Doc(battle.BattleId)
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if len(battle.Commentary) > 0 {
+			doc, err := tx.Get(ref)
+			if err != nil && status.Code(err) != codes.NotFound {
+				return err
+			}
+			if err == nil {
+				current, err := battleFromDocument(doc)
+				if err != nil {
+					return err
+				}
+				keepNarratedCommentary(battle, current)
+			}
+		}
 
-	_, err = r.client.Collection(CollectionBattles).Doc(battle.BattleId).Set(ctx, battleMap)
+		battleMap, err := battleToDocument(battle)
+		if err != nil {
+			return err
+		}
+		return tx.Set(ref, battleMap)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to save battle: %w", err)
 	}
@@ -49,10 +61,70 @@ func (r *Repository) GetBattle(ctx context.Context, battleID string) (*ptera.Bat
 	if err != nil {
 		return nil, fmt.Errorf("failed to get battle: %w", err)
 	}
+	return battleFromDocument(doc)
+}
+
+// SetAICommentary replaces the text of a commentary entry with the one narrated by AI
+func (r *Repository) SetAICommentary(ctx context.Context, battleID string, index int32, text string) error {
+	ref := r.client.Collection(CollectionBattles).Doc(battleID)
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if err != nil {
+			return fmt.Errorf("failed to get battle: %w", err)
+		}
+		battle, err := battleFromDocument(doc)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range battle.Commentary {
+			if c.Index == index {
+				c.Text = text
+				c.AiGenerated = true
+				battleMap, err := battleToDocument(battle)
+				if err != nil {
+					return err
+				}
+				return tx.Set(ref, battleMap)
+			}
+		}
+		return fmt.Errorf("commentary %d not found in battle %s", index, battleID)
+	})
+}
+
+// keepNarratedCommentary copies the AI commentary saved in current to the matching template entries of battle
+func keepNarratedCommentary(battle, current *ptera.BattleState) {
+	narrated := make(map[int32]*ptera.BattleCommentary)
+	for _, c := range current.Commentary {
+		if c.AiGenerated {
+			narrated[c.Index] = c
+		}
+	}
+	for _, c := range battle.Commentary {
+		if n, ok := narrated[c.Index]; ok && !c.AiGenerated {
+			c.Text = n.Text
+			c.AiGenerated = true
+		}
+	}
+}
+
+// battleToDocument converts the battle state to a Firestore map with the JSON field names (camelCase)
+func battleToDocument(battle *ptera.BattleState) (map[string]interface{}, error) {
+	jsonBytes, err := protojson.Marshal(battle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal battle to JSON: %w", err)
+	}
+
+	var battleMap map[string]interface{}
+	if err := json.Unmarshal(jsonBytes, &battleMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON to map: %w", err)
+	}
+	return battleMap, nil
+}
 
+func battleFromDocument(doc *firestore.DocumentSnapshot) (*ptera.BattleState, error) {
 	// Convert Firestore document to JSON
-	data := doc.Data()
-	jsonBytes, err := json.Marshal(data)
+	jsonBytes, err := json.Marshal(doc.Data())
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal data to JSON: %w", err)
 	}
@@ -5,9 +5,16 @@ import (
 	"fmt"
 	"log/slog"
 	"math"
+	"math/rand"
+	"strconv"
 	"time"
 
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/ai"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/fairness"
 	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/moderation"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/quota"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/user"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -17,14 +24,26 @@ type Service struct {
 	ptera.UnimplementedBattleServiceServer
 	repo               *Repository
 	cardRepo           *CardRepository
+	fairnessRepo       *fairness.Repository
+	userRepo           *user.Repository
+	commentator        Commentator   // nil keeps the template commentary
+	commentarySlots    chan struct{} // semaphore of maxConcurrentCommentaries
+	quotaService       *quota.Service
+	moderator          *moderation.Moderator
 	logger             *slog.Logger
 	enableMockFallback bool
 }
 
-func NewService(logger *slog.Logger, repo *Repository, cardRepo *CardRepository, enableMockFallback bool) *Service {
+func NewService(logger *slog.Logger, repo *Repository, cardRepo *CardRepository, fairnessRepo *fairness.Repository, userRepo *user.Repository, commentator Commentator, quotaService *quota.Service, moderator *moderation.Moderator, enableMockFallback bool) *Service {
 	return &Service{
 		repo:               repo,
 		cardRepo:           cardRepo,
+		fairnessRepo:       fairnessRepo,
+		userRepo:           userRepo,
+		commentator:        commentator,
+		commentarySlots:    make(chan struct{}, maxConcurrentCommentaries),
+		quotaService:       quotaService,
+		moderator:          moderator,
 		logger:             logger,
 		enableMockFallback: enableMockFallback,
 	}
@@ -32,17 +51,21 @@ func NewService(logger *slog.Logger, repo *Repository, cardRepo *CardRepository,
 
 // StartBattle initializes a new battle
 func (s *Service) StartBattle(ctx context.Context, req *ptera.StartBattleRequest) (*ptera.StartBattleResponse, error) {
+	if req.MyCircleId == "" || req.OpponentCircleId == "" {
+		return nil, status.Error(codes.InvalidArgument, "circle IDs required")
+	}
 	// Re-use the internal logic
-	state, err := s.createBattle(ctx, req.MyCircleId, req.OpponentCircleId)
+	state, err := s.createBattle(ctx, req.MyCircleId, req.OpponentCircleId, req.IncludeGraduated, req.Commentary, req.MyCircleId, req.GetCommitmentId(), req.ClientNonce)
 	if err != nil {
 		return nil, err
 	}
 	return &ptera.StartBattleResponse{BattleState: state}, nil
 }
 
-func (s *Service) createBattle(ctx context.Context, myCircleID, opponentCircleID string) (*ptera.BattleState, error) {
-	// Fetch real cards from Firestore
-	myCards, err := s.cardRepo.GetCircleCards(ctx, myCircleID)
+// createBattle builds the decks and binds the commitment of committerID (the circle that starts or accepts the battle)
+func (s *Service) createBattle(ctx context.Context, myCircleID, opponentCircleID string, includeGraduated, commentary bool, committerID, commitmentID, clientNonce string) (*ptera.BattleState, error) {
+	// Fetch real cards from Firestore (graduated cards only in the OB/OG mode)
+	myCards, err := s.cardRepo.GetCircleCards(ctx, myCircleID, includeGraduated)
 	if err != nil {
 		s.logger.Error("failed to get my circle cards", "circle_id", myCircleID, "error", err)
 		if !s.enableMockFallback {
@@ -52,7 +75,7 @@ func (s *Service) createBattle(ctx context.Context, myCircleID, opponentCircleID
 		myCards = generateMockCards(myCircleID, 5)
 	}
 
-	opponentCards, err := s.cardRepo.GetCircleCards(ctx, opponentCircleID)
+	opponentCards, err := s.cardRepo.GetCircleCards(ctx, opponentCircleID, includeGraduated)
 	if err != nil {
 		s.logger.Error("failed to get opponent circle cards", "circle_id", opponentCircleID, "error", err)
 		if !s.enableMockFallback {
@@ -74,12 +97,25 @@ func (s *Service) createBattle(ctx context.Context, myCircleID, opponentCircleID
 		opponentCircleName = "Circle " + opponentCircleID
 	}
 
-	// Build decks from the fetched/mock cards
-	myDeck := BuildDeck(myCards)
-	opponentDeck := BuildDeck(opponentCards)
+	// Build decks from the fetched/mock cards (推しメン of each circle get a guaranteed slot)
+	myCards, myFavorites := s.withFavorites(ctx, myCircleID, myCards, includeGraduated)
+	opponentCards, opponentFavorites := s.withFavorites(ctx, opponentCircleID, opponentCards, includeGraduated)
+	myDeck := BuildDeck(myCards, myFavorites)
+	opponentDeck := BuildDeck(opponentCards, opponentFavorites)
 
 	battleID := fmt.Sprintf("battle-%d", time.Now().UnixNano())
 
+	// Bind the committed server seed to this battle (damage rolls are derived from it)
+	commitment, err := s.bindCommitment(ctx, commitmentID, committerID, clientNonce, battleID)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := []string{"Battle Start!"}
+	if includeGraduated {
+		logs = []string{"Battle Start! (OB/OG Mode)"}
+	}
+
 	state := &ptera.BattleState{
 		BattleId: battleID,
 		PlayerMe: &ptera.Player{
@@ -99,7 +135,12 @@ func (s *Service) createBattle(ctx context.Context, myCircleID, opponentCircleID
 		CurrentTurn:     1,
 		CurrentPlayerId: myCircleID, // Player starts
 		WinnerId:        "",
-		Logs:            []string{"Battle Start!"},
+		Logs:            logs,
+		Fairness:        commitment.Info(),
+	}
+	if commentary {
+		state.CommentaryEnabled = true
+		state.Commentary = []*ptera.BattleCommentary{startCommentary(state)}
 	}
 
 	if err := s.repo.SaveBattle(ctx, state); err != nil {
@@ -130,14 +171,16 @@ func (s *Service) SendBattleRequest(ctx context.Context, req *ptera.SendBattleRe
 	requestID := fmt.Sprintf("req-%d", time.Now().UnixNano())
 
 	battleReq := &ptera.BattleRequest{
-		RequestId:      requestID,
-		FromCircleId:   req.FromCircleId,
-		ToCircleId:     req.ToCircleId,
-		FromCircleName: fromCircleName,
-		ToCircleName:   toCircleName,
-		Status:         "pending",
-		CreatedAt:      timestamppb.Now(),
-		BattleId:       nil,
+		RequestId:        requestID,
+		FromCircleId:     req.FromCircleId,
+		ToCircleId:       req.ToCircleId,
+		FromCircleName:   fromCircleName,
+		ToCircleName:     toCircleName,
+		Status:           "pending",
+		CreatedAt:        timestamppb.Now(),
+		BattleId:         nil,
+		IncludeGraduated: req.IncludeGraduated,
+		Commentary:       req.Commentary,
 	}
 
 	if err := s.repo.SaveBattleRequest(ctx, battleReq); err != nil {
@@ -158,8 +201,8 @@ func (s *Service) AcceptBattleRequest(ctx context.Context, req *ptera.AcceptBatt
 		return nil, status.Errorf(codes.FailedPrecondition, "request is not pending")
 	}
 
-	// Create Battle
-	battleState, err := s.createBattle(ctx, battleReq.FromCircleId, battleReq.ToCircleId)
+	// Create Battle (the seed is committed by the accepting circle)
+	battleState, err := s.createBattle(ctx, battleReq.FromCircleId, battleReq.ToCircleId, battleReq.IncludeGraduated, battleReq.Commentary, battleReq.ToCircleId, req.GetCommitmentId(), req.ClientNonce)
 	if err != nil {
 		return nil, err
 	}
@@ -236,9 +279,28 @@ func (s *Service) Attack(ctx context.Context, req *ptera.AttackRequest) (*ptera.
 	attackerCard = attackerPlayer.Deck[0]
 	defenderCard = defenderPlayer.Deck[0]
 
-	damage := CalculateDamage(attackerCard)
+	rollIndex := nextRollIndex(state.DamageRolls)
+	rng, err := s.damageRng(ctx, state, rollIndex)
+	if err != nil {
+		return nil, err
+	}
+	damage := CalculateDamage(attackerCard, rng)
 	defenderCard.CurrentHp -= damage
 
+	// Record the roll so it can be verified after the seed is revealed,
+	// with the index the next roll starts at (the roller may have used several)
+	nextIndex := rollIndex + 1
+	if roller, ok := rng.(*fairness.Roller); ok {
+		nextIndex = roller.Index()
+	}
+	state.DamageRolls = append(state.DamageRolls, &ptera.DamageRoll{
+		RollIndex:      rollIndex,
+		AttackerCardId: attackerCard.Id,
+		Attack:         attackerCard.Attack,
+		Damage:         damage,
+		NextRollIndex:  nextIndex,
+	})
+
 	// Update Defender HP
 	newHp := int32(math.Max(0, float64(defenderCard.CurrentHp)))
 
@@ -250,6 +312,16 @@ func (s *Service) Attack(ctx context.Context, req *ptera.AttackRequest) (*ptera.
 
 	defenderCard.CurrentHp = newHp // explicitly set back just in case
 
+	turn := ai.BattleTurn{
+		Action:         ai.BattleActionAttack,
+		Circle:         attackerName,
+		OpponentCircle: defenderName,
+		Card:           commentaryCard(attackerCard),
+		Target:         commentaryCard(defenderCard),
+		Damage:         damage,
+		KnockOut:       defenderCard.CurrentHp <= 0,
+	}
+
 	if defenderCard.CurrentHp <= 0 {
 		state.Logs = append([]string{fmt.Sprintf("%s's card KO!", defenderName)}, state.Logs...)
 		defenderPlayer.Hp -= 1
@@ -265,10 +337,14 @@ func (s *Service) Attack(ctx context.Context, req *ptera.AttackRequest) (*ptera.
 		if defenderPlayer.Hp <= 0 {
 			state.WinnerId = attackerPlayer.PlayerId
 			state.Logs = append([]string{fmt.Sprintf("%s Wins!", attackerName)}, state.Logs...)
+			s.revealCommitment(ctx, state)
+			turn.Win = true
+			commentaryIndex := s.commentTurn(state, turn)
 			if err := s.repo.SaveBattle(ctx, state); err != nil {
 				fmt.Printf("Attack error: failed to save battle (win): %v\n", err) // DEBUG
 				return nil, status.Errorf(codes.Internal, "failed to save battle: %v", err)
 			}
+			s.narrateTurn(state, commentaryIndex, turn)
 			return &ptera.AttackResponse{BattleState: state}, nil
 		}
 	}
@@ -276,11 +352,13 @@ func (s *Service) Attack(ctx context.Context, req *ptera.AttackRequest) (*ptera.
 	// Switch Turn
 	state.CurrentPlayerId = defenderPlayer.PlayerId
 	state.Logs = append([]string{fmt.Sprintf("Turn Change: %s's Turn", defenderPlayer.CircleName)}, state.Logs...)
+	commentaryIndex := s.commentTurn(state, turn)
 
 	if err := s.repo.SaveBattle(ctx, state); err != nil {
 		fmt.Printf("Attack error: failed to save battle (end): %v\n", err) // DEBUG
 		return nil, status.Errorf(codes.Internal, "failed to save battle: %v", err)
 	}
+	s.narrateTurn(state, commentaryIndex, turn)
 
 	return &ptera.AttackResponse{BattleState: state}, nil
 }
@@ -327,13 +405,132 @@ func (s *Service) Retreat(ctx context.Context, req *ptera.RetreatRequest) (*pter
 	state.CurrentPlayerId = opponent.PlayerId
 	state.Logs = append([]string{fmt.Sprintf("Turn Change: %s's Turn", opponent.CircleName)}, state.Logs...)
 
+	turn := ai.BattleTurn{
+		Action:         ai.BattleActionRetreat,
+		Circle:         player.CircleName,
+		OpponentCircle: opponent.CircleName,
+		Card:           commentaryCard(deck[0]),
+		Target:         commentaryCard(deck[idx]),
+	}
+	commentaryIndex := s.commentTurn(state, turn)
+
 	if err := s.repo.SaveBattle(ctx, state); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to save battle: %v", err)
 	}
+	s.narrateTurn(state, commentaryIndex, turn)
 
 	return &ptera.RetreatResponse{BattleState: state}, nil
 }
 
+// withFavorites returns the 推しメン card IDs of the circle members (none if they cannot be fetched)
+// and adds those cards to the pool. GetCircleCards is capped at maxCircleCards, so favorites outside
+// of it are fetched explicitly to keep their guaranteed slot.
+func (s *Service) withFavorites(ctx context.Context, circleID string, cards []*ptera.Card, includeGraduated bool) ([]*ptera.Card, []string) {
+	ids, err := s.userRepo.GetCircleFavoriteCardIDs(ctx, circleID)
+	if err != nil {
+		s.logger.Warn("failed to get favorite cards", "circle_id", circleID, "error", err)
+		return cards, nil
+	}
+
+	inPool := make(map[string]bool, len(cards))
+	for _, card := range cards {
+		inPool[card.Id] = true
+	}
+	var missing []string
+	for _, id := range ids {
+		if !inPool[id] {
+			inPool[id] = true
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return cards, ids
+	}
+
+	favorites, err := s.cardRepo.GetCards(ctx, missing)
+	if err != nil {
+		s.logger.Warn("failed to get favorite cards", "circle_id", circleID, "error", err)
+		return cards, ids
+	}
+	for _, card := range favorites {
+		// Only the circle's own cards can join its deck, and graduated ones only in the OB/OG mode
+		if card.GetCircleId() != circleID || (card.Graduated && !includeGraduated) {
+			continue
+		}
+		cards = append(cards, card)
+	}
+	return cards, ids
+}
+
+// bindCommitment binds the commitment the client requested with CommitSeed to the battle
+func (s *Service) bindCommitment(ctx context.Context, commitmentID, ownerID, clientNonce, battleID string) (*fairness.Commitment, error) {
+	if commitmentID == "" {
+		return nil, status.Error(codes.InvalidArgument, "commitment_id is required (call CommitSeed first)")
+	}
+
+	c, err := s.fairnessRepo.Bind(ctx, commitmentID, fairness.PurposeBattle, ownerID, clientNonce, battleID)
+	if err != nil {
+		return nil, fairness.BindStatus(s.logger, err)
+	}
+	return c, nil
+}
+
+// damageRng returns the roller for the next damage roll of the battle, starting at rollIndex
+func (s *Service) damageRng(ctx context.Context, state *ptera.BattleState, rollIndex int64) (Rng, error) {
+	if state.Fairness == nil {
+		// Battles created before commit-reveal was introduced
+		return rand.New(rand.NewSource(time.Now().UnixNano())), nil
+	}
+
+	c, err := s.fairnessRepo.GetCommitment(ctx, state.Fairness.CommitmentId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get commitment: %v", err)
+	}
+	roller, err := c.Roller(rollIndex)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create roller: %v", err)
+	}
+	return roller, nil
+}
+
+// revealCommitment publishes the server seed once the battle is over
+func (s *Service) revealCommitment(ctx context.Context, state *ptera.BattleState) {
+	if state.Fairness == nil {
+		return
+	}
+	if _, err := s.fairnessRepo.Reveal(ctx, state.Fairness.CommitmentId); err != nil {
+		// The battle result is still valid; the seed can be revealed later
+		s.logger.Error("failed to reveal server seed", "battle_id", state.BattleId, "commitment_id", state.Fairness.CommitmentId, "error", err)
+		return
+	}
+	state.Logs = append([]string{"Server seed revealed. Rolls can now be verified."}, state.Logs...)
+}
+
+// VerifyRolls recomputes the damage rolls of the battle bound to the commitment
+func (s *Service) VerifyRolls(ctx context.Context, c *fairness.Commitment) ([]*ptera.VerifiedRoll, error) {
+	state, err := s.repo.GetBattle(ctx, c.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rolls []*ptera.VerifiedRoll
+	for i, dr := range state.DamageRolls {
+		roller, err := c.Roller(dr.RollIndex)
+		if err != nil {
+			return nil, err
+		}
+		damage := CalculateDamage(&ptera.Card{Attack: dr.Attack}, roller)
+		rolls = append(rolls, &ptera.VerifiedRoll{
+			Index:      int64(i),
+			Kind:       "damage",
+			Recorded:   strconv.Itoa(int(dr.Damage)),
+			Recomputed: strconv.Itoa(int(damage)),
+			Match:      damage == dr.Damage,
+		})
+	}
+	return rolls, nil
+}
+
 func generateMockCards(prefix string, count int) []*ptera.Card {
 	cards := make([]*ptera.Card, count)
 	for i := 0; i < count; i++ {
@@ -0,0 +1,98 @@
+package battle
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+)
+
+const (
+	ProfileBalanced  = "balanced"
+	ProfileTank      = "tank"
+	ProfileAttacker  = "attacker"
+	ProfileSpeedster = "speedster"
+
+	maxProfileReasonLength = 100
+)
+
+// statModifier is the ratio added to HP and attack at full intensity.
+// HP and attack are traded off so that a profile never makes a card stronger overall.
+type statModifier struct {
+	hp     float64
+	attack float64
+}
+
+var profileModifiers = map[string]statModifier{
+	ProfileBalanced:  {hp: 0, attack: 0},
+	ProfileTank:      {hp: 0.25, attack: -0.2}, // 打たれ強いが火力は控えめ
+	ProfileAttacker:  {hp: -0.2, attack: 0.25}, // 火力重視で打たれ弱い
+	ProfileSpeedster: {hp: -0.1, attack: 0.1},  // 身軽で手数が多い
+}
+
+// NormalizeStatProfile clamps a (possibly AI-proposed) profile to the balance bounds.
+// Unknown types fall back to balanced. nil stays nil.
+func NormalizeStatProfile(p *ptera.StatProfile) *ptera.StatProfile {
+	if p == nil {
+		return nil
+	}
+
+	profileType := strings.ToLower(strings.TrimSpace(p.Type))
+	if _, ok := profileModifiers[profileType]; !ok {
+		profileType = ProfileBalanced
+	}
+
+	intensity := float64(p.Intensity)
+	if math.IsNaN(intensity) {
+		intensity = 0
+	}
+	intensity = math.Max(0, math.Min(1, intensity))
+
+	reason := strings.TrimSpace(p.Reason)
+	if utf8.RuneCountInString(reason) > maxProfileReasonLength {
+		reason = string([]rune(reason)[:maxProfileReasonLength])
+	}
+
+	return &ptera.StatProfile{
+		Type:      profileType,
+		Intensity: float32(intensity),
+		Reason:    reason,
+	}
+}
+
+// GenerateCardStats derives the battle stats of a card from its ID, grade and stat profile
+func GenerateCardStats(card *ptera.Card) *ptera.Card {
+	stats := GenerateBattleStats(card.Id, card.Grade)
+
+	p := NormalizeStatProfile(card.StatProfile)
+	if p == nil {
+		return stats
+	}
+	mod := profileModifiers[p.Type]
+	intensity := float64(p.Intensity)
+	stats.MaxHp = int32(math.Round(float64(stats.MaxHp) * (1 + mod.hp*intensity)))
+	stats.Attack = int32(math.Round(float64(stats.Attack) * (1 + mod.attack*intensity)))
+	return stats
+}
+
+func statProfileToDocument(p *ptera.StatProfile) map[string]interface{} {
+	return map[string]interface{}{
+		"type":      p.Type,
+		"intensity": float64(p.Intensity),
+		"reason":    p.Reason,
+	}
+}
+
+func statProfileFromDocument(data map[string]interface{}) *ptera.StatProfile {
+	m, ok := data["statProfile"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	intensity, _ := m["intensity"].(float64)
+	return NormalizeStatProfile(&ptera.StatProfile{
+		Type:      getStringField(m, "type"),
+		Intensity: float32(intensity),
+		Reason:    getStringField(m, "reason"),
+	})
+}
@@ -0,0 +1,345 @@
+package card
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/battle"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/collection"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/moderation"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/quota"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/storage"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/user"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	flavorGenerationTimeout = 30 * time.Second
+	// maxConcurrentFlavors bounds the AI flavor generations running in the background
+	maxConcurrentFlavors = 4
+)
+
+var errNotCreator = errors.New("only the creator can modify this card")
+
+// FlavorGenerator generates the flavor text of a card with AI
+type FlavorGenerator interface {
+	GenerateFlavor(ctx context.Context, name, position, hobby, description string, grade int32) (string, error)
+}
+
+// Service implements the card CRUD RPCs of PteraService
+type Service struct {
+	cardRepo        *battle.CardRepository
+	userRepo        *user.Repository
+	flavorGenerator FlavorGenerator // nil disables AI-generated flavor
+	flavorInFlight  sync.Map        // card IDs whose flavor is being generated
+	flavorSlots     chan struct{}   // semaphore of maxConcurrentFlavors
+	quotaService    *quota.Service  // AI flavors count against the creator's quota
+	store           storage.Store   // uploaded card images
+	moderator       *moderation.Moderator
+	logger          *slog.Logger
+}
+
+func NewService(logger *slog.Logger, cardRepo *battle.CardRepository, userRepo *user.Repository, flavorGenerator FlavorGenerator, quotaService *quota.Service, store storage.Store, moderator *moderation.Moderator) *Service {
+	return &Service{
+		cardRepo:        cardRepo,
+		userRepo:        userRepo,
+		flavorGenerator: flavorGenerator,
+		flavorSlots:     make(chan struct{}, maxConcurrentFlavors),
+		quotaService:    quotaService,
+		store:           store,
+		moderator:       moderator,
+		logger:          logger,
+	}
+}
+
+// CreateCard validates and saves a new card for the user
+func (s *Service) CreateCard(ctx context.Context, req *ptera.CreateCardRequest) (*ptera.Card, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if req.Card == nil {
+		return nil, status.Error(codes.InvalidArgument, "card is required")
+	}
+
+	card, err := sanitize(req.Card)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid card: %v", err)
+	}
+	if reasons := s.moderator.CheckCard(card); len(reasons) > 0 {
+		return nil, moderation.Status(reasons)
+	}
+
+	// The card always belongs to the creator's current circle
+	circleID, err := s.userRepo.GetCircleID(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
+	}
+	card.CircleId = nil
+	if circleID != "" {
+		card.CircleId = &circleID
+	}
+
+	now := time.Now()
+	card.CreatorId = req.UserId
+	card.CreatedAt = timestamppb.New(now)
+	if req.Card.ExpiryDate == nil {
+		card.ExpiryDate = timestamppb.New(battle.GraduationDate(card.Grade, now))
+	}
+
+	created, err := s.cardRepo.CreateCard(ctx, card)
+	if err != nil {
+		s.logger.Error("failed to create card", "user_id", req.UserId, "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to create card: %v", err)
+	}
+
+	s.logger.Info("card created", "card_id", created.Id, "creator_id", created.CreatorId)
+	s.requestAIFlavor(created)
+	return created, nil
+}
+
+// GetCard returns a single card with its battle stats
+func (s *Service) GetCard(ctx context.Context, req *ptera.GetCardRequest) (*ptera.Card, error) {
+	if req.CardId == "" {
+		return nil, status.Error(codes.InvalidArgument, "card_id is required")
+	}
+
+	card, err := s.cardRepo.GetCard(ctx, req.CardId)
+	if err != nil {
+		return nil, s.toStatus("failed to get card", err)
+	}
+	s.requestAIFlavor(card)
+	return card, nil
+}
+
+// UpdateCard replaces the editable fields of a card owned by the user
+func (s *Service) UpdateCard(ctx context.Context, req *ptera.UpdateCardRequest) (*ptera.Card, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if req.Card == nil || req.Card.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "card.id is required")
+	}
+
+	input, err := sanitize(req.Card)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid card: %v", err)
+	}
+	if reasons := s.moderator.CheckCard(input); len(reasons) > 0 {
+		return nil, moderation.Status(reasons)
+	}
+
+	updated, err := s.cardRepo.UpdateCard(ctx, req.Card.Id, func(current *ptera.Card) error {
+		if current.CreatorId != req.UserId {
+			return errNotCreator
+		}
+
+		current.Name = input.Name
+		current.Grade = input.Grade
+		current.Position = input.Position
+		current.Hobby = input.Hobby
+		current.Description = input.Description
+		current.ImageUrl = input.ImageUrl
+		current.AffiliatedGroup = input.AffiliatedGroup
+		if input.StatProfile != nil {
+			current.StatProfile = input.StatProfile
+		}
+		if input.PromptVersion != "" {
+			current.PromptVersion = input.PromptVersion
+		}
+
+		// Keep expiry consistent with the grade unless it was given explicitly
+		if req.Card.ExpiryDate != nil {
+			current.ExpiryDate = input.ExpiryDate
+		} else {
+			current.ExpiryDate = timestamppb.New(battle.GraduationDate(current.Grade, current.CreatedAt.AsTime()))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, s.toStatus("failed to update card", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteCard deletes a card created by the user, unless another user has it in their collection
+func (s *Service) DeleteCard(ctx context.Context, req *ptera.DeleteCardRequest) (*ptera.DeleteCardResponse, error) {
+	if req.UserId == "" || req.CardId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and card_id are required")
+	}
+
+	err := s.cardRepo.DeleteCard(ctx, req.CardId, func(current *ptera.Card) error {
+		if current.CreatorId != req.UserId {
+			return errNotCreator
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, s.toStatus("failed to delete card", err)
+	}
+
+	s.logger.Info("card deleted", "card_id", req.CardId, "user_id", req.UserId)
+	return &ptera.DeleteCardResponse{CardId: req.CardId}, nil
+}
+
+// ListCards lists cards page by page, newest first
+func (s *Service) ListCards(ctx context.Context, req *ptera.ListCardsRequest) (*ptera.ListCardsResponse, error) {
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	filter := battle.CardFilter{
+		CircleID:         req.GetFilter().GetCircleId(),
+		CreatorID:        req.GetFilter().GetCreatorId(),
+		IncludeGraduated: req.GetFilter().GetIncludeGraduated(),
+	}
+
+	cards, nextPageToken, err := s.cardRepo.ListCardsPage(ctx, filter, pageSize, req.PageToken)
+	if err != nil {
+		return nil, s.toStatus("failed to list cards", err)
+	}
+
+	return &ptera.ListCardsResponse{
+		Cards:         cards,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// SetFavoriteCard sets the user's 推しメン card ("" clears it)
+func (s *Service) SetFavoriteCard(ctx context.Context, req *ptera.SetFavoriteCardRequest) (*ptera.User, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	if req.CardId != "" {
+		if _, err := s.cardRepo.GetCard(ctx, req.CardId); err != nil {
+			return nil, s.toStatus("failed to set favorite card", err)
+		}
+	}
+
+	u, err := s.userRepo.SetFavoriteCard(ctx, req.UserId, req.CardId, time.Now())
+	if err != nil {
+		return nil, s.toStatus("failed to set favorite card", err)
+	}
+
+	s.logger.Info("favorite card set", "user_id", req.UserId, "card_id", req.CardId)
+	return u, nil
+}
+
+// ListFavoriteCards returns the user's 推しメン cards. Deleted cards are skipped.
+func (s *Service) ListFavoriteCards(ctx context.Context, req *ptera.ListFavoriteCardsRequest) (*ptera.ListFavoriteCardsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	u, err := s.userRepo.GetUser(ctx, req.UserId)
+	if err != nil {
+		return nil, s.toStatus("failed to list favorite cards", err)
+	}
+
+	cards, err := s.cardRepo.GetCards(ctx, u.FavoriteCardIds)
+	if err != nil {
+		return nil, s.toStatus("failed to list favorite cards", err)
+	}
+	return &ptera.ListFavoriteCardsResponse{Cards: cards}, nil
+}
+
+// requestAIFlavor generates the AI flavor of the card in the background, once per card.
+// Until it is cached in Firestore the derived flavor is served. At most maxConcurrentFlavors
+// run at a time; when all are busy the request is dropped and made again on a later read.
+func (s *Service) requestAIFlavor(card *ptera.Card) {
+	if s.flavorGenerator == nil || card.FlavorAiGenerated {
+		return
+	}
+	if _, running := s.flavorInFlight.LoadOrStore(card.Id, true); running {
+		return
+	}
+	select {
+	case s.flavorSlots <- struct{}{}:
+	default:
+		s.flavorInFlight.Delete(card.Id)
+		return
+	}
+
+	go func() {
+		defer func() { <-s.flavorSlots }()
+		defer s.flavorInFlight.Delete(card.Id)
+
+		ctx, cancel := context.WithTimeout(context.Background(), flavorGenerationTimeout)
+		defer cancel()
+		s.generateAIFlavor(ctx, card)
+	}()
+}
+
+// generateAIFlavor claims the card's AI flavor in Firestore, charges the creator's quota and saves the
+// generated text. Failures are persisted with a backoff, so that they are not retried on every read.
+func (s *Service) generateAIFlavor(ctx context.Context, card *ptera.Card) {
+	claimed, err := s.cardRepo.ClaimAIFlavor(ctx, card.Id, time.Now())
+	if err != nil {
+		s.logger.Warn("failed to claim flavor generation", "card_id", card.Id, "error", err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	if _, err := s.quotaService.Reserve(ctx, card.CreatorId); err != nil {
+		s.logger.Warn("flavor generation not reserved", "card_id", card.Id, "creator_id", card.CreatorId, "error", err)
+		s.failAIFlavor(ctx, card.Id)
+		return
+	}
+
+	flavor, err := s.flavorGenerator.GenerateFlavor(ctx, card.Name, card.Position, card.Hobby, card.Description, card.Grade)
+	if err != nil {
+		s.logger.Warn("failed to generate flavor", "card_id", card.Id, "error", err)
+		s.failAIFlavor(ctx, card.Id)
+		return
+	}
+	if reasons := s.moderator.CheckText(moderation.StageOutput, moderation.Field{Name: "flavor", Value: flavor}); len(reasons) > 0 {
+		s.logger.Warn("generated flavor flagged", "card_id", card.Id, "word", reasons[0].Detail)
+		s.failAIFlavor(ctx, card.Id)
+		return
+	}
+	if err := s.cardRepo.SetAIFlavor(ctx, card.Id, flavor); err != nil {
+		s.logger.Warn("failed to save flavor", "card_id", card.Id, "error", err)
+		return
+	}
+	s.logger.Info("flavor generated", "card_id", card.Id)
+}
+
+// failAIFlavor persists a failed flavor generation (only logged if that fails too)
+func (s *Service) failAIFlavor(ctx context.Context, cardID string) {
+	if err := s.cardRepo.FailAIFlavor(ctx, cardID, time.Now()); err != nil {
+		s.logger.Warn("failed to record flavor failure", "card_id", cardID, "error", err)
+	}
+}
+
+// toStatus maps repository errors to gRPC status codes
+func (s *Service) toStatus(msg string, err error) error {
+	switch {
+	case errors.Is(err, battle.ErrCardNotFound), errors.Is(err, user.ErrUserNotFound):
+		return status.Errorf(codes.NotFound, "%s: %v", msg, err)
+	case errors.Is(err, battle.ErrInvalidPageToken):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", msg, err)
+	case errors.Is(err, errNotCreator):
+		return status.Errorf(codes.PermissionDenied, "%s: %v", msg, err)
+	case errors.Is(err, collection.ErrCardLocked), errors.Is(err, collection.ErrCardOwned):
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", msg, err)
+	default:
+		s.logger.Error(msg, "error", err)
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
@@ -0,0 +1,97 @@
+package card
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxUploadSize = 10 * 1024 * 1024 // 10MB
+	maxChunkSize  = 1024 * 1024      // 1MB
+)
+
+// UploadCardImage receives an image in chunks, verifies its size and checksum, and stores it.
+// The image is stored under its SHA-256, so uploading the same image twice returns the same ID.
+func (s *Service) UploadCardImage(stream ptera.PteraService_UploadCardImageServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to receive metadata: %v", err)
+	}
+	meta := first.GetMetadata()
+	if meta == nil {
+		return status.Error(codes.InvalidArgument, "the first message must contain metadata")
+	}
+	if meta.UserId == "" {
+		return status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if meta.Size <= 0 || meta.Size > maxUploadSize {
+		return status.Errorf(codes.InvalidArgument, "size must be between 1 and %d bytes", maxUploadSize)
+	}
+	expectedSum := strings.ToLower(meta.Sha256)
+	if len(expectedSum) != sha256.Size*2 {
+		return status.Error(codes.InvalidArgument, "sha256 must be a hex encoded SHA-256")
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(int(meta.Size))
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return status.Errorf(codes.Canceled, "failed to receive chunk: %v", err)
+		}
+
+		chunk := req.GetChunk()
+		if req.GetMetadata() != nil {
+			return status.Error(codes.InvalidArgument, "metadata must be sent only once")
+		}
+		if len(chunk) > maxChunkSize {
+			return status.Errorf(codes.InvalidArgument, "chunk must be at most %d bytes", maxChunkSize)
+		}
+		if int64(buf.Len()+len(chunk)) > meta.Size {
+			return status.Error(codes.InvalidArgument, "received more bytes than the declared size")
+		}
+		buf.Write(chunk)
+	}
+
+	data := buf.Bytes()
+	if int64(len(data)) != meta.Size {
+		return status.Errorf(codes.InvalidArgument, "received %d bytes, expected %d", len(data), meta.Size)
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != expectedSum {
+		return status.Error(codes.DataLoss, "sha256 mismatch")
+	}
+
+	contentType := http.DetectContentType(data)
+	ext := storage.ExtensionFor(contentType)
+	if ext == "" {
+		return status.Errorf(codes.InvalidArgument, "unsupported image type: %s", contentType)
+	}
+
+	imageID := expectedSum + ext
+	imageURL, err := s.store.Put(stream.Context(), imageID, &storage.Object{Data: data, ContentType: contentType})
+	if err != nil {
+		s.logger.Error("failed to store image", "user_id", meta.UserId, "error", err)
+		return status.Errorf(codes.Internal, "failed to store image: %v", err)
+	}
+
+	s.logger.Info("card image uploaded", "image_id", imageID, "user_id", meta.UserId, "size", meta.Size)
+	return stream.SendAndClose(&ptera.UploadCardImageResponse{
+		ImageId:     imageID,
+		ImageUrl:    imageURL,
+		ContentType: contentType,
+		Size:        meta.Size,
+	})
+}
@@ -0,0 +1,92 @@
+package card
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/battle"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+)
+
+const (
+	minGrade          = 1
+	maxGrade          = 4
+	maxNameLength     = 50
+	maxPositionLength = 30
+	maxHobbyLength    = 100
+	maxDescLength     = 500
+	maxGroupLength    = 100
+	maxPromptVersion  = 64
+)
+
+// sanitize validates the user editable fields of a card and returns a trimmed copy.
+// Mirrors validateCardData on the frontend, with length limits added.
+func sanitize(in *ptera.Card) (*ptera.Card, error) {
+	var errs []string
+
+	name := strings.TrimSpace(in.Name)
+	position := strings.TrimSpace(in.Position)
+	hobby := strings.TrimSpace(in.Hobby)
+	description := strings.TrimSpace(in.Description)
+	imageURL := strings.TrimSpace(in.ImageUrl)
+	promptVersion := strings.TrimSpace(in.PromptVersion)
+
+	// Required fields
+	if name == "" {
+		errs = append(errs, "name is required")
+	}
+	if position == "" {
+		errs = append(errs, "position is required")
+	}
+	if in.Grade < minGrade || in.Grade > maxGrade {
+		errs = append(errs, fmt.Sprintf("grade must be between %d and %d", minGrade, maxGrade))
+	}
+
+	// Length limits
+	errs = appendIfTooLong(errs, "name", name, maxNameLength)
+	errs = appendIfTooLong(errs, "position", position, maxPositionLength)
+	errs = appendIfTooLong(errs, "hobby", hobby, maxHobbyLength)
+	errs = appendIfTooLong(errs, "description", description, maxDescLength)
+	errs = appendIfTooLong(errs, "prompt_version", promptVersion, maxPromptVersion)
+
+	if imageURL != "" {
+		u, err := url.Parse(imageURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, "image_url must be an http(s) URL")
+		}
+	}
+
+	out := &ptera.Card{
+		Name:          name,
+		Grade:         in.Grade,
+		Position:      position,
+		Hobby:         hobby,
+		Description:   description,
+		ImageUrl:      imageURL,
+		ExpiryDate:    in.ExpiryDate,
+		StatProfile:   battle.NormalizeStatProfile(in.StatProfile),
+		PromptVersion: promptVersion,
+	}
+
+	if in.AffiliatedGroup != nil {
+		group := strings.TrimSpace(in.GetAffiliatedGroup())
+		errs = appendIfTooLong(errs, "affiliated_group", group, maxGroupLength)
+		if group != "" {
+			out.AffiliatedGroup = &group
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+	return out, nil
+}
+
+func appendIfTooLong(errs []string, field, value string, max int) []string {
+	if utf8.RuneCountInString(value) > max {
+		return append(errs, fmt.Sprintf("%s must be at most %d characters", field, max))
+	}
+	return errs
+}
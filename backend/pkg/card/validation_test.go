@@ -0,0 +1,167 @@
+package card
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/battle"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+)
+
+// validCard returns a card that passes sanitize, for the tests to break one field at a time
+func validCard() *ptera.Card {
+	return &ptera.Card{
+		Name:     "山田 太郎",
+		Grade:    2,
+		Position: "部員",
+	}
+}
+
+func TestSanitizeTrims(t *testing.T) {
+	in := &ptera.Card{
+		Name:            "  山田 太郎 ",
+		Grade:           3,
+		Position:        "\t部長\n",
+		Hobby:           " 写真 ",
+		Description:     " よろしく ",
+		ImageUrl:        " https://example.com/a.png ",
+		AffiliatedGroup: proto.String(" 写真部 "),
+		PromptVersion:   " v2 ",
+		// Set by the server, not by the user
+		Id:        "card1",
+		CreatorId: "someone",
+		MaxHp:     9999,
+	}
+	got, err := sanitize(in)
+	if err != nil {
+		t.Fatalf("sanitize() error = %v", err)
+	}
+
+	want := &ptera.Card{
+		Name:            "山田 太郎",
+		Grade:           3,
+		Position:        "部長",
+		Hobby:           "写真",
+		Description:     "よろしく",
+		ImageUrl:        "https://example.com/a.png",
+		AffiliatedGroup: proto.String("写真部"),
+		PromptVersion:   "v2",
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("sanitize() = %v, want %v", got, want)
+	}
+}
+
+func TestSanitizeAffiliatedGroup(t *testing.T) {
+	tests := []struct {
+		name  string
+		group *string
+		want  *string
+	}{
+		{"unset", nil, nil},
+		{"blank", proto.String("  "), nil},
+		{"set", proto.String("写真部"), proto.String("写真部")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := validCard()
+			in.AffiliatedGroup = tt.group
+			got, err := sanitize(in)
+			if err != nil {
+				t.Fatalf("sanitize() error = %v", err)
+			}
+			if (got.AffiliatedGroup == nil) != (tt.want == nil) || (tt.want != nil && *got.AffiliatedGroup != *tt.want) {
+				t.Errorf("AffiliatedGroup = %v, want %v", got.AffiliatedGroup, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *ptera.Card)
+		wantErr string // empty if the card is valid
+	}{
+		{"valid", func(c *ptera.Card) {}, ""},
+		{"blank name", func(c *ptera.Card) { c.Name = "  " }, "name is required"},
+		{"blank position", func(c *ptera.Card) { c.Position = "" }, "position is required"},
+		{"grade below range", func(c *ptera.Card) { c.Grade = minGrade - 1 }, "grade must be between"},
+		{"grade above range", func(c *ptera.Card) { c.Grade = maxGrade + 1 }, "grade must be between"},
+		{"lowest grade", func(c *ptera.Card) { c.Grade = minGrade }, ""},
+		{"highest grade", func(c *ptera.Card) { c.Grade = maxGrade }, ""},
+		{"name at the limit", func(c *ptera.Card) { c.Name = strings.Repeat("あ", maxNameLength) }, ""},
+		// The limit applies after trimming
+		{"name at the limit with spaces", func(c *ptera.Card) { c.Name = " " + strings.Repeat("あ", maxNameLength) + " " }, ""},
+		{"long name", func(c *ptera.Card) { c.Name = strings.Repeat("あ", maxNameLength+1) }, "name must be at most"},
+		{"long position", func(c *ptera.Card) { c.Position = strings.Repeat("あ", maxPositionLength+1) }, "position must be at most"},
+		{"long hobby", func(c *ptera.Card) { c.Hobby = strings.Repeat("あ", maxHobbyLength+1) }, "hobby must be at most"},
+		{"long description", func(c *ptera.Card) { c.Description = strings.Repeat("あ", maxDescLength+1) }, "description must be at most"},
+		{"long group", func(c *ptera.Card) { c.AffiliatedGroup = proto.String(strings.Repeat("あ", maxGroupLength+1)) }, "affiliated_group must be at most"},
+		{"long prompt version", func(c *ptera.Card) { c.PromptVersion = strings.Repeat("v", maxPromptVersion+1) }, "prompt_version must be at most"},
+		{"image url without scheme", func(c *ptera.Card) { c.ImageUrl = "example.com/a.png" }, "image_url must be an http(s) URL"},
+		{"image url with another scheme", func(c *ptera.Card) { c.ImageUrl = "javascript:alert(1)" }, "image_url must be an http(s) URL"},
+		{"image url without host", func(c *ptera.Card) { c.ImageUrl = "https:///a.png" }, "image_url must be an http(s) URL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := validCard()
+			tt.modify(in)
+			_, err := sanitize(in)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("sanitize() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("sanitize() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSanitizeReportsAllErrors(t *testing.T) {
+	_, err := sanitize(&ptera.Card{})
+	if err == nil {
+		t.Fatal("sanitize() of an empty card: expected error")
+	}
+	for _, want := range []string{"name is required", "position is required", "grade must be between"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("sanitize() error = %v, want it to contain %q", err, want)
+		}
+	}
+}
+
+func TestSanitizeStatProfile(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *ptera.StatProfile
+		want *ptera.StatProfile
+	}{
+		{"unset", nil, nil},
+		{"valid", &ptera.StatProfile{Type: "tank", Intensity: 0.5, Reason: "頑丈"}, &ptera.StatProfile{Type: battle.ProfileTank, Intensity: 0.5, Reason: "頑丈"}},
+		{"type is case-insensitive", &ptera.StatProfile{Type: " Attacker "}, &ptera.StatProfile{Type: battle.ProfileAttacker}},
+		{"unknown type", &ptera.StatProfile{Type: "wizard", Intensity: 0.3}, &ptera.StatProfile{Type: battle.ProfileBalanced, Intensity: 0.3}},
+		{"intensity above 1", &ptera.StatProfile{Type: "speedster", Intensity: 5}, &ptera.StatProfile{Type: battle.ProfileSpeedster, Intensity: 1}},
+		{"negative intensity", &ptera.StatProfile{Type: "tank", Intensity: -1}, &ptera.StatProfile{Type: battle.ProfileTank, Intensity: 0}},
+		{"NaN intensity", &ptera.StatProfile{Type: "tank", Intensity: float32(math.NaN())}, &ptera.StatProfile{Type: battle.ProfileTank, Intensity: 0}},
+		{"long reason", &ptera.StatProfile{Type: "tank", Reason: strings.Repeat("あ", 150)}, &ptera.StatProfile{Type: battle.ProfileTank, Reason: strings.Repeat("あ", 100)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := validCard()
+			in.StatProfile = tt.in
+			got, err := sanitize(in)
+			if err != nil {
+				t.Fatalf("sanitize() error = %v", err)
+			}
+			if !proto.Equal(got.StatProfile, tt.want) {
+				t.Errorf("StatProfile = %v, want %v", got.StatProfile, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package cardqr
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// tokenPrefix versions the token format: "ptq1.<card_id>.<expires_unix_base36>.<mac>"
+	tokenPrefix = "ptq1."
+	macSize     = 16 // bytes of HMAC-SHA256 kept, to keep the QR code small
+
+	DefaultTTL = 15 * time.Minute
+	MinKeySize = 32
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid card QR token")
+	ErrTokenExpired = errors.New("card QR token expired")
+)
+
+// Signer issues and verifies card QR tokens with HMAC-SHA256
+type Signer struct {
+	key []byte
+	ttl time.Duration
+}
+
+func NewSigner(key []byte, ttl time.Duration) (*Signer, error) {
+	if len(key) < MinKeySize {
+		return nil, fmt.Errorf("card QR key must be at least %d bytes", MinKeySize)
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("invalid card QR ttl: %v", ttl)
+	}
+	return &Signer{key: key, ttl: ttl}, nil
+}
+
+// GenerateKey creates a random key, for when no key is configured (tokens do not survive a restart)
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, MinKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate card QR key: %w", err)
+	}
+	return key, nil
+}
+
+// Issue returns a token for the card that expires after the TTL
+func (s *Signer) Issue(cardID string, now time.Time) (string, time.Time) {
+	expiresAt := now.Add(s.ttl).Truncate(time.Second)
+	payload := tokenPrefix + cardID + "." + strconv.FormatInt(expiresAt.Unix(), 36)
+	return payload + "." + s.mac(payload), expiresAt
+}
+
+// Verify checks the signature and expiry of a token and returns its card ID
+func (s *Signer) Verify(token string, now time.Time) (string, time.Time, error) {
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return "", time.Time{}, ErrInvalidToken
+	}
+	i := strings.LastIndex(token, ".")
+	payload, mac := token[:i], token[i+1:]
+	if !hmac.Equal([]byte(mac), []byte(s.mac(payload))) {
+		return "", time.Time{}, ErrInvalidToken
+	}
+
+	// The payload is signed, so from here on it was issued by us
+	rest := strings.TrimPrefix(payload, tokenPrefix)
+	j := strings.LastIndex(rest, ".")
+	if j <= 0 {
+		return "", time.Time{}, ErrInvalidToken
+	}
+	expires, err := strconv.ParseInt(rest[j+1:], 36, 64)
+	if err != nil {
+		return "", time.Time{}, ErrInvalidToken
+	}
+	expiresAt := time.Unix(expires, 0)
+	if !now.Before(expiresAt) {
+		return "", expiresAt, ErrTokenExpired
+	}
+	return rest[:j], expiresAt, nil
+}
+
+func (s *Signer) mac(payload string) string {
+	m := hmac.New(sha256.New, s.key)
+	m.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(m.Sum(nil)[:macSize])
+}
@@ -0,0 +1,124 @@
+package cardqr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testSigner(t *testing.T, key string) *Signer {
+	t.Helper()
+	s, err := NewSigner([]byte(key), DefaultTTL)
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+	return s
+}
+
+func TestNewSigner(t *testing.T) {
+	key := []byte(strings.Repeat("k", MinKeySize))
+
+	if _, err := NewSigner(key, DefaultTTL); err != nil {
+		t.Errorf("NewSigner() error = %v", err)
+	}
+	if _, err := NewSigner(key[:MinKeySize-1], DefaultTTL); err == nil {
+		t.Error("NewSigner() with a short key: expected error")
+	}
+	if _, err := NewSigner(key, 0); err == nil {
+		t.Error("NewSigner() with zero ttl: expected error")
+	}
+
+	generated, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	if _, err := NewSigner(generated, DefaultTTL); err != nil {
+		t.Errorf("NewSigner() with a generated key: error = %v", err)
+	}
+}
+
+func TestIssueVerify(t *testing.T) {
+	s := testSigner(t, strings.Repeat("a", MinKeySize))
+	now := time.Date(2025, 12, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		cardID string
+	}{
+		{"simple", "card123"},
+		{"dots in id", "card.with.dots"},
+		{"firestore id", "AbCdEfGhIjKlMnOpQrSt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, expiresAt := s.Issue(tt.cardID, now)
+			if !strings.HasPrefix(token, tokenPrefix) {
+				t.Errorf("token %q does not start with %q", token, tokenPrefix)
+			}
+			if want := now.Add(DefaultTTL); !expiresAt.Equal(want) {
+				t.Errorf("expiresAt = %v, want %v", expiresAt, want)
+			}
+
+			cardID, gotExpires, err := s.Verify(token, now.Add(time.Minute))
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if cardID != tt.cardID {
+				t.Errorf("cardID = %q, want %q", cardID, tt.cardID)
+			}
+			if !gotExpires.Equal(expiresAt) {
+				t.Errorf("expiresAt = %v, want %v", gotExpires, expiresAt)
+			}
+		})
+	}
+}
+
+func TestVerifyExpired(t *testing.T) {
+	s := testSigner(t, strings.Repeat("a", MinKeySize))
+	now := time.Date(2025, 12, 1, 12, 0, 0, 0, time.UTC)
+	token, expiresAt := s.Issue("card123", now)
+
+	for _, at := range []time.Time{expiresAt, expiresAt.Add(time.Hour)} {
+		_, _, err := s.Verify(token, at)
+		if !errors.Is(err, ErrTokenExpired) {
+			t.Errorf("Verify() at %v: error = %v, want %v", at, err, ErrTokenExpired)
+		}
+	}
+}
+
+func TestVerifyInvalid(t *testing.T) {
+	s := testSigner(t, strings.Repeat("a", MinKeySize))
+	other := testSigner(t, strings.Repeat("b", MinKeySize))
+	now := time.Date(2025, 12, 1, 12, 0, 0, 0, time.UTC)
+	token, _ := s.Issue("card123", now)
+	otherToken, _ := other.Issue("card123", now)
+
+	i := strings.LastIndex(token, ".")
+	payload, mac := token[:i], token[i+1:]
+	flipped := "A"
+	if mac[0] == 'A' {
+		flipped = "B"
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"wrong prefix", "ptq0." + strings.TrimPrefix(token, tokenPrefix)},
+		{"tampered mac", payload + "." + flipped + mac[1:]},
+		{"tampered card id", strings.Replace(token, "card123", "card124", 1)},
+		{"tampered expiry", payload + "0." + mac},
+		{"signed with another key", otherToken},
+		{"no mac", payload},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := s.Verify(tt.token, now)
+			if !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("Verify(%q) error = %v, want %v", tt.token, err, ErrInvalidToken)
+			}
+		})
+	}
+}
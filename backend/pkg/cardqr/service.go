@@ -0,0 +1,103 @@
+package cardqr
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"rsc.io/qr"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/battle"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/collection"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+)
+
+// Service issues QR codes for cards and resolves them back to cards (QRメンコ)
+type Service struct {
+	cardRepo  *battle.CardRepository
+	userCards *collection.Repository
+	signer    *Signer
+	logger    *slog.Logger
+}
+
+func NewService(logger *slog.Logger, cardRepo *battle.CardRepository, userCards *collection.Repository, signer *Signer) *Service {
+	return &Service{
+		cardRepo:  cardRepo,
+		userCards: userCards,
+		signer:    signer,
+		logger:    logger,
+	}
+}
+
+// IssueCardQR returns a signed token for the card and its QR code.
+// Only the creator of the card or a user who has it in their collection can issue one.
+// The token only carries the card ID, so a card edited after issuing resolves to its current stats.
+func (s *Service) IssueCardQR(ctx context.Context, req *ptera.IssueCardQRRequest) (*ptera.IssueCardQRResponse, error) {
+	if req.CardId == "" {
+		return nil, status.Error(codes.InvalidArgument, "card_id is required")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	card, err := s.cardRepo.GetCard(ctx, req.CardId)
+	if err != nil {
+		if errors.Is(err, battle.ErrCardNotFound) {
+			return nil, status.Errorf(codes.NotFound, "failed to issue card QR: %v", err)
+		}
+		s.logger.Error("failed to get card", "card_id", req.CardId, "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to issue card QR: %v", err)
+	}
+	if card.CreatorId != req.UserId {
+		owns, err := s.userCards.OwnsCard(ctx, req.UserId, req.CardId)
+		if err != nil {
+			s.logger.Error("failed to check card owner", "card_id", req.CardId, "user_id", req.UserId, "error", err)
+			return nil, status.Errorf(codes.Internal, "failed to issue card QR: %v", err)
+		}
+		if !owns {
+			return nil, status.Error(codes.PermissionDenied, "only the creator or an owner of the card can issue its QR code")
+		}
+	}
+
+	token, expiresAt := s.signer.Issue(req.CardId, time.Now())
+	code, err := qr.Encode(token, qr.M)
+	if err != nil {
+		s.logger.Error("failed to encode card QR", "card_id", req.CardId, "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to encode card QR: %v", err)
+	}
+
+	return &ptera.IssueCardQRResponse{
+		Token:     token,
+		ExpiresAt: timestamppb.New(expiresAt),
+		QrPng:     code.PNG(),
+	}, nil
+}
+
+// ResolveCardQR verifies a scanned token and returns the card with its battle stats
+func (s *Service) ResolveCardQR(ctx context.Context, req *ptera.ResolveCardQRRequest) (*ptera.Card, error) {
+	if req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+	cardID, _, err := s.signer.Verify(req.Token, time.Now())
+	if errors.Is(err, ErrTokenExpired) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		s.logger.Warn("invalid card QR token", "error", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	card, err := s.cardRepo.GetCard(ctx, cardID)
+	if errors.Is(err, battle.ErrCardNotFound) {
+		return nil, status.Errorf(codes.NotFound, "failed to resolve card QR: %v", err)
+	}
+	if err != nil {
+		s.logger.Error("failed to get card", "card_id", cardID, "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to resolve card QR: %v", err)
+	}
+	return card, nil
+}
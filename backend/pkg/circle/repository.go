@@ -0,0 +1,326 @@
+package circle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/user"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	CollectionCircles = "circles"
+	CollectionInvites = "circle_invites"
+)
+
+var (
+	ErrCircleNotFound  = errors.New("circle not found")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrInviteNotFound  = errors.New("invite code not found")
+	ErrInviteExpired   = errors.New("invite code has expired or reached its usage limit")
+	ErrNotMember       = errors.New("user is not a member of the circle")
+	ErrNotInCircle     = errors.New("user has not joined a circle")
+	ErrInviteCodeTaken = errors.New("invite code already exists")
+)
+
+// Circle mirrors FirestoreCircle on the frontend
+type Circle struct {
+	ID          string    `firestore:"-"`
+	Name        string    `firestore:"name"`
+	Description string    `firestore:"description,omitempty"`
+	ImageURL    string    `firestore:"imageUrl,omitempty"`
+	MemberIDs   []string  `firestore:"memberIds"`
+	CreatorID   string    `firestore:"creatorId,omitempty"`
+	CreatedAt   time.Time `firestore:"createdAt"`
+	UpdatedAt   time.Time `firestore:"updatedAt"`
+}
+
+func (c *Circle) ToProto() *ptera.Circle {
+	return &ptera.Circle{
+		Id:          c.ID,
+		Name:        c.Name,
+		Description: c.Description,
+		ImageUrl:    c.ImageURL,
+		MemberIds:   c.MemberIDs,
+		CreatorId:   c.CreatorID,
+		CreatedAt:   timestamppb.New(c.CreatedAt),
+		UpdatedAt:   timestamppb.New(c.UpdatedAt),
+	}
+}
+
+// Invite is an invite code document (the document ID is the code)
+type Invite struct {
+	Code      string    `firestore:"-"`
+	CircleID  string    `firestore:"circleId"`
+	CreatedBy string    `firestore:"createdBy"`
+	CreatedAt time.Time `firestore:"createdAt"`
+	ExpiresAt time.Time `firestore:"expiresAt"`
+	MaxUses   int       `firestore:"maxUses"` // 0 なら無制限
+	Uses      int       `firestore:"uses"`
+}
+
+func (i *Invite) usable(now time.Time) bool {
+	return i.ExpiresAt.After(now) && (i.MaxUses == 0 || i.Uses < i.MaxUses)
+}
+
+func (i *Invite) ToProto() *ptera.InviteCode {
+	return &ptera.InviteCode{
+		Code:      i.Code,
+		CircleId:  i.CircleID,
+		CreatedBy: i.CreatedBy,
+		ExpiresAt: timestamppb.New(i.ExpiresAt),
+		MaxUses:   int32(i.MaxUses),
+		Uses:      int32(i.Uses),
+	}
+}
+
+type Repository struct {
+	client *firestore.Client
+}
+
+func NewRepository(client *firestore.Client) *Repository {
+	return &Repository{client: client}
+}
+
+// GetCircle retrieves a circle from Firestore
+func (r *Repository) GetCircle(ctx context.Context, circleID string) (*Circle, error) {
+	doc, err := r.circleRef(circleID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrCircleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circle: %w", err)
+	}
+	return circleFromDocument(doc)
+}
+
+// CreateCircle creates a circle with the creator as its only member.
+// users.circleId and circles.memberIds are updated in the same transaction.
+func (r *Repository) CreateCircle(ctx context.Context, c *Circle) (*Circle, error) {
+	ref := r.client.Collection(CollectionCircles).NewDoc()
+	c.ID = ref.ID
+	c.MemberIDs = []string{c.CreatorID}
+
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		currentCircleID, err := r.getUserCircleID(tx, c.CreatorID)
+		if errors.Is(err, ErrCircleNotFound) {
+			// The current circle no longer exists, so there is nothing to leave
+			currentCircleID, err = "", nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Create(ref, c); err != nil {
+			return fmt.Errorf("failed to create circle: %w", err)
+		}
+		return r.moveMember(tx, c.CreatorID, currentCircleID, c.ID, c.CreatedAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// CreateInvite stores a new invite code. Fails with ErrInviteCodeTaken if the code already exists.
+func (r *Repository) CreateInvite(ctx context.Context, invite *Invite) error {
+	_, err := r.client.Collection(CollectionInvites).Doc(invite.Code).Create(ctx, invite)
+	if status.Code(err) == codes.AlreadyExists {
+		return ErrInviteCodeTaken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create invite: %w", err)
+	}
+	return nil
+}
+
+// JoinCircle adds the user to the circle of the invite code, leaving the current circle if any
+func (r *Repository) JoinCircle(ctx context.Context, userID, code string, now time.Time) (*Circle, error) {
+	inviteRef := r.client.Collection(CollectionInvites).Doc(code)
+
+	var circleID string
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(inviteRef)
+		if status.Code(err) == codes.NotFound {
+			return ErrInviteNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get invite: %w", err)
+		}
+		var invite Invite
+		if err := doc.DataTo(&invite); err != nil {
+			return fmt.Errorf("failed to parse invite: %w", err)
+		}
+		if !invite.usable(now) {
+			return ErrInviteExpired
+		}
+		circleID = invite.CircleID
+
+		if _, err := tx.Get(r.circleRef(circleID)); err != nil {
+			if status.Code(err) == codes.NotFound {
+				return ErrCircleNotFound
+			}
+			return fmt.Errorf("failed to get circle: %w", err)
+		}
+
+		currentCircleID, err := r.getUserCircleID(tx, userID)
+		if errors.Is(err, ErrCircleNotFound) {
+			// The current circle no longer exists, so there is nothing to leave
+			currentCircleID, err = "", nil
+		}
+		if err != nil {
+			return err
+		}
+		if currentCircleID == circleID {
+			// Already a member; keep memberIds in sync without consuming the code
+			return tx.Update(r.circleRef(circleID), []firestore.Update{
+				{Path: "memberIds", Value: firestore.ArrayUnion(userID)},
+			})
+		}
+
+		if err := tx.Update(inviteRef, []firestore.Update{
+			{Path: "uses", Value: firestore.Increment(1)},
+		}); err != nil {
+			return fmt.Errorf("failed to update invite: %w", err)
+		}
+		return r.moveMember(tx, userID, currentCircleID, circleID, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetCircle(ctx, circleID)
+}
+
+// LeaveCircle removes the user from the current circle and returns its ID.
+// If the circle was deleted, only the user's dangling circleId is cleared.
+func (r *Repository) LeaveCircle(ctx context.Context, userID string, now time.Time) (string, error) {
+	var circleID string
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		current, exists, err := r.readUserCircle(tx, userID)
+		if err != nil {
+			return err
+		}
+		from, err := circleToLeave(current, exists)
+		if err != nil {
+			return err
+		}
+		circleID = current
+		return r.moveMember(tx, userID, from, "", now)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return circleID, nil
+}
+
+// circleToLeave returns the circle whose memberIds must drop the leaving user ("" if the circle no longer exists)
+func circleToLeave(current string, exists bool) (string, error) {
+	if current == "" {
+		return "", ErrNotInCircle
+	}
+	if !exists {
+		return "", nil
+	}
+	return current, nil
+}
+
+// IsMember reports whether the user belongs to the circle
+func (r *Repository) IsMember(ctx context.Context, circleID, userID string) (bool, error) {
+	c, err := r.GetCircle(ctx, circleID)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(c.MemberIDs, userID), nil
+}
+
+// moveMember updates both membership representations: the user's circleId and the circles' memberIds.
+// toCircleID "" means leaving without joining another circle. All reads must be done before calling this.
+func (r *Repository) moveMember(tx *firestore.Transaction, userID, fromCircleID, toCircleID string, now time.Time) error {
+	if fromCircleID != "" && fromCircleID != toCircleID {
+		if err := tx.Update(r.circleRef(fromCircleID), []firestore.Update{
+			{Path: "memberIds", Value: firestore.ArrayRemove(userID)},
+			{Path: "updatedAt", Value: now},
+		}); err != nil {
+			return fmt.Errorf("failed to remove member from circle: %w", err)
+		}
+	}
+
+	userUpdate := firestore.Update{Path: "circleId", Value: firestore.Delete}
+	if toCircleID != "" {
+		userUpdate.Value = toCircleID
+		if err := tx.Update(r.circleRef(toCircleID), []firestore.Update{
+			{Path: "memberIds", Value: firestore.ArrayUnion(userID)},
+			{Path: "updatedAt", Value: now},
+		}); err != nil {
+			return fmt.Errorf("failed to add member to circle: %w", err)
+		}
+	}
+
+	if err := tx.Update(r.client.Collection(user.CollectionUsers).Doc(userID), []firestore.Update{
+		userUpdate,
+		{Path: "updatedAt", Value: now},
+	}); err != nil {
+		return fmt.Errorf("failed to update user circle: %w", err)
+	}
+	return nil
+}
+
+// getUserCircleID reads the user's current circle inside a transaction.
+// A circleId pointing at a deleted circle yields ErrCircleNotFound so that
+// callers do not write to the missing document.
+func (r *Repository) getUserCircleID(tx *firestore.Transaction, userID string) (string, error) {
+	circleID, exists, err := r.readUserCircle(tx, userID)
+	if err != nil {
+		return "", err
+	}
+	if circleID != "" && !exists {
+		return "", fmt.Errorf("%w: %s", ErrCircleNotFound, circleID)
+	}
+	return circleID, nil
+}
+
+// readUserCircle reads the user's circleId inside a transaction and whether that circle still exists
+func (r *Repository) readUserCircle(tx *firestore.Transaction, userID string) (string, bool, error) {
+	doc, err := tx.Get(r.client.Collection(user.CollectionUsers).Doc(userID))
+	if status.Code(err) == codes.NotFound {
+		return "", false, ErrUserNotFound
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get user: %w", err)
+	}
+	circleID, _ := doc.Data()["circleId"].(string)
+	if circleID == "" {
+		return "", false, nil
+	}
+
+	if _, err := tx.Get(r.circleRef(circleID)); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return circleID, false, nil
+		}
+		return "", false, fmt.Errorf("failed to get circle: %w", err)
+	}
+	return circleID, true, nil
+}
+
+func (r *Repository) circleRef(circleID string) *firestore.DocumentRef {
+	return r.client.Collection(CollectionCircles).Doc(circleID)
+}
+
+func circleFromDocument(doc *firestore.DocumentSnapshot) (*Circle, error) {
+	var c Circle
+	if err := doc.DataTo(&c); err != nil {
+		return nil, fmt.Errorf("failed to parse circle: %w", err)
+	}
+	c.ID = doc.Ref.ID
+	return &c, nil
+}
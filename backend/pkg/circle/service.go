@@ -0,0 +1,217 @@
+package circle
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/user"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	inviteTTL          = 7 * 24 * time.Hour
+	inviteCodeLength   = 8
+	inviteCodeAttempts = 5
+	maxInviteUses      = 100
+	maxNameLength      = 50
+	maxDescLength      = 500
+
+	// 見間違えやすい文字 (0/O, 1/I/L) を除いた英数字
+	inviteCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+)
+
+type Service struct {
+	ptera.UnimplementedCircleServiceServer
+	repo     *Repository
+	userRepo *user.Repository
+	logger   *slog.Logger
+}
+
+func NewService(logger *slog.Logger, repo *Repository, userRepo *user.Repository) *Service {
+	return &Service{
+		repo:     repo,
+		userRepo: userRepo,
+		logger:   logger,
+	}
+}
+
+// CreateCircle creates a circle and makes the creator its first member
+func (s *Service) CreateCircle(ctx context.Context, req *ptera.CreateCircleRequest) (*ptera.Circle, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	name := strings.TrimSpace(req.Name)
+	description := strings.TrimSpace(req.Description)
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return nil, status.Errorf(codes.InvalidArgument, "name must be at most %d characters", maxNameLength)
+	}
+	if utf8.RuneCountInString(description) > maxDescLength {
+		return nil, status.Errorf(codes.InvalidArgument, "description must be at most %d characters", maxDescLength)
+	}
+
+	now := time.Now()
+	c, err := s.repo.CreateCircle(ctx, &Circle{
+		Name:        name,
+		Description: description,
+		ImageURL:    strings.TrimSpace(req.ImageUrl),
+		CreatorID:   req.UserId,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	if err != nil {
+		return nil, s.toStatus("failed to create circle", err)
+	}
+
+	s.logger.Info("circle created", "circle_id", c.ID, "creator_id", c.CreatorID)
+	return c.ToProto(), nil
+}
+
+func (s *Service) GetCircle(ctx context.Context, req *ptera.GetCircleRequest) (*ptera.Circle, error) {
+	if req.CircleId == "" {
+		return nil, status.Error(codes.InvalidArgument, "circle_id is required")
+	}
+
+	c, err := s.repo.GetCircle(ctx, req.CircleId)
+	if err != nil {
+		return nil, s.toStatus("failed to get circle", err)
+	}
+	return c.ToProto(), nil
+}
+
+// GenerateInviteCode issues a new invite code. Only members of the circle can invite.
+func (s *Service) GenerateInviteCode(ctx context.Context, req *ptera.GenerateInviteCodeRequest) (*ptera.InviteCode, error) {
+	if req.UserId == "" || req.CircleId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and circle_id are required")
+	}
+	if req.MaxUses < 0 || req.MaxUses > maxInviteUses {
+		return nil, status.Errorf(codes.InvalidArgument, "max_uses must be between 0 and %d", maxInviteUses)
+	}
+
+	isMember, err := s.repo.IsMember(ctx, req.CircleId, req.UserId)
+	if err != nil {
+		return nil, s.toStatus("failed to generate invite code", err)
+	}
+	if !isMember {
+		return nil, s.toStatus("failed to generate invite code", ErrNotMember)
+	}
+
+	now := time.Now()
+	invite := &Invite{
+		CircleID:  req.CircleId,
+		CreatedBy: req.UserId,
+		CreatedAt: now,
+		ExpiresAt: now.Add(inviteTTL),
+		MaxUses:   int(req.MaxUses),
+	}
+
+	// Retry on the (unlikely) collision with an existing code
+	for attempt := 0; ; attempt++ {
+		invite.Code, err = newInviteCode()
+		if err != nil {
+			break
+		}
+		err = s.repo.CreateInvite(ctx, invite)
+		if !errors.Is(err, ErrInviteCodeTaken) || attempt+1 >= inviteCodeAttempts {
+			break
+		}
+	}
+	if err != nil {
+		return nil, s.toStatus("failed to generate invite code", err)
+	}
+
+	s.logger.Info("invite code generated", "circle_id", invite.CircleID, "created_by", invite.CreatedBy)
+	return invite.ToProto(), nil
+}
+
+// JoinCircle joins the circle of the invite code, leaving the current circle if any
+func (s *Service) JoinCircle(ctx context.Context, req *ptera.JoinCircleRequest) (*ptera.Circle, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if req.UserId == "" || code == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and code are required")
+	}
+
+	c, err := s.repo.JoinCircle(ctx, req.UserId, code, time.Now())
+	if err != nil {
+		return nil, s.toStatus("failed to join circle", err)
+	}
+
+	s.logger.Info("user joined circle", "circle_id", c.ID, "user_id", req.UserId)
+	return c.ToProto(), nil
+}
+
+func (s *Service) LeaveCircle(ctx context.Context, req *ptera.LeaveCircleRequest) (*ptera.LeaveCircleResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	circleID, err := s.repo.LeaveCircle(ctx, req.UserId, time.Now())
+	if err != nil {
+		return nil, s.toStatus("failed to leave circle", err)
+	}
+
+	s.logger.Info("user left circle", "circle_id", circleID, "user_id", req.UserId)
+	return &ptera.LeaveCircleResponse{CircleId: circleID}, nil
+}
+
+func (s *Service) ListMembers(ctx context.Context, req *ptera.ListMembersRequest) (*ptera.ListMembersResponse, error) {
+	if req.CircleId == "" {
+		return nil, status.Error(codes.InvalidArgument, "circle_id is required")
+	}
+
+	c, err := s.repo.GetCircle(ctx, req.CircleId)
+	if err != nil {
+		return nil, s.toStatus("failed to list members", err)
+	}
+
+	members, err := s.userRepo.GetUsers(ctx, c.MemberIDs)
+	if err != nil {
+		return nil, s.toStatus("failed to list members", err)
+	}
+	// Email is private to its owner, as in UserService.GetUser
+	for _, m := range members {
+		m.Email = nil
+	}
+	return &ptera.ListMembersResponse{Members: members}, nil
+}
+
+// toStatus maps repository errors to gRPC status codes
+func (s *Service) toStatus(msg string, err error) error {
+	switch {
+	case errors.Is(err, ErrCircleNotFound), errors.Is(err, ErrUserNotFound), errors.Is(err, ErrInviteNotFound):
+		return status.Errorf(codes.NotFound, "%s: %v", msg, err)
+	case errors.Is(err, ErrNotMember):
+		return status.Errorf(codes.PermissionDenied, "%s: %v", msg, err)
+	case errors.Is(err, ErrInviteExpired), errors.Is(err, ErrNotInCircle):
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", msg, err)
+	default:
+		s.logger.Error(msg, "error", err)
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
+// newInviteCode returns a random code from inviteCodeAlphabet, each character equally likely
+func newInviteCode() (string, error) {
+	b := make([]byte, inviteCodeLength)
+	n := big.NewInt(int64(len(inviteCodeAlphabet)))
+	for i := range b {
+		// rand.Int rejects out-of-range values instead of taking a biased modulo
+		v, err := rand.Int(rand.Reader, n)
+		if err != nil {
+			return "", fmt.Errorf("failed to read random bytes: %w", err)
+		}
+		b[i] = inviteCodeAlphabet[v.Int64()]
+	}
+	return string(b), nil
+}
@@ -0,0 +1,177 @@
+package circle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+)
+
+func testService() *Service {
+	return NewService(slog.New(slog.DiscardHandler), nil, nil)
+}
+
+func TestNewInviteCode(t *testing.T) {
+	counts := make(map[rune]int)
+	const samples = 2000
+	for range samples {
+		code, err := newInviteCode()
+		if err != nil {
+			t.Fatalf("newInviteCode() error = %v", err)
+		}
+		if len(code) != inviteCodeLength {
+			t.Fatalf("newInviteCode() = %q, want %d characters", code, inviteCodeLength)
+		}
+		for _, c := range code {
+			if !strings.ContainsRune(inviteCodeAlphabet, c) {
+				t.Fatalf("newInviteCode() = %q contains %q", code, c)
+			}
+			counts[c]++
+		}
+	}
+
+	// With a modulo of random bytes, the first 256%31 characters came up 9/8 as often as the rest.
+	// Each character is expected 2000*8/31 ≈ 516 times; allow a generous margin for randomness.
+	expected := float64(samples*inviteCodeLength) / float64(len(inviteCodeAlphabet))
+	for _, c := range inviteCodeAlphabet {
+		if n := float64(counts[c]); n < expected*0.75 || n > expected*1.25 {
+			t.Errorf("%q appeared %v times, expected about %v", c, n, expected)
+		}
+	}
+}
+
+func TestInviteUsable(t *testing.T) {
+	now := time.Date(2025, 12, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		invite Invite
+		want   bool
+	}{
+		{"unlimited", Invite{ExpiresAt: now.Add(time.Hour)}, true},
+		{"uses left", Invite{ExpiresAt: now.Add(time.Hour), MaxUses: 2, Uses: 1}, true},
+		{"used up", Invite{ExpiresAt: now.Add(time.Hour), MaxUses: 2, Uses: 2}, false},
+		{"expired", Invite{ExpiresAt: now}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.invite.usable(now); got != tt.want {
+				t.Errorf("usable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCircleToLeave(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		exists  bool
+		want    string
+		wantErr error
+	}{
+		{"member", "circle1", true, "circle1", nil},
+		{"deleted circle", "circle1", false, "", nil},
+		{"not in a circle", "", false, "", ErrNotInCircle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := circleToLeave(tt.current, tt.exists)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Errorf("circleToLeave() = %q, %v, want %q, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestValidation covers the requests rejected before the repository is used
+func TestValidation(t *testing.T) {
+	s := testService()
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"create without user", func() error {
+			_, err := s.CreateCircle(ctx, &ptera.CreateCircleRequest{Name: "写真部"})
+			return err
+		}},
+		{"create with a blank name", func() error {
+			_, err := s.CreateCircle(ctx, &ptera.CreateCircleRequest{UserId: "user1", Name: "  "})
+			return err
+		}},
+		{"create with a long name", func() error {
+			_, err := s.CreateCircle(ctx, &ptera.CreateCircleRequest{UserId: "user1", Name: strings.Repeat("あ", maxNameLength+1)})
+			return err
+		}},
+		{"create with a long description", func() error {
+			_, err := s.CreateCircle(ctx, &ptera.CreateCircleRequest{UserId: "user1", Name: "写真部", Description: strings.Repeat("あ", maxDescLength+1)})
+			return err
+		}},
+		{"get without id", func() error {
+			_, err := s.GetCircle(ctx, &ptera.GetCircleRequest{})
+			return err
+		}},
+		{"invite without circle", func() error {
+			_, err := s.GenerateInviteCode(ctx, &ptera.GenerateInviteCodeRequest{UserId: "user1"})
+			return err
+		}},
+		{"invite with negative max uses", func() error {
+			_, err := s.GenerateInviteCode(ctx, &ptera.GenerateInviteCodeRequest{UserId: "user1", CircleId: "circle1", MaxUses: -1})
+			return err
+		}},
+		{"invite with too many uses", func() error {
+			_, err := s.GenerateInviteCode(ctx, &ptera.GenerateInviteCodeRequest{UserId: "user1", CircleId: "circle1", MaxUses: maxInviteUses + 1})
+			return err
+		}},
+		{"join with a blank code", func() error {
+			_, err := s.JoinCircle(ctx, &ptera.JoinCircleRequest{UserId: "user1", Code: " "})
+			return err
+		}},
+		{"leave without user", func() error {
+			_, err := s.LeaveCircle(ctx, &ptera.LeaveCircleRequest{})
+			return err
+		}},
+		{"list members without circle", func() error {
+			_, err := s.ListMembers(ctx, &ptera.ListMembersRequest{})
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("error = %v, want %v", err, codes.InvalidArgument)
+			}
+		})
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	s := testService()
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{ErrCircleNotFound, codes.NotFound},
+		{fmt.Errorf("%w: circle1", ErrCircleNotFound), codes.NotFound},
+		{ErrUserNotFound, codes.NotFound},
+		{ErrInviteNotFound, codes.NotFound},
+		{ErrNotMember, codes.PermissionDenied},
+		{ErrInviteExpired, codes.FailedPrecondition},
+		{ErrNotInCircle, codes.FailedPrecondition},
+		{ErrInviteCodeTaken, codes.Internal},
+		{errors.New("firestore is down"), codes.Internal},
+	}
+	for _, tt := range tests {
+		if got := status.Code(s.toStatus("failed", tt.err)); got != tt.want {
+			t.Errorf("toStatus(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
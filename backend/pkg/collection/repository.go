@@ -0,0 +1,159 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+var (
+	ErrUserCardNotFound = errors.New("user card not found")
+	ErrCardLocked       = errors.New("card is locked by a pending trade")
+	ErrCardOwned        = errors.New("card is owned by other users")
+)
+
+// Repository is the only writer of user_cards. Every change goes through a transaction that
+// re-reads the card and checks its lock, so no feature can move or remove a card held by a trade.
+type Repository struct {
+	client *firestore.Client
+}
+
+func NewRepository(client *firestore.Client) *Repository {
+	return &Repository{client: client}
+}
+
+// GetUserCards reads owned cards inside a transaction
+func (r *Repository) GetUserCards(tx *firestore.Transaction, ids []string) ([]*UserCard, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	refs := make([]*firestore.DocumentRef, len(ids))
+	for i, id := range ids {
+		refs[i] = r.ref(id)
+	}
+
+	docs, err := tx.GetAll(refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user cards: %w", err)
+	}
+
+	cards := make([]*UserCard, len(docs))
+	for i, doc := range docs {
+		if !doc.Exists() {
+			return nil, fmt.Errorf("%w: %s", ErrUserCardNotFound, ids[i])
+		}
+		var c UserCard
+		if err := doc.DataTo(&c); err != nil {
+			return nil, fmt.Errorf("failed to parse user card: %w", err)
+		}
+		c.ID = doc.Ref.ID
+		cards[i] = &c
+	}
+	return cards, nil
+}
+
+// AddUserCard adds a new card to a collection and sets its ID
+func (r *Repository) AddUserCard(tx *firestore.Transaction, c *UserCard) error {
+	ref := r.client.Collection(CollectionUserCards).NewDoc()
+	c.ID = ref.ID
+	if err := tx.Create(ref, c); err != nil {
+		return fmt.Errorf("failed to add card to collection: %w", err)
+	}
+	return nil
+}
+
+// Lock holds the card for a trade until expiresAt
+func (r *Repository) Lock(tx *firestore.Transaction, c *UserCard, tradeID string, expiresAt, now time.Time) error {
+	if err := c.CheckTransfer("", now); err != nil {
+		return err
+	}
+	if err := tx.Update(r.ref(c.ID), []firestore.Update{
+		{Path: "lockedBy", Value: tradeID},
+		{Path: "lockExpiresAt", Value: expiresAt},
+	}); err != nil {
+		return fmt.Errorf("failed to lock card: %w", err)
+	}
+	return nil
+}
+
+// Unlock releases the card if it is still locked by the trade
+func (r *Repository) Unlock(tx *firestore.Transaction, c *UserCard, tradeID string) error {
+	if c.LockedBy != tradeID {
+		return nil
+	}
+	if err := tx.Update(r.ref(c.ID), []firestore.Update{
+		{Path: "lockedBy", Value: firestore.Delete},
+		{Path: "lockExpiresAt", Value: firestore.Delete},
+	}); err != nil {
+		return fmt.Errorf("failed to unlock card: %w", err)
+	}
+	return nil
+}
+
+// Transfer moves the card to a new owner and releases its lock.
+// holder is the trade allowed to move a locked card ("" if the card must be free).
+func (r *Repository) Transfer(tx *firestore.Transaction, c *UserCard, newOwnerID, source, holder string, now time.Time) error {
+	if err := c.CheckTransfer(holder, now); err != nil {
+		return err
+	}
+	if err := tx.Update(r.ref(c.ID), []firestore.Update{
+		{Path: "ownerId", Value: newOwnerID},
+		{Path: "source", Value: source},
+		{Path: "acquiredAt", Value: now},
+		{Path: "lockedBy", Value: firestore.Delete},
+		{Path: "lockExpiresAt", Value: firestore.Delete},
+	}); err != nil {
+		return fmt.Errorf("failed to transfer card: %w", err)
+	}
+	return nil
+}
+
+// RemoveCard removes every owned copy of a card that is being deleted, all of which must belong to ownerID.
+// Returns ErrCardOwned if another user has the card and ErrCardLocked if a copy is held by a trade,
+// so that no collection or trade is left pointing at a deleted card.
+func (r *Repository) RemoveCard(tx *firestore.Transaction, cardID, ownerID string, now time.Time) error {
+	docs, err := tx.Documents(r.client.Collection(CollectionUserCards).Where("cardId", "==", cardID)).GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to get user cards: %w", err)
+	}
+	copies := make([]*UserCard, len(docs))
+	for i, doc := range docs {
+		var c UserCard
+		if err := doc.DataTo(&c); err != nil {
+			return fmt.Errorf("failed to parse user card: %w", err)
+		}
+		c.ID = doc.Ref.ID
+		copies[i] = &c
+	}
+	if err := CheckRemovable(copies, ownerID, now); err != nil {
+		return err
+	}
+
+	for _, c := range copies {
+		if err := tx.Delete(r.ref(c.ID)); err != nil {
+			return fmt.Errorf("failed to remove card from collection: %w", err)
+		}
+	}
+	return nil
+}
+
+// OwnsCard reports whether the user has the card in their collection
+func (r *Repository) OwnsCard(ctx context.Context, ownerID, cardID string) (bool, error) {
+	docs, err := r.client.Collection(CollectionUserCards).
+		Where("ownerId", "==", ownerID).
+		Where("cardId", "==", cardID).
+		Limit(1).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return false, fmt.Errorf("failed to get user cards: %w", err)
+	}
+	return len(docs) > 0, nil
+}
+
+func (r *Repository) ref(id string) *firestore.DocumentRef {
+	return r.client.Collection(CollectionUserCards).Doc(id)
+}
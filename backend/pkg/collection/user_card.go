@@ -0,0 +1,57 @@
+package collection
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	CollectionUserCards = "user_cards"
+
+	SourceGacha = "gacha"
+	SourceTrade = "trade"
+)
+
+// UserCard is a card owned by a user (an entry in the user's collection)
+type UserCard struct {
+	ID            string    `firestore:"-"`
+	OwnerID       string    `firestore:"ownerId"`
+	CardID        string    `firestore:"cardId"`
+	Rarity        string    `firestore:"rarity"`
+	Source        string    `firestore:"source"` // "gacha", "trade"
+	PullID        string    `firestore:"pullId,omitempty"`
+	AcquiredAt    time.Time `firestore:"acquiredAt"`
+	LockedBy      string    `firestore:"lockedBy,omitempty"` // 取引中のトレードID
+	LockExpiresAt time.Time `firestore:"lockExpiresAt,omitempty"`
+}
+
+// IsLocked reports whether the card is locked by a pending trade at now.
+// Locks of expired trades are ignored.
+func (c *UserCard) IsLocked(now time.Time) bool {
+	return c.LockedBy != "" && c.LockExpiresAt.After(now)
+}
+
+// CheckTransfer returns ErrCardLocked unless the card can be locked or moved at now.
+// holder is the trade allowed to move a card it locked ("" if the card must be free).
+func (c *UserCard) CheckTransfer(holder string, now time.Time) error {
+	if c.IsLocked(now) && c.LockedBy != holder {
+		return fmt.Errorf("%w: %s", ErrCardLocked, c.ID)
+	}
+	return nil
+}
+
+// CheckRemovable returns ErrCardOwned if a copy of a card belongs to someone other than ownerID,
+// and ErrCardLocked if a copy is held by a trade
+func CheckRemovable(copies []*UserCard, ownerID string, now time.Time) error {
+	for _, c := range copies {
+		if c.OwnerID != ownerID {
+			return fmt.Errorf("%w: %s", ErrCardOwned, c.ID)
+		}
+	}
+	for _, c := range copies {
+		if err := c.CheckTransfer("", now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package collection
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUserCardIsLocked(t *testing.T) {
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		card UserCard
+		want bool
+	}{
+		{name: "free", card: UserCard{}, want: false},
+		{name: "locked", card: UserCard{LockedBy: "trade-1", LockExpiresAt: now.Add(time.Hour)}, want: true},
+		{name: "lock expired", card: UserCard{LockedBy: "trade-1", LockExpiresAt: now.Add(-time.Second)}, want: false},
+		{name: "expires now", card: UserCard{LockedBy: "trade-1", LockExpiresAt: now}, want: false},
+		{name: "expiry without trade", card: UserCard{LockExpiresAt: now.Add(time.Hour)}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.card.IsLocked(now); got != tt.want {
+				t.Errorf("IsLocked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserCardCheckTransfer(t *testing.T) {
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	locked := UserCard{ID: "uc1", LockedBy: "trade-1", LockExpiresAt: now.Add(time.Hour)}
+	tests := []struct {
+		name   string
+		card   UserCard
+		holder string
+		want   error
+	}{
+		{name: "free card", card: UserCard{ID: "uc1"}},
+		{name: "free card moved by a trade", card: UserCard{ID: "uc1"}, holder: "trade-1"},
+		{name: "locked card moved by its trade", card: locked, holder: "trade-1"},
+		{name: "locked card moved by another trade", card: locked, holder: "trade-2", want: ErrCardLocked},
+		{name: "locked card moved outside a trade", card: locked, want: ErrCardLocked},
+		{name: "lock expired", card: UserCard{ID: "uc1", LockedBy: "trade-1", LockExpiresAt: now}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.card.CheckTransfer(tt.holder, now)
+			if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Errorf("CheckTransfer() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckRemovable(t *testing.T) {
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		copies []*UserCard
+		want   error
+	}{
+		{name: "no copies"},
+		{name: "only the creator's copies", copies: []*UserCard{{ID: "uc1", OwnerID: "creator"}, {ID: "uc2", OwnerID: "creator"}}},
+		{name: "collected by another user", copies: []*UserCard{{ID: "uc1", OwnerID: "creator"}, {ID: "uc2", OwnerID: "other"}}, want: ErrCardOwned},
+		{name: "creator's copy in a trade", copies: []*UserCard{{ID: "uc1", OwnerID: "creator", LockedBy: "trade-1", LockExpiresAt: now.Add(time.Hour)}}, want: ErrCardLocked},
+		{name: "creator's copy after its trade expired", copies: []*UserCard{{ID: "uc1", OwnerID: "creator", LockedBy: "trade-1", LockExpiresAt: now}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckRemovable(tt.copies, "creator", now)
+			if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Errorf("CheckRemovable() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
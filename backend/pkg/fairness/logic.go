@@ -0,0 +1,79 @@
+package fairness
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+const seedSize = 32
+
+// GenerateSeed creates a new random server seed
+func GenerateSeed() ([]byte, error) {
+	seed := make([]byte, seedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, fmt.Errorf("failed to generate seed: %w", err)
+	}
+	return seed, nil
+}
+
+// HashSeed returns the hex encoded SHA-256 of the seed (the published commitment)
+func HashSeed(seed []byte) string {
+	sum := sha256.Sum256(seed)
+	return hex.EncodeToString(sum[:])
+}
+
+// Roller derives a deterministic sequence of rolls from the server seed and client nonce.
+// Roll i is the first 8 bytes (big endian) of HMAC-SHA256(server_seed, client_nonce + ":" + i).
+// Float64 uses one index; Intn may use several (see Intn), so callers that make several draws
+// record where each draw started and continue from Index, not from the number of draws.
+type Roller struct {
+	seed  []byte
+	nonce string
+	index int64
+}
+
+// NewRoller creates a roller starting at the given roll index
+func NewRoller(seed []byte, clientNonce string, index int64) *Roller {
+	return &Roller{seed: seed, nonce: clientNonce, index: index}
+}
+
+// Index returns the index of the next roll
+func (r *Roller) Index() int64 {
+	return r.index
+}
+
+// Uint64 returns the next roll
+func (r *Roller) Uint64() uint64 {
+	mac := hmac.New(sha256.New, r.seed)
+	mac.Write([]byte(r.nonce + ":" + strconv.FormatInt(r.index, 10)))
+	r.index++
+	return binary.BigEndian.Uint64(mac.Sum(nil)[:8])
+}
+
+// Float64 returns the next roll as a float in [0.0, 1.0)
+func (r *Roller) Float64() float64 {
+	return float64(r.Uint64()>>11) / (1 << 53)
+}
+
+// Intn returns the next roll as an int in [0, n), uniformly: rolls in the incomplete
+// range at the top of uint64 are skipped (each skipped roll still consumes an index),
+// and the first remaining roll is taken modulo n
+func (r *Roller) Intn(n int) int {
+	if n <= 0 {
+		panic("fairness: invalid argument to Intn")
+	}
+	bound := uint64(n)
+	// 2^64 % bound, computed without overflowing
+	excess := (math.MaxUint64%bound + 1) % bound
+	for {
+		if v := r.Uint64(); v <= math.MaxUint64-excess {
+			return int(v % bound)
+		}
+	}
+}
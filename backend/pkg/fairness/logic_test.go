@@ -0,0 +1,134 @@
+package fairness
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// referenceRoll recomputes roll i the way the FairnessService documents it, independently of Roller
+func referenceRoll(seed []byte, nonce string, i int64) uint64 {
+	mac := hmac.New(sha256.New, seed)
+	fmt.Fprintf(mac, "%s:%d", nonce, i)
+	return binary.BigEndian.Uint64(mac.Sum(nil)[:8])
+}
+
+// referenceIntn is the documented rejection sampling, using big integers instead of uint64 arithmetic
+func referenceIntn(seed []byte, nonce string, index int64, n int) (int, int64) {
+	two64 := new(big.Int).Lsh(big.NewInt(1), 64)
+	bound := big.NewInt(int64(n))
+	limit := new(big.Int).Sub(two64, new(big.Int).Mod(two64, bound))
+	for {
+		v := new(big.Int).SetUint64(referenceRoll(seed, nonce, index))
+		index++
+		if v.Cmp(limit) < 0 {
+			return int(new(big.Int).Mod(v, bound).Int64()), index
+		}
+	}
+}
+
+func TestHashSeed(t *testing.T) {
+	got := HashSeed(make([]byte, 32))
+	want := "66687aadf862bd776c8fc18b8e9f8e20089714856ee233b3902a591d0d5f2925"
+	if got != want {
+		t.Errorf("HashSeed(zeros) = %s, want %s", got, want)
+	}
+}
+
+func TestRollerUint64(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	tests := []struct {
+		name  string
+		nonce string
+		start int64
+	}{
+		{name: "from zero", nonce: "nonce", start: 0},
+		{name: "resumed battle", nonce: "nonce", start: 7},
+		{name: "empty nonce", nonce: "", start: 0},
+		{name: "multibyte nonce", nonce: "ノンス", start: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRoller(seed, tt.nonce, tt.start)
+			for i := tt.start; i < tt.start+5; i++ {
+				if got, want := r.Uint64(), referenceRoll(seed, tt.nonce, i); got != want {
+					t.Fatalf("roll %d = %d, want %d", i, got, want)
+				}
+			}
+			if r.Index() != tt.start+5 {
+				t.Errorf("Index() = %d, want %d", r.Index(), tt.start+5)
+			}
+		})
+	}
+}
+
+func TestRollerIntn(t *testing.T) {
+	seed := []byte("a server seed of thirty-two byte")
+	tests := []struct {
+		name string
+		n    int
+	}{
+		{name: "single outcome", n: 1},
+		{name: "die", n: 6},
+		{name: "rarity weights", n: 100},
+		{name: "not a power of two", n: 1000003},
+		// 2^64 mod n = 2^62, so a quarter of the rolls are skipped
+		{name: "frequent rejection", n: 3 << 61},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRoller(seed, "nonce", 0)
+			index := int64(0)
+			for i := 0; i < 200; i++ {
+				var want int
+				want, index = referenceIntn(seed, "nonce", index, tt.n)
+				got := r.Intn(tt.n)
+				if got != want {
+					t.Fatalf("draw %d: Intn(%d) = %d, want %d", i, tt.n, got, want)
+				}
+				if got < 0 || got >= tt.n {
+					t.Fatalf("draw %d: Intn(%d) = %d out of range", i, tt.n, got)
+				}
+				if r.Index() != index {
+					t.Fatalf("draw %d: Index() = %d, want %d", i, r.Index(), index)
+				}
+			}
+		})
+	}
+}
+
+func TestRollerIntnSkipsIncompleteRange(t *testing.T) {
+	r := NewRoller([]byte("a server seed of thirty-two byte"), "nonce", 0)
+	for i := 0; i < 200; i++ {
+		r.Intn(3 << 61)
+	}
+	if r.Index() == 200 {
+		t.Error("expected some rolls to be skipped for n = 3<<61")
+	}
+}
+
+func TestRollerIntnPanicsOnInvalidN(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Intn(%d) did not panic", n)
+				}
+			}()
+			NewRoller([]byte("seed"), "nonce", 0).Intn(n)
+		}()
+	}
+}
+
+func TestRollerFloat64(t *testing.T) {
+	r := NewRoller([]byte("seed"), "nonce", 0)
+	for i := 0; i < 1000; i++ {
+		if f := r.Float64(); f < 0 || f >= 1 {
+			t.Fatalf("Float64() = %v out of [0, 1)", f)
+		}
+	}
+}
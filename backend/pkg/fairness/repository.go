@@ -0,0 +1,207 @@
+package fairness
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	CollectionCommitments = "fairness_commitments"
+
+	PurposeBattle = "battle"
+	PurposeGacha  = "gacha"
+
+	StatusCommitted = "committed"
+	StatusBound     = "bound"
+	StatusRevealed  = "revealed"
+)
+
+var (
+	ErrCommitmentNotFound = errors.New("commitment not found")
+	ErrCommitmentMismatch = errors.New("commitment does not match purpose or owner")
+	ErrCommitmentUsed     = errors.New("commitment has already been used")
+	ErrNonceRequired      = errors.New("client nonce is required")
+)
+
+// Commitment is a server seed committed (by its hash) before it is used
+type Commitment struct {
+	ID             string    `firestore:"-"`
+	Purpose        string    `firestore:"purpose"`
+	OwnerID        string    `firestore:"ownerId"`
+	ServerSeed     string    `firestore:"serverSeed"` // hex, kept secret until revealed
+	ServerSeedHash string    `firestore:"serverSeedHash"`
+	ClientNonce    string    `firestore:"clientNonce"`
+	SubjectID      string    `firestore:"subjectId"`
+	Status         string    `firestore:"status"`
+	CreatedAt      time.Time `firestore:"createdAt"`
+	RevealedAt     time.Time `firestore:"revealedAt,omitempty"`
+}
+
+// Seed decodes the server seed
+func (c *Commitment) Seed() ([]byte, error) {
+	seed, err := hex.DecodeString(c.ServerSeed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode server seed: %w", err)
+	}
+	return seed, nil
+}
+
+// Roller creates a roller for this commitment starting at index
+func (c *Commitment) Roller(index int64) (*Roller, error) {
+	seed, err := c.Seed()
+	if err != nil {
+		return nil, err
+	}
+	return NewRoller(seed, c.ClientNonce, index), nil
+}
+
+// Info returns the public fairness info attached to battles
+func (c *Commitment) Info() *ptera.FairnessInfo {
+	return &ptera.FairnessInfo{
+		CommitmentId:   c.ID,
+		ServerSeedHash: c.ServerSeedHash,
+		ClientNonce:    c.ClientNonce,
+	}
+}
+
+// ToProto converts the commitment, exposing the server seed only after it is revealed
+func (c *Commitment) ToProto() *ptera.SeedCommitment {
+	pb := &ptera.SeedCommitment{
+		CommitmentId:   c.ID,
+		Purpose:        c.Purpose,
+		OwnerId:        c.OwnerID,
+		ServerSeedHash: c.ServerSeedHash,
+		ClientNonce:    c.ClientNonce,
+		SubjectId:      c.SubjectID,
+		Status:         c.Status,
+		CreatedAt:      timestamppb.New(c.CreatedAt),
+	}
+	if c.Status == StatusRevealed {
+		seed := c.ServerSeed
+		pb.ServerSeed = &seed
+		pb.RevealedAt = timestamppb.New(c.RevealedAt)
+	}
+	return pb
+}
+
+type Repository struct {
+	client *firestore.Client
+}
+
+func NewRepository(client *firestore.Client) *Repository {
+	return &Repository{client: client}
+}
+
+// Commit generates a new server seed and stores it with its hash
+func (r *Repository) Commit(ctx context.Context, purpose, ownerID string) (*Commitment, error) {
+	seed, err := GenerateSeed()
+	if err != nil {
+		return nil, err
+	}
+
+	ref := r.client.Collection(CollectionCommitments).NewDoc()
+	c := &Commitment{
+		ID:             ref.ID,
+		Purpose:        purpose,
+		OwnerID:        ownerID,
+		ServerSeed:     hex.EncodeToString(seed),
+		ServerSeedHash: HashSeed(seed),
+		Status:         StatusCommitted,
+		CreatedAt:      time.Now(),
+	}
+
+	if _, err := ref.Create(ctx, c); err != nil {
+		return nil, fmt.Errorf("failed to save commitment: %w", err)
+	}
+	return c, nil
+}
+
+// GetCommitment retrieves a commitment from Firestore
+func (r *Repository) GetCommitment(ctx context.Context, commitmentID string) (*Commitment, error) {
+	doc, err := r.client.Collection(CollectionCommitments).Doc(commitmentID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, fmt.Errorf("%w: %s", ErrCommitmentNotFound, commitmentID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commitment: %w", err)
+	}
+
+	var c Commitment
+	if err := doc.DataTo(&c); err != nil {
+		return nil, fmt.Errorf("failed to parse commitment: %w", err)
+	}
+	c.ID = doc.Ref.ID
+	return &c, nil
+}
+
+// Bind marks a committed seed as used by subjectID with the client nonce.
+// A commitment can be bound only once, so every seed drives exactly one battle or pull.
+// The seed must have been committed (and its hash published) before the nonce is sent,
+// so there is no way to bind a fresh seed to a nonce the server already knows.
+func (r *Repository) Bind(ctx context.Context, commitmentID, purpose, ownerID, clientNonce, subjectID string) (*Commitment, error) {
+	if clientNonce == "" {
+		return nil, ErrNonceRequired
+	}
+	ref := r.client.Collection(CollectionCommitments).Doc(commitmentID)
+
+	var c Commitment
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return fmt.Errorf("%w: %s", ErrCommitmentNotFound, commitmentID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get commitment: %w", err)
+		}
+		if err := doc.DataTo(&c); err != nil {
+			return fmt.Errorf("failed to parse commitment: %w", err)
+		}
+
+		if c.Purpose != purpose || c.OwnerID != ownerID {
+			return ErrCommitmentMismatch
+		}
+		if c.Status != StatusCommitted {
+			return ErrCommitmentUsed
+		}
+
+		c.ClientNonce = clientNonce
+		c.SubjectID = subjectID
+		c.Status = StatusBound
+		return tx.Update(ref, []firestore.Update{
+			{Path: "clientNonce", Value: clientNonce},
+			{Path: "subjectId", Value: subjectID},
+			{Path: "status", Value: StatusBound},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.ID = commitmentID
+	return &c, nil
+}
+
+// Reveal publishes the server seed of a used commitment
+func (r *Repository) Reveal(ctx context.Context, commitmentID string) (*Commitment, error) {
+	ref := r.client.Collection(CollectionCommitments).Doc(commitmentID)
+	now := time.Now()
+
+	_, err := ref.Update(ctx, []firestore.Update{
+		{Path: "status", Value: StatusRevealed},
+		{Path: "revealedAt", Value: now},
+	}, firestore.Exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reveal commitment: %w", err)
+	}
+
+	return r.GetCommitment(ctx, commitmentID)
+}
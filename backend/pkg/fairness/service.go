@@ -0,0 +1,139 @@
+package fairness
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Verifier recomputes the rolls recorded for a revealed commitment
+type Verifier interface {
+	VerifyRolls(ctx context.Context, commitment *Commitment) ([]*ptera.VerifiedRoll, error)
+}
+
+type Service struct {
+	ptera.UnimplementedFairnessServiceServer
+	repo      *Repository
+	verifiers map[string]Verifier
+	logger    *slog.Logger
+}
+
+// NewService creates the fairness service. verifiers maps a purpose ("battle", "gacha") to its verifier.
+func NewService(logger *slog.Logger, repo *Repository, verifiers map[string]Verifier) *Service {
+	return &Service{
+		repo:      repo,
+		verifiers: verifiers,
+		logger:    logger,
+	}
+}
+
+// CommitSeed generates a server seed and publishes its hash
+func (s *Service) CommitSeed(ctx context.Context, req *ptera.CommitSeedRequest) (*ptera.SeedCommitment, error) {
+	if req.Purpose != PurposeBattle && req.Purpose != PurposeGacha {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown purpose: %s", req.Purpose)
+	}
+	if req.OwnerId == "" {
+		return nil, status.Error(codes.InvalidArgument, "owner_id is required")
+	}
+
+	c, err := s.repo.Commit(ctx, req.Purpose, req.OwnerId)
+	if err != nil {
+		s.logger.Error("failed to commit seed", "purpose", req.Purpose, "owner_id", req.OwnerId, "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to commit seed: %v", err)
+	}
+
+	return c.ToProto(), nil
+}
+
+// GetCommitment returns a commitment (with the seed only once revealed)
+func (s *Service) GetCommitment(ctx context.Context, req *ptera.GetCommitmentRequest) (*ptera.SeedCommitment, error) {
+	if req.CommitmentId == "" {
+		return nil, status.Error(codes.InvalidArgument, "commitment_id is required")
+	}
+	c, err := s.repo.GetCommitment(ctx, req.CommitmentId)
+	if err != nil {
+		return nil, s.toStatus("failed to get commitment", err)
+	}
+	return c.ToProto(), nil
+}
+
+// VerifyRolls recomputes all rolls of a revealed commitment and compares them with the records
+func (s *Service) VerifyRolls(ctx context.Context, req *ptera.VerifyRollsRequest) (*ptera.VerifyRollsResponse, error) {
+	if req.CommitmentId == "" {
+		return nil, status.Error(codes.InvalidArgument, "commitment_id is required")
+	}
+	c, err := s.repo.GetCommitment(ctx, req.CommitmentId)
+	if err != nil {
+		return nil, s.toStatus("failed to verify rolls", err)
+	}
+
+	if c.Status != StatusRevealed {
+		return nil, status.Error(codes.FailedPrecondition, "server seed has not been revealed yet")
+	}
+
+	resp := &ptera.VerifyRollsResponse{Commitment: c.ToProto()}
+
+	// The published hash must match the revealed seed
+	seed, err := c.Seed()
+	if err != nil || HashSeed(seed) != c.ServerSeedHash {
+		msg := "revealed server seed does not match the committed hash"
+		resp.ErrorMessage = &msg
+		return resp, nil
+	}
+
+	verifier, ok := s.verifiers[c.Purpose]
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "no verifier for purpose: %s", c.Purpose)
+	}
+
+	rolls, err := verifier.VerifyRolls(ctx, c)
+	if err != nil {
+		s.logger.Error("failed to verify rolls", "commitment_id", c.ID, "error", err)
+		msg := err.Error()
+		resp.ErrorMessage = &msg
+		return resp, nil
+	}
+
+	resp.Rolls = rolls
+	resp.Valid = true
+	for _, r := range rolls {
+		if !r.Match {
+			resp.Valid = false
+		}
+	}
+
+	return resp, nil
+}
+
+// toStatus maps repository errors to gRPC status codes
+func (s *Service) toStatus(msg string, err error) error {
+	if errors.Is(err, ErrCommitmentNotFound) {
+		return status.Errorf(codes.NotFound, "%s: %v", msg, err)
+	}
+	s.logger.Error(msg, "error", err)
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
+
+// BindStatus maps an error of Repository.Bind to a gRPC status, for the services that bind commitments
+func BindStatus(logger *slog.Logger, err error) error {
+	switch {
+	case errors.Is(err, ErrNonceRequired):
+		return status.Error(codes.InvalidArgument, "client_nonce is required")
+	case errors.Is(err, ErrCommitmentMismatch):
+		return status.Errorf(codes.InvalidArgument, "invalid commitment: %v", err)
+	case errors.Is(err, ErrCommitmentUsed):
+		return status.Errorf(codes.FailedPrecondition, "invalid commitment: %v", err)
+	case errors.Is(err, ErrCommitmentNotFound):
+		return status.Errorf(codes.NotFound, "failed to bind commitment: %v", err)
+	case status.Code(err) == codes.Aborted:
+		// The transaction kept conflicting with another bind; the client may retry
+		return status.Errorf(codes.Aborted, "failed to bind commitment: %v", err)
+	default:
+		logger.Error("failed to bind commitment", "error", err)
+		return status.Errorf(codes.Internal, "failed to bind commitment: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package fairness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+)
+
+func TestBindStatus(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"nonce required", ErrNonceRequired, codes.InvalidArgument},
+		{"mismatch", ErrCommitmentMismatch, codes.InvalidArgument},
+		{"already used", ErrCommitmentUsed, codes.FailedPrecondition},
+		{"not found", fmt.Errorf("%w: c1", ErrCommitmentNotFound), codes.NotFound},
+		{"transaction contention", fmt.Errorf("failed to bind: %w", status.Error(codes.Aborted, "too much contention")), codes.Aborted},
+		{"firestore unavailable", fmt.Errorf("failed to get commitment: %w", status.Error(codes.Unavailable, "unavailable")), codes.Internal},
+		{"other error", errors.New("failed to parse commitment"), codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(BindStatus(logger, tt.err)); got != tt.want {
+				t.Errorf("BindStatus(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	s := NewService(slog.New(slog.DiscardHandler), nil, nil)
+	if got := status.Code(s.toStatus("failed", fmt.Errorf("%w: c1", ErrCommitmentNotFound))); got != codes.NotFound {
+		t.Errorf("toStatus(not found) = %v, want %v", got, codes.NotFound)
+	}
+	if got := status.Code(s.toStatus("failed", errors.New("firestore is down"))); got != codes.Internal {
+		t.Errorf("toStatus(other) = %v, want %v", got, codes.Internal)
+	}
+}
+
+func TestCommitmentIDRequired(t *testing.T) {
+	s := NewService(slog.New(slog.DiscardHandler), nil, nil)
+	ctx := context.Background()
+	if _, err := s.GetCommitment(ctx, &ptera.GetCommitmentRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetCommitment() = %v, want %v", err, codes.InvalidArgument)
+	}
+	if _, err := s.VerifyRolls(ctx, &ptera.VerifyRollsRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("VerifyRolls() = %v, want %v", err, codes.InvalidArgument)
+	}
+}
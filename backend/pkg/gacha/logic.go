@@ -0,0 +1,197 @@
+package gacha
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+)
+
+type Rarity string
+
+const (
+	RarityN   Rarity = "N"
+	RarityR   Rarity = "R"
+	RaritySR  Rarity = "SR"
+	RaritySSR Rarity = "SSR"
+)
+
+// rarityOrder lists rarities from lowest to highest
+var rarityOrder = []Rarity{RarityN, RarityR, RaritySR, RaritySSR}
+
+// Config holds the rarity table and pity settings
+type Config struct {
+	Weights       map[Rarity]int // 排出の重み (合計に対する比率)
+	PityThreshold int            // この回数までに最高レアが出なければ確定 (0 で無効)
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Weights: map[Rarity]int{
+			RarityN:   60,
+			RarityR:   30,
+			RaritySR:  8,
+			RaritySSR: 2,
+		},
+		PityThreshold: 50,
+	}
+}
+
+// ParseRarityWeights parses a rarity table such as "N=60,R=30,SR=8,SSR=2"
+func ParseRarityWeights(s string) (map[Rarity]int, error) {
+	weights := make(map[Rarity]int)
+	total := 0
+	for _, entry := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rarity weight entry: %q", entry)
+		}
+
+		rarity := Rarity(strings.ToUpper(strings.TrimSpace(key)))
+		if !isValidRarity(rarity) {
+			return nil, fmt.Errorf("unknown rarity: %q", key)
+		}
+
+		weight, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight for %s: %q", rarity, value)
+		}
+		weights[rarity] = weight
+		total += weight
+	}
+
+	if total == 0 {
+		return nil, fmt.Errorf("rarity weights must not all be zero")
+	}
+	return weights, nil
+}
+
+func isValidRarity(r Rarity) bool {
+	for _, o := range rarityOrder {
+		if o == r {
+			return true
+		}
+	}
+	return false
+}
+
+// CardRarity deterministic rarity based on card ID (SSR 5%, SR 15%, R 30%, N 50%)
+func CardRarity(cardID string) Rarity {
+	h := fnv.New32a()
+	h.Write([]byte("rarity:" + cardID))
+	bucket := h.Sum32() % 100
+
+	switch {
+	case bucket < 5:
+		return RaritySSR
+	case bucket < 20:
+		return RaritySR
+	case bucket < 50:
+		return RarityR
+	default:
+		return RarityN
+	}
+}
+
+// Rng is the source of gacha rolls (fairness.Roller for committed pulls)
+type Rng interface {
+	Intn(n int) int
+}
+
+// Draw is a single gacha result before it is stored
+type Draw struct {
+	Card   *ptera.Card
+	Rarity Rarity
+	Pity   bool
+}
+
+// DrawCards performs count draws from the pool.
+// pityCount is the number of consecutive draws without the top rarity; the updated count is returned.
+func DrawCards(rng Rng, pool []*ptera.Card, config Config, pityCount, count int) ([]Draw, int) {
+	// Group pool by rarity
+	byRarity := make(map[Rarity][]*ptera.Card)
+	for _, card := range pool {
+		r := CardRarity(card.Id)
+		byRarity[r] = append(byRarity[r], card)
+	}
+	top := topRarity(byRarity)
+
+	draws := make([]Draw, 0, count)
+	for i := 0; i < count; i++ {
+		pityCount++
+
+		var rarity Rarity
+		pity := false
+		if config.PityThreshold > 0 && pityCount >= config.PityThreshold {
+			// 天井: 最高レアを確定
+			rarity = top
+			pity = true
+		} else {
+			rarity = availableRarity(byRarity, rollRarity(rng, config.Weights))
+		}
+
+		candidates := byRarity[rarity]
+		card := candidates[rng.Intn(len(candidates))]
+
+		if rarity == top {
+			pityCount = 0
+		}
+
+		draws = append(draws, Draw{Card: card, Rarity: rarity, Pity: pity})
+	}
+
+	return draws, pityCount
+}
+
+// rollRarity picks a rarity according to weights
+func rollRarity(rng Rng, weights map[Rarity]int) Rarity {
+	total := 0
+	for _, r := range rarityOrder {
+		total += weights[r]
+	}
+	if total <= 0 {
+		return RarityN
+	}
+
+	n := rng.Intn(total)
+	for _, r := range rarityOrder {
+		n -= weights[r]
+		if n < 0 {
+			return r
+		}
+	}
+	return RarityN
+}
+
+// availableRarity falls back to the nearest lower rarity (then higher) present in the pool
+func availableRarity(byRarity map[Rarity][]*ptera.Card, rolled Rarity) Rarity {
+	idx := 0
+	for i, r := range rarityOrder {
+		if r == rolled {
+			idx = i
+		}
+	}
+	for i := idx; i >= 0; i-- {
+		if len(byRarity[rarityOrder[i]]) > 0 {
+			return rarityOrder[i]
+		}
+	}
+	for i := idx + 1; i < len(rarityOrder); i++ {
+		if len(byRarity[rarityOrder[i]]) > 0 {
+			return rarityOrder[i]
+		}
+	}
+	return rolled
+}
+
+// topRarity returns the highest rarity present in the pool
+func topRarity(byRarity map[Rarity][]*ptera.Card) Rarity {
+	for i := len(rarityOrder) - 1; i >= 0; i-- {
+		if len(byRarity[rarityOrder[i]]) > 0 {
+			return rarityOrder[i]
+		}
+	}
+	return RarityN
+}
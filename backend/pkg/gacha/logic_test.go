@@ -0,0 +1,96 @@
+package gacha
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/fairness"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+)
+
+func testPool(n int) []*ptera.Card {
+	pool := make([]*ptera.Card, n)
+	for i := range pool {
+		pool[i] = &ptera.Card{Id: fmt.Sprintf("card-%d", i)}
+	}
+	return pool
+}
+
+// TestDrawCardsReplay checks what VerifyRolls relies on: the same seed, nonce and pity count give the same draws
+func TestDrawCardsReplay(t *testing.T) {
+	seed := []byte("a server seed of thirty-two byte")
+	tests := []struct {
+		name      string
+		pityCount int
+		count     int
+	}{
+		{name: "single pull", pityCount: 0, count: 1},
+		{name: "ten pulls", pityCount: 0, count: 10},
+		{name: "near pity", pityCount: 45, count: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := testPool(40)
+			first, pityFirst := DrawCards(fairness.NewRoller(seed, "nonce", 0), pool, DefaultConfig(), tt.pityCount, tt.count)
+			again, pityAgain := DrawCards(fairness.NewRoller(seed, "nonce", 0), pool, DefaultConfig(), tt.pityCount, tt.count)
+			if !reflect.DeepEqual(first, again) || pityFirst != pityAgain {
+				t.Fatal("draws differ for the same seed and nonce")
+			}
+			if len(first) != tt.count {
+				t.Fatalf("got %d draws, want %d", len(first), tt.count)
+			}
+		})
+	}
+}
+
+func TestDrawCardsPity(t *testing.T) {
+	pool := testPool(40)
+	byRarity := make(map[Rarity][]*ptera.Card)
+	for _, c := range pool {
+		byRarity[CardRarity(c.Id)] = append(byRarity[CardRarity(c.Id)], c)
+	}
+	top := topRarity(byRarity)
+	if top == RarityN {
+		t.Fatal("test pool has only N cards")
+	}
+
+	// Only N can be rolled, so the top rarity comes from the pity alone
+	config := Config{Weights: map[Rarity]int{RarityN: 1}, PityThreshold: 3}
+	draws, pity := DrawCards(fairness.NewRoller([]byte("seed"), "nonce", 0), pool, config, 0, 4)
+	for i, d := range draws {
+		wantPity := i == 2
+		if d.Pity != wantPity || (d.Rarity == top) != wantPity {
+			t.Errorf("draw %d = %s (pity %v), want pity %v", i, d.Rarity, d.Pity, wantPity)
+		}
+	}
+	if pity != 1 {
+		t.Errorf("pity count = %d, want 1", pity)
+	}
+}
+
+func TestParseRarityWeights(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    map[Rarity]int
+		wantErr bool
+	}{
+		{in: "N=60,R=30,SR=8,SSR=2", want: map[Rarity]int{RarityN: 60, RarityR: 30, RaritySR: 8, RaritySSR: 2}},
+		{in: " ssr = 1 , n=9", want: map[Rarity]int{RaritySSR: 1, RarityN: 9}},
+		{in: "N=0,R=0", wantErr: true},
+		{in: "UR=1", wantErr: true},
+		{in: "N=-1,R=2", wantErr: true},
+		{in: "N", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseRarityWeights(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRarityWeights(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRarityWeights(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package gacha
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/collection"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	CollectionGachaPulls = "gacha_pulls"
+	CollectionGachaPity  = "gacha_pity"
+)
+
+// PullRecord is the audit record of a single Pull call
+type PullRecord struct {
+	ID            string         `firestore:"-"`
+	UserID        string         `firestore:"userId"`
+	Pool          string         `firestore:"pool"`
+	CircleID      string         `firestore:"circleId,omitempty"`
+	Count         int            `firestore:"count"`
+	PoolCardIDs   []string       `firestore:"poolCardIds"` // 抽選対象だったカード (順序も検証に必要)
+	Weights       map[string]int `firestore:"weights"`
+	PityThreshold int            `firestore:"pityThreshold"`
+	PityBefore    int            `firestore:"pityBefore"`
+	PityAfter     int            `firestore:"pityAfter"`
+	Results       []PullOutcome  `firestore:"results"`
+	CommitmentID  string         `firestore:"commitmentId"`
+	ClientNonce   string         `firestore:"clientNonce"`
+	CreatedAt     time.Time      `firestore:"createdAt"`
+}
+
+// PullOutcome is a single drawn card within a PullRecord
+type PullOutcome struct {
+	CardID     string `firestore:"cardId"`
+	Rarity     string `firestore:"rarity"`
+	Pity       bool   `firestore:"pity"`
+	UserCardID string `firestore:"userCardId"`
+}
+
+type pityState struct {
+	Count     int       `firestore:"count"`
+	UpdatedAt time.Time `firestore:"updatedAt"`
+}
+
+type Repository struct {
+	client    *firestore.Client
+	userCards *collection.Repository
+}
+
+func NewRepository(client *firestore.Client, userCards *collection.Repository) *Repository {
+	return &Repository{client: client, userCards: userCards}
+}
+
+// NewPullID reserves a document ID for a pull record
+func (r *Repository) NewPullID() string {
+	return r.client.Collection(CollectionGachaPulls).NewDoc().ID
+}
+
+// GetPullRecord retrieves a pull record from Firestore
+func (r *Repository) GetPullRecord(ctx context.Context, pullID string) (*PullRecord, error) {
+	doc, err := r.client.Collection(CollectionGachaPulls).Doc(pullID).Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull record: %w", err)
+	}
+
+	var record PullRecord
+	if err := doc.DataTo(&record); err != nil {
+		return nil, fmt.Errorf("failed to parse pull record: %w", err)
+	}
+	record.ID = doc.Ref.ID
+	return &record, nil
+}
+
+// RecordPull reads the user's pity count, runs draw and stores the pull record,
+// the drawn cards and the new pity count in a single transaction.
+// draw may be called more than once if the transaction is retried.
+func (r *Repository) RecordPull(ctx context.Context, pullID, userID string, draw func(pityCount int) (*PullRecord, error)) (*PullRecord, error) {
+	pityRef := r.client.Collection(CollectionGachaPity).Doc(userID)
+
+	var record *PullRecord
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Read current pity count (missing document means 0)
+		var pity pityState
+		doc, err := tx.Get(pityRef)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return fmt.Errorf("failed to get pity count: %w", err)
+		}
+		if err == nil {
+			if err := doc.DataTo(&pity); err != nil {
+				return fmt.Errorf("failed to parse pity count: %w", err)
+			}
+		}
+
+		rec, err := draw(pity.Count)
+		if err != nil {
+			return err
+		}
+
+		pullRef := r.client.Collection(CollectionGachaPulls).Doc(pullID)
+		rec.ID = pullID
+
+		// Add drawn cards to the user's collection
+		for i := range rec.Results {
+			userCard := &collection.UserCard{
+				OwnerID:    userID,
+				CardID:     rec.Results[i].CardID,
+				Rarity:     rec.Results[i].Rarity,
+				Source:     collection.SourceGacha,
+				PullID:     rec.ID,
+				AcquiredAt: rec.CreatedAt,
+			}
+			if err := r.userCards.AddUserCard(tx, userCard); err != nil {
+				return err
+			}
+			rec.Results[i].UserCardID = userCard.ID
+		}
+
+		if err := tx.Create(pullRef, rec); err != nil {
+			return fmt.Errorf("failed to save pull record: %w", err)
+		}
+		if err := tx.Set(pityRef, pityState{Count: rec.PityAfter, UpdatedAt: rec.CreatedAt}); err != nil {
+			return fmt.Errorf("failed to update pity count: %w", err)
+		}
+
+		record = rec
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
@@ -0,0 +1,226 @@
+package gacha
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/battle"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/fairness"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/user"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	PoolCircle = "circle"
+	PoolPublic = "public"
+
+	maxPullCount = 10
+	maxPoolSize  = 200
+)
+
+type Service struct {
+	ptera.UnimplementedGachaServiceServer
+	repo         *Repository
+	cardRepo     *battle.CardRepository
+	userRepo     *user.Repository
+	fairnessRepo *fairness.Repository
+	logger       *slog.Logger
+	config       Config
+}
+
+func NewService(logger *slog.Logger, repo *Repository, cardRepo *battle.CardRepository, userRepo *user.Repository, fairnessRepo *fairness.Repository, config Config) *Service {
+	return &Service{
+		repo:         repo,
+		cardRepo:     cardRepo,
+		userRepo:     userRepo,
+		fairnessRepo: fairnessRepo,
+		logger:       logger,
+		config:       config,
+	}
+}
+
+// Pull draws cards for the user and adds them to the user's collection
+func (s *Service) Pull(ctx context.Context, req *ptera.PullRequest) (*ptera.PullResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if req.Count < 1 || req.Count > maxPullCount {
+		return nil, status.Errorf(codes.InvalidArgument, "count must be between 1 and %d", maxPullCount)
+	}
+
+	pool := req.Pool
+	if pool == "" {
+		pool = PoolPublic
+	}
+
+	// Resolve the card pool
+	var circleID string
+	switch pool {
+	case PoolCircle:
+		id, err := s.userRepo.GetCircleID(ctx, req.UserId)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
+		}
+		if id == "" {
+			return nil, status.Error(codes.FailedPrecondition, "user has not joined a circle")
+		}
+		circleID = id
+	case PoolPublic:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown pool: %s", pool)
+	}
+
+	cards, err := s.cardRepo.ListCards(ctx, circleID, false, maxPoolSize)
+	if err != nil {
+		s.logger.Error("failed to get gacha pool", "pool", pool, "circle_id", circleID, "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to get cards: %v", err)
+	}
+	if len(cards) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "no cards available in %s pool", pool)
+	}
+
+	cardsByID := make(map[string]*ptera.Card, len(cards))
+	poolCardIDs := make([]string, len(cards))
+	for i, card := range cards {
+		cardsByID[card.Id] = card
+		poolCardIDs[i] = card.Id
+	}
+
+	// Bind the committed server seed to this pull (all rolls are derived from it)
+	pullID := s.repo.NewPullID()
+	commitment, err := s.bindCommitment(ctx, req.GetCommitmentId(), req.UserId, req.ClientNonce, pullID)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make(map[string]int, len(s.config.Weights))
+	for r, w := range s.config.Weights {
+		weights[string(r)] = w
+	}
+
+	record, err := s.repo.RecordPull(ctx, pullID, req.UserId, func(pityCount int) (*PullRecord, error) {
+		roller, err := commitment.Roller(0)
+		if err != nil {
+			return nil, err
+		}
+		draws, pityAfter := DrawCards(roller, cards, s.config, pityCount, int(req.Count))
+
+		results := make([]PullOutcome, len(draws))
+		for i, d := range draws {
+			results[i] = PullOutcome{
+				CardID: d.Card.Id,
+				Rarity: string(d.Rarity),
+				Pity:   d.Pity,
+			}
+		}
+
+		return &PullRecord{
+			UserID:        req.UserId,
+			Pool:          pool,
+			CircleID:      circleID,
+			Count:         int(req.Count),
+			PoolCardIDs:   poolCardIDs,
+			Weights:       weights,
+			PityThreshold: s.config.PityThreshold,
+			PityBefore:    pityCount,
+			PityAfter:     pityAfter,
+			Results:       results,
+			CommitmentID:  commitment.ID,
+			ClientNonce:   commitment.ClientNonce,
+			CreatedAt:     time.Now(),
+		}, nil
+	})
+	if err != nil {
+		s.logger.Error("failed to record pull", "user_id", req.UserId, "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to pull: %v", err)
+	}
+
+	// The pull is settled, so the seed can be revealed right away
+	revealed, err := s.fairnessRepo.Reveal(ctx, commitment.ID)
+	if err != nil {
+		s.logger.Error("failed to reveal server seed", "pull_id", record.ID, "commitment_id", commitment.ID, "error", err)
+		revealed = commitment
+	}
+
+	resp := &ptera.PullResponse{
+		PullId:        record.ID,
+		PityCount:     int32(record.PityAfter),
+		PityThreshold: int32(record.PityThreshold),
+		Commitment:    revealed.ToProto(),
+	}
+	for _, r := range record.Results {
+		resp.Results = append(resp.Results, &ptera.PullResult{
+			Card:       cardsByID[r.CardID],
+			Rarity:     r.Rarity,
+			Pity:       r.Pity,
+			UserCardId: r.UserCardID,
+		})
+	}
+
+	s.logger.Info("gacha pulled", "user_id", req.UserId, "pull_id", record.ID, "count", req.Count, "pity_count", record.PityAfter)
+
+	return resp, nil
+}
+
+// bindCommitment binds the commitment the client requested with CommitSeed to the pull
+func (s *Service) bindCommitment(ctx context.Context, commitmentID, userID, clientNonce, pullID string) (*fairness.Commitment, error) {
+	if commitmentID == "" {
+		return nil, status.Error(codes.InvalidArgument, "commitment_id is required (call CommitSeed first)")
+	}
+
+	c, err := s.fairnessRepo.Bind(ctx, commitmentID, fairness.PurposeGacha, userID, clientNonce, pullID)
+	if err != nil {
+		return nil, fairness.BindStatus(s.logger, err)
+	}
+	return c, nil
+}
+
+// VerifyRolls recomputes the pull bound to the commitment from its recorded pool and pity count
+func (s *Service) VerifyRolls(ctx context.Context, c *fairness.Commitment) ([]*ptera.VerifiedRoll, error) {
+	record, err := s.repo.GetPullRecord(ctx, c.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := make([]*ptera.Card, len(record.PoolCardIDs))
+	for i, id := range record.PoolCardIDs {
+		pool[i] = &ptera.Card{Id: id}
+	}
+	config := Config{
+		Weights:       make(map[Rarity]int, len(record.Weights)),
+		PityThreshold: record.PityThreshold,
+	}
+	for r, w := range record.Weights {
+		config.Weights[Rarity(r)] = w
+	}
+
+	roller, err := c.Roller(0)
+	if err != nil {
+		return nil, err
+	}
+	draws, _ := DrawCards(roller, pool, config, record.PityBefore, record.Count)
+
+	var rolls []*ptera.VerifiedRoll
+	for i, d := range draws {
+		recomputed := fmt.Sprintf("%s (%s)", d.Card.Id, d.Rarity)
+		recorded := ""
+		match := false
+		if i < len(record.Results) {
+			r := record.Results[i]
+			recorded = fmt.Sprintf("%s (%s)", r.CardID, r.Rarity)
+			match = r.CardID == d.Card.Id && r.Rarity == string(d.Rarity) && r.Pity == d.Pity
+		}
+		rolls = append(rolls, &ptera.VerifiedRoll{
+			Index:      int64(i),
+			Kind:       "pull",
+			Recorded:   recorded,
+			Recomputed: recomputed,
+			Match:      match,
+		})
+	}
+	return rolls, nil
+}
@@ -23,14 +23,16 @@ const (
 )
 
 type User struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	IconUrl       string                 `protobuf:"bytes,3,opt,name=icon_url,json=iconUrl,proto3" json:"icon_url,omitempty"`
-	Email         *string                `protobuf:"bytes,4,opt,name=email,proto3,oneof" json:"email,omitempty"`
-	CircleId      *string                `protobuf:"bytes,5,opt,name=circle_id,json=circleId,proto3,oneof" json:"circle_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	IconUrl         string                 `protobuf:"bytes,3,opt,name=icon_url,json=iconUrl,proto3" json:"icon_url,omitempty"`
+	Email           *string                `protobuf:"bytes,4,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	CircleId        *string                `protobuf:"bytes,5,opt,name=circle_id,json=circleId,proto3,oneof" json:"circle_id,omitempty"`
+	Bio             string                 `protobuf:"bytes,6,opt,name=bio,proto3" json:"bio,omitempty"`
+	FavoriteCardIds []string               `protobuf:"bytes,7,rep,name=favorite_card_ids,json=favoriteCardIds,proto3" json:"favorite_card_ids,omitempty"` // 推しメンのカードID
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *User) Reset() {
@@ -98,6 +100,20 @@ func (x *User) GetCircleId() string {
 	return ""
 }
 
+func (x *User) GetBio() string {
+	if x != nil {
+		return x.Bio
+	}
+	return ""
+}
+
+func (x *User) GetFavoriteCardIds() []string {
+	if x != nil {
+		return x.FavoriteCardIds
+	}
+	return nil
+}
+
 type Card struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -112,12 +128,18 @@ type Card struct {
 	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	CircleId        *string                `protobuf:"bytes,11,opt,name=circle_id,json=circleId,proto3,oneof" json:"circle_id,omitempty"`
 	// Battle Stats
-	MaxHp         int32  `protobuf:"varint,12,opt,name=max_hp,json=maxHp,proto3" json:"max_hp,omitempty"`
-	Attack        int32  `protobuf:"varint,13,opt,name=attack,proto3" json:"attack,omitempty"`
-	Flavor        string `protobuf:"bytes,14,opt,name=flavor,proto3" json:"flavor,omitempty"`
-	CurrentHp     int32  `protobuf:"varint,15,opt,name=current_hp,json=currentHp,proto3" json:"current_hp,omitempty"` // バトル中の現在HP
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	MaxHp             int32                  `protobuf:"varint,12,opt,name=max_hp,json=maxHp,proto3" json:"max_hp,omitempty"`
+	Attack            int32                  `protobuf:"varint,13,opt,name=attack,proto3" json:"attack,omitempty"`
+	Flavor            string                 `protobuf:"bytes,14,opt,name=flavor,proto3" json:"flavor,omitempty"`
+	CurrentHp         int32                  `protobuf:"varint,15,opt,name=current_hp,json=currentHp,proto3" json:"current_hp,omitempty"`                           // バトル中の現在HP
+	ExpiryDate        *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`                         // 有効期限(卒業日)
+	Graduated         bool                   `protobuf:"varint,17,opt,name=graduated,proto3" json:"graduated,omitempty"`                                            // 有効期限切れ(卒業済み = OB/OG)かどうか
+	Favorite          bool                   `protobuf:"varint,18,opt,name=favorite,proto3" json:"favorite,omitempty"`                                              // バトル中: サークルメンバーの推しメン (士気ボーナス付き)
+	FlavorAiGenerated bool                   `protobuf:"varint,19,opt,name=flavor_ai_generated,json=flavorAiGenerated,proto3" json:"flavor_ai_generated,omitempty"` // flavor がAIで生成されたものかどうか
+	StatProfile       *StatProfile           `protobuf:"bytes,20,opt,name=stat_profile,json=statProfile,proto3" json:"stat_profile,omitempty"`                      // バトルステータスの傾向 (省略時は balanced)
+	PromptVersion     string                 `protobuf:"bytes,21,opt,name=prompt_version,json=promptVersion,proto3" json:"prompt_version,omitempty"`                // AI補完の結果を使った場合、その CompleteCardResponse.prompt_version
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *Card) Reset() {
@@ -255,17 +277,127 @@ func (x *Card) GetCurrentHp() int32 {
 	return 0
 }
 
+func (x *Card) GetExpiryDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return nil
+}
+
+func (x *Card) GetGraduated() bool {
+	if x != nil {
+		return x.Graduated
+	}
+	return false
+}
+
+func (x *Card) GetFavorite() bool {
+	if x != nil {
+		return x.Favorite
+	}
+	return false
+}
+
+func (x *Card) GetFlavorAiGenerated() bool {
+	if x != nil {
+		return x.FlavorAiGenerated
+	}
+	return false
+}
+
+func (x *Card) GetStatProfile() *StatProfile {
+	if x != nil {
+		return x.StatProfile
+	}
+	return nil
+}
+
+func (x *Card) GetPromptVersion() string {
+	if x != nil {
+		return x.PromptVersion
+	}
+	return ""
+}
+
+// StatProfile はバトルステータスの傾向です。
+// 種類ごとにHPと攻撃力の配分が決まっており、合計の強さはほぼ変わりません。
+type StatProfile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`             // "balanced", "tank", "attacker", "speedster"
+	Intensity     float32                `protobuf:"fixed32,2,opt,name=intensity,proto3" json:"intensity,omitempty"` // 傾向の強さ 0.0〜1.0
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`         // AIが提案した理由
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatProfile) Reset() {
+	*x = StatProfile{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatProfile) ProtoMessage() {}
+
+func (x *StatProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatProfile.ProtoReflect.Descriptor instead.
+func (*StatProfile) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StatProfile) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *StatProfile) GetIntensity() float32 {
+	if x != nil {
+		return x.Intensity
+	}
+	return 0
+}
+
+func (x *StatProfile) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type Circle struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,4,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	MemberIds     []string               `protobuf:"bytes,5,rep,name=member_ids,json=memberIds,proto3" json:"member_ids,omitempty"`
+	CreatorId     string                 `protobuf:"bytes,6,opt,name=creator_id,json=creatorId,proto3" json:"creator_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Circle) Reset() {
 	*x = Circle{}
-	mi := &file_ptera_v1_ptera_proto_msgTypes[2]
+	mi := &file_ptera_v1_ptera_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -277,7 +409,7 @@ func (x *Circle) String() string {
 func (*Circle) ProtoMessage() {}
 
 func (x *Circle) ProtoReflect() protoreflect.Message {
-	mi := &file_ptera_v1_ptera_proto_msgTypes[2]
+	mi := &file_ptera_v1_ptera_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -290,7 +422,7 @@ func (x *Circle) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Circle.ProtoReflect.Descriptor instead.
 func (*Circle) Descriptor() ([]byte, []int) {
-	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{2}
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Circle) GetId() string {
@@ -307,23 +439,69 @@ func (x *Circle) GetName() string {
 	return ""
 }
 
+func (x *Circle) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Circle) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *Circle) GetMemberIds() []string {
+	if x != nil {
+		return x.MemberIds
+	}
+	return nil
+}
+
+func (x *Circle) GetCreatorId() string {
+	if x != nil {
+		return x.CreatorId
+	}
+	return ""
+}
+
+func (x *Circle) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Circle) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
 type CompleteCardRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ImageUrl      string                 `protobuf:"bytes,1,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"` // 画像URL
-	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`                   // 既存の名前
-	Faculty       *string                `protobuf:"bytes,3,opt,name=faculty,proto3,oneof" json:"faculty,omitempty"`             // 既存の学部
-	Department    *string                `protobuf:"bytes,4,opt,name=department,proto3,oneof" json:"department,omitempty"`       // 既存の学科
-	Grade         *int32                 `protobuf:"varint,5,opt,name=grade,proto3,oneof" json:"grade,omitempty"`                // 既存の学年
-	Position      *string                `protobuf:"bytes,6,opt,name=position,proto3,oneof" json:"position,omitempty"`           // 既存の職位
-	Hobby         *string                `protobuf:"bytes,7,opt,name=hobby,proto3,oneof" json:"hobby,omitempty"`                 // 既存の趣味
-	Description   *string                `protobuf:"bytes,8,opt,name=description,proto3,oneof" json:"description,omitempty"`     // 既存の説明文
+	ImageUrl      string                 `protobuf:"bytes,1,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`               // 画像URL
+	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`                                 // 既存の名前
+	Faculty       *string                `protobuf:"bytes,3,opt,name=faculty,proto3,oneof" json:"faculty,omitempty"`                           // 既存の学部
+	Department    *string                `protobuf:"bytes,4,opt,name=department,proto3,oneof" json:"department,omitempty"`                     // 既存の学科
+	Grade         *int32                 `protobuf:"varint,5,opt,name=grade,proto3,oneof" json:"grade,omitempty"`                              // 既存の学年
+	Position      *string                `protobuf:"bytes,6,opt,name=position,proto3,oneof" json:"position,omitempty"`                         // 既存の職位
+	Hobby         *string                `protobuf:"bytes,7,opt,name=hobby,proto3,oneof" json:"hobby,omitempty"`                               // 既存の趣味
+	Description   *string                `protobuf:"bytes,8,opt,name=description,proto3,oneof" json:"description,omitempty"`                   // 既存の説明文
+	ImageId       *string                `protobuf:"bytes,9,opt,name=image_id,json=imageId,proto3,oneof" json:"image_id,omitempty"`            // UploadCardImage で取得したID (指定時は image_url より優先)
+	ForceRefresh  bool                   `protobuf:"varint,10,opt,name=force_refresh,json=forceRefresh,proto3" json:"force_refresh,omitempty"` // trueの場合キャッシュを使わずに新しい提案を生成する
+	Locale        string                 `protobuf:"bytes,12,opt,name=locale,proto3" json:"locale,omitempty"`                                  // 生成する文章の言語 "ja", "en" (省略時は ja)
+	Tone          string                 `protobuf:"bytes,13,opt,name=tone,proto3" json:"tone,omitempty"`                                      // 文体 "funny", "formal", "neutral" (省略時は funny)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CompleteCardRequest) Reset() {
 	*x = CompleteCardRequest{}
-	mi := &file_ptera_v1_ptera_proto_msgTypes[3]
+	mi := &file_ptera_v1_ptera_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -335,7 +513,7 @@ func (x *CompleteCardRequest) String() string {
 func (*CompleteCardRequest) ProtoMessage() {}
 
 func (x *CompleteCardRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ptera_v1_ptera_proto_msgTypes[3]
+	mi := &file_ptera_v1_ptera_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -348,7 +526,7 @@ func (x *CompleteCardRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompleteCardRequest.ProtoReflect.Descriptor instead.
 func (*CompleteCardRequest) Descriptor() ([]byte, []int) {
-	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{3}
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CompleteCardRequest) GetImageUrl() string {
@@ -407,24 +585,57 @@ func (x *CompleteCardRequest) GetDescription() string {
 	return ""
 }
 
+func (x *CompleteCardRequest) GetImageId() string {
+	if x != nil && x.ImageId != nil {
+		return *x.ImageId
+	}
+	return ""
+}
+
+func (x *CompleteCardRequest) GetForceRefresh() bool {
+	if x != nil {
+		return x.ForceRefresh
+	}
+	return false
+}
+
+func (x *CompleteCardRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *CompleteCardRequest) GetTone() string {
+	if x != nil {
+		return x.Tone
+	}
+	return ""
+}
+
 type CompleteCardResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                           // 補完された名前
-	Faculty       string                 `protobuf:"bytes,2,opt,name=faculty,proto3" json:"faculty,omitempty"`                                     // 補完された学部
-	Department    string                 `protobuf:"bytes,3,opt,name=department,proto3" json:"department,omitempty"`                               // 補完された学科
-	Grade         int32                  `protobuf:"varint,4,opt,name=grade,proto3" json:"grade,omitempty"`                                        // 補完された学年
-	Position      string                 `protobuf:"bytes,5,opt,name=position,proto3" json:"position,omitempty"`                                   // 補完された職位
-	Hobby         string                 `protobuf:"bytes,6,opt,name=hobby,proto3" json:"hobby,omitempty"`                                         // 補完された趣味
-	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`                             // 補完された説明文
-	Success       bool                   `protobuf:"varint,8,opt,name=success,proto3" json:"success,omitempty"`                                    // 補完が成功したかどうかを示すフラグ
-	ErrorMessage  *string                `protobuf:"bytes,9,opt,name=error_message,json=errorMessage,proto3,oneof" json:"error_message,omitempty"` // エラーメッセージ（存在する場合）
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                     // 補完された名前
+	Faculty           string                 `protobuf:"bytes,2,opt,name=faculty,proto3" json:"faculty,omitempty"`                                               // 補完された学部
+	Department        string                 `protobuf:"bytes,3,opt,name=department,proto3" json:"department,omitempty"`                                         // 補完された学科
+	Grade             int32                  `protobuf:"varint,4,opt,name=grade,proto3" json:"grade,omitempty"`                                                  // 補完された学年
+	Position          string                 `protobuf:"bytes,5,opt,name=position,proto3" json:"position,omitempty"`                                             // 補完された職位
+	Hobby             string                 `protobuf:"bytes,6,opt,name=hobby,proto3" json:"hobby,omitempty"`                                                   // 補完された趣味
+	Description       string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`                                       // 補完された説明文
+	Success           bool                   `protobuf:"varint,8,opt,name=success,proto3" json:"success,omitempty"`                                              // 補完が成功したかどうかを示すフラグ
+	ErrorMessage      *string                `protobuf:"bytes,9,opt,name=error_message,json=errorMessage,proto3,oneof" json:"error_message,omitempty"`           // エラーメッセージ（存在する場合）
+	StatProfile       *StatProfile           `protobuf:"bytes,10,opt,name=stat_profile,json=statProfile,proto3" json:"stat_profile,omitempty"`                   // 提案されたバトルステータスの傾向
+	Cached            bool                   `protobuf:"varint,11,opt,name=cached,proto3" json:"cached,omitempty"`                                               // キャッシュされた提案を返した場合true
+	Flagged           bool                   `protobuf:"varint,12,opt,name=flagged,proto3" json:"flagged,omitempty"`                                             // モデレーションで不適切と判定された場合true (提案内容は返さない)
+	ModerationReasons []*ModerationReason    `protobuf:"bytes,13,rep,name=moderation_reasons,json=moderationReasons,proto3" json:"moderation_reasons,omitempty"` // flagged の理由
+	PromptVersion     string                 `protobuf:"bytes,14,opt,name=prompt_version,json=promptVersion,proto3" json:"prompt_version,omitempty"`             // 生成に使ったプロンプトのバージョン (例: card-v3/ja/funny)。カード保存時に Card.prompt_version へ設定する
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *CompleteCardResponse) Reset() {
 	*x = CompleteCardResponse{}
-	mi := &file_ptera_v1_ptera_proto_msgTypes[4]
+	mi := &file_ptera_v1_ptera_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -436,7 +647,7 @@ func (x *CompleteCardResponse) String() string {
 func (*CompleteCardResponse) ProtoMessage() {}
 
 func (x *CompleteCardResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ptera_v1_ptera_proto_msgTypes[4]
+	mi := &file_ptera_v1_ptera_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -449,7 +660,7 @@ func (x *CompleteCardResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompleteCardResponse.ProtoReflect.Descriptor instead.
 func (*CompleteCardResponse) Descriptor() ([]byte, []int) {
-	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{4}
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CompleteCardResponse) GetName() string {
@@ -515,123 +726,65 @@ func (x *CompleteCardResponse) GetErrorMessage() string {
 	return ""
 }
 
-type BattleState struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	BattleId        string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`
-	PlayerMe        *Player                `protobuf:"bytes,2,opt,name=player_me,json=playerMe,proto3" json:"player_me,omitempty"`                   // リクエストしたプレイヤー（自分）
-	PlayerOpponent  *Player                `protobuf:"bytes,3,opt,name=player_opponent,json=playerOpponent,proto3" json:"player_opponent,omitempty"` // 対戦相手
-	CurrentTurn     int32                  `protobuf:"varint,4,opt,name=current_turn,json=currentTurn,proto3" json:"current_turn,omitempty"`
-	CurrentPlayerId string                 `protobuf:"bytes,5,opt,name=current_player_id,json=currentPlayerId,proto3" json:"current_player_id,omitempty"` // ターンプレイヤーのID
-	WinnerId        string                 `protobuf:"bytes,6,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"`                        // 勝者のID ("" なら対戦中)
-	Logs            []string               `protobuf:"bytes,7,rep,name=logs,proto3" json:"logs,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
-}
-
-func (x *BattleState) Reset() {
-	*x = BattleState{}
-	mi := &file_ptera_v1_ptera_proto_msgTypes[5]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *BattleState) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*BattleState) ProtoMessage() {}
-
-func (x *BattleState) ProtoReflect() protoreflect.Message {
-	mi := &file_ptera_v1_ptera_proto_msgTypes[5]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use BattleState.ProtoReflect.Descriptor instead.
-func (*BattleState) Descriptor() ([]byte, []int) {
-	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{5}
-}
-
-func (x *BattleState) GetBattleId() string {
-	if x != nil {
-		return x.BattleId
-	}
-	return ""
-}
-
-func (x *BattleState) GetPlayerMe() *Player {
+func (x *CompleteCardResponse) GetStatProfile() *StatProfile {
 	if x != nil {
-		return x.PlayerMe
+		return x.StatProfile
 	}
 	return nil
 }
 
-func (x *BattleState) GetPlayerOpponent() *Player {
+func (x *CompleteCardResponse) GetCached() bool {
 	if x != nil {
-		return x.PlayerOpponent
+		return x.Cached
 	}
-	return nil
+	return false
 }
 
-func (x *BattleState) GetCurrentTurn() int32 {
+func (x *CompleteCardResponse) GetFlagged() bool {
 	if x != nil {
-		return x.CurrentTurn
+		return x.Flagged
 	}
-	return 0
+	return false
 }
 
-func (x *BattleState) GetCurrentPlayerId() string {
+func (x *CompleteCardResponse) GetModerationReasons() []*ModerationReason {
 	if x != nil {
-		return x.CurrentPlayerId
+		return x.ModerationReasons
 	}
-	return ""
+	return nil
 }
 
-func (x *BattleState) GetWinnerId() string {
+func (x *CompleteCardResponse) GetPromptVersion() string {
 	if x != nil {
-		return x.WinnerId
+		return x.PromptVersion
 	}
 	return ""
 }
 
-func (x *BattleState) GetLogs() []string {
-	if x != nil {
-		return x.Logs
-	}
-	return nil
-}
-
-type Player struct {
+// CardFieldUpdate は生成途中で確定したフィールドです。
+// 最終的な値は result が正です (result が flagged の場合は表示した値を破棄してください)。
+type CardFieldUpdate struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
-	CircleId      string                 `protobuf:"bytes,2,opt,name=circle_id,json=circleId,proto3" json:"circle_id,omitempty"`
-	CircleName    string                 `protobuf:"bytes,3,opt,name=circle_name,json=circleName,proto3" json:"circle_name,omitempty"`
-	Hp            int32                  `protobuf:"varint,4,opt,name=hp,proto3" json:"hp,omitempty"`    // プレイヤーHP
-	Deck          []*Card                `protobuf:"bytes,5,rep,name=deck,proto3" json:"deck,omitempty"` // [0] is active, [1..] are bench
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"` // name, faculty, department, grade, position, hobby, description, stat_profile.type, stat_profile.intensity, stat_profile.reason
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"` // 値 (数値は文字列で表現)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Player) Reset() {
-	*x = Player{}
+func (x *CardFieldUpdate) Reset() {
+	*x = CardFieldUpdate{}
 	mi := &file_ptera_v1_ptera_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Player) String() string {
+func (x *CardFieldUpdate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Player) ProtoMessage() {}
+func (*CardFieldUpdate) ProtoMessage() {}
 
-func (x *Player) ProtoReflect() protoreflect.Message {
+func (x *CardFieldUpdate) ProtoReflect() protoreflect.Message {
 	mi := &file_ptera_v1_ptera_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -643,69 +796,133 @@ func (x *Player) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Player.ProtoReflect.Descriptor instead.
-func (*Player) Descriptor() ([]byte, []int) {
+// Deprecated: Use CardFieldUpdate.ProtoReflect.Descriptor instead.
+func (*CardFieldUpdate) Descriptor() ([]byte, []int) {
 	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *Player) GetPlayerId() string {
+func (x *CardFieldUpdate) GetField() string {
 	if x != nil {
-		return x.PlayerId
+		return x.Field
 	}
 	return ""
 }
 
-func (x *Player) GetCircleId() string {
+func (x *CardFieldUpdate) GetValue() string {
 	if x != nil {
-		return x.CircleId
+		return x.Value
 	}
 	return ""
 }
 
-func (x *Player) GetCircleName() string {
-	if x != nil {
-		return x.CircleName
-	}
-	return ""
+type StreamCompleteCardResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*StreamCompleteCardResponse_Field
+	//	*StreamCompleteCardResponse_Result
+	Event         isStreamCompleteCardResponse_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Player) GetHp() int32 {
+func (x *StreamCompleteCardResponse) Reset() {
+	*x = StreamCompleteCardResponse{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamCompleteCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamCompleteCardResponse) ProtoMessage() {}
+
+func (x *StreamCompleteCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[7]
 	if x != nil {
-		return x.Hp
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *Player) GetDeck() []*Card {
+// Deprecated: Use StreamCompleteCardResponse.ProtoReflect.Descriptor instead.
+func (*StreamCompleteCardResponse) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StreamCompleteCardResponse) GetEvent() isStreamCompleteCardResponse_Event {
 	if x != nil {
-		return x.Deck
+		return x.Event
 	}
 	return nil
 }
 
-type StartBattleRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	MyCircleId       string                 `protobuf:"bytes,1,opt,name=my_circle_id,json=myCircleId,proto3" json:"my_circle_id,omitempty"`
-	OpponentCircleId string                 `protobuf:"bytes,2,opt,name=opponent_circle_id,json=opponentCircleId,proto3" json:"opponent_circle_id,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+func (x *StreamCompleteCardResponse) GetField() *CardFieldUpdate {
+	if x != nil {
+		if x, ok := x.Event.(*StreamCompleteCardResponse_Field); ok {
+			return x.Field
+		}
+	}
+	return nil
 }
 
-func (x *StartBattleRequest) Reset() {
-	*x = StartBattleRequest{}
-	mi := &file_ptera_v1_ptera_proto_msgTypes[7]
+func (x *StreamCompleteCardResponse) GetResult() *CompleteCardResponse {
+	if x != nil {
+		if x, ok := x.Event.(*StreamCompleteCardResponse_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+type isStreamCompleteCardResponse_Event interface {
+	isStreamCompleteCardResponse_Event()
+}
+
+type StreamCompleteCardResponse_Field struct {
+	Field *CardFieldUpdate `protobuf:"bytes,1,opt,name=field,proto3,oneof"`
+}
+
+type StreamCompleteCardResponse_Result struct {
+	Result *CompleteCardResponse `protobuf:"bytes,2,opt,name=result,proto3,oneof"` // 最後のメッセージ
+}
+
+func (*StreamCompleteCardResponse_Field) isStreamCompleteCardResponse_Event() {}
+
+func (*StreamCompleteCardResponse_Result) isStreamCompleteCardResponse_Event() {}
+
+// ModerationReason はモデレーションで不適切と判定された理由です。
+type ModerationReason struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stage         string                 `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"`   // "input" (画像・入力) または "output" (生成結果)
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"` // "safety" (モデルの安全性評価) または "blocklist" (禁止語句)
+	Field         string                 `protobuf:"bytes,3,opt,name=field,proto3" json:"field,omitempty"`   // 該当するフィールド名 (画像全体の場合は空)
+	Detail        string                 `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"` // 安全性カテゴリや一致した語句
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerationReason) Reset() {
+	*x = ModerationReason{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartBattleRequest) String() string {
+func (x *ModerationReason) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartBattleRequest) ProtoMessage() {}
+func (*ModerationReason) ProtoMessage() {}
 
-func (x *StartBattleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ptera_v1_ptera_proto_msgTypes[7]
+func (x *ModerationReason) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -716,47 +933,64 @@ func (x *StartBattleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartBattleRequest.ProtoReflect.Descriptor instead.
-func (*StartBattleRequest) Descriptor() ([]byte, []int) {
-	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ModerationReason.ProtoReflect.Descriptor instead.
+func (*ModerationReason) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *StartBattleRequest) GetMyCircleId() string {
+func (x *ModerationReason) GetStage() string {
 	if x != nil {
-		return x.MyCircleId
+		return x.Stage
 	}
 	return ""
 }
 
-func (x *StartBattleRequest) GetOpponentCircleId() string {
+func (x *ModerationReason) GetSource() string {
 	if x != nil {
-		return x.OpponentCircleId
+		return x.Source
 	}
 	return ""
 }
 
-type StartBattleResponse struct {
+func (x *ModerationReason) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ModerationReason) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+type DetectMembersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BattleState   *BattleState           `protobuf:"bytes,1,opt,name=battle_state,json=battleState,proto3" json:"battle_state,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,2,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`    // 集合写真のURL
+	ImageId       *string                `protobuf:"bytes,3,opt,name=image_id,json=imageId,proto3,oneof" json:"image_id,omitempty"` // UploadCardImage で取得したID (指定時は image_url より優先)
+	Locale        string                 `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`                        // 下書きの言語 (CompleteCardRequest.locale と同じ)
+	Tone          string                 `protobuf:"bytes,5,opt,name=tone,proto3" json:"tone,omitempty"`                            // 下書きの文体 (CompleteCardRequest.tone と同じ)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartBattleResponse) Reset() {
-	*x = StartBattleResponse{}
-	mi := &file_ptera_v1_ptera_proto_msgTypes[8]
+func (x *DetectMembersRequest) Reset() {
+	*x = DetectMembersRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartBattleResponse) String() string {
+func (x *DetectMembersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartBattleResponse) ProtoMessage() {}
+func (*DetectMembersRequest) ProtoMessage() {}
 
-func (x *StartBattleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ptera_v1_ptera_proto_msgTypes[8]
+func (x *DetectMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -767,41 +1001,3961 @@ func (x *StartBattleResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartBattleResponse.ProtoReflect.Descriptor instead.
-func (*StartBattleResponse) Descriptor() ([]byte, []int) {
-	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use DetectMembersRequest.ProtoReflect.Descriptor instead.
+func (*DetectMembersRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *StartBattleResponse) GetBattleState() *BattleState {
+func (x *DetectMembersRequest) GetImageUrl() string {
 	if x != nil {
-		return x.BattleState
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *DetectMembersRequest) GetImageId() string {
+	if x != nil && x.ImageId != nil {
+		return *x.ImageId
+	}
+	return ""
+}
+
+func (x *DetectMembersRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *DetectMembersRequest) GetTone() string {
+	if x != nil {
+		return x.Tone
+	}
+	return ""
+}
+
+// BoundingBox は画像内の領域です (座標は画像の幅・高さに対する0.0〜1.0の割合)。
+type BoundingBox struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	XMin          float32                `protobuf:"fixed32,1,opt,name=x_min,json=xMin,proto3" json:"x_min,omitempty"`
+	YMin          float32                `protobuf:"fixed32,2,opt,name=y_min,json=yMin,proto3" json:"y_min,omitempty"`
+	XMax          float32                `protobuf:"fixed32,3,opt,name=x_max,json=xMax,proto3" json:"x_max,omitempty"`
+	YMax          float32                `protobuf:"fixed32,4,opt,name=y_max,json=yMax,proto3" json:"y_max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BoundingBox) Reset() {
+	*x = BoundingBox{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BoundingBox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoundingBox) ProtoMessage() {}
+
+func (x *BoundingBox) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoundingBox.ProtoReflect.Descriptor instead.
+func (*BoundingBox) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BoundingBox) GetXMin() float32 {
+	if x != nil {
+		return x.XMin
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetYMin() float32 {
+	if x != nil {
+		return x.YMin
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetXMax() float32 {
+	if x != nil {
+		return x.XMax
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetYMax() float32 {
+	if x != nil {
+		return x.YMax
+	}
+	return 0
+}
+
+type MemberDraft struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Box           *BoundingBox           `protobuf:"bytes,1,opt,name=box,proto3" json:"box,omitempty"`                           // 元画像で検出された顔の位置
+	ImageId       string                 `protobuf:"bytes,2,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`    // 切り抜いた画像のID (カード作成時の画像として使用可能)
+	ImageUrl      string                 `protobuf:"bytes,3,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"` // 切り抜いた画像のURL
+	Draft         *CompleteCardResponse  `protobuf:"bytes,4,opt,name=draft,proto3" json:"draft,omitempty"`                       // カードの下書き (失敗した場合は success=false)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemberDraft) Reset() {
+	*x = MemberDraft{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemberDraft) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemberDraft) ProtoMessage() {}
+
+func (x *MemberDraft) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemberDraft.ProtoReflect.Descriptor instead.
+func (*MemberDraft) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MemberDraft) GetBox() *BoundingBox {
+	if x != nil {
+		return x.Box
 	}
 	return nil
 }
 
-type AttackRequest struct {
+func (x *MemberDraft) GetImageId() string {
+	if x != nil {
+		return x.ImageId
+	}
+	return ""
+}
+
+func (x *MemberDraft) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *MemberDraft) GetDraft() *CompleteCardResponse {
+	if x != nil {
+		return x.Draft
+	}
+	return nil
+}
+
+type DetectMembersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BattleId      string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`
-	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 認証情報から取るべきだが、一旦IDを送る
+	Members       []*MemberDraft         `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"` // 左から順に並ぶ
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage  *string                `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3,oneof" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetectMembersResponse) Reset() {
+	*x = DetectMembersResponse{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetectMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectMembersResponse) ProtoMessage() {}
+
+func (x *DetectMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectMembersResponse.ProtoReflect.Descriptor instead.
+func (*DetectMembersResponse) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DetectMembersResponse) GetMembers() []*MemberDraft {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *DetectMembersResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DetectMembersResponse) GetErrorMessage() string {
+	if x != nil && x.ErrorMessage != nil {
+		return *x.ErrorMessage
+	}
+	return ""
+}
+
+// CardFields は編集中のカードのプロフィール項目です (未入力は空)。
+type CardFields struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Faculty       string                 `protobuf:"bytes,2,opt,name=faculty,proto3" json:"faculty,omitempty"`
+	Department    string                 `protobuf:"bytes,3,opt,name=department,proto3" json:"department,omitempty"`
+	Grade         int32                  `protobuf:"varint,4,opt,name=grade,proto3" json:"grade,omitempty"`
+	Position      string                 `protobuf:"bytes,5,opt,name=position,proto3" json:"position,omitempty"`
+	Hobby         string                 `protobuf:"bytes,6,opt,name=hobby,proto3" json:"hobby,omitempty"`
+	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CardFields) Reset() {
+	*x = CardFields{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CardFields) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CardFields) ProtoMessage() {}
+
+func (x *CardFields) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CardFields.ProtoReflect.Descriptor instead.
+func (*CardFields) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CardFields) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CardFields) GetFaculty() string {
+	if x != nil {
+		return x.Faculty
+	}
+	return ""
+}
+
+func (x *CardFields) GetDepartment() string {
+	if x != nil {
+		return x.Department
+	}
+	return ""
+}
+
+func (x *CardFields) GetGrade() int32 {
+	if x != nil {
+		return x.Grade
+	}
+	return 0
+}
+
+func (x *CardFields) GetPosition() string {
+	if x != nil {
+		return x.Position
+	}
+	return ""
+}
+
+func (x *CardFields) GetHobby() string {
+	if x != nil {
+		return x.Hobby
+	}
+	return ""
+}
+
+func (x *CardFields) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type RegenerateFieldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fields        *CardFields            `protobuf:"bytes,2,opt,name=fields,proto3" json:"fields,omitempty"`                        // 現在の入力内容 (field_name 以外は文脈として固定される)
+	FieldName     string                 `protobuf:"bytes,3,opt,name=field_name,json=fieldName,proto3" json:"field_name,omitempty"` // "name", "faculty", "department", "position", "hobby", "description"
+	Style         string                 `protobuf:"bytes,4,opt,name=style,proto3" json:"style,omitempty"`                          // 文体 "funny", "formal", "neutral" (省略時は funny)
+	N             int32                  `protobuf:"varint,5,opt,name=n,proto3" json:"n,omitempty"`                                 // 候補の数 1〜5 (省略時は3)
+	Locale        string                 `protobuf:"bytes,6,opt,name=locale,proto3" json:"locale,omitempty"`                        // 候補の言語 "ja", "en" (省略時は ja)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegenerateFieldRequest) Reset() {
+	*x = RegenerateFieldRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateFieldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateFieldRequest) ProtoMessage() {}
+
+func (x *RegenerateFieldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegenerateFieldRequest.ProtoReflect.Descriptor instead.
+func (*RegenerateFieldRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RegenerateFieldRequest) GetFields() *CardFields {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *RegenerateFieldRequest) GetFieldName() string {
+	if x != nil {
+		return x.FieldName
+	}
+	return ""
+}
+
+func (x *RegenerateFieldRequest) GetStyle() string {
+	if x != nil {
+		return x.Style
+	}
+	return ""
+}
+
+func (x *RegenerateFieldRequest) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *RegenerateFieldRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type RegenerateFieldResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Values            []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"` // 候補 (現在の値とは異なる。不適切と判定された候補は除かれるため n より少ない場合がある)
+	Success           bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage      *string                `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3,oneof" json:"error_message,omitempty"`
+	Flagged           bool                   `protobuf:"varint,4,opt,name=flagged,proto3" json:"flagged,omitempty"`                                             // 入力または全ての候補が不適切と判定された場合true
+	ModerationReasons []*ModerationReason    `protobuf:"bytes,5,rep,name=moderation_reasons,json=moderationReasons,proto3" json:"moderation_reasons,omitempty"` // flagged の理由
+	PromptVersion     string                 `protobuf:"bytes,6,opt,name=prompt_version,json=promptVersion,proto3" json:"prompt_version,omitempty"`             // 生成に使ったプロンプトのバージョン
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RegenerateFieldResponse) Reset() {
+	*x = RegenerateFieldResponse{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateFieldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateFieldResponse) ProtoMessage() {}
+
+func (x *RegenerateFieldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegenerateFieldResponse.ProtoReflect.Descriptor instead.
+func (*RegenerateFieldResponse) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RegenerateFieldResponse) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *RegenerateFieldResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RegenerateFieldResponse) GetErrorMessage() string {
+	if x != nil && x.ErrorMessage != nil {
+		return *x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *RegenerateFieldResponse) GetFlagged() bool {
+	if x != nil {
+		return x.Flagged
+	}
+	return false
+}
+
+func (x *RegenerateFieldResponse) GetModerationReasons() []*ModerationReason {
+	if x != nil {
+		return x.ModerationReasons
+	}
+	return nil
+}
+
+func (x *RegenerateFieldResponse) GetPromptVersion() string {
+	if x != nil {
+		return x.PromptVersion
+	}
+	return ""
+}
+
+type CreateCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 認証情報から取るべきだが、一旦IDを送る (creator_id として保存)
+	Card          *Card                  `protobuf:"bytes,2,opt,name=card,proto3" json:"card,omitempty"`                   // id, creator_id, circle_id, 及びバトルステータスはサーバー側で設定
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCardRequest) Reset() {
+	*x = CreateCardRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCardRequest) ProtoMessage() {}
+
+func (x *CreateCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCardRequest.ProtoReflect.Descriptor instead.
+func (*CreateCardRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateCardRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateCardRequest) GetCard() *Card {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+type GetCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardId        string                 `protobuf:"bytes,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCardRequest) Reset() {
+	*x = GetCardRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCardRequest) ProtoMessage() {}
+
+func (x *GetCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCardRequest.ProtoReflect.Descriptor instead.
+func (*GetCardRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetCardRequest) GetCardId() string {
+	if x != nil {
+		return x.CardId
+	}
+	return ""
+}
+
+type UpdateCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 認証情報から取るべきだが、一旦IDを送る
+	Card          *Card                  `protobuf:"bytes,2,opt,name=card,proto3" json:"card,omitempty"`                   // card.id のカードの編集可能な項目を置き換える
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCardRequest) Reset() {
+	*x = UpdateCardRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCardRequest) ProtoMessage() {}
+
+func (x *UpdateCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCardRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCardRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateCardRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateCardRequest) GetCard() *Card {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+type DeleteCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 認証情報から取るべきだが、一旦IDを送る
+	CardId        string                 `protobuf:"bytes,2,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCardRequest) Reset() {
+	*x = DeleteCardRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCardRequest) ProtoMessage() {}
+
+func (x *DeleteCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCardRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCardRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeleteCardRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteCardRequest) GetCardId() string {
+	if x != nil {
+		return x.CardId
+	}
+	return ""
+}
+
+type DeleteCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardId        string                 `protobuf:"bytes,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCardResponse) Reset() {
+	*x = DeleteCardResponse{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCardResponse) ProtoMessage() {}
+
+func (x *DeleteCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCardResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCardResponse) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteCardResponse) GetCardId() string {
+	if x != nil {
+		return x.CardId
+	}
+	return ""
+}
+
+type CardFilter struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	CircleId         *string                `protobuf:"bytes,1,opt,name=circle_id,json=circleId,proto3,oneof" json:"circle_id,omitempty"`
+	CreatorId        *string                `protobuf:"bytes,2,opt,name=creator_id,json=creatorId,proto3,oneof" json:"creator_id,omitempty"`
+	IncludeGraduated bool                   `protobuf:"varint,3,opt,name=include_graduated,json=includeGraduated,proto3" json:"include_graduated,omitempty"` // 卒業済み(OB/OG)カードも含める
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CardFilter) Reset() {
+	*x = CardFilter{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CardFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CardFilter) ProtoMessage() {}
+
+func (x *CardFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CardFilter.ProtoReflect.Descriptor instead.
+func (*CardFilter) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CardFilter) GetCircleId() string {
+	if x != nil && x.CircleId != nil {
+		return *x.CircleId
+	}
+	return ""
+}
+
+func (x *CardFilter) GetCreatorId() string {
+	if x != nil && x.CreatorId != nil {
+		return *x.CreatorId
+	}
+	return ""
+}
+
+func (x *CardFilter) GetIncludeGraduated() bool {
+	if x != nil {
+		return x.IncludeGraduated
+	}
+	return false
+}
+
+type ListCardsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *CardFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // 省略時は20、最大100
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // 前回のレスポンスの next_page_token
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCardsRequest) Reset() {
+	*x = ListCardsRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCardsRequest) ProtoMessage() {}
+
+func (x *ListCardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCardsRequest.ProtoReflect.Descriptor instead.
+func (*ListCardsRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListCardsRequest) GetFilter() *CardFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListCardsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCardsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListCardsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cards         []*Card                `protobuf:"bytes,1,rep,name=cards,proto3" json:"cards,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // 空なら最後のページ
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCardsResponse) Reset() {
+	*x = ListCardsResponse{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCardsResponse) ProtoMessage() {}
+
+func (x *ListCardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCardsResponse.ProtoReflect.Descriptor instead.
+func (*ListCardsResponse) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListCardsResponse) GetCards() []*Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *ListCardsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type SetFavoriteCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 認証情報から取るべきだが、一旦IDを送る
+	CardId        string                 `protobuf:"bytes,2,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"` // 空文字で推しメンを解除
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFavoriteCardRequest) Reset() {
+	*x = SetFavoriteCardRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFavoriteCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFavoriteCardRequest) ProtoMessage() {}
+
+func (x *SetFavoriteCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFavoriteCardRequest.ProtoReflect.Descriptor instead.
+func (*SetFavoriteCardRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetFavoriteCardRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetFavoriteCardRequest) GetCardId() string {
+	if x != nil {
+		return x.CardId
+	}
+	return ""
+}
+
+type ListFavoriteCardsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFavoriteCardsRequest) Reset() {
+	*x = ListFavoriteCardsRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFavoriteCardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFavoriteCardsRequest) ProtoMessage() {}
+
+func (x *ListFavoriteCardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFavoriteCardsRequest.ProtoReflect.Descriptor instead.
+func (*ListFavoriteCardsRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListFavoriteCardsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListFavoriteCardsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cards         []*Card                `protobuf:"bytes,1,rep,name=cards,proto3" json:"cards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFavoriteCardsResponse) Reset() {
+	*x = ListFavoriteCardsResponse{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFavoriteCardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFavoriteCardsResponse) ProtoMessage() {}
+
+func (x *ListFavoriteCardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFavoriteCardsResponse.ProtoReflect.Descriptor instead.
+func (*ListFavoriteCardsResponse) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListFavoriteCardsResponse) GetCards() []*Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+type RenderCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardId        string                 `protobuf:"bytes,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	Style         string                 `protobuf:"bytes,2,opt,name=style,proto3" json:"style,omitempty"` // "classic", "dark", "sakura" (省略時は classic)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderCardRequest) Reset() {
+	*x = RenderCardRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderCardRequest) ProtoMessage() {}
+
+func (x *RenderCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderCardRequest.ProtoReflect.Descriptor instead.
+func (*RenderCardRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RenderCardRequest) GetCardId() string {
+	if x != nil {
+		return x.CardId
+	}
+	return ""
+}
+
+func (x *RenderCardRequest) GetStyle() string {
+	if x != nil {
+		return x.Style
+	}
+	return ""
+}
+
+type RenderCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Png           []byte                 `protobuf:"bytes,1,opt,name=png,proto3" json:"png,omitempty"`                           // 600x840のPNG
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`                   // 描画したカードのバージョン (内容かスタイルが変わると変わる)
+	ImageUrl      string                 `protobuf:"bytes,3,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"` // 共有用のURL
+	Cached        bool                   `protobuf:"varint,4,opt,name=cached,proto3" json:"cached,omitempty"`                    // キャッシュされた画像を返した場合true
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderCardResponse) Reset() {
+	*x = RenderCardResponse{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderCardResponse) ProtoMessage() {}
+
+func (x *RenderCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderCardResponse.ProtoReflect.Descriptor instead.
+func (*RenderCardResponse) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RenderCardResponse) GetPng() []byte {
+	if x != nil {
+		return x.Png
+	}
+	return nil
+}
+
+func (x *RenderCardResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *RenderCardResponse) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *RenderCardResponse) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+type IssueCardQRRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardId        string                 `protobuf:"bytes,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 認証情報から取るべきだが、一旦IDを送る
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueCardQRRequest) Reset() {
+	*x = IssueCardQRRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueCardQRRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueCardQRRequest) ProtoMessage() {}
+
+func (x *IssueCardQRRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueCardQRRequest.ProtoReflect.Descriptor instead.
+func (*IssueCardQRRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *IssueCardQRRequest) GetCardId() string {
+	if x != nil {
+		return x.CardId
+	}
+	return ""
+}
+
+func (x *IssueCardQRRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type IssueCardQRResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                          // QRコードに埋め込まれたトークン
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // トークンの有効期限
+	QrPng         []byte                 `protobuf:"bytes,3,opt,name=qr_png,json=qrPng,proto3" json:"qr_png,omitempty"`             // token のQRコード (PNG)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueCardQRResponse) Reset() {
+	*x = IssueCardQRResponse{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueCardQRResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueCardQRResponse) ProtoMessage() {}
+
+func (x *IssueCardQRResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueCardQRResponse.ProtoReflect.Descriptor instead.
+func (*IssueCardQRResponse) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *IssueCardQRResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *IssueCardQRResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *IssueCardQRResponse) GetQrPng() []byte {
+	if x != nil {
+		return x.QrPng
+	}
+	return nil
+}
+
+type ResolveCardQRRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // QRコードから読み取ったトークン
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveCardQRRequest) Reset() {
+	*x = ResolveCardQRRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveCardQRRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveCardQRRequest) ProtoMessage() {}
+
+func (x *ResolveCardQRRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveCardQRRequest.ProtoReflect.Descriptor instead.
+func (*ResolveCardQRRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ResolveCardQRRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ImageUploadMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 認証情報から取るべきだが、一旦IDを送る
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`                  // 画像全体のバイト数 (最大10MB)
+	Sha256        string                 `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`               // 画像全体のSHA-256 (16進数)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageUploadMetadata) Reset() {
+	*x = ImageUploadMetadata{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageUploadMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageUploadMetadata) ProtoMessage() {}
+
+func (x *ImageUploadMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageUploadMetadata.ProtoReflect.Descriptor instead.
+func (*ImageUploadMetadata) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ImageUploadMetadata) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ImageUploadMetadata) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ImageUploadMetadata) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+type UploadCardImageRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*UploadCardImageRequest_Metadata
+	//	*UploadCardImageRequest_Chunk
+	Payload       isUploadCardImageRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadCardImageRequest) Reset() {
+	*x = UploadCardImageRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadCardImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadCardImageRequest) ProtoMessage() {}
+
+func (x *UploadCardImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadCardImageRequest.ProtoReflect.Descriptor instead.
+func (*UploadCardImageRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UploadCardImageRequest) GetPayload() isUploadCardImageRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *UploadCardImageRequest) GetMetadata() *ImageUploadMetadata {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadCardImageRequest_Metadata); ok {
+			return x.Metadata
+		}
+	}
+	return nil
+}
+
+func (x *UploadCardImageRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadCardImageRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadCardImageRequest_Payload interface {
+	isUploadCardImageRequest_Payload()
+}
+
+type UploadCardImageRequest_Metadata struct {
+	Metadata *ImageUploadMetadata `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
+}
+
+type UploadCardImageRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"` // 最大1MB
+}
+
+func (*UploadCardImageRequest_Metadata) isUploadCardImageRequest_Payload() {}
+
+func (*UploadCardImageRequest_Chunk) isUploadCardImageRequest_Payload() {}
+
+type UploadCardImageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ImageId       string                 `protobuf:"bytes,1,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`    // CompleteCard の image_id に指定できるID
+	ImageUrl      string                 `protobuf:"bytes,2,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"` // カードの image_url に指定できるURL
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadCardImageResponse) Reset() {
+	*x = UploadCardImageResponse{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadCardImageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadCardImageResponse) ProtoMessage() {}
+
+func (x *UploadCardImageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadCardImageResponse.ProtoReflect.Descriptor instead.
+func (*UploadCardImageResponse) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UploadCardImageResponse) GetImageId() string {
+	if x != nil {
+		return x.ImageId
+	}
+	return ""
+}
+
+func (x *UploadCardImageResponse) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *UploadCardImageResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *UploadCardImageResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type BattleState struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	BattleId          string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`
+	PlayerMe          *Player                `protobuf:"bytes,2,opt,name=player_me,json=playerMe,proto3" json:"player_me,omitempty"`                   // リクエストしたプレイヤー（自分）
+	PlayerOpponent    *Player                `protobuf:"bytes,3,opt,name=player_opponent,json=playerOpponent,proto3" json:"player_opponent,omitempty"` // 対戦相手
+	CurrentTurn       int32                  `protobuf:"varint,4,opt,name=current_turn,json=currentTurn,proto3" json:"current_turn,omitempty"`
+	CurrentPlayerId   string                 `protobuf:"bytes,5,opt,name=current_player_id,json=currentPlayerId,proto3" json:"current_player_id,omitempty"` // ターンプレイヤーのID
+	WinnerId          string                 `protobuf:"bytes,6,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"`                        // 勝者のID ("" なら対戦中)
+	Logs              []string               `protobuf:"bytes,7,rep,name=logs,proto3" json:"logs,omitempty"`
+	Fairness          *FairnessInfo          `protobuf:"bytes,8,opt,name=fairness,proto3" json:"fairness,omitempty"`                                              // 乱数のコミットメント情報
+	DamageRolls       []*DamageRoll          `protobuf:"bytes,9,rep,name=damage_rolls,json=damageRolls,proto3" json:"damage_rolls,omitempty"`                     // 検証用のダメージ乱数の記録
+	CommentaryEnabled bool                   `protobuf:"varint,10,opt,name=commentary_enabled,json=commentaryEnabled,proto3" json:"commentary_enabled,omitempty"` // 実況モード
+	Commentary        []*BattleCommentary    `protobuf:"bytes,11,rep,name=commentary,proto3" json:"commentary,omitempty"`                                         // 実況 (logs と同じく新しい順)
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *BattleState) Reset() {
+	*x = BattleState{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BattleState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattleState) ProtoMessage() {}
+
+func (x *BattleState) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BattleState.ProtoReflect.Descriptor instead.
+func (*BattleState) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *BattleState) GetBattleId() string {
+	if x != nil {
+		return x.BattleId
+	}
+	return ""
+}
+
+func (x *BattleState) GetPlayerMe() *Player {
+	if x != nil {
+		return x.PlayerMe
+	}
+	return nil
+}
+
+func (x *BattleState) GetPlayerOpponent() *Player {
+	if x != nil {
+		return x.PlayerOpponent
+	}
+	return nil
+}
+
+func (x *BattleState) GetCurrentTurn() int32 {
+	if x != nil {
+		return x.CurrentTurn
+	}
+	return 0
+}
+
+func (x *BattleState) GetCurrentPlayerId() string {
+	if x != nil {
+		return x.CurrentPlayerId
+	}
+	return ""
+}
+
+func (x *BattleState) GetWinnerId() string {
+	if x != nil {
+		return x.WinnerId
+	}
+	return ""
+}
+
+func (x *BattleState) GetLogs() []string {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+func (x *BattleState) GetFairness() *FairnessInfo {
+	if x != nil {
+		return x.Fairness
+	}
+	return nil
+}
+
+func (x *BattleState) GetDamageRolls() []*DamageRoll {
+	if x != nil {
+		return x.DamageRolls
+	}
+	return nil
+}
+
+func (x *BattleState) GetCommentaryEnabled() bool {
+	if x != nil {
+		return x.CommentaryEnabled
+	}
+	return false
+}
+
+func (x *BattleState) GetCommentary() []*BattleCommentary {
+	if x != nil {
+		return x.Commentary
+	}
+	return nil
+}
+
+// BattleCommentary は1ターン分の実況です。
+// まずテンプレートの実況が保存され、AI実況が有効な場合は生成でき次第 text が置き換わります。
+type BattleCommentary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // 実況の通し番号 (0 は試合開始)
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	AiGenerated   bool                   `protobuf:"varint,3,opt,name=ai_generated,json=aiGenerated,proto3" json:"ai_generated,omitempty"` // text がAIで生成されたものかどうか
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BattleCommentary) Reset() {
+	*x = BattleCommentary{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BattleCommentary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattleCommentary) ProtoMessage() {}
+
+func (x *BattleCommentary) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BattleCommentary.ProtoReflect.Descriptor instead.
+func (*BattleCommentary) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *BattleCommentary) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BattleCommentary) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *BattleCommentary) GetAiGenerated() bool {
+	if x != nil {
+		return x.AiGenerated
+	}
+	return false
+}
+
+type Player struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	CircleId      string                 `protobuf:"bytes,2,opt,name=circle_id,json=circleId,proto3" json:"circle_id,omitempty"`
+	CircleName    string                 `protobuf:"bytes,3,opt,name=circle_name,json=circleName,proto3" json:"circle_name,omitempty"`
+	Hp            int32                  `protobuf:"varint,4,opt,name=hp,proto3" json:"hp,omitempty"`    // プレイヤーHP
+	Deck          []*Card                `protobuf:"bytes,5,rep,name=deck,proto3" json:"deck,omitempty"` // [0] is active, [1..] are bench
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Player) Reset() {
+	*x = Player{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Player) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Player) ProtoMessage() {}
+
+func (x *Player) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Player.ProtoReflect.Descriptor instead.
+func (*Player) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Player) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *Player) GetCircleId() string {
+	if x != nil {
+		return x.CircleId
+	}
+	return ""
+}
+
+func (x *Player) GetCircleName() string {
+	if x != nil {
+		return x.CircleName
+	}
+	return ""
+}
+
+func (x *Player) GetHp() int32 {
+	if x != nil {
+		return x.Hp
+	}
+	return 0
+}
+
+func (x *Player) GetDeck() []*Card {
+	if x != nil {
+		return x.Deck
+	}
+	return nil
+}
+
+type StartBattleRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MyCircleId       string                 `protobuf:"bytes,1,opt,name=my_circle_id,json=myCircleId,proto3" json:"my_circle_id,omitempty"`
+	OpponentCircleId string                 `protobuf:"bytes,2,opt,name=opponent_circle_id,json=opponentCircleId,proto3" json:"opponent_circle_id,omitempty"`
+	IncludeGraduated bool                   `protobuf:"varint,3,opt,name=include_graduated,json=includeGraduated,proto3" json:"include_graduated,omitempty"` // OB/OGカード(卒業済み)もデッキに含める特別モード
+	CommitmentId     *string                `protobuf:"bytes,4,opt,name=commitment_id,json=commitmentId,proto3,oneof" json:"commitment_id,omitempty"`        // 事前に CommitSeed で取得したコミットメントID (必須)
+	ClientNonce      string                 `protobuf:"bytes,5,opt,name=client_nonce,json=clientNonce,proto3" json:"client_nonce,omitempty"`                 // 乱数の導出に使うクライアントノンス (必須、コミットメント取得後にクライアントで生成)
+	Commentary       bool                   `protobuf:"varint,6,opt,name=commentary,proto3" json:"commentary,omitempty"`                                     // 実況モード (カード名・趣味・フレーバーを使った日本語の実況を追加)
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *StartBattleRequest) Reset() {
+	*x = StartBattleRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartBattleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartBattleRequest) ProtoMessage() {}
+
+func (x *StartBattleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartBattleRequest.ProtoReflect.Descriptor instead.
+func (*StartBattleRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *StartBattleRequest) GetMyCircleId() string {
+	if x != nil {
+		return x.MyCircleId
+	}
+	return ""
+}
+
+func (x *StartBattleRequest) GetOpponentCircleId() string {
+	if x != nil {
+		return x.OpponentCircleId
+	}
+	return ""
+}
+
+func (x *StartBattleRequest) GetIncludeGraduated() bool {
+	if x != nil {
+		return x.IncludeGraduated
+	}
+	return false
+}
+
+func (x *StartBattleRequest) GetCommitmentId() string {
+	if x != nil && x.CommitmentId != nil {
+		return *x.CommitmentId
+	}
+	return ""
+}
+
+func (x *StartBattleRequest) GetClientNonce() string {
+	if x != nil {
+		return x.ClientNonce
+	}
+	return ""
+}
+
+func (x *StartBattleRequest) GetCommentary() bool {
+	if x != nil {
+		return x.Commentary
+	}
+	return false
+}
+
+type StartBattleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BattleState   *BattleState           `protobuf:"bytes,1,opt,name=battle_state,json=battleState,proto3" json:"battle_state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartBattleResponse) Reset() {
+	*x = StartBattleResponse{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartBattleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartBattleResponse) ProtoMessage() {}
+
+func (x *StartBattleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartBattleResponse.ProtoReflect.Descriptor instead.
+func (*StartBattleResponse) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *StartBattleResponse) GetBattleState() *BattleState {
+	if x != nil {
+		return x.BattleState
+	}
+	return nil
+}
+
+type AttackRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BattleId      string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 認証情報から取るべきだが、一旦IDを送る
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttackRequest) Reset() {
+	*x = AttackRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttackRequest) ProtoMessage() {}
+
+func (x *AttackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttackRequest.ProtoReflect.Descriptor instead.
+func (*AttackRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *AttackRequest) GetBattleId() string {
+	if x != nil {
+		return x.BattleId
+	}
+	return ""
+}
+
+func (x *AttackRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type AttackResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BattleState   *BattleState           `protobuf:"bytes,1,opt,name=battle_state,json=battleState,proto3" json:"battle_state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttackResponse) Reset() {
+	*x = AttackResponse{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttackResponse) ProtoMessage() {}
+
+func (x *AttackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttackResponse.ProtoReflect.Descriptor instead.
+func (*AttackResponse) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AttackResponse) GetBattleState() *BattleState {
+	if x != nil {
+		return x.BattleState
+	}
+	return nil
+}
+
+type RetreatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BattleId      string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	BenchIndex    int32                  `protobuf:"varint,3,opt,name=bench_index,json=benchIndex,proto3" json:"bench_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetreatRequest) Reset() {
+	*x = RetreatRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetreatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetreatRequest) ProtoMessage() {}
+
+func (x *RetreatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetreatRequest.ProtoReflect.Descriptor instead.
+func (*RetreatRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RetreatRequest) GetBattleId() string {
+	if x != nil {
+		return x.BattleId
+	}
+	return ""
+}
+
+func (x *RetreatRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *RetreatRequest) GetBenchIndex() int32 {
+	if x != nil {
+		return x.BenchIndex
+	}
+	return 0
+}
+
+type RetreatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BattleState   *BattleState           `protobuf:"bytes,1,opt,name=battle_state,json=battleState,proto3" json:"battle_state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetreatResponse) Reset() {
+	*x = RetreatResponse{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetreatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetreatResponse) ProtoMessage() {}
+
+func (x *RetreatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetreatResponse.ProtoReflect.Descriptor instead.
+func (*RetreatResponse) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RetreatResponse) GetBattleState() *BattleState {
+	if x != nil {
+		return x.BattleState
+	}
+	return nil
+}
+
+type BattleRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	RequestId        string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	FromCircleId     string                 `protobuf:"bytes,2,opt,name=from_circle_id,json=fromCircleId,proto3" json:"from_circle_id,omitempty"`
+	ToCircleId       string                 `protobuf:"bytes,3,opt,name=to_circle_id,json=toCircleId,proto3" json:"to_circle_id,omitempty"`
+	FromCircleName   string                 `protobuf:"bytes,4,opt,name=from_circle_name,json=fromCircleName,proto3" json:"from_circle_name,omitempty"`
+	ToCircleName     string                 `protobuf:"bytes,5,opt,name=to_circle_name,json=toCircleName,proto3" json:"to_circle_name,omitempty"`
+	Status           string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // "pending", "accepted", "rejected"
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	BattleId         *string                `protobuf:"bytes,8,opt,name=battle_id,json=battleId,proto3,oneof" json:"battle_id,omitempty"`                    // Set after acceptance
+	IncludeGraduated bool                   `protobuf:"varint,9,opt,name=include_graduated,json=includeGraduated,proto3" json:"include_graduated,omitempty"` // OB/OGカード(卒業済み)もデッキに含める特別モード
+	Commentary       bool                   `protobuf:"varint,10,opt,name=commentary,proto3" json:"commentary,omitempty"`                                    // 実況モード
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *BattleRequest) Reset() {
+	*x = BattleRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BattleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattleRequest) ProtoMessage() {}
+
+func (x *BattleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BattleRequest.ProtoReflect.Descriptor instead.
+func (*BattleRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *BattleRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *BattleRequest) GetFromCircleId() string {
+	if x != nil {
+		return x.FromCircleId
+	}
+	return ""
+}
+
+func (x *BattleRequest) GetToCircleId() string {
+	if x != nil {
+		return x.ToCircleId
+	}
+	return ""
+}
+
+func (x *BattleRequest) GetFromCircleName() string {
+	if x != nil {
+		return x.FromCircleName
+	}
+	return ""
+}
+
+func (x *BattleRequest) GetToCircleName() string {
+	if x != nil {
+		return x.ToCircleName
+	}
+	return ""
+}
+
+func (x *BattleRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BattleRequest) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *BattleRequest) GetBattleId() string {
+	if x != nil && x.BattleId != nil {
+		return *x.BattleId
+	}
+	return ""
+}
+
+func (x *BattleRequest) GetIncludeGraduated() bool {
+	if x != nil {
+		return x.IncludeGraduated
+	}
+	return false
+}
+
+func (x *BattleRequest) GetCommentary() bool {
+	if x != nil {
+		return x.Commentary
+	}
+	return false
+}
+
+type SendBattleRequestRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	FromCircleId     string                 `protobuf:"bytes,1,opt,name=from_circle_id,json=fromCircleId,proto3" json:"from_circle_id,omitempty"`
+	ToCircleId       string                 `protobuf:"bytes,2,opt,name=to_circle_id,json=toCircleId,proto3" json:"to_circle_id,omitempty"`
+	IncludeGraduated bool                   `protobuf:"varint,3,opt,name=include_graduated,json=includeGraduated,proto3" json:"include_graduated,omitempty"` // OB/OGカード(卒業済み)もデッキに含める特別モード
+	Commentary       bool                   `protobuf:"varint,4,opt,name=commentary,proto3" json:"commentary,omitempty"`                                     // 実況モード
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SendBattleRequestRequest) Reset() {
+	*x = SendBattleRequestRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendBattleRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendBattleRequestRequest) ProtoMessage() {}
+
+func (x *SendBattleRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendBattleRequestRequest.ProtoReflect.Descriptor instead.
+func (*SendBattleRequestRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *SendBattleRequestRequest) GetFromCircleId() string {
+	if x != nil {
+		return x.FromCircleId
+	}
+	return ""
+}
+
+func (x *SendBattleRequestRequest) GetToCircleId() string {
+	if x != nil {
+		return x.ToCircleId
+	}
+	return ""
+}
+
+func (x *SendBattleRequestRequest) GetIncludeGraduated() bool {
+	if x != nil {
+		return x.IncludeGraduated
+	}
+	return false
+}
+
+func (x *SendBattleRequestRequest) GetCommentary() bool {
+	if x != nil {
+		return x.Commentary
+	}
+	return false
+}
+
+type AcceptBattleRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	CommitmentId  string                 `protobuf:"bytes,2,opt,name=commitment_id,json=commitmentId,proto3" json:"commitment_id,omitempty"` // 承認するサークル (to_circle_id) が CommitSeed で取得したコミットメントID (必須)
+	ClientNonce   string                 `protobuf:"bytes,3,opt,name=client_nonce,json=clientNonce,proto3" json:"client_nonce,omitempty"`    // 乱数の導出に使うクライアントノンス (必須、コミットメント取得後にクライアントで生成)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptBattleRequestRequest) Reset() {
+	*x = AcceptBattleRequestRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptBattleRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptBattleRequestRequest) ProtoMessage() {}
+
+func (x *AcceptBattleRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptBattleRequestRequest.ProtoReflect.Descriptor instead.
+func (*AcceptBattleRequestRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *AcceptBattleRequestRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AcceptBattleRequestRequest) GetCommitmentId() string {
+	if x != nil {
+		return x.CommitmentId
+	}
+	return ""
+}
+
+func (x *AcceptBattleRequestRequest) GetClientNonce() string {
+	if x != nil {
+		return x.ClientNonce
+	}
+	return ""
+}
+
+type RejectBattleRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectBattleRequestRequest) Reset() {
+	*x = RejectBattleRequestRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectBattleRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectBattleRequestRequest) ProtoMessage() {}
+
+func (x *RejectBattleRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectBattleRequestRequest.ProtoReflect.Descriptor instead.
+func (*RejectBattleRequestRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *RejectBattleRequestRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type PullRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                         // 認証情報から取るべきだが、一旦IDを送る
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`                                        // 引く回数 (1〜10)
+	Pool          string                 `protobuf:"bytes,3,opt,name=pool,proto3" json:"pool,omitempty"`                                           // "circle" (自サークルのカード) or "public" (全公開カード)
+	CommitmentId  *string                `protobuf:"bytes,4,opt,name=commitment_id,json=commitmentId,proto3,oneof" json:"commitment_id,omitempty"` // 事前に CommitSeed で取得したコミットメントID (必須)
+	ClientNonce   string                 `protobuf:"bytes,5,opt,name=client_nonce,json=clientNonce,proto3" json:"client_nonce,omitempty"`          // 乱数の導出に使うクライアントノンス (必須、コミットメント取得後にクライアントで生成)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullRequest) Reset() {
+	*x = PullRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequest) ProtoMessage() {}
+
+func (x *PullRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequest.ProtoReflect.Descriptor instead.
+func (*PullRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *PullRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *PullRequest) GetPool() string {
+	if x != nil {
+		return x.Pool
+	}
+	return ""
+}
+
+func (x *PullRequest) GetCommitmentId() string {
+	if x != nil && x.CommitmentId != nil {
+		return *x.CommitmentId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetClientNonce() string {
+	if x != nil {
+		return x.ClientNonce
+	}
+	return ""
+}
+
+type PullResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Card          *Card                  `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	Rarity        string                 `protobuf:"bytes,2,opt,name=rarity,proto3" json:"rarity,omitempty"`                             // "N", "R", "SR", "SSR"
+	Pity          bool                   `protobuf:"varint,3,opt,name=pity,proto3" json:"pity,omitempty"`                                // 天井で確定した排出かどうか
+	UserCardId    string                 `protobuf:"bytes,4,opt,name=user_card_id,json=userCardId,proto3" json:"user_card_id,omitempty"` // コレクションに追加された所持カードのID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullResult) Reset() {
+	*x = PullResult{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullResult) ProtoMessage() {}
+
+func (x *PullResult) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullResult.ProtoReflect.Descriptor instead.
+func (*PullResult) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *PullResult) GetCard() *Card {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+func (x *PullResult) GetRarity() string {
+	if x != nil {
+		return x.Rarity
+	}
+	return ""
+}
+
+func (x *PullResult) GetPity() bool {
+	if x != nil {
+		return x.Pity
+	}
+	return false
+}
+
+func (x *PullResult) GetUserCardId() string {
+	if x != nil {
+		return x.UserCardId
+	}
+	return ""
+}
+
+type PullResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullId        string                 `protobuf:"bytes,1,opt,name=pull_id,json=pullId,proto3" json:"pull_id,omitempty"` // 監査用の抽選記録ID
+	Results       []*PullResult          `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	PityCount     int32                  `protobuf:"varint,3,opt,name=pity_count,json=pityCount,proto3" json:"pity_count,omitempty"`             // 最高レアが出ていない連続回数
+	PityThreshold int32                  `protobuf:"varint,4,opt,name=pity_threshold,json=pityThreshold,proto3" json:"pity_threshold,omitempty"` // 天井の回数
+	Commitment    *SeedCommitment        `protobuf:"bytes,5,opt,name=commitment,proto3" json:"commitment,omitempty"`                             // 抽選に使用したコミットメント (シード公開済み)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullResponse) Reset() {
+	*x = PullResponse{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullResponse) ProtoMessage() {}
+
+func (x *PullResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullResponse.ProtoReflect.Descriptor instead.
+func (*PullResponse) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *PullResponse) GetPullId() string {
+	if x != nil {
+		return x.PullId
+	}
+	return ""
+}
+
+func (x *PullResponse) GetResults() []*PullResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *PullResponse) GetPityCount() int32 {
+	if x != nil {
+		return x.PityCount
+	}
+	return 0
+}
+
+func (x *PullResponse) GetPityThreshold() int32 {
+	if x != nil {
+		return x.PityThreshold
+	}
+	return 0
+}
+
+func (x *PullResponse) GetCommitment() *SeedCommitment {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+type FairnessInfo struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CommitmentId   string                 `protobuf:"bytes,1,opt,name=commitment_id,json=commitmentId,proto3" json:"commitment_id,omitempty"`
+	ServerSeedHash string                 `protobuf:"bytes,2,opt,name=server_seed_hash,json=serverSeedHash,proto3" json:"server_seed_hash,omitempty"` // SHA-256(server_seed) の16進文字列
+	ClientNonce    string                 `protobuf:"bytes,3,opt,name=client_nonce,json=clientNonce,proto3" json:"client_nonce,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FairnessInfo) Reset() {
+	*x = FairnessInfo{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FairnessInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FairnessInfo) ProtoMessage() {}
+
+func (x *FairnessInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FairnessInfo.ProtoReflect.Descriptor instead.
+func (*FairnessInfo) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *FairnessInfo) GetCommitmentId() string {
+	if x != nil {
+		return x.CommitmentId
+	}
+	return ""
+}
+
+func (x *FairnessInfo) GetServerSeedHash() string {
+	if x != nil {
+		return x.ServerSeedHash
+	}
+	return ""
+}
+
+func (x *FairnessInfo) GetClientNonce() string {
+	if x != nil {
+		return x.ClientNonce
+	}
+	return ""
+}
+
+// ダメージ1回分の乱数。roll_index から順に通し番号を使い (棄却サンプリングで複数使うことがあります)、
+// 次のロールは next_roll_index から始まります
+type DamageRoll struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RollIndex      int64                  `protobuf:"varint,1,opt,name=roll_index,json=rollIndex,proto3" json:"roll_index,omitempty"` // このロールで最初に使った乱数の通し番号
+	AttackerCardId string                 `protobuf:"bytes,2,opt,name=attacker_card_id,json=attackerCardId,proto3" json:"attacker_card_id,omitempty"`
+	Attack         int32                  `protobuf:"varint,3,opt,name=attack,proto3" json:"attack,omitempty"`                                      // 攻撃時の攻撃力
+	Damage         int32                  `protobuf:"varint,4,opt,name=damage,proto3" json:"damage,omitempty"`                                      // 算出されたダメージ
+	NextRollIndex  int64                  `protobuf:"varint,5,opt,name=next_roll_index,json=nextRollIndex,proto3" json:"next_roll_index,omitempty"` // 次のロールの最初の通し番号 (0 なら roll_index + 1)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DamageRoll) Reset() {
+	*x = DamageRoll{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DamageRoll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DamageRoll) ProtoMessage() {}
+
+func (x *DamageRoll) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DamageRoll.ProtoReflect.Descriptor instead.
+func (*DamageRoll) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DamageRoll) GetRollIndex() int64 {
+	if x != nil {
+		return x.RollIndex
+	}
+	return 0
+}
+
+func (x *DamageRoll) GetAttackerCardId() string {
+	if x != nil {
+		return x.AttackerCardId
+	}
+	return ""
+}
+
+func (x *DamageRoll) GetAttack() int32 {
+	if x != nil {
+		return x.Attack
+	}
+	return 0
+}
+
+func (x *DamageRoll) GetDamage() int32 {
+	if x != nil {
+		return x.Damage
+	}
+	return 0
+}
+
+func (x *DamageRoll) GetNextRollIndex() int64 {
+	if x != nil {
+		return x.NextRollIndex
+	}
+	return 0
+}
+
+type SeedCommitment struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CommitmentId   string                 `protobuf:"bytes,1,opt,name=commitment_id,json=commitmentId,proto3" json:"commitment_id,omitempty"`
+	Purpose        string                 `protobuf:"bytes,2,opt,name=purpose,proto3" json:"purpose,omitempty"`                                       // "battle" or "gacha"
+	OwnerId        string                 `protobuf:"bytes,3,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`                        // コミットメントを要求したユーザー/サークルのID
+	ServerSeedHash string                 `protobuf:"bytes,4,opt,name=server_seed_hash,json=serverSeedHash,proto3" json:"server_seed_hash,omitempty"` // SHA-256(server_seed) の16進文字列
+	ServerSeed     *string                `protobuf:"bytes,5,opt,name=server_seed,json=serverSeed,proto3,oneof" json:"server_seed,omitempty"`         // 公開後のみ設定される16進文字列
+	ClientNonce    string                 `protobuf:"bytes,6,opt,name=client_nonce,json=clientNonce,proto3" json:"client_nonce,omitempty"`
+	SubjectId      string                 `protobuf:"bytes,7,opt,name=subject_id,json=subjectId,proto3" json:"subject_id,omitempty"` // 紐付いたバトルID/抽選ID
+	Status         string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`                        // "committed", "bound", "revealed"
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	RevealedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=revealed_at,json=revealedAt,proto3" json:"revealed_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SeedCommitment) Reset() {
+	*x = SeedCommitment{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedCommitment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedCommitment) ProtoMessage() {}
+
+func (x *SeedCommitment) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedCommitment.ProtoReflect.Descriptor instead.
+func (*SeedCommitment) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *SeedCommitment) GetCommitmentId() string {
+	if x != nil {
+		return x.CommitmentId
+	}
+	return ""
+}
+
+func (x *SeedCommitment) GetPurpose() string {
+	if x != nil {
+		return x.Purpose
+	}
+	return ""
+}
+
+func (x *SeedCommitment) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *SeedCommitment) GetServerSeedHash() string {
+	if x != nil {
+		return x.ServerSeedHash
+	}
+	return ""
+}
+
+func (x *SeedCommitment) GetServerSeed() string {
+	if x != nil && x.ServerSeed != nil {
+		return *x.ServerSeed
+	}
+	return ""
+}
+
+func (x *SeedCommitment) GetClientNonce() string {
+	if x != nil {
+		return x.ClientNonce
+	}
+	return ""
+}
+
+func (x *SeedCommitment) GetSubjectId() string {
+	if x != nil {
+		return x.SubjectId
+	}
+	return ""
+}
+
+func (x *SeedCommitment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SeedCommitment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SeedCommitment) GetRevealedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevealedAt
+	}
+	return nil
+}
+
+type CommitSeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Purpose       string                 `protobuf:"bytes,1,opt,name=purpose,proto3" json:"purpose,omitempty"`                // "battle" or "gacha"
+	OwnerId       string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // バトルならサークルID、ガチャならユーザーID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitSeedRequest) Reset() {
+	*x = CommitSeedRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitSeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitSeedRequest) ProtoMessage() {}
+
+func (x *CommitSeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitSeedRequest.ProtoReflect.Descriptor instead.
+func (*CommitSeedRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *CommitSeedRequest) GetPurpose() string {
+	if x != nil {
+		return x.Purpose
+	}
+	return ""
+}
+
+func (x *CommitSeedRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+type GetCommitmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommitmentId  string                 `protobuf:"bytes,1,opt,name=commitment_id,json=commitmentId,proto3" json:"commitment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommitmentRequest) Reset() {
+	*x = GetCommitmentRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommitmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommitmentRequest) ProtoMessage() {}
+
+func (x *GetCommitmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommitmentRequest.ProtoReflect.Descriptor instead.
+func (*GetCommitmentRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetCommitmentRequest) GetCommitmentId() string {
+	if x != nil {
+		return x.CommitmentId
+	}
+	return ""
+}
+
+type VerifyRollsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommitmentId  string                 `protobuf:"bytes,1,opt,name=commitment_id,json=commitmentId,proto3" json:"commitment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyRollsRequest) Reset() {
+	*x = VerifyRollsRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyRollsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyRollsRequest) ProtoMessage() {}
+
+func (x *VerifyRollsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyRollsRequest.ProtoReflect.Descriptor instead.
+func (*VerifyRollsRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *VerifyRollsRequest) GetCommitmentId() string {
+	if x != nil {
+		return x.CommitmentId
+	}
+	return ""
+}
+
+type VerifiedRoll struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int64                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`          // 結果の通し番号
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`             // "damage" or "pull"
+	Recorded      string                 `protobuf:"bytes,3,opt,name=recorded,proto3" json:"recorded,omitempty"`     // 記録されている結果
+	Recomputed    string                 `protobuf:"bytes,4,opt,name=recomputed,proto3" json:"recomputed,omitempty"` // シードから再計算した結果
+	Match         bool                   `protobuf:"varint,5,opt,name=match,proto3" json:"match,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifiedRoll) Reset() {
+	*x = VerifiedRoll{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifiedRoll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifiedRoll) ProtoMessage() {}
+
+func (x *VerifiedRoll) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifiedRoll.ProtoReflect.Descriptor instead.
+func (*VerifiedRoll) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *VerifiedRoll) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *VerifiedRoll) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *VerifiedRoll) GetRecorded() string {
+	if x != nil {
+		return x.Recorded
+	}
+	return ""
+}
+
+func (x *VerifiedRoll) GetRecomputed() string {
+	if x != nil {
+		return x.Recomputed
+	}
+	return ""
+}
+
+func (x *VerifiedRoll) GetMatch() bool {
+	if x != nil {
+		return x.Match
+	}
+	return false
+}
+
+type VerifyRollsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Commitment    *SeedCommitment        `protobuf:"bytes,1,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"` // 全ての結果が一致したかどうか
+	Rolls         []*VerifiedRoll        `protobuf:"bytes,3,rep,name=rolls,proto3" json:"rolls,omitempty"`
+	ErrorMessage  *string                `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3,oneof" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyRollsResponse) Reset() {
+	*x = VerifyRollsResponse{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyRollsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyRollsResponse) ProtoMessage() {}
+
+func (x *VerifyRollsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyRollsResponse.ProtoReflect.Descriptor instead.
+func (*VerifyRollsResponse) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *VerifyRollsResponse) GetCommitment() *SeedCommitment {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+func (x *VerifyRollsResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *VerifyRollsResponse) GetRolls() []*VerifiedRoll {
+	if x != nil {
+		return x.Rolls
+	}
+	return nil
+}
+
+func (x *VerifyRollsResponse) GetErrorMessage() string {
+	if x != nil && x.ErrorMessage != nil {
+		return *x.ErrorMessage
+	}
+	return ""
+}
+
+type Trade struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	TradeId              string                 `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	ProposerId           string                 `protobuf:"bytes,2,opt,name=proposer_id,json=proposerId,proto3" json:"proposer_id,omitempty"`                                   // 提案したユーザーのID
+	TargetId             string                 `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`                                         // 提案されたユーザーのID
+	OfferedUserCardIds   []string               `protobuf:"bytes,4,rep,name=offered_user_card_ids,json=offeredUserCardIds,proto3" json:"offered_user_card_ids,omitempty"`       // 提案者が差し出す所持カード
+	RequestedUserCardIds []string               `protobuf:"bytes,5,rep,name=requested_user_card_ids,json=requestedUserCardIds,proto3" json:"requested_user_card_ids,omitempty"` // 提案者が求める相手の所持カード
+	Status               string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                                                             // "pending", "accepted", "rejected", "cancelled", "expired"
+	CreatedAt            *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt            *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	UpdatedAt            *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Trade) Reset() {
+	*x = Trade{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trade) ProtoMessage() {}
+
+func (x *Trade) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
+func (*Trade) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *Trade) GetTradeId() string {
+	if x != nil {
+		return x.TradeId
+	}
+	return ""
+}
+
+func (x *Trade) GetProposerId() string {
+	if x != nil {
+		return x.ProposerId
+	}
+	return ""
+}
+
+func (x *Trade) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *Trade) GetOfferedUserCardIds() []string {
+	if x != nil {
+		return x.OfferedUserCardIds
+	}
+	return nil
+}
+
+func (x *Trade) GetRequestedUserCardIds() []string {
+	if x != nil {
+		return x.RequestedUserCardIds
+	}
+	return nil
+}
+
+func (x *Trade) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Trade) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Trade) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Trade) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ProposeTradeRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	UserId               string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 認証情報から取るべきだが、一旦IDを送る
+	TargetUserId         string                 `protobuf:"bytes,2,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	OfferedUserCardIds   []string               `protobuf:"bytes,3,rep,name=offered_user_card_ids,json=offeredUserCardIds,proto3" json:"offered_user_card_ids,omitempty"`
+	RequestedUserCardIds []string               `protobuf:"bytes,4,rep,name=requested_user_card_ids,json=requestedUserCardIds,proto3" json:"requested_user_card_ids,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ProposeTradeRequest) Reset() {
+	*x = ProposeTradeRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProposeTradeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProposeTradeRequest) ProtoMessage() {}
+
+func (x *ProposeTradeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProposeTradeRequest.ProtoReflect.Descriptor instead.
+func (*ProposeTradeRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ProposeTradeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ProposeTradeRequest) GetTargetUserId() string {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return ""
+}
+
+func (x *ProposeTradeRequest) GetOfferedUserCardIds() []string {
+	if x != nil {
+		return x.OfferedUserCardIds
+	}
+	return nil
+}
+
+func (x *ProposeTradeRequest) GetRequestedUserCardIds() []string {
+	if x != nil {
+		return x.RequestedUserCardIds
+	}
+	return nil
+}
+
+type AcceptTradeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TradeId       string                 `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 提案された側のユーザーID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptTradeRequest) Reset() {
+	*x = AcceptTradeRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptTradeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptTradeRequest) ProtoMessage() {}
+
+func (x *AcceptTradeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptTradeRequest.ProtoReflect.Descriptor instead.
+func (*AcceptTradeRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *AcceptTradeRequest) GetTradeId() string {
+	if x != nil {
+		return x.TradeId
+	}
+	return ""
+}
+
+func (x *AcceptTradeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RejectTradeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TradeId       string                 `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 提案された側のユーザーID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectTradeRequest) Reset() {
+	*x = RejectTradeRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectTradeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectTradeRequest) ProtoMessage() {}
+
+func (x *RejectTradeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectTradeRequest.ProtoReflect.Descriptor instead.
+func (*RejectTradeRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *RejectTradeRequest) GetTradeId() string {
+	if x != nil {
+		return x.TradeId
+	}
+	return ""
+}
+
+func (x *RejectTradeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CancelTradeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TradeId       string                 `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 提案した側のユーザーID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTradeRequest) Reset() {
+	*x = CancelTradeRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTradeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTradeRequest) ProtoMessage() {}
+
+func (x *CancelTradeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTradeRequest.ProtoReflect.Descriptor instead.
+func (*CancelTradeRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *CancelTradeRequest) GetTradeId() string {
+	if x != nil {
+		return x.TradeId
+	}
+	return ""
+}
+
+func (x *CancelTradeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type InviteCode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	CircleId      string                 `protobuf:"bytes,2,opt,name=circle_id,json=circleId,proto3" json:"circle_id,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	MaxUses       int32                  `protobuf:"varint,5,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"` // 0 なら無制限
+	Uses          int32                  `protobuf:"varint,6,opt,name=uses,proto3" json:"uses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteCode) Reset() {
+	*x = InviteCode{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteCode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteCode) ProtoMessage() {}
+
+func (x *InviteCode) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteCode.ProtoReflect.Descriptor instead.
+func (*InviteCode) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *InviteCode) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *InviteCode) GetCircleId() string {
+	if x != nil {
+		return x.CircleId
+	}
+	return ""
+}
+
+func (x *InviteCode) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *InviteCode) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *InviteCode) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *InviteCode) GetUses() int32 {
+	if x != nil {
+		return x.Uses
+	}
+	return 0
+}
+
+type CreateCircleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 認証情報から取るべきだが、一旦IDを送る
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,4,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCircleRequest) Reset() {
+	*x = CreateCircleRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCircleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCircleRequest) ProtoMessage() {}
+
+func (x *CreateCircleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCircleRequest.ProtoReflect.Descriptor instead.
+func (*CreateCircleRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *CreateCircleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateCircleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCircleRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateCircleRequest) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+type GetCircleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CircleId      string                 `protobuf:"bytes,1,opt,name=circle_id,json=circleId,proto3" json:"circle_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCircleRequest) Reset() {
+	*x = GetCircleRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCircleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCircleRequest) ProtoMessage() {}
+
+func (x *GetCircleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCircleRequest.ProtoReflect.Descriptor instead.
+func (*GetCircleRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *GetCircleRequest) GetCircleId() string {
+	if x != nil {
+		return x.CircleId
+	}
+	return ""
+}
+
+type GenerateInviteCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 認証情報から取るべきだが、一旦IDを送る
+	CircleId      string                 `protobuf:"bytes,2,opt,name=circle_id,json=circleId,proto3" json:"circle_id,omitempty"`
+	MaxUses       int32                  `protobuf:"varint,3,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"` // 0 なら無制限
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateInviteCodeRequest) Reset() {
+	*x = GenerateInviteCodeRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateInviteCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateInviteCodeRequest) ProtoMessage() {}
+
+func (x *GenerateInviteCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateInviteCodeRequest.ProtoReflect.Descriptor instead.
+func (*GenerateInviteCodeRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GenerateInviteCodeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GenerateInviteCodeRequest) GetCircleId() string {
+	if x != nil {
+		return x.CircleId
+	}
+	return ""
+}
+
+func (x *GenerateInviteCodeRequest) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+type JoinCircleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 認証情報から取るべきだが、一旦IDを送る
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`                   // 招待コード
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinCircleRequest) Reset() {
+	*x = JoinCircleRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinCircleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinCircleRequest) ProtoMessage() {}
+
+func (x *JoinCircleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinCircleRequest.ProtoReflect.Descriptor instead.
+func (*JoinCircleRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *JoinCircleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *JoinCircleRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type LeaveCircleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 認証情報から取るべきだが、一旦IDを送る
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveCircleRequest) Reset() {
+	*x = LeaveCircleRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveCircleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveCircleRequest) ProtoMessage() {}
+
+func (x *LeaveCircleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveCircleRequest.ProtoReflect.Descriptor instead.
+func (*LeaveCircleRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *LeaveCircleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type LeaveCircleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CircleId      string                 `protobuf:"bytes,1,opt,name=circle_id,json=circleId,proto3" json:"circle_id,omitempty"` // 抜けたサークルのID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveCircleResponse) Reset() {
+	*x = LeaveCircleResponse{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveCircleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveCircleResponse) ProtoMessage() {}
+
+func (x *LeaveCircleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveCircleResponse.ProtoReflect.Descriptor instead.
+func (*LeaveCircleResponse) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *LeaveCircleResponse) GetCircleId() string {
+	if x != nil {
+		return x.CircleId
+	}
+	return ""
+}
+
+type ListMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CircleId      string                 `protobuf:"bytes,1,opt,name=circle_id,json=circleId,proto3" json:"circle_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMembersRequest) Reset() {
+	*x = ListMembersRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMembersRequest) ProtoMessage() {}
+
+func (x *ListMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListMembersRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ListMembersRequest) GetCircleId() string {
+	if x != nil {
+		return x.CircleId
+	}
+	return ""
+}
+
+type ListMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*User                `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AttackRequest) Reset() {
-	*x = AttackRequest{}
-	mi := &file_ptera_v1_ptera_proto_msgTypes[9]
+func (x *ListMembersResponse) Reset() {
+	*x = ListMembersResponse{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AttackRequest) String() string {
+func (x *ListMembersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AttackRequest) ProtoMessage() {}
+func (*ListMembersResponse) ProtoMessage() {}
 
-func (x *AttackRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ptera_v1_ptera_proto_msgTypes[9]
+func (x *ListMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -812,47 +4966,39 @@ func (x *AttackRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AttackRequest.ProtoReflect.Descriptor instead.
-func (*AttackRequest) Descriptor() ([]byte, []int) {
-	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *AttackRequest) GetBattleId() string {
-	if x != nil {
-		return x.BattleId
-	}
-	return ""
+// Deprecated: Use ListMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListMembersResponse) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *AttackRequest) GetPlayerId() string {
+func (x *ListMembersResponse) GetMembers() []*User {
 	if x != nil {
-		return x.PlayerId
+		return x.Members
 	}
-	return ""
+	return nil
 }
 
-type AttackResponse struct {
+type GetMeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BattleState   *BattleState           `protobuf:"bytes,1,opt,name=battle_state,json=battleState,proto3" json:"battle_state,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AttackResponse) Reset() {
-	*x = AttackResponse{}
-	mi := &file_ptera_v1_ptera_proto_msgTypes[10]
+func (x *GetMeRequest) Reset() {
+	*x = GetMeRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AttackResponse) String() string {
+func (x *GetMeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AttackResponse) ProtoMessage() {}
+func (*GetMeRequest) ProtoMessage() {}
 
-func (x *AttackResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ptera_v1_ptera_proto_msgTypes[10]
+func (x *GetMeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -863,42 +5009,33 @@ func (x *AttackResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AttackResponse.ProtoReflect.Descriptor instead.
-func (*AttackResponse) Descriptor() ([]byte, []int) {
-	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *AttackResponse) GetBattleState() *BattleState {
-	if x != nil {
-		return x.BattleState
-	}
-	return nil
+// Deprecated: Use GetMeRequest.ProtoReflect.Descriptor instead.
+func (*GetMeRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{73}
 }
 
-type RetreatRequest struct {
+type GetUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BattleId      string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`
-	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
-	BenchIndex    int32                  `protobuf:"varint,3,opt,name=bench_index,json=benchIndex,proto3" json:"bench_index,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RetreatRequest) Reset() {
-	*x = RetreatRequest{}
-	mi := &file_ptera_v1_ptera_proto_msgTypes[11]
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RetreatRequest) String() string {
+func (x *GetUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RetreatRequest) ProtoMessage() {}
+func (*GetUserRequest) ProtoMessage() {}
 
-func (x *RetreatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ptera_v1_ptera_proto_msgTypes[11]
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -909,54 +5046,43 @@ func (x *RetreatRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RetreatRequest.ProtoReflect.Descriptor instead.
-func (*RetreatRequest) Descriptor() ([]byte, []int) {
-	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{11}
-}
-
-func (x *RetreatRequest) GetBattleId() string {
-	if x != nil {
-		return x.BattleId
-	}
-	return ""
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *RetreatRequest) GetPlayerId() string {
+func (x *GetUserRequest) GetUserId() string {
 	if x != nil {
-		return x.PlayerId
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *RetreatRequest) GetBenchIndex() int32 {
-	if x != nil {
-		return x.BenchIndex
-	}
-	return 0
-}
-
-type RetreatResponse struct {
+type UpdateProfileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BattleState   *BattleState           `protobuf:"bytes,1,opt,name=battle_state,json=battleState,proto3" json:"battle_state,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 認証情報から取るべきだが、一旦IDを送る
+	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	IconUrl       *string                `protobuf:"bytes,3,opt,name=icon_url,json=iconUrl,proto3,oneof" json:"icon_url,omitempty"`
+	Bio           *string                `protobuf:"bytes,4,opt,name=bio,proto3,oneof" json:"bio,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RetreatResponse) Reset() {
-	*x = RetreatResponse{}
-	mi := &file_ptera_v1_ptera_proto_msgTypes[12]
+func (x *UpdateProfileRequest) Reset() {
+	*x = UpdateProfileRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RetreatResponse) String() string {
+func (x *UpdateProfileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RetreatResponse) ProtoMessage() {}
+func (*UpdateProfileRequest) ProtoMessage() {}
 
-func (x *RetreatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ptera_v1_ptera_proto_msgTypes[12]
+func (x *UpdateProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -967,47 +5093,69 @@ func (x *RetreatResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RetreatResponse.ProtoReflect.Descriptor instead.
-func (*RetreatResponse) Descriptor() ([]byte, []int) {
-	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use UpdateProfileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProfileRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *RetreatResponse) GetBattleState() *BattleState {
+func (x *UpdateProfileRequest) GetUserId() string {
 	if x != nil {
-		return x.BattleState
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-type BattleRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	RequestId      string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
-	FromCircleId   string                 `protobuf:"bytes,2,opt,name=from_circle_id,json=fromCircleId,proto3" json:"from_circle_id,omitempty"`
-	ToCircleId     string                 `protobuf:"bytes,3,opt,name=to_circle_id,json=toCircleId,proto3" json:"to_circle_id,omitempty"`
-	FromCircleName string                 `protobuf:"bytes,4,opt,name=from_circle_name,json=fromCircleName,proto3" json:"from_circle_name,omitempty"`
-	ToCircleName   string                 `protobuf:"bytes,5,opt,name=to_circle_name,json=toCircleName,proto3" json:"to_circle_name,omitempty"`
-	Status         string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // "pending", "accepted", "rejected"
-	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	BattleId       *string                `protobuf:"bytes,8,opt,name=battle_id,json=battleId,proto3,oneof" json:"battle_id,omitempty"` // Set after acceptance
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *UpdateProfileRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
 }
 
-func (x *BattleRequest) Reset() {
-	*x = BattleRequest{}
-	mi := &file_ptera_v1_ptera_proto_msgTypes[13]
+func (x *UpdateProfileRequest) GetIconUrl() string {
+	if x != nil && x.IconUrl != nil {
+		return *x.IconUrl
+	}
+	return ""
+}
+
+func (x *UpdateProfileRequest) GetBio() string {
+	if x != nil && x.Bio != nil {
+		return *x.Bio
+	}
+	return ""
+}
+
+type QuotaUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scope         string                 `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`                          // "user" または "circle"
+	SubjectId     string                 `protobuf:"bytes,2,opt,name=subject_id,json=subjectId,proto3" json:"subject_id,omitempty"` // ユーザーIDまたはサークルID
+	Window        string                 `protobuf:"bytes,3,opt,name=window,proto3" json:"window,omitempty"`                        // "daily" または "monthly"
+	Period        string                 `protobuf:"bytes,4,opt,name=period,proto3" json:"period,omitempty"`                        // 集計期間 (例: "2026-10-19", "2026-10")
+	Calls         int64                  `protobuf:"varint,5,opt,name=calls,proto3" json:"calls,omitempty"`
+	CallLimit     int64                  `protobuf:"varint,6,opt,name=call_limit,json=callLimit,proto3" json:"call_limit,omitempty"` // 0 なら無制限
+	Tokens        int64                  `protobuf:"varint,7,opt,name=tokens,proto3" json:"tokens,omitempty"`
+	TokenLimit    int64                  `protobuf:"varint,8,opt,name=token_limit,json=tokenLimit,proto3" json:"token_limit,omitempty"` // 0 なら無制限
+	ResetsAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=resets_at,json=resetsAt,proto3" json:"resets_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuotaUsage) Reset() {
+	*x = QuotaUsage{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BattleRequest) String() string {
+func (x *QuotaUsage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BattleRequest) ProtoMessage() {}
+func (*QuotaUsage) ProtoMessage() {}
 
-func (x *BattleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ptera_v1_ptera_proto_msgTypes[13]
+func (x *QuotaUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1018,90 +5166,97 @@ func (x *BattleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BattleRequest.ProtoReflect.Descriptor instead.
-func (*BattleRequest) Descriptor() ([]byte, []int) {
-	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use QuotaUsage.ProtoReflect.Descriptor instead.
+func (*QuotaUsage) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *BattleRequest) GetRequestId() string {
+func (x *QuotaUsage) GetScope() string {
 	if x != nil {
-		return x.RequestId
+		return x.Scope
 	}
 	return ""
 }
 
-func (x *BattleRequest) GetFromCircleId() string {
+func (x *QuotaUsage) GetSubjectId() string {
 	if x != nil {
-		return x.FromCircleId
+		return x.SubjectId
 	}
 	return ""
 }
 
-func (x *BattleRequest) GetToCircleId() string {
+func (x *QuotaUsage) GetWindow() string {
 	if x != nil {
-		return x.ToCircleId
+		return x.Window
 	}
 	return ""
 }
 
-func (x *BattleRequest) GetFromCircleName() string {
+func (x *QuotaUsage) GetPeriod() string {
 	if x != nil {
-		return x.FromCircleName
+		return x.Period
 	}
 	return ""
 }
 
-func (x *BattleRequest) GetToCircleName() string {
+func (x *QuotaUsage) GetCalls() int64 {
 	if x != nil {
-		return x.ToCircleName
+		return x.Calls
 	}
-	return ""
+	return 0
 }
 
-func (x *BattleRequest) GetStatus() string {
+func (x *QuotaUsage) GetCallLimit() int64 {
 	if x != nil {
-		return x.Status
+		return x.CallLimit
 	}
-	return ""
+	return 0
 }
 
-func (x *BattleRequest) GetCreatedAt() *timestamppb.Timestamp {
+func (x *QuotaUsage) GetTokens() int64 {
 	if x != nil {
-		return x.CreatedAt
+		return x.Tokens
 	}
-	return nil
+	return 0
 }
 
-func (x *BattleRequest) GetBattleId() string {
-	if x != nil && x.BattleId != nil {
-		return *x.BattleId
+func (x *QuotaUsage) GetTokenLimit() int64 {
+	if x != nil {
+		return x.TokenLimit
 	}
-	return ""
+	return 0
 }
 
-type SendBattleRequestRequest struct {
+func (x *QuotaUsage) GetResetsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResetsAt
+	}
+	return nil
+}
+
+type GetQuotaUsageRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FromCircleId  string                 `protobuf:"bytes,1,opt,name=from_circle_id,json=fromCircleId,proto3" json:"from_circle_id,omitempty"`
-	ToCircleId    string                 `protobuf:"bytes,2,opt,name=to_circle_id,json=toCircleId,proto3" json:"to_circle_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // user_id と circle_id のどちらか一方を指定
+	CircleId      string                 `protobuf:"bytes,3,opt,name=circle_id,json=circleId,proto3" json:"circle_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SendBattleRequestRequest) Reset() {
-	*x = SendBattleRequestRequest{}
-	mi := &file_ptera_v1_ptera_proto_msgTypes[14]
+func (x *GetQuotaUsageRequest) Reset() {
+	*x = GetQuotaUsageRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendBattleRequestRequest) String() string {
+func (x *GetQuotaUsageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendBattleRequestRequest) ProtoMessage() {}
+func (*GetQuotaUsageRequest) ProtoMessage() {}
 
-func (x *SendBattleRequestRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ptera_v1_ptera_proto_msgTypes[14]
+func (x *GetQuotaUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1112,47 +5267,47 @@ func (x *SendBattleRequestRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendBattleRequestRequest.ProtoReflect.Descriptor instead.
-func (*SendBattleRequestRequest) Descriptor() ([]byte, []int) {
-	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use GetQuotaUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetQuotaUsageRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *SendBattleRequestRequest) GetFromCircleId() string {
+func (x *GetQuotaUsageRequest) GetUserId() string {
 	if x != nil {
-		return x.FromCircleId
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *SendBattleRequestRequest) GetToCircleId() string {
+func (x *GetQuotaUsageRequest) GetCircleId() string {
 	if x != nil {
-		return x.ToCircleId
+		return x.CircleId
 	}
 	return ""
 }
 
-type AcceptBattleRequestRequest struct {
+type GetQuotaUsageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Usages        []*QuotaUsage          `protobuf:"bytes,1,rep,name=usages,proto3" json:"usages,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AcceptBattleRequestRequest) Reset() {
-	*x = AcceptBattleRequestRequest{}
-	mi := &file_ptera_v1_ptera_proto_msgTypes[15]
+func (x *GetQuotaUsageResponse) Reset() {
+	*x = GetQuotaUsageResponse{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AcceptBattleRequestRequest) String() string {
+func (x *GetQuotaUsageResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AcceptBattleRequestRequest) ProtoMessage() {}
+func (*GetQuotaUsageResponse) ProtoMessage() {}
 
-func (x *AcceptBattleRequestRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ptera_v1_ptera_proto_msgTypes[15]
+func (x *GetQuotaUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1163,40 +5318,42 @@ func (x *AcceptBattleRequestRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AcceptBattleRequestRequest.ProtoReflect.Descriptor instead.
-func (*AcceptBattleRequestRequest) Descriptor() ([]byte, []int) {
-	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use GetQuotaUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetQuotaUsageResponse) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *AcceptBattleRequestRequest) GetRequestId() string {
+func (x *GetQuotaUsageResponse) GetUsages() []*QuotaUsage {
 	if x != nil {
-		return x.RequestId
+		return x.Usages
 	}
-	return ""
+	return nil
 }
 
-type RejectBattleRequestRequest struct {
+type ResetQuotaUsageRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // user_id と circle_id のどちらか一方を指定
+	CircleId      string                 `protobuf:"bytes,3,opt,name=circle_id,json=circleId,proto3" json:"circle_id,omitempty"`
+	Window        string                 `protobuf:"bytes,4,opt,name=window,proto3" json:"window,omitempty"` // "daily" または "monthly" (空の場合は両方)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RejectBattleRequestRequest) Reset() {
-	*x = RejectBattleRequestRequest{}
-	mi := &file_ptera_v1_ptera_proto_msgTypes[16]
+func (x *ResetQuotaUsageRequest) Reset() {
+	*x = ResetQuotaUsageRequest{}
+	mi := &file_ptera_v1_ptera_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RejectBattleRequestRequest) String() string {
+func (x *ResetQuotaUsageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RejectBattleRequestRequest) ProtoMessage() {}
+func (*ResetQuotaUsageRequest) ProtoMessage() {}
 
-func (x *RejectBattleRequestRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ptera_v1_ptera_proto_msgTypes[16]
+func (x *ResetQuotaUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ptera_v1_ptera_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1207,14 +5364,28 @@ func (x *RejectBattleRequestRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RejectBattleRequestRequest.ProtoReflect.Descriptor instead.
-func (*RejectBattleRequestRequest) Descriptor() ([]byte, []int) {
-	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use ResetQuotaUsageRequest.ProtoReflect.Descriptor instead.
+func (*ResetQuotaUsageRequest) Descriptor() ([]byte, []int) {
+	return file_ptera_v1_ptera_proto_rawDescGZIP(), []int{79}
 }
 
-func (x *RejectBattleRequestRequest) GetRequestId() string {
+func (x *ResetQuotaUsageRequest) GetUserId() string {
 	if x != nil {
-		return x.RequestId
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ResetQuotaUsageRequest) GetCircleId() string {
+	if x != nil {
+		return x.CircleId
+	}
+	return ""
+}
+
+func (x *ResetQuotaUsageRequest) GetWindow() string {
+	if x != nil {
+		return x.Window
 	}
 	return ""
 }
@@ -1223,16 +5394,18 @@ var File_ptera_v1_ptera_proto protoreflect.FileDescriptor
 
 const file_ptera_v1_ptera_proto_rawDesc = "" +
 	"\n" +
-	"\x14ptera/v1/ptera.proto\x12\bptera.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9a\x01\n" +
+	"\x14ptera/v1/ptera.proto\x12\bptera.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd8\x01\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x19\n" +
 	"\bicon_url\x18\x03 \x01(\tR\aiconUrl\x12\x19\n" +
 	"\x05email\x18\x04 \x01(\tH\x00R\x05email\x88\x01\x01\x12 \n" +
-	"\tcircle_id\x18\x05 \x01(\tH\x01R\bcircleId\x88\x01\x01B\b\n" +
+	"\tcircle_id\x18\x05 \x01(\tH\x01R\bcircleId\x88\x01\x01\x12\x10\n" +
+	"\x03bio\x18\x06 \x01(\tR\x03bio\x12*\n" +
+	"\x11favorite_card_ids\x18\a \x03(\tR\x0ffavoriteCardIdsB\b\n" +
 	"\x06_emailB\f\n" +
 	"\n" +
-	"_circle_id\"\xe6\x03\n" +
+	"_circle_id\"\xee\x05\n" +
 	"\x04Card\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -1252,13 +5425,34 @@ const file_ptera_v1_ptera_proto_rawDesc = "" +
 	"\x06attack\x18\r \x01(\x05R\x06attack\x12\x16\n" +
 	"\x06flavor\x18\x0e \x01(\tR\x06flavor\x12\x1d\n" +
 	"\n" +
-	"current_hp\x18\x0f \x01(\x05R\tcurrentHpB\x13\n" +
+	"current_hp\x18\x0f \x01(\x05R\tcurrentHp\x12;\n" +
+	"\vexpiry_date\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expiryDate\x12\x1c\n" +
+	"\tgraduated\x18\x11 \x01(\bR\tgraduated\x12\x1a\n" +
+	"\bfavorite\x18\x12 \x01(\bR\bfavorite\x12.\n" +
+	"\x13flavor_ai_generated\x18\x13 \x01(\bR\x11flavorAiGenerated\x128\n" +
+	"\fstat_profile\x18\x14 \x01(\v2\x15.ptera.v1.StatProfileR\vstatProfile\x12%\n" +
+	"\x0eprompt_version\x18\x15 \x01(\tR\rpromptVersionB\x13\n" +
 	"\x11_affiliated_groupB\f\n" +
 	"\n" +
-	"_circle_id\",\n" +
+	"_circle_id\"W\n" +
+	"\vStatProfile\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1c\n" +
+	"\tintensity\x18\x02 \x01(\x02R\tintensity\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x9f\x02\n" +
 	"\x06Circle\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"\xe2\x02\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
+	"\timage_url\x18\x04 \x01(\tR\bimageUrl\x12\x1d\n" +
+	"\n" +
+	"member_ids\x18\x05 \x03(\tR\tmemberIds\x12\x1d\n" +
+	"\n" +
+	"creator_id\x18\x06 \x01(\tR\tcreatorId\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xef\x03\n" +
 	"\x13CompleteCardRequest\x12\x1b\n" +
 	"\timage_url\x18\x01 \x01(\tR\bimageUrl\x12\x17\n" +
 	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x1d\n" +
@@ -1269,7 +5463,12 @@ const file_ptera_v1_ptera_proto_rawDesc = "" +
 	"\x05grade\x18\x05 \x01(\x05H\x03R\x05grade\x88\x01\x01\x12\x1f\n" +
 	"\bposition\x18\x06 \x01(\tH\x04R\bposition\x88\x01\x01\x12\x19\n" +
 	"\x05hobby\x18\a \x01(\tH\x05R\x05hobby\x88\x01\x01\x12%\n" +
-	"\vdescription\x18\b \x01(\tH\x06R\vdescription\x88\x01\x01B\a\n" +
+	"\vdescription\x18\b \x01(\tH\x06R\vdescription\x88\x01\x01\x12\x1e\n" +
+	"\bimage_id\x18\t \x01(\tH\aR\aimageId\x88\x01\x01\x12#\n" +
+	"\rforce_refresh\x18\n" +
+	" \x01(\bR\fforceRefresh\x12\x16\n" +
+	"\x06locale\x18\f \x01(\tR\x06locale\x12\x12\n" +
+	"\x04tone\x18\r \x01(\tR\x04toneB\a\n" +
 	"\x05_nameB\n" +
 	"\n" +
 	"\b_facultyB\r\n" +
@@ -1277,7 +5476,8 @@ const file_ptera_v1_ptera_proto_rawDesc = "" +
 	"\x06_gradeB\v\n" +
 	"\t_positionB\b\n" +
 	"\x06_hobbyB\x0e\n" +
-	"\f_description\"\xa4\x02\n" +
+	"\f_descriptionB\v\n" +
+	"\t_image_idJ\x04\b\v\x10\fR\auser_id\"\x82\x04\n" +
 	"\x14CompleteCardResponse\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
 	"\afaculty\x18\x02 \x01(\tR\afaculty\x12\x1e\n" +
@@ -1289,8 +5489,141 @@ const file_ptera_v1_ptera_proto_rawDesc = "" +
 	"\x05hobby\x18\x06 \x01(\tR\x05hobby\x12 \n" +
 	"\vdescription\x18\a \x01(\tR\vdescription\x12\x18\n" +
 	"\asuccess\x18\b \x01(\bR\asuccess\x12(\n" +
-	"\rerror_message\x18\t \x01(\tH\x00R\ferrorMessage\x88\x01\x01B\x10\n" +
-	"\x0e_error_message\"\x94\x02\n" +
+	"\rerror_message\x18\t \x01(\tH\x00R\ferrorMessage\x88\x01\x01\x128\n" +
+	"\fstat_profile\x18\n" +
+	" \x01(\v2\x15.ptera.v1.StatProfileR\vstatProfile\x12\x16\n" +
+	"\x06cached\x18\v \x01(\bR\x06cached\x12\x18\n" +
+	"\aflagged\x18\f \x01(\bR\aflagged\x12I\n" +
+	"\x12moderation_reasons\x18\r \x03(\v2\x1a.ptera.v1.ModerationReasonR\x11moderationReasons\x12%\n" +
+	"\x0eprompt_version\x18\x0e \x01(\tR\rpromptVersionB\x10\n" +
+	"\x0e_error_message\"=\n" +
+	"\x0fCardFieldUpdate\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\x92\x01\n" +
+	"\x1aStreamCompleteCardResponse\x121\n" +
+	"\x05field\x18\x01 \x01(\v2\x19.ptera.v1.CardFieldUpdateH\x00R\x05field\x128\n" +
+	"\x06result\x18\x02 \x01(\v2\x1e.ptera.v1.CompleteCardResponseH\x00R\x06resultB\a\n" +
+	"\x05event\"n\n" +
+	"\x10ModerationReason\x12\x14\n" +
+	"\x05stage\x18\x01 \x01(\tR\x05stage\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x14\n" +
+	"\x05field\x18\x03 \x01(\tR\x05field\x12\x16\n" +
+	"\x06detail\x18\x04 \x01(\tR\x06detail\"\x9b\x01\n" +
+	"\x14DetectMembersRequest\x12\x1b\n" +
+	"\timage_url\x18\x02 \x01(\tR\bimageUrl\x12\x1e\n" +
+	"\bimage_id\x18\x03 \x01(\tH\x00R\aimageId\x88\x01\x01\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\x12\x12\n" +
+	"\x04tone\x18\x05 \x01(\tR\x04toneB\v\n" +
+	"\t_image_idJ\x04\b\x01\x10\x02R\auser_id\"a\n" +
+	"\vBoundingBox\x12\x13\n" +
+	"\x05x_min\x18\x01 \x01(\x02R\x04xMin\x12\x13\n" +
+	"\x05y_min\x18\x02 \x01(\x02R\x04yMin\x12\x13\n" +
+	"\x05x_max\x18\x03 \x01(\x02R\x04xMax\x12\x13\n" +
+	"\x05y_max\x18\x04 \x01(\x02R\x04yMax\"\xa4\x01\n" +
+	"\vMemberDraft\x12'\n" +
+	"\x03box\x18\x01 \x01(\v2\x15.ptera.v1.BoundingBoxR\x03box\x12\x19\n" +
+	"\bimage_id\x18\x02 \x01(\tR\aimageId\x12\x1b\n" +
+	"\timage_url\x18\x03 \x01(\tR\bimageUrl\x124\n" +
+	"\x05draft\x18\x04 \x01(\v2\x1e.ptera.v1.CompleteCardResponseR\x05draft\"\x9e\x01\n" +
+	"\x15DetectMembersResponse\x12/\n" +
+	"\amembers\x18\x01 \x03(\v2\x15.ptera.v1.MemberDraftR\amembers\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12(\n" +
+	"\rerror_message\x18\x03 \x01(\tH\x00R\ferrorMessage\x88\x01\x01B\x10\n" +
+	"\x0e_error_message\"\xc4\x01\n" +
+	"\n" +
+	"CardFields\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\afaculty\x18\x02 \x01(\tR\afaculty\x12\x1e\n" +
+	"\n" +
+	"department\x18\x03 \x01(\tR\n" +
+	"department\x12\x14\n" +
+	"\x05grade\x18\x04 \x01(\x05R\x05grade\x12\x1a\n" +
+	"\bposition\x18\x05 \x01(\tR\bposition\x12\x14\n" +
+	"\x05hobby\x18\x06 \x01(\tR\x05hobby\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\"\xb0\x01\n" +
+	"\x16RegenerateFieldRequest\x12,\n" +
+	"\x06fields\x18\x02 \x01(\v2\x14.ptera.v1.CardFieldsR\x06fields\x12\x1d\n" +
+	"\n" +
+	"field_name\x18\x03 \x01(\tR\tfieldName\x12\x14\n" +
+	"\x05style\x18\x04 \x01(\tR\x05style\x12\f\n" +
+	"\x01n\x18\x05 \x01(\x05R\x01n\x12\x16\n" +
+	"\x06locale\x18\x06 \x01(\tR\x06localeJ\x04\b\x01\x10\x02R\auser_id\"\x93\x02\n" +
+	"\x17RegenerateFieldResponse\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12(\n" +
+	"\rerror_message\x18\x03 \x01(\tH\x00R\ferrorMessage\x88\x01\x01\x12\x18\n" +
+	"\aflagged\x18\x04 \x01(\bR\aflagged\x12I\n" +
+	"\x12moderation_reasons\x18\x05 \x03(\v2\x1a.ptera.v1.ModerationReasonR\x11moderationReasons\x12%\n" +
+	"\x0eprompt_version\x18\x06 \x01(\tR\rpromptVersionB\x10\n" +
+	"\x0e_error_message\"P\n" +
+	"\x11CreateCardRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\"\n" +
+	"\x04card\x18\x02 \x01(\v2\x0e.ptera.v1.CardR\x04card\")\n" +
+	"\x0eGetCardRequest\x12\x17\n" +
+	"\acard_id\x18\x01 \x01(\tR\x06cardId\"P\n" +
+	"\x11UpdateCardRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\"\n" +
+	"\x04card\x18\x02 \x01(\v2\x0e.ptera.v1.CardR\x04card\"E\n" +
+	"\x11DeleteCardRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\acard_id\x18\x02 \x01(\tR\x06cardId\"-\n" +
+	"\x12DeleteCardResponse\x12\x17\n" +
+	"\acard_id\x18\x01 \x01(\tR\x06cardId\"\x9c\x01\n" +
+	"\n" +
+	"CardFilter\x12 \n" +
+	"\tcircle_id\x18\x01 \x01(\tH\x00R\bcircleId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"creator_id\x18\x02 \x01(\tH\x01R\tcreatorId\x88\x01\x01\x12+\n" +
+	"\x11include_graduated\x18\x03 \x01(\bR\x10includeGraduatedB\f\n" +
+	"\n" +
+	"_circle_idB\r\n" +
+	"\v_creator_id\"|\n" +
+	"\x10ListCardsRequest\x12,\n" +
+	"\x06filter\x18\x01 \x01(\v2\x14.ptera.v1.CardFilterR\x06filter\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"a\n" +
+	"\x11ListCardsResponse\x12$\n" +
+	"\x05cards\x18\x01 \x03(\v2\x0e.ptera.v1.CardR\x05cards\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"J\n" +
+	"\x16SetFavoriteCardRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\acard_id\x18\x02 \x01(\tR\x06cardId\"3\n" +
+	"\x18ListFavoriteCardsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"A\n" +
+	"\x19ListFavoriteCardsResponse\x12$\n" +
+	"\x05cards\x18\x01 \x03(\v2\x0e.ptera.v1.CardR\x05cards\"B\n" +
+	"\x11RenderCardRequest\x12\x17\n" +
+	"\acard_id\x18\x01 \x01(\tR\x06cardId\x12\x14\n" +
+	"\x05style\x18\x02 \x01(\tR\x05style\"u\n" +
+	"\x12RenderCardResponse\x12\x10\n" +
+	"\x03png\x18\x01 \x01(\fR\x03png\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1b\n" +
+	"\timage_url\x18\x03 \x01(\tR\bimageUrl\x12\x16\n" +
+	"\x06cached\x18\x04 \x01(\bR\x06cached\"F\n" +
+	"\x12IssueCardQRRequest\x12\x17\n" +
+	"\acard_id\x18\x01 \x01(\tR\x06cardId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"}\n" +
+	"\x13IssueCardQRResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x15\n" +
+	"\x06qr_png\x18\x03 \x01(\fR\x05qrPng\",\n" +
+	"\x14ResolveCardQRRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"Z\n" +
+	"\x13ImageUploadMetadata\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\"x\n" +
+	"\x16UploadCardImageRequest\x12;\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1d.ptera.v1.ImageUploadMetadataH\x00R\bmetadata\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"\x88\x01\n" +
+	"\x17UploadCardImageResponse\x12\x19\n" +
+	"\bimage_id\x18\x01 \x01(\tR\aimageId\x12\x1b\n" +
+	"\timage_url\x18\x02 \x01(\tR\bimageUrl\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\"\xec\x03\n" +
 	"\vBattleState\x12\x1b\n" +
 	"\tbattle_id\x18\x01 \x01(\tR\bbattleId\x12-\n" +
 	"\tplayer_me\x18\x02 \x01(\v2\x10.ptera.v1.PlayerR\bplayerMe\x129\n" +
@@ -1298,18 +5631,36 @@ const file_ptera_v1_ptera_proto_rawDesc = "" +
 	"\fcurrent_turn\x18\x04 \x01(\x05R\vcurrentTurn\x12*\n" +
 	"\x11current_player_id\x18\x05 \x01(\tR\x0fcurrentPlayerId\x12\x1b\n" +
 	"\twinner_id\x18\x06 \x01(\tR\bwinnerId\x12\x12\n" +
-	"\x04logs\x18\a \x03(\tR\x04logs\"\x97\x01\n" +
+	"\x04logs\x18\a \x03(\tR\x04logs\x122\n" +
+	"\bfairness\x18\b \x01(\v2\x16.ptera.v1.FairnessInfoR\bfairness\x127\n" +
+	"\fdamage_rolls\x18\t \x03(\v2\x14.ptera.v1.DamageRollR\vdamageRolls\x12-\n" +
+	"\x12commentary_enabled\x18\n" +
+	" \x01(\bR\x11commentaryEnabled\x12:\n" +
+	"\n" +
+	"commentary\x18\v \x03(\v2\x1a.ptera.v1.BattleCommentaryR\n" +
+	"commentary\"_\n" +
+	"\x10BattleCommentary\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12!\n" +
+	"\fai_generated\x18\x03 \x01(\bR\vaiGenerated\"\x97\x01\n" +
 	"\x06Player\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1b\n" +
 	"\tcircle_id\x18\x02 \x01(\tR\bcircleId\x12\x1f\n" +
 	"\vcircle_name\x18\x03 \x01(\tR\n" +
 	"circleName\x12\x0e\n" +
 	"\x02hp\x18\x04 \x01(\x05R\x02hp\x12\"\n" +
-	"\x04deck\x18\x05 \x03(\v2\x0e.ptera.v1.CardR\x04deck\"d\n" +
+	"\x04deck\x18\x05 \x03(\v2\x0e.ptera.v1.CardR\x04deck\"\x90\x02\n" +
 	"\x12StartBattleRequest\x12 \n" +
 	"\fmy_circle_id\x18\x01 \x01(\tR\n" +
 	"myCircleId\x12,\n" +
-	"\x12opponent_circle_id\x18\x02 \x01(\tR\x10opponentCircleId\"O\n" +
+	"\x12opponent_circle_id\x18\x02 \x01(\tR\x10opponentCircleId\x12+\n" +
+	"\x11include_graduated\x18\x03 \x01(\bR\x10includeGraduated\x12(\n" +
+	"\rcommitment_id\x18\x04 \x01(\tH\x00R\fcommitmentId\x88\x01\x01\x12!\n" +
+	"\fclient_nonce\x18\x05 \x01(\tR\vclientNonce\x12\x1e\n" +
+	"\n" +
+	"commentary\x18\x06 \x01(\bR\n" +
+	"commentaryB\x10\n" +
+	"\x0e_commitment_id\"O\n" +
 	"\x13StartBattleResponse\x128\n" +
 	"\fbattle_state\x18\x01 \x01(\v2\x15.ptera.v1.BattleStateR\vbattleState\"I\n" +
 	"\rAttackRequest\x12\x1b\n" +
@@ -1323,7 +5674,7 @@ const file_ptera_v1_ptera_proto_rawDesc = "" +
 	"\vbench_index\x18\x03 \x01(\x05R\n" +
 	"benchIndex\"K\n" +
 	"\x0fRetreatResponse\x128\n" +
-	"\fbattle_state\x18\x01 \x01(\v2\x15.ptera.v1.BattleStateR\vbattleState\"\xc9\x02\n" +
+	"\fbattle_state\x18\x01 \x01(\v2\x15.ptera.v1.BattleStateR\vbattleState\"\x96\x03\n" +
 	"\rBattleRequest\x12\x1d\n" +
 	"\n" +
 	"request_id\x18\x01 \x01(\tR\trequestId\x12$\n" +
@@ -1335,28 +5686,253 @@ const file_ptera_v1_ptera_proto_rawDesc = "" +
 	"\x06status\x18\x06 \x01(\tR\x06status\x129\n" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12 \n" +
-	"\tbattle_id\x18\b \x01(\tH\x00R\bbattleId\x88\x01\x01B\f\n" +
+	"\tbattle_id\x18\b \x01(\tH\x00R\bbattleId\x88\x01\x01\x12+\n" +
+	"\x11include_graduated\x18\t \x01(\bR\x10includeGraduated\x12\x1e\n" +
+	"\n" +
+	"commentary\x18\n" +
+	" \x01(\bR\n" +
+	"commentaryB\f\n" +
 	"\n" +
-	"_battle_id\"b\n" +
+	"_battle_id\"\xaf\x01\n" +
 	"\x18SendBattleRequestRequest\x12$\n" +
 	"\x0efrom_circle_id\x18\x01 \x01(\tR\ffromCircleId\x12 \n" +
 	"\fto_circle_id\x18\x02 \x01(\tR\n" +
-	"toCircleId\";\n" +
+	"toCircleId\x12+\n" +
+	"\x11include_graduated\x18\x03 \x01(\bR\x10includeGraduated\x12\x1e\n" +
+	"\n" +
+	"commentary\x18\x04 \x01(\bR\n" +
+	"commentary\"\x83\x01\n" +
 	"\x1aAcceptBattleRequestRequest\x12\x1d\n" +
 	"\n" +
-	"request_id\x18\x01 \x01(\tR\trequestId\";\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12#\n" +
+	"\rcommitment_id\x18\x02 \x01(\tR\fcommitmentId\x12!\n" +
+	"\fclient_nonce\x18\x03 \x01(\tR\vclientNonce\";\n" +
 	"\x1aRejectBattleRequestRequest\x12\x1d\n" +
 	"\n" +
-	"request_id\x18\x01 \x01(\tR\trequestId2]\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\"\xaf\x01\n" +
+	"\vPullRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x12\n" +
+	"\x04pool\x18\x03 \x01(\tR\x04pool\x12(\n" +
+	"\rcommitment_id\x18\x04 \x01(\tH\x00R\fcommitmentId\x88\x01\x01\x12!\n" +
+	"\fclient_nonce\x18\x05 \x01(\tR\vclientNonceB\x10\n" +
+	"\x0e_commitment_id\"~\n" +
+	"\n" +
+	"PullResult\x12\"\n" +
+	"\x04card\x18\x01 \x01(\v2\x0e.ptera.v1.CardR\x04card\x12\x16\n" +
+	"\x06rarity\x18\x02 \x01(\tR\x06rarity\x12\x12\n" +
+	"\x04pity\x18\x03 \x01(\bR\x04pity\x12 \n" +
+	"\fuser_card_id\x18\x04 \x01(\tR\n" +
+	"userCardId\"\xd7\x01\n" +
+	"\fPullResponse\x12\x17\n" +
+	"\apull_id\x18\x01 \x01(\tR\x06pullId\x12.\n" +
+	"\aresults\x18\x02 \x03(\v2\x14.ptera.v1.PullResultR\aresults\x12\x1d\n" +
+	"\n" +
+	"pity_count\x18\x03 \x01(\x05R\tpityCount\x12%\n" +
+	"\x0epity_threshold\x18\x04 \x01(\x05R\rpityThreshold\x128\n" +
+	"\n" +
+	"commitment\x18\x05 \x01(\v2\x18.ptera.v1.SeedCommitmentR\n" +
+	"commitment\"\x80\x01\n" +
+	"\fFairnessInfo\x12#\n" +
+	"\rcommitment_id\x18\x01 \x01(\tR\fcommitmentId\x12(\n" +
+	"\x10server_seed_hash\x18\x02 \x01(\tR\x0eserverSeedHash\x12!\n" +
+	"\fclient_nonce\x18\x03 \x01(\tR\vclientNonce\"\xad\x01\n" +
+	"\n" +
+	"DamageRoll\x12\x1d\n" +
+	"\n" +
+	"roll_index\x18\x01 \x01(\x03R\trollIndex\x12(\n" +
+	"\x10attacker_card_id\x18\x02 \x01(\tR\x0eattackerCardId\x12\x16\n" +
+	"\x06attack\x18\x03 \x01(\x05R\x06attack\x12\x16\n" +
+	"\x06damage\x18\x04 \x01(\x05R\x06damage\x12&\n" +
+	"\x0fnext_roll_index\x18\x05 \x01(\x03R\rnextRollIndex\"\x9c\x03\n" +
+	"\x0eSeedCommitment\x12#\n" +
+	"\rcommitment_id\x18\x01 \x01(\tR\fcommitmentId\x12\x18\n" +
+	"\apurpose\x18\x02 \x01(\tR\apurpose\x12\x19\n" +
+	"\bowner_id\x18\x03 \x01(\tR\aownerId\x12(\n" +
+	"\x10server_seed_hash\x18\x04 \x01(\tR\x0eserverSeedHash\x12$\n" +
+	"\vserver_seed\x18\x05 \x01(\tH\x00R\n" +
+	"serverSeed\x88\x01\x01\x12!\n" +
+	"\fclient_nonce\x18\x06 \x01(\tR\vclientNonce\x12\x1d\n" +
+	"\n" +
+	"subject_id\x18\a \x01(\tR\tsubjectId\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vrevealed_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"revealedAtB\x0e\n" +
+	"\f_server_seed\"H\n" +
+	"\x11CommitSeedRequest\x12\x18\n" +
+	"\apurpose\x18\x01 \x01(\tR\apurpose\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\";\n" +
+	"\x14GetCommitmentRequest\x12#\n" +
+	"\rcommitment_id\x18\x01 \x01(\tR\fcommitmentId\"9\n" +
+	"\x12VerifyRollsRequest\x12#\n" +
+	"\rcommitment_id\x18\x01 \x01(\tR\fcommitmentId\"\x8a\x01\n" +
+	"\fVerifiedRoll\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1a\n" +
+	"\brecorded\x18\x03 \x01(\tR\brecorded\x12\x1e\n" +
+	"\n" +
+	"recomputed\x18\x04 \x01(\tR\n" +
+	"recomputed\x12\x14\n" +
+	"\x05match\x18\x05 \x01(\bR\x05match\"\xcf\x01\n" +
+	"\x13VerifyRollsResponse\x128\n" +
+	"\n" +
+	"commitment\x18\x01 \x01(\v2\x18.ptera.v1.SeedCommitmentR\n" +
+	"commitment\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\x12,\n" +
+	"\x05rolls\x18\x03 \x03(\v2\x16.ptera.v1.VerifiedRollR\x05rolls\x12(\n" +
+	"\rerror_message\x18\x04 \x01(\tH\x00R\ferrorMessage\x88\x01\x01B\x10\n" +
+	"\x0e_error_message\"\x93\x03\n" +
+	"\x05Trade\x12\x19\n" +
+	"\btrade_id\x18\x01 \x01(\tR\atradeId\x12\x1f\n" +
+	"\vproposer_id\x18\x02 \x01(\tR\n" +
+	"proposerId\x12\x1b\n" +
+	"\ttarget_id\x18\x03 \x01(\tR\btargetId\x121\n" +
+	"\x15offered_user_card_ids\x18\x04 \x03(\tR\x12offeredUserCardIds\x125\n" +
+	"\x17requested_user_card_ids\x18\x05 \x03(\tR\x14requestedUserCardIds\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xbe\x01\n" +
+	"\x13ProposeTradeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
+	"\x0etarget_user_id\x18\x02 \x01(\tR\ftargetUserId\x121\n" +
+	"\x15offered_user_card_ids\x18\x03 \x03(\tR\x12offeredUserCardIds\x125\n" +
+	"\x17requested_user_card_ids\x18\x04 \x03(\tR\x14requestedUserCardIds\"H\n" +
+	"\x12AcceptTradeRequest\x12\x19\n" +
+	"\btrade_id\x18\x01 \x01(\tR\atradeId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"H\n" +
+	"\x12RejectTradeRequest\x12\x19\n" +
+	"\btrade_id\x18\x01 \x01(\tR\atradeId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"H\n" +
+	"\x12CancelTradeRequest\x12\x19\n" +
+	"\btrade_id\x18\x01 \x01(\tR\atradeId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xc6\x01\n" +
+	"\n" +
+	"InviteCode\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1b\n" +
+	"\tcircle_id\x18\x02 \x01(\tR\bcircleId\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x03 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x19\n" +
+	"\bmax_uses\x18\x05 \x01(\x05R\amaxUses\x12\x12\n" +
+	"\x04uses\x18\x06 \x01(\x05R\x04uses\"\x81\x01\n" +
+	"\x13CreateCircleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
+	"\timage_url\x18\x04 \x01(\tR\bimageUrl\"/\n" +
+	"\x10GetCircleRequest\x12\x1b\n" +
+	"\tcircle_id\x18\x01 \x01(\tR\bcircleId\"l\n" +
+	"\x19GenerateInviteCodeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tcircle_id\x18\x02 \x01(\tR\bcircleId\x12\x19\n" +
+	"\bmax_uses\x18\x03 \x01(\x05R\amaxUses\"@\n" +
+	"\x11JoinCircleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"-\n" +
+	"\x12LeaveCircleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"2\n" +
+	"\x13LeaveCircleResponse\x12\x1b\n" +
+	"\tcircle_id\x18\x01 \x01(\tR\bcircleId\"1\n" +
+	"\x12ListMembersRequest\x12\x1b\n" +
+	"\tcircle_id\x18\x01 \x01(\tR\bcircleId\"?\n" +
+	"\x13ListMembersResponse\x12(\n" +
+	"\amembers\x18\x01 \x03(\v2\x0e.ptera.v1.UserR\amembers\"\x1d\n" +
+	"\fGetMeRequestJ\x04\b\x01\x10\x02R\auser_id\")\n" +
+	"\x0eGetUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x9d\x01\n" +
+	"\x14UpdateProfileRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x1e\n" +
+	"\bicon_url\x18\x03 \x01(\tH\x01R\aiconUrl\x88\x01\x01\x12\x15\n" +
+	"\x03bio\x18\x04 \x01(\tH\x02R\x03bio\x88\x01\x01B\a\n" +
+	"\x05_nameB\v\n" +
+	"\t_icon_urlB\x06\n" +
+	"\x04_bio\"\x98\x02\n" +
+	"\n" +
+	"QuotaUsage\x12\x14\n" +
+	"\x05scope\x18\x01 \x01(\tR\x05scope\x12\x1d\n" +
+	"\n" +
+	"subject_id\x18\x02 \x01(\tR\tsubjectId\x12\x16\n" +
+	"\x06window\x18\x03 \x01(\tR\x06window\x12\x16\n" +
+	"\x06period\x18\x04 \x01(\tR\x06period\x12\x14\n" +
+	"\x05calls\x18\x05 \x01(\x03R\x05calls\x12\x1d\n" +
+	"\n" +
+	"call_limit\x18\x06 \x01(\x03R\tcallLimit\x12\x16\n" +
+	"\x06tokens\x18\a \x01(\x03R\x06tokens\x12\x1f\n" +
+	"\vtoken_limit\x18\b \x01(\x03R\n" +
+	"tokenLimit\x127\n" +
+	"\tresets_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\bresetsAt\"a\n" +
+	"\x14GetQuotaUsageRequest\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tcircle_id\x18\x03 \x01(\tR\bcircleIdJ\x04\b\x01\x10\x02R\radmin_user_id\"E\n" +
+	"\x15GetQuotaUsageResponse\x12,\n" +
+	"\x06usages\x18\x01 \x03(\v2\x14.ptera.v1.QuotaUsageR\x06usages\"{\n" +
+	"\x16ResetQuotaUsageRequest\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tcircle_id\x18\x03 \x01(\tR\bcircleId\x12\x16\n" +
+	"\x06window\x18\x04 \x01(\tR\x06windowJ\x04\b\x01\x10\x02R\radmin_user_id2\xf1\b\n" +
 	"\fPteraService\x12M\n" +
-	"\fCompleteCard\x12\x1d.ptera.v1.CompleteCardRequest\x1a\x1e.ptera.v1.CompleteCardResponse2\xd4\x03\n" +
+	"\fCompleteCard\x12\x1d.ptera.v1.CompleteCardRequest\x1a\x1e.ptera.v1.CompleteCardResponse\x12[\n" +
+	"\x12StreamCompleteCard\x12\x1d.ptera.v1.CompleteCardRequest\x1a$.ptera.v1.StreamCompleteCardResponse0\x01\x12P\n" +
+	"\rDetectMembers\x12\x1e.ptera.v1.DetectMembersRequest\x1a\x1f.ptera.v1.DetectMembersResponse\x12V\n" +
+	"\x0fRegenerateField\x12 .ptera.v1.RegenerateFieldRequest\x1a!.ptera.v1.RegenerateFieldResponse\x12X\n" +
+	"\x0fUploadCardImage\x12 .ptera.v1.UploadCardImageRequest\x1a!.ptera.v1.UploadCardImageResponse(\x01\x129\n" +
+	"\n" +
+	"CreateCard\x12\x1b.ptera.v1.CreateCardRequest\x1a\x0e.ptera.v1.Card\x123\n" +
+	"\aGetCard\x12\x18.ptera.v1.GetCardRequest\x1a\x0e.ptera.v1.Card\x129\n" +
+	"\n" +
+	"UpdateCard\x12\x1b.ptera.v1.UpdateCardRequest\x1a\x0e.ptera.v1.Card\x12G\n" +
+	"\n" +
+	"DeleteCard\x12\x1b.ptera.v1.DeleteCardRequest\x1a\x1c.ptera.v1.DeleteCardResponse\x12D\n" +
+	"\tListCards\x12\x1a.ptera.v1.ListCardsRequest\x1a\x1b.ptera.v1.ListCardsResponse\x12C\n" +
+	"\x0fSetFavoriteCard\x12 .ptera.v1.SetFavoriteCardRequest\x1a\x0e.ptera.v1.User\x12\\\n" +
+	"\x11ListFavoriteCards\x12\".ptera.v1.ListFavoriteCardsRequest\x1a#.ptera.v1.ListFavoriteCardsResponse\x12G\n" +
+	"\n" +
+	"RenderCard\x12\x1b.ptera.v1.RenderCardRequest\x1a\x1c.ptera.v1.RenderCardResponse\x12J\n" +
+	"\vIssueCardQR\x12\x1c.ptera.v1.IssueCardQRRequest\x1a\x1d.ptera.v1.IssueCardQRResponse\x12?\n" +
+	"\rResolveCardQR\x12\x1e.ptera.v1.ResolveCardQRRequest\x1a\x0e.ptera.v1.Card2\xd4\x03\n" +
 	"\rBattleService\x12J\n" +
 	"\vStartBattle\x12\x1c.ptera.v1.StartBattleRequest\x1a\x1d.ptera.v1.StartBattleResponse\x12;\n" +
 	"\x06Attack\x12\x17.ptera.v1.AttackRequest\x1a\x18.ptera.v1.AttackResponse\x12>\n" +
 	"\aRetreat\x12\x18.ptera.v1.RetreatRequest\x1a\x19.ptera.v1.RetreatResponse\x12P\n" +
 	"\x11SendBattleRequest\x12\".ptera.v1.SendBattleRequestRequest\x1a\x17.ptera.v1.BattleRequest\x12R\n" +
 	"\x13AcceptBattleRequest\x12$.ptera.v1.AcceptBattleRequestRequest\x1a\x15.ptera.v1.BattleState\x12T\n" +
-	"\x13RejectBattleRequest\x12$.ptera.v1.RejectBattleRequestRequest\x1a\x17.ptera.v1.BattleRequestBAZ?github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1;pterab\x06proto3"
+	"\x13RejectBattleRequest\x12$.ptera.v1.RejectBattleRequestRequest\x1a\x17.ptera.v1.BattleRequest2E\n" +
+	"\fGachaService\x125\n" +
+	"\x04Pull\x12\x15.ptera.v1.PullRequest\x1a\x16.ptera.v1.PullResponse2\xed\x01\n" +
+	"\x0fFairnessService\x12C\n" +
+	"\n" +
+	"CommitSeed\x12\x1b.ptera.v1.CommitSeedRequest\x1a\x18.ptera.v1.SeedCommitment\x12I\n" +
+	"\rGetCommitment\x12\x1e.ptera.v1.GetCommitmentRequest\x1a\x18.ptera.v1.SeedCommitment\x12J\n" +
+	"\vVerifyRolls\x12\x1c.ptera.v1.VerifyRollsRequest\x1a\x1d.ptera.v1.VerifyRollsResponse2\x88\x02\n" +
+	"\fTradeService\x12>\n" +
+	"\fProposeTrade\x12\x1d.ptera.v1.ProposeTradeRequest\x1a\x0f.ptera.v1.Trade\x12<\n" +
+	"\vAcceptTrade\x12\x1c.ptera.v1.AcceptTradeRequest\x1a\x0f.ptera.v1.Trade\x12<\n" +
+	"\vRejectTrade\x12\x1c.ptera.v1.RejectTradeRequest\x1a\x0f.ptera.v1.Trade\x12<\n" +
+	"\vCancelTrade\x12\x1c.ptera.v1.CancelTradeRequest\x1a\x0f.ptera.v1.Trade2\xb1\x03\n" +
+	"\rCircleService\x12?\n" +
+	"\fCreateCircle\x12\x1d.ptera.v1.CreateCircleRequest\x1a\x10.ptera.v1.Circle\x129\n" +
+	"\tGetCircle\x12\x1a.ptera.v1.GetCircleRequest\x1a\x10.ptera.v1.Circle\x12O\n" +
+	"\x12GenerateInviteCode\x12#.ptera.v1.GenerateInviteCodeRequest\x1a\x14.ptera.v1.InviteCode\x12;\n" +
+	"\n" +
+	"JoinCircle\x12\x1b.ptera.v1.JoinCircleRequest\x1a\x10.ptera.v1.Circle\x12J\n" +
+	"\vLeaveCircle\x12\x1c.ptera.v1.LeaveCircleRequest\x1a\x1d.ptera.v1.LeaveCircleResponse\x12J\n" +
+	"\vListMembers\x12\x1c.ptera.v1.ListMembersRequest\x1a\x1d.ptera.v1.ListMembersResponse2\xb4\x01\n" +
+	"\vUserService\x12/\n" +
+	"\x05GetMe\x12\x16.ptera.v1.GetMeRequest\x1a\x0e.ptera.v1.User\x123\n" +
+	"\aGetUser\x12\x18.ptera.v1.GetUserRequest\x1a\x0e.ptera.v1.User\x12?\n" +
+	"\rUpdateProfile\x12\x1e.ptera.v1.UpdateProfileRequest\x1a\x0e.ptera.v1.User2\xb6\x01\n" +
+	"\fQuotaService\x12P\n" +
+	"\rGetQuotaUsage\x12\x1e.ptera.v1.GetQuotaUsageRequest\x1a\x1f.ptera.v1.GetQuotaUsageResponse\x12T\n" +
+	"\x0fResetQuotaUsage\x12 .ptera.v1.ResetQuotaUsageRequest\x1a\x1f.ptera.v1.GetQuotaUsageResponseBAZ?github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1;pterab\x06proto3"
 
 var (
 	file_ptera_v1_ptera_proto_rawDescOnce sync.Once
@@ -1370,55 +5946,221 @@ func file_ptera_v1_ptera_proto_rawDescGZIP() []byte {
 	return file_ptera_v1_ptera_proto_rawDescData
 }
 
-var file_ptera_v1_ptera_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_ptera_v1_ptera_proto_msgTypes = make([]protoimpl.MessageInfo, 80)
 var file_ptera_v1_ptera_proto_goTypes = []any{
 	(*User)(nil),                       // 0: ptera.v1.User
 	(*Card)(nil),                       // 1: ptera.v1.Card
-	(*Circle)(nil),                     // 2: ptera.v1.Circle
-	(*CompleteCardRequest)(nil),        // 3: ptera.v1.CompleteCardRequest
-	(*CompleteCardResponse)(nil),       // 4: ptera.v1.CompleteCardResponse
-	(*BattleState)(nil),                // 5: ptera.v1.BattleState
-	(*Player)(nil),                     // 6: ptera.v1.Player
-	(*StartBattleRequest)(nil),         // 7: ptera.v1.StartBattleRequest
-	(*StartBattleResponse)(nil),        // 8: ptera.v1.StartBattleResponse
-	(*AttackRequest)(nil),              // 9: ptera.v1.AttackRequest
-	(*AttackResponse)(nil),             // 10: ptera.v1.AttackResponse
-	(*RetreatRequest)(nil),             // 11: ptera.v1.RetreatRequest
-	(*RetreatResponse)(nil),            // 12: ptera.v1.RetreatResponse
-	(*BattleRequest)(nil),              // 13: ptera.v1.BattleRequest
-	(*SendBattleRequestRequest)(nil),   // 14: ptera.v1.SendBattleRequestRequest
-	(*AcceptBattleRequestRequest)(nil), // 15: ptera.v1.AcceptBattleRequestRequest
-	(*RejectBattleRequestRequest)(nil), // 16: ptera.v1.RejectBattleRequestRequest
-	(*timestamppb.Timestamp)(nil),      // 17: google.protobuf.Timestamp
+	(*StatProfile)(nil),                // 2: ptera.v1.StatProfile
+	(*Circle)(nil),                     // 3: ptera.v1.Circle
+	(*CompleteCardRequest)(nil),        // 4: ptera.v1.CompleteCardRequest
+	(*CompleteCardResponse)(nil),       // 5: ptera.v1.CompleteCardResponse
+	(*CardFieldUpdate)(nil),            // 6: ptera.v1.CardFieldUpdate
+	(*StreamCompleteCardResponse)(nil), // 7: ptera.v1.StreamCompleteCardResponse
+	(*ModerationReason)(nil),           // 8: ptera.v1.ModerationReason
+	(*DetectMembersRequest)(nil),       // 9: ptera.v1.DetectMembersRequest
+	(*BoundingBox)(nil),                // 10: ptera.v1.BoundingBox
+	(*MemberDraft)(nil),                // 11: ptera.v1.MemberDraft
+	(*DetectMembersResponse)(nil),      // 12: ptera.v1.DetectMembersResponse
+	(*CardFields)(nil),                 // 13: ptera.v1.CardFields
+	(*RegenerateFieldRequest)(nil),     // 14: ptera.v1.RegenerateFieldRequest
+	(*RegenerateFieldResponse)(nil),    // 15: ptera.v1.RegenerateFieldResponse
+	(*CreateCardRequest)(nil),          // 16: ptera.v1.CreateCardRequest
+	(*GetCardRequest)(nil),             // 17: ptera.v1.GetCardRequest
+	(*UpdateCardRequest)(nil),          // 18: ptera.v1.UpdateCardRequest
+	(*DeleteCardRequest)(nil),          // 19: ptera.v1.DeleteCardRequest
+	(*DeleteCardResponse)(nil),         // 20: ptera.v1.DeleteCardResponse
+	(*CardFilter)(nil),                 // 21: ptera.v1.CardFilter
+	(*ListCardsRequest)(nil),           // 22: ptera.v1.ListCardsRequest
+	(*ListCardsResponse)(nil),          // 23: ptera.v1.ListCardsResponse
+	(*SetFavoriteCardRequest)(nil),     // 24: ptera.v1.SetFavoriteCardRequest
+	(*ListFavoriteCardsRequest)(nil),   // 25: ptera.v1.ListFavoriteCardsRequest
+	(*ListFavoriteCardsResponse)(nil),  // 26: ptera.v1.ListFavoriteCardsResponse
+	(*RenderCardRequest)(nil),          // 27: ptera.v1.RenderCardRequest
+	(*RenderCardResponse)(nil),         // 28: ptera.v1.RenderCardResponse
+	(*IssueCardQRRequest)(nil),         // 29: ptera.v1.IssueCardQRRequest
+	(*IssueCardQRResponse)(nil),        // 30: ptera.v1.IssueCardQRResponse
+	(*ResolveCardQRRequest)(nil),       // 31: ptera.v1.ResolveCardQRRequest
+	(*ImageUploadMetadata)(nil),        // 32: ptera.v1.ImageUploadMetadata
+	(*UploadCardImageRequest)(nil),     // 33: ptera.v1.UploadCardImageRequest
+	(*UploadCardImageResponse)(nil),    // 34: ptera.v1.UploadCardImageResponse
+	(*BattleState)(nil),                // 35: ptera.v1.BattleState
+	(*BattleCommentary)(nil),           // 36: ptera.v1.BattleCommentary
+	(*Player)(nil),                     // 37: ptera.v1.Player
+	(*StartBattleRequest)(nil),         // 38: ptera.v1.StartBattleRequest
+	(*StartBattleResponse)(nil),        // 39: ptera.v1.StartBattleResponse
+	(*AttackRequest)(nil),              // 40: ptera.v1.AttackRequest
+	(*AttackResponse)(nil),             // 41: ptera.v1.AttackResponse
+	(*RetreatRequest)(nil),             // 42: ptera.v1.RetreatRequest
+	(*RetreatResponse)(nil),            // 43: ptera.v1.RetreatResponse
+	(*BattleRequest)(nil),              // 44: ptera.v1.BattleRequest
+	(*SendBattleRequestRequest)(nil),   // 45: ptera.v1.SendBattleRequestRequest
+	(*AcceptBattleRequestRequest)(nil), // 46: ptera.v1.AcceptBattleRequestRequest
+	(*RejectBattleRequestRequest)(nil), // 47: ptera.v1.RejectBattleRequestRequest
+	(*PullRequest)(nil),                // 48: ptera.v1.PullRequest
+	(*PullResult)(nil),                 // 49: ptera.v1.PullResult
+	(*PullResponse)(nil),               // 50: ptera.v1.PullResponse
+	(*FairnessInfo)(nil),               // 51: ptera.v1.FairnessInfo
+	(*DamageRoll)(nil),                 // 52: ptera.v1.DamageRoll
+	(*SeedCommitment)(nil),             // 53: ptera.v1.SeedCommitment
+	(*CommitSeedRequest)(nil),          // 54: ptera.v1.CommitSeedRequest
+	(*GetCommitmentRequest)(nil),       // 55: ptera.v1.GetCommitmentRequest
+	(*VerifyRollsRequest)(nil),         // 56: ptera.v1.VerifyRollsRequest
+	(*VerifiedRoll)(nil),               // 57: ptera.v1.VerifiedRoll
+	(*VerifyRollsResponse)(nil),        // 58: ptera.v1.VerifyRollsResponse
+	(*Trade)(nil),                      // 59: ptera.v1.Trade
+	(*ProposeTradeRequest)(nil),        // 60: ptera.v1.ProposeTradeRequest
+	(*AcceptTradeRequest)(nil),         // 61: ptera.v1.AcceptTradeRequest
+	(*RejectTradeRequest)(nil),         // 62: ptera.v1.RejectTradeRequest
+	(*CancelTradeRequest)(nil),         // 63: ptera.v1.CancelTradeRequest
+	(*InviteCode)(nil),                 // 64: ptera.v1.InviteCode
+	(*CreateCircleRequest)(nil),        // 65: ptera.v1.CreateCircleRequest
+	(*GetCircleRequest)(nil),           // 66: ptera.v1.GetCircleRequest
+	(*GenerateInviteCodeRequest)(nil),  // 67: ptera.v1.GenerateInviteCodeRequest
+	(*JoinCircleRequest)(nil),          // 68: ptera.v1.JoinCircleRequest
+	(*LeaveCircleRequest)(nil),         // 69: ptera.v1.LeaveCircleRequest
+	(*LeaveCircleResponse)(nil),        // 70: ptera.v1.LeaveCircleResponse
+	(*ListMembersRequest)(nil),         // 71: ptera.v1.ListMembersRequest
+	(*ListMembersResponse)(nil),        // 72: ptera.v1.ListMembersResponse
+	(*GetMeRequest)(nil),               // 73: ptera.v1.GetMeRequest
+	(*GetUserRequest)(nil),             // 74: ptera.v1.GetUserRequest
+	(*UpdateProfileRequest)(nil),       // 75: ptera.v1.UpdateProfileRequest
+	(*QuotaUsage)(nil),                 // 76: ptera.v1.QuotaUsage
+	(*GetQuotaUsageRequest)(nil),       // 77: ptera.v1.GetQuotaUsageRequest
+	(*GetQuotaUsageResponse)(nil),      // 78: ptera.v1.GetQuotaUsageResponse
+	(*ResetQuotaUsageRequest)(nil),     // 79: ptera.v1.ResetQuotaUsageRequest
+	(*timestamppb.Timestamp)(nil),      // 80: google.protobuf.Timestamp
 }
 var file_ptera_v1_ptera_proto_depIdxs = []int32{
-	17, // 0: ptera.v1.Card.created_at:type_name -> google.protobuf.Timestamp
-	6,  // 1: ptera.v1.BattleState.player_me:type_name -> ptera.v1.Player
-	6,  // 2: ptera.v1.BattleState.player_opponent:type_name -> ptera.v1.Player
-	1,  // 3: ptera.v1.Player.deck:type_name -> ptera.v1.Card
-	5,  // 4: ptera.v1.StartBattleResponse.battle_state:type_name -> ptera.v1.BattleState
-	5,  // 5: ptera.v1.AttackResponse.battle_state:type_name -> ptera.v1.BattleState
-	5,  // 6: ptera.v1.RetreatResponse.battle_state:type_name -> ptera.v1.BattleState
-	17, // 7: ptera.v1.BattleRequest.created_at:type_name -> google.protobuf.Timestamp
-	3,  // 8: ptera.v1.PteraService.CompleteCard:input_type -> ptera.v1.CompleteCardRequest
-	7,  // 9: ptera.v1.BattleService.StartBattle:input_type -> ptera.v1.StartBattleRequest
-	9,  // 10: ptera.v1.BattleService.Attack:input_type -> ptera.v1.AttackRequest
-	11, // 11: ptera.v1.BattleService.Retreat:input_type -> ptera.v1.RetreatRequest
-	14, // 12: ptera.v1.BattleService.SendBattleRequest:input_type -> ptera.v1.SendBattleRequestRequest
-	15, // 13: ptera.v1.BattleService.AcceptBattleRequest:input_type -> ptera.v1.AcceptBattleRequestRequest
-	16, // 14: ptera.v1.BattleService.RejectBattleRequest:input_type -> ptera.v1.RejectBattleRequestRequest
-	4,  // 15: ptera.v1.PteraService.CompleteCard:output_type -> ptera.v1.CompleteCardResponse
-	8,  // 16: ptera.v1.BattleService.StartBattle:output_type -> ptera.v1.StartBattleResponse
-	10, // 17: ptera.v1.BattleService.Attack:output_type -> ptera.v1.AttackResponse
-	12, // 18: ptera.v1.BattleService.Retreat:output_type -> ptera.v1.RetreatResponse
-	13, // 19: ptera.v1.BattleService.SendBattleRequest:output_type -> ptera.v1.BattleRequest
-	5,  // 20: ptera.v1.BattleService.AcceptBattleRequest:output_type -> ptera.v1.BattleState
-	13, // 21: ptera.v1.BattleService.RejectBattleRequest:output_type -> ptera.v1.BattleRequest
-	15, // [15:22] is the sub-list for method output_type
-	8,  // [8:15] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	80, // 0: ptera.v1.Card.created_at:type_name -> google.protobuf.Timestamp
+	80, // 1: ptera.v1.Card.expiry_date:type_name -> google.protobuf.Timestamp
+	2,  // 2: ptera.v1.Card.stat_profile:type_name -> ptera.v1.StatProfile
+	80, // 3: ptera.v1.Circle.created_at:type_name -> google.protobuf.Timestamp
+	80, // 4: ptera.v1.Circle.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 5: ptera.v1.CompleteCardResponse.stat_profile:type_name -> ptera.v1.StatProfile
+	8,  // 6: ptera.v1.CompleteCardResponse.moderation_reasons:type_name -> ptera.v1.ModerationReason
+	6,  // 7: ptera.v1.StreamCompleteCardResponse.field:type_name -> ptera.v1.CardFieldUpdate
+	5,  // 8: ptera.v1.StreamCompleteCardResponse.result:type_name -> ptera.v1.CompleteCardResponse
+	10, // 9: ptera.v1.MemberDraft.box:type_name -> ptera.v1.BoundingBox
+	5,  // 10: ptera.v1.MemberDraft.draft:type_name -> ptera.v1.CompleteCardResponse
+	11, // 11: ptera.v1.DetectMembersResponse.members:type_name -> ptera.v1.MemberDraft
+	13, // 12: ptera.v1.RegenerateFieldRequest.fields:type_name -> ptera.v1.CardFields
+	8,  // 13: ptera.v1.RegenerateFieldResponse.moderation_reasons:type_name -> ptera.v1.ModerationReason
+	1,  // 14: ptera.v1.CreateCardRequest.card:type_name -> ptera.v1.Card
+	1,  // 15: ptera.v1.UpdateCardRequest.card:type_name -> ptera.v1.Card
+	21, // 16: ptera.v1.ListCardsRequest.filter:type_name -> ptera.v1.CardFilter
+	1,  // 17: ptera.v1.ListCardsResponse.cards:type_name -> ptera.v1.Card
+	1,  // 18: ptera.v1.ListFavoriteCardsResponse.cards:type_name -> ptera.v1.Card
+	80, // 19: ptera.v1.IssueCardQRResponse.expires_at:type_name -> google.protobuf.Timestamp
+	32, // 20: ptera.v1.UploadCardImageRequest.metadata:type_name -> ptera.v1.ImageUploadMetadata
+	37, // 21: ptera.v1.BattleState.player_me:type_name -> ptera.v1.Player
+	37, // 22: ptera.v1.BattleState.player_opponent:type_name -> ptera.v1.Player
+	51, // 23: ptera.v1.BattleState.fairness:type_name -> ptera.v1.FairnessInfo
+	52, // 24: ptera.v1.BattleState.damage_rolls:type_name -> ptera.v1.DamageRoll
+	36, // 25: ptera.v1.BattleState.commentary:type_name -> ptera.v1.BattleCommentary
+	1,  // 26: ptera.v1.Player.deck:type_name -> ptera.v1.Card
+	35, // 27: ptera.v1.StartBattleResponse.battle_state:type_name -> ptera.v1.BattleState
+	35, // 28: ptera.v1.AttackResponse.battle_state:type_name -> ptera.v1.BattleState
+	35, // 29: ptera.v1.RetreatResponse.battle_state:type_name -> ptera.v1.BattleState
+	80, // 30: ptera.v1.BattleRequest.created_at:type_name -> google.protobuf.Timestamp
+	1,  // 31: ptera.v1.PullResult.card:type_name -> ptera.v1.Card
+	49, // 32: ptera.v1.PullResponse.results:type_name -> ptera.v1.PullResult
+	53, // 33: ptera.v1.PullResponse.commitment:type_name -> ptera.v1.SeedCommitment
+	80, // 34: ptera.v1.SeedCommitment.created_at:type_name -> google.protobuf.Timestamp
+	80, // 35: ptera.v1.SeedCommitment.revealed_at:type_name -> google.protobuf.Timestamp
+	53, // 36: ptera.v1.VerifyRollsResponse.commitment:type_name -> ptera.v1.SeedCommitment
+	57, // 37: ptera.v1.VerifyRollsResponse.rolls:type_name -> ptera.v1.VerifiedRoll
+	80, // 38: ptera.v1.Trade.created_at:type_name -> google.protobuf.Timestamp
+	80, // 39: ptera.v1.Trade.expires_at:type_name -> google.protobuf.Timestamp
+	80, // 40: ptera.v1.Trade.updated_at:type_name -> google.protobuf.Timestamp
+	80, // 41: ptera.v1.InviteCode.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 42: ptera.v1.ListMembersResponse.members:type_name -> ptera.v1.User
+	80, // 43: ptera.v1.QuotaUsage.resets_at:type_name -> google.protobuf.Timestamp
+	76, // 44: ptera.v1.GetQuotaUsageResponse.usages:type_name -> ptera.v1.QuotaUsage
+	4,  // 45: ptera.v1.PteraService.CompleteCard:input_type -> ptera.v1.CompleteCardRequest
+	4,  // 46: ptera.v1.PteraService.StreamCompleteCard:input_type -> ptera.v1.CompleteCardRequest
+	9,  // 47: ptera.v1.PteraService.DetectMembers:input_type -> ptera.v1.DetectMembersRequest
+	14, // 48: ptera.v1.PteraService.RegenerateField:input_type -> ptera.v1.RegenerateFieldRequest
+	33, // 49: ptera.v1.PteraService.UploadCardImage:input_type -> ptera.v1.UploadCardImageRequest
+	16, // 50: ptera.v1.PteraService.CreateCard:input_type -> ptera.v1.CreateCardRequest
+	17, // 51: ptera.v1.PteraService.GetCard:input_type -> ptera.v1.GetCardRequest
+	18, // 52: ptera.v1.PteraService.UpdateCard:input_type -> ptera.v1.UpdateCardRequest
+	19, // 53: ptera.v1.PteraService.DeleteCard:input_type -> ptera.v1.DeleteCardRequest
+	22, // 54: ptera.v1.PteraService.ListCards:input_type -> ptera.v1.ListCardsRequest
+	24, // 55: ptera.v1.PteraService.SetFavoriteCard:input_type -> ptera.v1.SetFavoriteCardRequest
+	25, // 56: ptera.v1.PteraService.ListFavoriteCards:input_type -> ptera.v1.ListFavoriteCardsRequest
+	27, // 57: ptera.v1.PteraService.RenderCard:input_type -> ptera.v1.RenderCardRequest
+	29, // 58: ptera.v1.PteraService.IssueCardQR:input_type -> ptera.v1.IssueCardQRRequest
+	31, // 59: ptera.v1.PteraService.ResolveCardQR:input_type -> ptera.v1.ResolveCardQRRequest
+	38, // 60: ptera.v1.BattleService.StartBattle:input_type -> ptera.v1.StartBattleRequest
+	40, // 61: ptera.v1.BattleService.Attack:input_type -> ptera.v1.AttackRequest
+	42, // 62: ptera.v1.BattleService.Retreat:input_type -> ptera.v1.RetreatRequest
+	45, // 63: ptera.v1.BattleService.SendBattleRequest:input_type -> ptera.v1.SendBattleRequestRequest
+	46, // 64: ptera.v1.BattleService.AcceptBattleRequest:input_type -> ptera.v1.AcceptBattleRequestRequest
+	47, // 65: ptera.v1.BattleService.RejectBattleRequest:input_type -> ptera.v1.RejectBattleRequestRequest
+	48, // 66: ptera.v1.GachaService.Pull:input_type -> ptera.v1.PullRequest
+	54, // 67: ptera.v1.FairnessService.CommitSeed:input_type -> ptera.v1.CommitSeedRequest
+	55, // 68: ptera.v1.FairnessService.GetCommitment:input_type -> ptera.v1.GetCommitmentRequest
+	56, // 69: ptera.v1.FairnessService.VerifyRolls:input_type -> ptera.v1.VerifyRollsRequest
+	60, // 70: ptera.v1.TradeService.ProposeTrade:input_type -> ptera.v1.ProposeTradeRequest
+	61, // 71: ptera.v1.TradeService.AcceptTrade:input_type -> ptera.v1.AcceptTradeRequest
+	62, // 72: ptera.v1.TradeService.RejectTrade:input_type -> ptera.v1.RejectTradeRequest
+	63, // 73: ptera.v1.TradeService.CancelTrade:input_type -> ptera.v1.CancelTradeRequest
+	65, // 74: ptera.v1.CircleService.CreateCircle:input_type -> ptera.v1.CreateCircleRequest
+	66, // 75: ptera.v1.CircleService.GetCircle:input_type -> ptera.v1.GetCircleRequest
+	67, // 76: ptera.v1.CircleService.GenerateInviteCode:input_type -> ptera.v1.GenerateInviteCodeRequest
+	68, // 77: ptera.v1.CircleService.JoinCircle:input_type -> ptera.v1.JoinCircleRequest
+	69, // 78: ptera.v1.CircleService.LeaveCircle:input_type -> ptera.v1.LeaveCircleRequest
+	71, // 79: ptera.v1.CircleService.ListMembers:input_type -> ptera.v1.ListMembersRequest
+	73, // 80: ptera.v1.UserService.GetMe:input_type -> ptera.v1.GetMeRequest
+	74, // 81: ptera.v1.UserService.GetUser:input_type -> ptera.v1.GetUserRequest
+	75, // 82: ptera.v1.UserService.UpdateProfile:input_type -> ptera.v1.UpdateProfileRequest
+	77, // 83: ptera.v1.QuotaService.GetQuotaUsage:input_type -> ptera.v1.GetQuotaUsageRequest
+	79, // 84: ptera.v1.QuotaService.ResetQuotaUsage:input_type -> ptera.v1.ResetQuotaUsageRequest
+	5,  // 85: ptera.v1.PteraService.CompleteCard:output_type -> ptera.v1.CompleteCardResponse
+	7,  // 86: ptera.v1.PteraService.StreamCompleteCard:output_type -> ptera.v1.StreamCompleteCardResponse
+	12, // 87: ptera.v1.PteraService.DetectMembers:output_type -> ptera.v1.DetectMembersResponse
+	15, // 88: ptera.v1.PteraService.RegenerateField:output_type -> ptera.v1.RegenerateFieldResponse
+	34, // 89: ptera.v1.PteraService.UploadCardImage:output_type -> ptera.v1.UploadCardImageResponse
+	1,  // 90: ptera.v1.PteraService.CreateCard:output_type -> ptera.v1.Card
+	1,  // 91: ptera.v1.PteraService.GetCard:output_type -> ptera.v1.Card
+	1,  // 92: ptera.v1.PteraService.UpdateCard:output_type -> ptera.v1.Card
+	20, // 93: ptera.v1.PteraService.DeleteCard:output_type -> ptera.v1.DeleteCardResponse
+	23, // 94: ptera.v1.PteraService.ListCards:output_type -> ptera.v1.ListCardsResponse
+	0,  // 95: ptera.v1.PteraService.SetFavoriteCard:output_type -> ptera.v1.User
+	26, // 96: ptera.v1.PteraService.ListFavoriteCards:output_type -> ptera.v1.ListFavoriteCardsResponse
+	28, // 97: ptera.v1.PteraService.RenderCard:output_type -> ptera.v1.RenderCardResponse
+	30, // 98: ptera.v1.PteraService.IssueCardQR:output_type -> ptera.v1.IssueCardQRResponse
+	1,  // 99: ptera.v1.PteraService.ResolveCardQR:output_type -> ptera.v1.Card
+	39, // 100: ptera.v1.BattleService.StartBattle:output_type -> ptera.v1.StartBattleResponse
+	41, // 101: ptera.v1.BattleService.Attack:output_type -> ptera.v1.AttackResponse
+	43, // 102: ptera.v1.BattleService.Retreat:output_type -> ptera.v1.RetreatResponse
+	44, // 103: ptera.v1.BattleService.SendBattleRequest:output_type -> ptera.v1.BattleRequest
+	35, // 104: ptera.v1.BattleService.AcceptBattleRequest:output_type -> ptera.v1.BattleState
+	44, // 105: ptera.v1.BattleService.RejectBattleRequest:output_type -> ptera.v1.BattleRequest
+	50, // 106: ptera.v1.GachaService.Pull:output_type -> ptera.v1.PullResponse
+	53, // 107: ptera.v1.FairnessService.CommitSeed:output_type -> ptera.v1.SeedCommitment
+	53, // 108: ptera.v1.FairnessService.GetCommitment:output_type -> ptera.v1.SeedCommitment
+	58, // 109: ptera.v1.FairnessService.VerifyRolls:output_type -> ptera.v1.VerifyRollsResponse
+	59, // 110: ptera.v1.TradeService.ProposeTrade:output_type -> ptera.v1.Trade
+	59, // 111: ptera.v1.TradeService.AcceptTrade:output_type -> ptera.v1.Trade
+	59, // 112: ptera.v1.TradeService.RejectTrade:output_type -> ptera.v1.Trade
+	59, // 113: ptera.v1.TradeService.CancelTrade:output_type -> ptera.v1.Trade
+	3,  // 114: ptera.v1.CircleService.CreateCircle:output_type -> ptera.v1.Circle
+	3,  // 115: ptera.v1.CircleService.GetCircle:output_type -> ptera.v1.Circle
+	64, // 116: ptera.v1.CircleService.GenerateInviteCode:output_type -> ptera.v1.InviteCode
+	3,  // 117: ptera.v1.CircleService.JoinCircle:output_type -> ptera.v1.Circle
+	70, // 118: ptera.v1.CircleService.LeaveCircle:output_type -> ptera.v1.LeaveCircleResponse
+	72, // 119: ptera.v1.CircleService.ListMembers:output_type -> ptera.v1.ListMembersResponse
+	0,  // 120: ptera.v1.UserService.GetMe:output_type -> ptera.v1.User
+	0,  // 121: ptera.v1.UserService.GetUser:output_type -> ptera.v1.User
+	0,  // 122: ptera.v1.UserService.UpdateProfile:output_type -> ptera.v1.User
+	78, // 123: ptera.v1.QuotaService.GetQuotaUsage:output_type -> ptera.v1.GetQuotaUsageResponse
+	78, // 124: ptera.v1.QuotaService.ResetQuotaUsage:output_type -> ptera.v1.GetQuotaUsageResponse
+	85, // [85:125] is the sub-list for method output_type
+	45, // [45:85] is the sub-list for method input_type
+	45, // [45:45] is the sub-list for extension type_name
+	45, // [45:45] is the sub-list for extension extendee
+	0,  // [0:45] is the sub-list for field type_name
 }
 
 func init() { file_ptera_v1_ptera_proto_init() }
@@ -1428,18 +6170,35 @@ func file_ptera_v1_ptera_proto_init() {
 	}
 	file_ptera_v1_ptera_proto_msgTypes[0].OneofWrappers = []any{}
 	file_ptera_v1_ptera_proto_msgTypes[1].OneofWrappers = []any{}
-	file_ptera_v1_ptera_proto_msgTypes[3].OneofWrappers = []any{}
 	file_ptera_v1_ptera_proto_msgTypes[4].OneofWrappers = []any{}
-	file_ptera_v1_ptera_proto_msgTypes[13].OneofWrappers = []any{}
+	file_ptera_v1_ptera_proto_msgTypes[5].OneofWrappers = []any{}
+	file_ptera_v1_ptera_proto_msgTypes[7].OneofWrappers = []any{
+		(*StreamCompleteCardResponse_Field)(nil),
+		(*StreamCompleteCardResponse_Result)(nil),
+	}
+	file_ptera_v1_ptera_proto_msgTypes[9].OneofWrappers = []any{}
+	file_ptera_v1_ptera_proto_msgTypes[12].OneofWrappers = []any{}
+	file_ptera_v1_ptera_proto_msgTypes[15].OneofWrappers = []any{}
+	file_ptera_v1_ptera_proto_msgTypes[21].OneofWrappers = []any{}
+	file_ptera_v1_ptera_proto_msgTypes[33].OneofWrappers = []any{
+		(*UploadCardImageRequest_Metadata)(nil),
+		(*UploadCardImageRequest_Chunk)(nil),
+	}
+	file_ptera_v1_ptera_proto_msgTypes[38].OneofWrappers = []any{}
+	file_ptera_v1_ptera_proto_msgTypes[44].OneofWrappers = []any{}
+	file_ptera_v1_ptera_proto_msgTypes[48].OneofWrappers = []any{}
+	file_ptera_v1_ptera_proto_msgTypes[53].OneofWrappers = []any{}
+	file_ptera_v1_ptera_proto_msgTypes[58].OneofWrappers = []any{}
+	file_ptera_v1_ptera_proto_msgTypes[75].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ptera_v1_ptera_proto_rawDesc), len(file_ptera_v1_ptera_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   80,
 			NumExtensions: 0,
-			NumServices:   2,
+			NumServices:   8,
 		},
 		GoTypes:           file_ptera_v1_ptera_proto_goTypes,
 		DependencyIndexes: file_ptera_v1_ptera_proto_depIdxs,
@@ -19,7 +19,21 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PteraService_CompleteCard_FullMethodName = "/ptera.v1.PteraService/CompleteCard"
+	PteraService_CompleteCard_FullMethodName       = "/ptera.v1.PteraService/CompleteCard"
+	PteraService_StreamCompleteCard_FullMethodName = "/ptera.v1.PteraService/StreamCompleteCard"
+	PteraService_DetectMembers_FullMethodName      = "/ptera.v1.PteraService/DetectMembers"
+	PteraService_RegenerateField_FullMethodName    = "/ptera.v1.PteraService/RegenerateField"
+	PteraService_UploadCardImage_FullMethodName    = "/ptera.v1.PteraService/UploadCardImage"
+	PteraService_CreateCard_FullMethodName         = "/ptera.v1.PteraService/CreateCard"
+	PteraService_GetCard_FullMethodName            = "/ptera.v1.PteraService/GetCard"
+	PteraService_UpdateCard_FullMethodName         = "/ptera.v1.PteraService/UpdateCard"
+	PteraService_DeleteCard_FullMethodName         = "/ptera.v1.PteraService/DeleteCard"
+	PteraService_ListCards_FullMethodName          = "/ptera.v1.PteraService/ListCards"
+	PteraService_SetFavoriteCard_FullMethodName    = "/ptera.v1.PteraService/SetFavoriteCard"
+	PteraService_ListFavoriteCards_FullMethodName  = "/ptera.v1.PteraService/ListFavoriteCards"
+	PteraService_RenderCard_FullMethodName         = "/ptera.v1.PteraService/RenderCard"
+	PteraService_IssueCardQR_FullMethodName        = "/ptera.v1.PteraService/IssueCardQR"
+	PteraService_ResolveCardQR_FullMethodName      = "/ptera.v1.PteraService/ResolveCardQR"
 )
 
 // PteraServiceClient is the client API for PteraService service.
@@ -28,7 +42,41 @@ const (
 type PteraServiceClient interface {
 	// CompleteCard は画像URLと任意の部分情報を受け取り、
 	// AIを使用してカード情報を自動補完します。
+	// AIを使うRPC (CompleteCard, StreamCompleteCard, DetectMembers, RegenerateField) は
+	// authorization メタデータに "Bearer <FirebaseのIDトークン>" が必要で、利用量はそのユーザーに集計されます。
 	CompleteCard(ctx context.Context, in *CompleteCardRequest, opts ...grpc.CallOption) (*CompleteCardResponse, error)
+	// StreamCompleteCard は CompleteCard のストリーミング版です。
+	// 生成中のフィールドを field で順次返し、最後に検証済みの result を1回返します。
+	StreamCompleteCard(ctx context.Context, in *CompleteCardRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamCompleteCardResponse], error)
+	// DetectMembers は集合写真から人物を検出し、一人ずつ切り抜いた画像と
+	// カードの下書きを返します (AI利用量は検出で1回、下書き1人ごとに1回として集計され、
+	// 上限に達した後の人物は下書きなしで返されます)。
+	DetectMembers(ctx context.Context, in *DetectMembersRequest, opts ...grpc.CallOption) (*DetectMembersResponse, error)
+	// RegenerateField は他のフィールドを固定したまま、1つのフィールドの候補をn個生成します。
+	// 編集フォームで選択肢として表示するためのものです (AI利用量は1回分として集計されます)。
+	RegenerateField(ctx context.Context, in *RegenerateFieldRequest, opts ...grpc.CallOption) (*RegenerateFieldResponse, error)
+	// UploadCardImage は画像をチャンクで受け取り保存します。
+	// 最初のメッセージで metadata を、続くメッセージで chunk を送ります。
+	UploadCardImage(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadCardImageRequest, UploadCardImageResponse], error)
+	// Card CRUD: サーバー側でバリデーション・所有者チェックを行い、
+	// circle_id と expiry_date を付与して保存します。
+	CreateCard(ctx context.Context, in *CreateCardRequest, opts ...grpc.CallOption) (*Card, error)
+	GetCard(ctx context.Context, in *GetCardRequest, opts ...grpc.CallOption) (*Card, error)
+	UpdateCard(ctx context.Context, in *UpdateCardRequest, opts ...grpc.CallOption) (*Card, error)
+	DeleteCard(ctx context.Context, in *DeleteCardRequest, opts ...grpc.CallOption) (*DeleteCardResponse, error)
+	ListCards(ctx context.Context, in *ListCardsRequest, opts ...grpc.CallOption) (*ListCardsResponse, error)
+	// 推しメン: ユーザーごとに1枚まで。推しメンのカードはバトルでデッキ確定枠と士気ボーナスを得ます。
+	SetFavoriteCard(ctx context.Context, in *SetFavoriteCardRequest, opts ...grpc.CallOption) (*User, error)
+	ListFavoriteCards(ctx context.Context, in *ListFavoriteCardsRequest, opts ...grpc.CallOption) (*ListFavoriteCardsResponse, error)
+	// RenderCard はカードを共有用のPNG画像にします (カードの内容が変わるまでキャッシュされます)。
+	// 同じ画像は HTTP の GET /cards/{card_id}.png?style= でも取得できます。
+	RenderCard(ctx context.Context, in *RenderCardRequest, opts ...grpc.CallOption) (*RenderCardResponse, error)
+	// IssueCardQR はカードを表す署名付きトークンとそのQRコードを発行します (QRメンコ用)。
+	// カードの作成者か、コレクションに持っているユーザーのみ発行できます。
+	// トークンは有効期限付きで、ResolveCardQR でのみ検証できます。
+	IssueCardQR(ctx context.Context, in *IssueCardQRRequest, opts ...grpc.CallOption) (*IssueCardQRResponse, error)
+	// ResolveCardQR は読み取ったトークンを検証し、バトルステータス付きのカードを返します。
+	ResolveCardQR(ctx context.Context, in *ResolveCardQRRequest, opts ...grpc.CallOption) (*Card, error)
 }
 
 type pteraServiceClient struct {
@@ -49,369 +97,2046 @@ func (c *pteraServiceClient) CompleteCard(ctx context.Context, in *CompleteCardR
 	return out, nil
 }
 
-// PteraServiceServer is the server API for PteraService service.
-// All implementations must embed UnimplementedPteraServiceServer
-// for forward compatibility.
-type PteraServiceServer interface {
-	// CompleteCard は画像URLと任意の部分情報を受け取り、
-	// AIを使用してカード情報を自動補完します。
-	CompleteCard(context.Context, *CompleteCardRequest) (*CompleteCardResponse, error)
-	mustEmbedUnimplementedPteraServiceServer()
+func (c *pteraServiceClient) StreamCompleteCard(ctx context.Context, in *CompleteCardRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamCompleteCardResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PteraService_ServiceDesc.Streams[0], PteraService_StreamCompleteCard_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CompleteCardRequest, StreamCompleteCardResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-// UnimplementedPteraServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedPteraServiceServer struct{}
-
-func (UnimplementedPteraServiceServer) CompleteCard(context.Context, *CompleteCardRequest) (*CompleteCardResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method CompleteCard not implemented")
-}
-func (UnimplementedPteraServiceServer) mustEmbedUnimplementedPteraServiceServer() {}
-func (UnimplementedPteraServiceServer) testEmbeddedByValue()                      {}
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PteraService_StreamCompleteCardClient = grpc.ServerStreamingClient[StreamCompleteCardResponse]
 
-// UnsafePteraServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to PteraServiceServer will
-// result in compilation errors.
-type UnsafePteraServiceServer interface {
-	mustEmbedUnimplementedPteraServiceServer()
+func (c *pteraServiceClient) DetectMembers(ctx context.Context, in *DetectMembersRequest, opts ...grpc.CallOption) (*DetectMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DetectMembersResponse)
+	err := c.cc.Invoke(ctx, PteraService_DetectMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func RegisterPteraServiceServer(s grpc.ServiceRegistrar, srv PteraServiceServer) {
-	// If the following call panics, it indicates UnimplementedPteraServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func (c *pteraServiceClient) RegenerateField(ctx context.Context, in *RegenerateFieldRequest, opts ...grpc.CallOption) (*RegenerateFieldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegenerateFieldResponse)
+	err := c.cc.Invoke(ctx, PteraService_RegenerateField_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
 	}
-	s.RegisterService(&PteraService_ServiceDesc, srv)
+	return out, nil
 }
 
-func _PteraService_CompleteCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CompleteCardRequest)
-	if err := dec(in); err != nil {
+func (c *pteraServiceClient) UploadCardImage(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadCardImageRequest, UploadCardImageResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PteraService_ServiceDesc.Streams[1], PteraService_UploadCardImage_FullMethodName, cOpts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(PteraServiceServer).CompleteCard(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: PteraService_CompleteCard_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PteraServiceServer).CompleteCard(ctx, req.(*CompleteCardRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	x := &grpc.GenericClientStream[UploadCardImageRequest, UploadCardImageResponse]{ClientStream: stream}
+	return x, nil
 }
 
-// PteraService_ServiceDesc is the grpc.ServiceDesc for PteraService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var PteraService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "ptera.v1.PteraService",
-	HandlerType: (*PteraServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CompleteCard",
-			Handler:    _PteraService_CompleteCard_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "ptera/v1/ptera.proto",
-}
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PteraService_UploadCardImageClient = grpc.ClientStreamingClient[UploadCardImageRequest, UploadCardImageResponse]
 
-const (
-	BattleService_StartBattle_FullMethodName         = "/ptera.v1.BattleService/StartBattle"
-	BattleService_Attack_FullMethodName              = "/ptera.v1.BattleService/Attack"
-	BattleService_Retreat_FullMethodName             = "/ptera.v1.BattleService/Retreat"
-	BattleService_SendBattleRequest_FullMethodName   = "/ptera.v1.BattleService/SendBattleRequest"
-	BattleService_AcceptBattleRequest_FullMethodName = "/ptera.v1.BattleService/AcceptBattleRequest"
-	BattleService_RejectBattleRequest_FullMethodName = "/ptera.v1.BattleService/RejectBattleRequest"
-)
+func (c *pteraServiceClient) CreateCard(ctx context.Context, in *CreateCardRequest, opts ...grpc.CallOption) (*Card, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Card)
+	err := c.cc.Invoke(ctx, PteraService_CreateCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-// BattleServiceClient is the client API for BattleService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type BattleServiceClient interface {
-	StartBattle(ctx context.Context, in *StartBattleRequest, opts ...grpc.CallOption) (*StartBattleResponse, error)
-	Attack(ctx context.Context, in *AttackRequest, opts ...grpc.CallOption) (*AttackResponse, error)
-	Retreat(ctx context.Context, in *RetreatRequest, opts ...grpc.CallOption) (*RetreatResponse, error)
-	// Battle Request (Matching) RPCs
-	SendBattleRequest(ctx context.Context, in *SendBattleRequestRequest, opts ...grpc.CallOption) (*BattleRequest, error)
-	AcceptBattleRequest(ctx context.Context, in *AcceptBattleRequestRequest, opts ...grpc.CallOption) (*BattleState, error)
-	RejectBattleRequest(ctx context.Context, in *RejectBattleRequestRequest, opts ...grpc.CallOption) (*BattleRequest, error)
+func (c *pteraServiceClient) GetCard(ctx context.Context, in *GetCardRequest, opts ...grpc.CallOption) (*Card, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Card)
+	err := c.cc.Invoke(ctx, PteraService_GetCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type battleServiceClient struct {
-	cc grpc.ClientConnInterface
+func (c *pteraServiceClient) UpdateCard(ctx context.Context, in *UpdateCardRequest, opts ...grpc.CallOption) (*Card, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Card)
+	err := c.cc.Invoke(ctx, PteraService_UpdateCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func NewBattleServiceClient(cc grpc.ClientConnInterface) BattleServiceClient {
-	return &battleServiceClient{cc}
+func (c *pteraServiceClient) DeleteCard(ctx context.Context, in *DeleteCardRequest, opts ...grpc.CallOption) (*DeleteCardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCardResponse)
+	err := c.cc.Invoke(ctx, PteraService_DeleteCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *battleServiceClient) StartBattle(ctx context.Context, in *StartBattleRequest, opts ...grpc.CallOption) (*StartBattleResponse, error) {
+func (c *pteraServiceClient) ListCards(ctx context.Context, in *ListCardsRequest, opts ...grpc.CallOption) (*ListCardsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(StartBattleResponse)
-	err := c.cc.Invoke(ctx, BattleService_StartBattle_FullMethodName, in, out, cOpts...)
+	out := new(ListCardsResponse)
+	err := c.cc.Invoke(ctx, PteraService_ListCards_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *battleServiceClient) Attack(ctx context.Context, in *AttackRequest, opts ...grpc.CallOption) (*AttackResponse, error) {
+func (c *pteraServiceClient) SetFavoriteCard(ctx context.Context, in *SetFavoriteCardRequest, opts ...grpc.CallOption) (*User, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(AttackResponse)
-	err := c.cc.Invoke(ctx, BattleService_Attack_FullMethodName, in, out, cOpts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, PteraService_SetFavoriteCard_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *battleServiceClient) Retreat(ctx context.Context, in *RetreatRequest, opts ...grpc.CallOption) (*RetreatResponse, error) {
+func (c *pteraServiceClient) ListFavoriteCards(ctx context.Context, in *ListFavoriteCardsRequest, opts ...grpc.CallOption) (*ListFavoriteCardsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(RetreatResponse)
-	err := c.cc.Invoke(ctx, BattleService_Retreat_FullMethodName, in, out, cOpts...)
+	out := new(ListFavoriteCardsResponse)
+	err := c.cc.Invoke(ctx, PteraService_ListFavoriteCards_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *battleServiceClient) SendBattleRequest(ctx context.Context, in *SendBattleRequestRequest, opts ...grpc.CallOption) (*BattleRequest, error) {
+func (c *pteraServiceClient) RenderCard(ctx context.Context, in *RenderCardRequest, opts ...grpc.CallOption) (*RenderCardResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(BattleRequest)
-	err := c.cc.Invoke(ctx, BattleService_SendBattleRequest_FullMethodName, in, out, cOpts...)
+	out := new(RenderCardResponse)
+	err := c.cc.Invoke(ctx, PteraService_RenderCard_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *battleServiceClient) AcceptBattleRequest(ctx context.Context, in *AcceptBattleRequestRequest, opts ...grpc.CallOption) (*BattleState, error) {
+func (c *pteraServiceClient) IssueCardQR(ctx context.Context, in *IssueCardQRRequest, opts ...grpc.CallOption) (*IssueCardQRResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(BattleState)
-	err := c.cc.Invoke(ctx, BattleService_AcceptBattleRequest_FullMethodName, in, out, cOpts...)
+	out := new(IssueCardQRResponse)
+	err := c.cc.Invoke(ctx, PteraService_IssueCardQR_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *battleServiceClient) RejectBattleRequest(ctx context.Context, in *RejectBattleRequestRequest, opts ...grpc.CallOption) (*BattleRequest, error) {
+func (c *pteraServiceClient) ResolveCardQR(ctx context.Context, in *ResolveCardQRRequest, opts ...grpc.CallOption) (*Card, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(BattleRequest)
-	err := c.cc.Invoke(ctx, BattleService_RejectBattleRequest_FullMethodName, in, out, cOpts...)
+	out := new(Card)
+	err := c.cc.Invoke(ctx, PteraService_ResolveCardQR_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// BattleServiceServer is the server API for BattleService service.
-// All implementations must embed UnimplementedBattleServiceServer
+// PteraServiceServer is the server API for PteraService service.
+// All implementations must embed UnimplementedPteraServiceServer
 // for forward compatibility.
-type BattleServiceServer interface {
-	StartBattle(context.Context, *StartBattleRequest) (*StartBattleResponse, error)
-	Attack(context.Context, *AttackRequest) (*AttackResponse, error)
-	Retreat(context.Context, *RetreatRequest) (*RetreatResponse, error)
-	// Battle Request (Matching) RPCs
-	SendBattleRequest(context.Context, *SendBattleRequestRequest) (*BattleRequest, error)
-	AcceptBattleRequest(context.Context, *AcceptBattleRequestRequest) (*BattleState, error)
-	RejectBattleRequest(context.Context, *RejectBattleRequestRequest) (*BattleRequest, error)
-	mustEmbedUnimplementedBattleServiceServer()
+type PteraServiceServer interface {
+	// CompleteCard は画像URLと任意の部分情報を受け取り、
+	// AIを使用してカード情報を自動補完します。
+	// AIを使うRPC (CompleteCard, StreamCompleteCard, DetectMembers, RegenerateField) は
+	// authorization メタデータに "Bearer <FirebaseのIDトークン>" が必要で、利用量はそのユーザーに集計されます。
+	CompleteCard(context.Context, *CompleteCardRequest) (*CompleteCardResponse, error)
+	// StreamCompleteCard は CompleteCard のストリーミング版です。
+	// 生成中のフィールドを field で順次返し、最後に検証済みの result を1回返します。
+	StreamCompleteCard(*CompleteCardRequest, grpc.ServerStreamingServer[StreamCompleteCardResponse]) error
+	// DetectMembers は集合写真から人物を検出し、一人ずつ切り抜いた画像と
+	// カードの下書きを返します (AI利用量は検出で1回、下書き1人ごとに1回として集計され、
+	// 上限に達した後の人物は下書きなしで返されます)。
+	DetectMembers(context.Context, *DetectMembersRequest) (*DetectMembersResponse, error)
+	// RegenerateField は他のフィールドを固定したまま、1つのフィールドの候補をn個生成します。
+	// 編集フォームで選択肢として表示するためのものです (AI利用量は1回分として集計されます)。
+	RegenerateField(context.Context, *RegenerateFieldRequest) (*RegenerateFieldResponse, error)
+	// UploadCardImage は画像をチャンクで受け取り保存します。
+	// 最初のメッセージで metadata を、続くメッセージで chunk を送ります。
+	UploadCardImage(grpc.ClientStreamingServer[UploadCardImageRequest, UploadCardImageResponse]) error
+	// Card CRUD: サーバー側でバリデーション・所有者チェックを行い、
+	// circle_id と expiry_date を付与して保存します。
+	CreateCard(context.Context, *CreateCardRequest) (*Card, error)
+	GetCard(context.Context, *GetCardRequest) (*Card, error)
+	UpdateCard(context.Context, *UpdateCardRequest) (*Card, error)
+	DeleteCard(context.Context, *DeleteCardRequest) (*DeleteCardResponse, error)
+	ListCards(context.Context, *ListCardsRequest) (*ListCardsResponse, error)
+	// 推しメン: ユーザーごとに1枚まで。推しメンのカードはバトルでデッキ確定枠と士気ボーナスを得ます。
+	SetFavoriteCard(context.Context, *SetFavoriteCardRequest) (*User, error)
+	ListFavoriteCards(context.Context, *ListFavoriteCardsRequest) (*ListFavoriteCardsResponse, error)
+	// RenderCard はカードを共有用のPNG画像にします (カードの内容が変わるまでキャッシュされます)。
+	// 同じ画像は HTTP の GET /cards/{card_id}.png?style= でも取得できます。
+	RenderCard(context.Context, *RenderCardRequest) (*RenderCardResponse, error)
+	// IssueCardQR はカードを表す署名付きトークンとそのQRコードを発行します (QRメンコ用)。
+	// カードの作成者か、コレクションに持っているユーザーのみ発行できます。
+	// トークンは有効期限付きで、ResolveCardQR でのみ検証できます。
+	IssueCardQR(context.Context, *IssueCardQRRequest) (*IssueCardQRResponse, error)
+	// ResolveCardQR は読み取ったトークンを検証し、バトルステータス付きのカードを返します。
+	ResolveCardQR(context.Context, *ResolveCardQRRequest) (*Card, error)
+	mustEmbedUnimplementedPteraServiceServer()
 }
 
-// UnimplementedBattleServiceServer must be embedded to have
+// UnimplementedPteraServiceServer must be embedded to have
 // forward compatible implementations.
 //
 // NOTE: this should be embedded by value instead of pointer to avoid a nil
 // pointer dereference when methods are called.
-type UnimplementedBattleServiceServer struct{}
+type UnimplementedPteraServiceServer struct{}
 
-func (UnimplementedBattleServiceServer) StartBattle(context.Context, *StartBattleRequest) (*StartBattleResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method StartBattle not implemented")
+func (UnimplementedPteraServiceServer) CompleteCard(context.Context, *CompleteCardRequest) (*CompleteCardResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CompleteCard not implemented")
 }
-func (UnimplementedBattleServiceServer) Attack(context.Context, *AttackRequest) (*AttackResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method Attack not implemented")
+func (UnimplementedPteraServiceServer) StreamCompleteCard(*CompleteCardRequest, grpc.ServerStreamingServer[StreamCompleteCardResponse]) error {
+	return status.Error(codes.Unimplemented, "method StreamCompleteCard not implemented")
 }
-func (UnimplementedBattleServiceServer) Retreat(context.Context, *RetreatRequest) (*RetreatResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method Retreat not implemented")
+func (UnimplementedPteraServiceServer) DetectMembers(context.Context, *DetectMembersRequest) (*DetectMembersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DetectMembers not implemented")
 }
-func (UnimplementedBattleServiceServer) SendBattleRequest(context.Context, *SendBattleRequestRequest) (*BattleRequest, error) {
-	return nil, status.Error(codes.Unimplemented, "method SendBattleRequest not implemented")
+func (UnimplementedPteraServiceServer) RegenerateField(context.Context, *RegenerateFieldRequest) (*RegenerateFieldResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegenerateField not implemented")
 }
-func (UnimplementedBattleServiceServer) AcceptBattleRequest(context.Context, *AcceptBattleRequestRequest) (*BattleState, error) {
-	return nil, status.Error(codes.Unimplemented, "method AcceptBattleRequest not implemented")
+func (UnimplementedPteraServiceServer) UploadCardImage(grpc.ClientStreamingServer[UploadCardImageRequest, UploadCardImageResponse]) error {
+	return status.Error(codes.Unimplemented, "method UploadCardImage not implemented")
 }
-func (UnimplementedBattleServiceServer) RejectBattleRequest(context.Context, *RejectBattleRequestRequest) (*BattleRequest, error) {
-	return nil, status.Error(codes.Unimplemented, "method RejectBattleRequest not implemented")
+func (UnimplementedPteraServiceServer) CreateCard(context.Context, *CreateCardRequest) (*Card, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateCard not implemented")
 }
-func (UnimplementedBattleServiceServer) mustEmbedUnimplementedBattleServiceServer() {}
-func (UnimplementedBattleServiceServer) testEmbeddedByValue()                       {}
+func (UnimplementedPteraServiceServer) GetCard(context.Context, *GetCardRequest) (*Card, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCard not implemented")
+}
+func (UnimplementedPteraServiceServer) UpdateCard(context.Context, *UpdateCardRequest) (*Card, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateCard not implemented")
+}
+func (UnimplementedPteraServiceServer) DeleteCard(context.Context, *DeleteCardRequest) (*DeleteCardResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteCard not implemented")
+}
+func (UnimplementedPteraServiceServer) ListCards(context.Context, *ListCardsRequest) (*ListCardsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCards not implemented")
+}
+func (UnimplementedPteraServiceServer) SetFavoriteCard(context.Context, *SetFavoriteCardRequest) (*User, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetFavoriteCard not implemented")
+}
+func (UnimplementedPteraServiceServer) ListFavoriteCards(context.Context, *ListFavoriteCardsRequest) (*ListFavoriteCardsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFavoriteCards not implemented")
+}
+func (UnimplementedPteraServiceServer) RenderCard(context.Context, *RenderCardRequest) (*RenderCardResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenderCard not implemented")
+}
+func (UnimplementedPteraServiceServer) IssueCardQR(context.Context, *IssueCardQRRequest) (*IssueCardQRResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method IssueCardQR not implemented")
+}
+func (UnimplementedPteraServiceServer) ResolveCardQR(context.Context, *ResolveCardQRRequest) (*Card, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResolveCardQR not implemented")
+}
+func (UnimplementedPteraServiceServer) mustEmbedUnimplementedPteraServiceServer() {}
+func (UnimplementedPteraServiceServer) testEmbeddedByValue()                      {}
 
-// UnsafeBattleServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to BattleServiceServer will
+// UnsafePteraServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PteraServiceServer will
 // result in compilation errors.
-type UnsafeBattleServiceServer interface {
-	mustEmbedUnimplementedBattleServiceServer()
+type UnsafePteraServiceServer interface {
+	mustEmbedUnimplementedPteraServiceServer()
 }
 
-func RegisterBattleServiceServer(s grpc.ServiceRegistrar, srv BattleServiceServer) {
-	// If the following call panics, it indicates UnimplementedBattleServiceServer was
+func RegisterPteraServiceServer(s grpc.ServiceRegistrar, srv PteraServiceServer) {
+	// If the following call panics, it indicates UnimplementedPteraServiceServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
 	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
 		t.testEmbeddedByValue()
 	}
-	s.RegisterService(&BattleService_ServiceDesc, srv)
+	s.RegisterService(&PteraService_ServiceDesc, srv)
 }
 
-func _BattleService_StartBattle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StartBattleRequest)
+func _PteraService_CompleteCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteCardRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BattleServiceServer).StartBattle(ctx, in)
+		return srv.(PteraServiceServer).CompleteCard(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BattleService_StartBattle_FullMethodName,
+		FullMethod: PteraService_CompleteCard_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BattleServiceServer).StartBattle(ctx, req.(*StartBattleRequest))
+		return srv.(PteraServiceServer).CompleteCard(ctx, req.(*CompleteCardRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BattleService_Attack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AttackRequest)
+func _PteraService_StreamCompleteCard_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CompleteCardRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PteraServiceServer).StreamCompleteCard(m, &grpc.GenericServerStream[CompleteCardRequest, StreamCompleteCardResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PteraService_StreamCompleteCardServer = grpc.ServerStreamingServer[StreamCompleteCardResponse]
+
+func _PteraService_DetectMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetectMembersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BattleServiceServer).Attack(ctx, in)
+		return srv.(PteraServiceServer).DetectMembers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BattleService_Attack_FullMethodName,
+		FullMethod: PteraService_DetectMembers_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BattleServiceServer).Attack(ctx, req.(*AttackRequest))
+		return srv.(PteraServiceServer).DetectMembers(ctx, req.(*DetectMembersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BattleService_Retreat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RetreatRequest)
+func _PteraService_RegenerateField_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegenerateFieldRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BattleServiceServer).Retreat(ctx, in)
+		return srv.(PteraServiceServer).RegenerateField(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BattleService_Retreat_FullMethodName,
+		FullMethod: PteraService_RegenerateField_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BattleServiceServer).Retreat(ctx, req.(*RetreatRequest))
+		return srv.(PteraServiceServer).RegenerateField(ctx, req.(*RegenerateFieldRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BattleService_SendBattleRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendBattleRequestRequest)
+func _PteraService_UploadCardImage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PteraServiceServer).UploadCardImage(&grpc.GenericServerStream[UploadCardImageRequest, UploadCardImageResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PteraService_UploadCardImageServer = grpc.ClientStreamingServer[UploadCardImageRequest, UploadCardImageResponse]
+
+func _PteraService_CreateCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCardRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BattleServiceServer).SendBattleRequest(ctx, in)
+		return srv.(PteraServiceServer).CreateCard(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BattleService_SendBattleRequest_FullMethodName,
+		FullMethod: PteraService_CreateCard_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BattleServiceServer).SendBattleRequest(ctx, req.(*SendBattleRequestRequest))
+		return srv.(PteraServiceServer).CreateCard(ctx, req.(*CreateCardRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BattleService_AcceptBattleRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AcceptBattleRequestRequest)
+func _PteraService_GetCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCardRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BattleServiceServer).AcceptBattleRequest(ctx, in)
+		return srv.(PteraServiceServer).GetCard(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BattleService_AcceptBattleRequest_FullMethodName,
+		FullMethod: PteraService_GetCard_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BattleServiceServer).AcceptBattleRequest(ctx, req.(*AcceptBattleRequestRequest))
+		return srv.(PteraServiceServer).GetCard(ctx, req.(*GetCardRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BattleService_RejectBattleRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RejectBattleRequestRequest)
+func _PteraService_UpdateCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCardRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BattleServiceServer).RejectBattleRequest(ctx, in)
+		return srv.(PteraServiceServer).UpdateCard(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BattleService_RejectBattleRequest_FullMethodName,
+		FullMethod: PteraService_UpdateCard_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BattleServiceServer).RejectBattleRequest(ctx, req.(*RejectBattleRequestRequest))
+		return srv.(PteraServiceServer).UpdateCard(ctx, req.(*UpdateCardRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// BattleService_ServiceDesc is the grpc.ServiceDesc for BattleService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var BattleService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "ptera.v1.BattleService",
-	HandlerType: (*BattleServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
+func _PteraService_DeleteCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PteraServiceServer).DeleteCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PteraService_DeleteCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PteraServiceServer).DeleteCard(ctx, req.(*DeleteCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PteraService_ListCards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PteraServiceServer).ListCards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PteraService_ListCards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PteraServiceServer).ListCards(ctx, req.(*ListCardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PteraService_SetFavoriteCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFavoriteCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PteraServiceServer).SetFavoriteCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PteraService_SetFavoriteCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PteraServiceServer).SetFavoriteCard(ctx, req.(*SetFavoriteCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PteraService_ListFavoriteCards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFavoriteCardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PteraServiceServer).ListFavoriteCards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PteraService_ListFavoriteCards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PteraServiceServer).ListFavoriteCards(ctx, req.(*ListFavoriteCardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PteraService_RenderCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PteraServiceServer).RenderCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PteraService_RenderCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PteraServiceServer).RenderCard(ctx, req.(*RenderCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PteraService_IssueCardQR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueCardQRRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PteraServiceServer).IssueCardQR(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PteraService_IssueCardQR_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PteraServiceServer).IssueCardQR(ctx, req.(*IssueCardQRRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PteraService_ResolveCardQR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveCardQRRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PteraServiceServer).ResolveCardQR(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PteraService_ResolveCardQR_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PteraServiceServer).ResolveCardQR(ctx, req.(*ResolveCardQRRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PteraService_ServiceDesc is the grpc.ServiceDesc for PteraService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PteraService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ptera.v1.PteraService",
+	HandlerType: (*PteraServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CompleteCard",
+			Handler:    _PteraService_CompleteCard_Handler,
+		},
+		{
+			MethodName: "DetectMembers",
+			Handler:    _PteraService_DetectMembers_Handler,
+		},
+		{
+			MethodName: "RegenerateField",
+			Handler:    _PteraService_RegenerateField_Handler,
+		},
+		{
+			MethodName: "CreateCard",
+			Handler:    _PteraService_CreateCard_Handler,
+		},
+		{
+			MethodName: "GetCard",
+			Handler:    _PteraService_GetCard_Handler,
+		},
+		{
+			MethodName: "UpdateCard",
+			Handler:    _PteraService_UpdateCard_Handler,
+		},
+		{
+			MethodName: "DeleteCard",
+			Handler:    _PteraService_DeleteCard_Handler,
+		},
+		{
+			MethodName: "ListCards",
+			Handler:    _PteraService_ListCards_Handler,
+		},
+		{
+			MethodName: "SetFavoriteCard",
+			Handler:    _PteraService_SetFavoriteCard_Handler,
+		},
+		{
+			MethodName: "ListFavoriteCards",
+			Handler:    _PteraService_ListFavoriteCards_Handler,
+		},
+		{
+			MethodName: "RenderCard",
+			Handler:    _PteraService_RenderCard_Handler,
+		},
+		{
+			MethodName: "IssueCardQR",
+			Handler:    _PteraService_IssueCardQR_Handler,
+		},
+		{
+			MethodName: "ResolveCardQR",
+			Handler:    _PteraService_ResolveCardQR_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamCompleteCard",
+			Handler:       _PteraService_StreamCompleteCard_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadCardImage",
+			Handler:       _PteraService_UploadCardImage_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "ptera/v1/ptera.proto",
+}
+
+const (
+	BattleService_StartBattle_FullMethodName         = "/ptera.v1.BattleService/StartBattle"
+	BattleService_Attack_FullMethodName              = "/ptera.v1.BattleService/Attack"
+	BattleService_Retreat_FullMethodName             = "/ptera.v1.BattleService/Retreat"
+	BattleService_SendBattleRequest_FullMethodName   = "/ptera.v1.BattleService/SendBattleRequest"
+	BattleService_AcceptBattleRequest_FullMethodName = "/ptera.v1.BattleService/AcceptBattleRequest"
+	BattleService_RejectBattleRequest_FullMethodName = "/ptera.v1.BattleService/RejectBattleRequest"
+)
+
+// BattleServiceClient is the client API for BattleService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BattleServiceClient interface {
+	StartBattle(ctx context.Context, in *StartBattleRequest, opts ...grpc.CallOption) (*StartBattleResponse, error)
+	Attack(ctx context.Context, in *AttackRequest, opts ...grpc.CallOption) (*AttackResponse, error)
+	Retreat(ctx context.Context, in *RetreatRequest, opts ...grpc.CallOption) (*RetreatResponse, error)
+	// Battle Request (Matching) RPCs
+	SendBattleRequest(ctx context.Context, in *SendBattleRequestRequest, opts ...grpc.CallOption) (*BattleRequest, error)
+	AcceptBattleRequest(ctx context.Context, in *AcceptBattleRequestRequest, opts ...grpc.CallOption) (*BattleState, error)
+	RejectBattleRequest(ctx context.Context, in *RejectBattleRequestRequest, opts ...grpc.CallOption) (*BattleRequest, error)
+}
+
+type battleServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBattleServiceClient(cc grpc.ClientConnInterface) BattleServiceClient {
+	return &battleServiceClient{cc}
+}
+
+func (c *battleServiceClient) StartBattle(ctx context.Context, in *StartBattleRequest, opts ...grpc.CallOption) (*StartBattleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartBattleResponse)
+	err := c.cc.Invoke(ctx, BattleService_StartBattle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleServiceClient) Attack(ctx context.Context, in *AttackRequest, opts ...grpc.CallOption) (*AttackResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttackResponse)
+	err := c.cc.Invoke(ctx, BattleService_Attack_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleServiceClient) Retreat(ctx context.Context, in *RetreatRequest, opts ...grpc.CallOption) (*RetreatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetreatResponse)
+	err := c.cc.Invoke(ctx, BattleService_Retreat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleServiceClient) SendBattleRequest(ctx context.Context, in *SendBattleRequestRequest, opts ...grpc.CallOption) (*BattleRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BattleRequest)
+	err := c.cc.Invoke(ctx, BattleService_SendBattleRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleServiceClient) AcceptBattleRequest(ctx context.Context, in *AcceptBattleRequestRequest, opts ...grpc.CallOption) (*BattleState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BattleState)
+	err := c.cc.Invoke(ctx, BattleService_AcceptBattleRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleServiceClient) RejectBattleRequest(ctx context.Context, in *RejectBattleRequestRequest, opts ...grpc.CallOption) (*BattleRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BattleRequest)
+	err := c.cc.Invoke(ctx, BattleService_RejectBattleRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BattleServiceServer is the server API for BattleService service.
+// All implementations must embed UnimplementedBattleServiceServer
+// for forward compatibility.
+type BattleServiceServer interface {
+	StartBattle(context.Context, *StartBattleRequest) (*StartBattleResponse, error)
+	Attack(context.Context, *AttackRequest) (*AttackResponse, error)
+	Retreat(context.Context, *RetreatRequest) (*RetreatResponse, error)
+	// Battle Request (Matching) RPCs
+	SendBattleRequest(context.Context, *SendBattleRequestRequest) (*BattleRequest, error)
+	AcceptBattleRequest(context.Context, *AcceptBattleRequestRequest) (*BattleState, error)
+	RejectBattleRequest(context.Context, *RejectBattleRequestRequest) (*BattleRequest, error)
+	mustEmbedUnimplementedBattleServiceServer()
+}
+
+// UnimplementedBattleServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBattleServiceServer struct{}
+
+func (UnimplementedBattleServiceServer) StartBattle(context.Context, *StartBattleRequest) (*StartBattleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartBattle not implemented")
+}
+func (UnimplementedBattleServiceServer) Attack(context.Context, *AttackRequest) (*AttackResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Attack not implemented")
+}
+func (UnimplementedBattleServiceServer) Retreat(context.Context, *RetreatRequest) (*RetreatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Retreat not implemented")
+}
+func (UnimplementedBattleServiceServer) SendBattleRequest(context.Context, *SendBattleRequestRequest) (*BattleRequest, error) {
+	return nil, status.Error(codes.Unimplemented, "method SendBattleRequest not implemented")
+}
+func (UnimplementedBattleServiceServer) AcceptBattleRequest(context.Context, *AcceptBattleRequestRequest) (*BattleState, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcceptBattleRequest not implemented")
+}
+func (UnimplementedBattleServiceServer) RejectBattleRequest(context.Context, *RejectBattleRequestRequest) (*BattleRequest, error) {
+	return nil, status.Error(codes.Unimplemented, "method RejectBattleRequest not implemented")
+}
+func (UnimplementedBattleServiceServer) mustEmbedUnimplementedBattleServiceServer() {}
+func (UnimplementedBattleServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeBattleServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BattleServiceServer will
+// result in compilation errors.
+type UnsafeBattleServiceServer interface {
+	mustEmbedUnimplementedBattleServiceServer()
+}
+
+func RegisterBattleServiceServer(s grpc.ServiceRegistrar, srv BattleServiceServer) {
+	// If the following call panics, it indicates UnimplementedBattleServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BattleService_ServiceDesc, srv)
+}
+
+func _BattleService_StartBattle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartBattleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleServiceServer).StartBattle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BattleService_StartBattle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleServiceServer).StartBattle(ctx, req.(*StartBattleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BattleService_Attack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleServiceServer).Attack(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BattleService_Attack_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleServiceServer).Attack(ctx, req.(*AttackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BattleService_Retreat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetreatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleServiceServer).Retreat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BattleService_Retreat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleServiceServer).Retreat(ctx, req.(*RetreatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BattleService_SendBattleRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendBattleRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleServiceServer).SendBattleRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BattleService_SendBattleRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleServiceServer).SendBattleRequest(ctx, req.(*SendBattleRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BattleService_AcceptBattleRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptBattleRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleServiceServer).AcceptBattleRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BattleService_AcceptBattleRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleServiceServer).AcceptBattleRequest(ctx, req.(*AcceptBattleRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BattleService_RejectBattleRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectBattleRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleServiceServer).RejectBattleRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BattleService_RejectBattleRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleServiceServer).RejectBattleRequest(ctx, req.(*RejectBattleRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BattleService_ServiceDesc is the grpc.ServiceDesc for BattleService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BattleService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ptera.v1.BattleService",
+	HandlerType: (*BattleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
 			MethodName: "StartBattle",
 			Handler:    _BattleService_StartBattle_Handler,
 		},
 		{
-			MethodName: "Attack",
-			Handler:    _BattleService_Attack_Handler,
+			MethodName: "Attack",
+			Handler:    _BattleService_Attack_Handler,
+		},
+		{
+			MethodName: "Retreat",
+			Handler:    _BattleService_Retreat_Handler,
+		},
+		{
+			MethodName: "SendBattleRequest",
+			Handler:    _BattleService_SendBattleRequest_Handler,
+		},
+		{
+			MethodName: "AcceptBattleRequest",
+			Handler:    _BattleService_AcceptBattleRequest_Handler,
+		},
+		{
+			MethodName: "RejectBattleRequest",
+			Handler:    _BattleService_RejectBattleRequest_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ptera/v1/ptera.proto",
+}
+
+const (
+	GachaService_Pull_FullMethodName = "/ptera.v1.GachaService/Pull"
+)
+
+// GachaServiceClient is the client API for GachaService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GachaServiceClient interface {
+	// Pull はガチャを count 回引き、結果をユーザーのコレクションに追加します。
+	// 抽選結果はすべて監査用に記録されます。
+	Pull(ctx context.Context, in *PullRequest, opts ...grpc.CallOption) (*PullResponse, error)
+}
+
+type gachaServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGachaServiceClient(cc grpc.ClientConnInterface) GachaServiceClient {
+	return &gachaServiceClient{cc}
+}
+
+func (c *gachaServiceClient) Pull(ctx context.Context, in *PullRequest, opts ...grpc.CallOption) (*PullResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PullResponse)
+	err := c.cc.Invoke(ctx, GachaService_Pull_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GachaServiceServer is the server API for GachaService service.
+// All implementations must embed UnimplementedGachaServiceServer
+// for forward compatibility.
+type GachaServiceServer interface {
+	// Pull はガチャを count 回引き、結果をユーザーのコレクションに追加します。
+	// 抽選結果はすべて監査用に記録されます。
+	Pull(context.Context, *PullRequest) (*PullResponse, error)
+	mustEmbedUnimplementedGachaServiceServer()
+}
+
+// UnimplementedGachaServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGachaServiceServer struct{}
+
+func (UnimplementedGachaServiceServer) Pull(context.Context, *PullRequest) (*PullResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Pull not implemented")
+}
+func (UnimplementedGachaServiceServer) mustEmbedUnimplementedGachaServiceServer() {}
+func (UnimplementedGachaServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeGachaServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GachaServiceServer will
+// result in compilation errors.
+type UnsafeGachaServiceServer interface {
+	mustEmbedUnimplementedGachaServiceServer()
+}
+
+func RegisterGachaServiceServer(s grpc.ServiceRegistrar, srv GachaServiceServer) {
+	// If the following call panics, it indicates UnimplementedGachaServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GachaService_ServiceDesc, srv)
+}
+
+func _GachaService_Pull_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PullRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GachaServiceServer).Pull(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GachaService_Pull_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GachaServiceServer).Pull(ctx, req.(*PullRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GachaService_ServiceDesc is the grpc.ServiceDesc for GachaService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GachaService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ptera.v1.GachaService",
+	HandlerType: (*GachaServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Pull",
+			Handler:    _GachaService_Pull_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ptera/v1/ptera.proto",
+}
+
+const (
+	FairnessService_CommitSeed_FullMethodName    = "/ptera.v1.FairnessService/CommitSeed"
+	FairnessService_GetCommitment_FullMethodName = "/ptera.v1.FairnessService/GetCommitment"
+	FairnessService_VerifyRolls_FullMethodName   = "/ptera.v1.FairnessService/VerifyRolls"
+)
+
+// FairnessServiceClient is the client API for FairnessService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FairnessService はバトル/ガチャの乱数を検証できるようにするコミット・リビール方式のサービスです。
+// 乱数 i は HMAC-SHA256(server_seed, client_nonce + ":" + i) の先頭8バイト (ビッグエンディアン) です。
+// n 通りの抽選では 2^64 を n で割り切れない上端の値を読み飛ばし (読み飛ばした分も i を進める)、
+// 最初に残った値を n で割った余りを使うため、公開されたシードから誰でも同じ結果を再計算できます。
+type FairnessServiceClient interface {
+	// CommitSeed はバトル/ガチャの前にサーバーシードを生成し、そのハッシュを公開します。
+	// クライアントはハッシュを受け取った後でノンスを生成し、StartBattle / AcceptBattleRequest / Pull に渡します。
+	CommitSeed(ctx context.Context, in *CommitSeedRequest, opts ...grpc.CallOption) (*SeedCommitment, error)
+	// GetCommitment はコミットメントを取得します。シードは公開後のみ含まれます。
+	GetCommitment(ctx context.Context, in *GetCommitmentRequest, opts ...grpc.CallOption) (*SeedCommitment, error)
+	// VerifyRolls は公開されたシードから全ての乱数を再計算し、記録された結果と照合します。
+	VerifyRolls(ctx context.Context, in *VerifyRollsRequest, opts ...grpc.CallOption) (*VerifyRollsResponse, error)
+}
+
+type fairnessServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFairnessServiceClient(cc grpc.ClientConnInterface) FairnessServiceClient {
+	return &fairnessServiceClient{cc}
+}
+
+func (c *fairnessServiceClient) CommitSeed(ctx context.Context, in *CommitSeedRequest, opts ...grpc.CallOption) (*SeedCommitment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeedCommitment)
+	err := c.cc.Invoke(ctx, FairnessService_CommitSeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fairnessServiceClient) GetCommitment(ctx context.Context, in *GetCommitmentRequest, opts ...grpc.CallOption) (*SeedCommitment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeedCommitment)
+	err := c.cc.Invoke(ctx, FairnessService_GetCommitment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fairnessServiceClient) VerifyRolls(ctx context.Context, in *VerifyRollsRequest, opts ...grpc.CallOption) (*VerifyRollsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyRollsResponse)
+	err := c.cc.Invoke(ctx, FairnessService_VerifyRolls_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FairnessServiceServer is the server API for FairnessService service.
+// All implementations must embed UnimplementedFairnessServiceServer
+// for forward compatibility.
+//
+// FairnessService はバトル/ガチャの乱数を検証できるようにするコミット・リビール方式のサービスです。
+// 乱数 i は HMAC-SHA256(server_seed, client_nonce + ":" + i) の先頭8バイト (ビッグエンディアン) です。
+// n 通りの抽選では 2^64 を n で割り切れない上端の値を読み飛ばし (読み飛ばした分も i を進める)、
+// 最初に残った値を n で割った余りを使うため、公開されたシードから誰でも同じ結果を再計算できます。
+type FairnessServiceServer interface {
+	// CommitSeed はバトル/ガチャの前にサーバーシードを生成し、そのハッシュを公開します。
+	// クライアントはハッシュを受け取った後でノンスを生成し、StartBattle / AcceptBattleRequest / Pull に渡します。
+	CommitSeed(context.Context, *CommitSeedRequest) (*SeedCommitment, error)
+	// GetCommitment はコミットメントを取得します。シードは公開後のみ含まれます。
+	GetCommitment(context.Context, *GetCommitmentRequest) (*SeedCommitment, error)
+	// VerifyRolls は公開されたシードから全ての乱数を再計算し、記録された結果と照合します。
+	VerifyRolls(context.Context, *VerifyRollsRequest) (*VerifyRollsResponse, error)
+	mustEmbedUnimplementedFairnessServiceServer()
+}
+
+// UnimplementedFairnessServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFairnessServiceServer struct{}
+
+func (UnimplementedFairnessServiceServer) CommitSeed(context.Context, *CommitSeedRequest) (*SeedCommitment, error) {
+	return nil, status.Error(codes.Unimplemented, "method CommitSeed not implemented")
+}
+func (UnimplementedFairnessServiceServer) GetCommitment(context.Context, *GetCommitmentRequest) (*SeedCommitment, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCommitment not implemented")
+}
+func (UnimplementedFairnessServiceServer) VerifyRolls(context.Context, *VerifyRollsRequest) (*VerifyRollsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyRolls not implemented")
+}
+func (UnimplementedFairnessServiceServer) mustEmbedUnimplementedFairnessServiceServer() {}
+func (UnimplementedFairnessServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeFairnessServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FairnessServiceServer will
+// result in compilation errors.
+type UnsafeFairnessServiceServer interface {
+	mustEmbedUnimplementedFairnessServiceServer()
+}
+
+func RegisterFairnessServiceServer(s grpc.ServiceRegistrar, srv FairnessServiceServer) {
+	// If the following call panics, it indicates UnimplementedFairnessServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FairnessService_ServiceDesc, srv)
+}
+
+func _FairnessService_CommitSeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitSeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FairnessServiceServer).CommitSeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FairnessService_CommitSeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FairnessServiceServer).CommitSeed(ctx, req.(*CommitSeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FairnessService_GetCommitment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommitmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FairnessServiceServer).GetCommitment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FairnessService_GetCommitment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FairnessServiceServer).GetCommitment(ctx, req.(*GetCommitmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FairnessService_VerifyRolls_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyRollsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FairnessServiceServer).VerifyRolls(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FairnessService_VerifyRolls_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FairnessServiceServer).VerifyRolls(ctx, req.(*VerifyRollsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FairnessService_ServiceDesc is the grpc.ServiceDesc for FairnessService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FairnessService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ptera.v1.FairnessService",
+	HandlerType: (*FairnessServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CommitSeed",
+			Handler:    _FairnessService_CommitSeed_Handler,
+		},
+		{
+			MethodName: "GetCommitment",
+			Handler:    _FairnessService_GetCommitment_Handler,
+		},
+		{
+			MethodName: "VerifyRolls",
+			Handler:    _FairnessService_VerifyRolls_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ptera/v1/ptera.proto",
+}
+
+const (
+	TradeService_ProposeTrade_FullMethodName = "/ptera.v1.TradeService/ProposeTrade"
+	TradeService_AcceptTrade_FullMethodName  = "/ptera.v1.TradeService/AcceptTrade"
+	TradeService_RejectTrade_FullMethodName  = "/ptera.v1.TradeService/RejectTrade"
+	TradeService_CancelTrade_FullMethodName  = "/ptera.v1.TradeService/CancelTrade"
+)
+
+// TradeServiceClient is the client API for TradeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TradeServiceClient interface {
+	// ProposeTrade は所持カードの交換を提案します。提示したカードは取引終了までロックされます。
+	ProposeTrade(ctx context.Context, in *ProposeTradeRequest, opts ...grpc.CallOption) (*Trade, error)
+	// AcceptTrade は提案を承諾し、双方のカードの所有権をアトミックに移動します。
+	AcceptTrade(ctx context.Context, in *AcceptTradeRequest, opts ...grpc.CallOption) (*Trade, error)
+	RejectTrade(ctx context.Context, in *RejectTradeRequest, opts ...grpc.CallOption) (*Trade, error)
+	CancelTrade(ctx context.Context, in *CancelTradeRequest, opts ...grpc.CallOption) (*Trade, error)
+}
+
+type tradeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTradeServiceClient(cc grpc.ClientConnInterface) TradeServiceClient {
+	return &tradeServiceClient{cc}
+}
+
+func (c *tradeServiceClient) ProposeTrade(ctx context.Context, in *ProposeTradeRequest, opts ...grpc.CallOption) (*Trade, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Trade)
+	err := c.cc.Invoke(ctx, TradeService_ProposeTrade_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradeServiceClient) AcceptTrade(ctx context.Context, in *AcceptTradeRequest, opts ...grpc.CallOption) (*Trade, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Trade)
+	err := c.cc.Invoke(ctx, TradeService_AcceptTrade_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradeServiceClient) RejectTrade(ctx context.Context, in *RejectTradeRequest, opts ...grpc.CallOption) (*Trade, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Trade)
+	err := c.cc.Invoke(ctx, TradeService_RejectTrade_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradeServiceClient) CancelTrade(ctx context.Context, in *CancelTradeRequest, opts ...grpc.CallOption) (*Trade, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Trade)
+	err := c.cc.Invoke(ctx, TradeService_CancelTrade_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TradeServiceServer is the server API for TradeService service.
+// All implementations must embed UnimplementedTradeServiceServer
+// for forward compatibility.
+type TradeServiceServer interface {
+	// ProposeTrade は所持カードの交換を提案します。提示したカードは取引終了までロックされます。
+	ProposeTrade(context.Context, *ProposeTradeRequest) (*Trade, error)
+	// AcceptTrade は提案を承諾し、双方のカードの所有権をアトミックに移動します。
+	AcceptTrade(context.Context, *AcceptTradeRequest) (*Trade, error)
+	RejectTrade(context.Context, *RejectTradeRequest) (*Trade, error)
+	CancelTrade(context.Context, *CancelTradeRequest) (*Trade, error)
+	mustEmbedUnimplementedTradeServiceServer()
+}
+
+// UnimplementedTradeServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTradeServiceServer struct{}
+
+func (UnimplementedTradeServiceServer) ProposeTrade(context.Context, *ProposeTradeRequest) (*Trade, error) {
+	return nil, status.Error(codes.Unimplemented, "method ProposeTrade not implemented")
+}
+func (UnimplementedTradeServiceServer) AcceptTrade(context.Context, *AcceptTradeRequest) (*Trade, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcceptTrade not implemented")
+}
+func (UnimplementedTradeServiceServer) RejectTrade(context.Context, *RejectTradeRequest) (*Trade, error) {
+	return nil, status.Error(codes.Unimplemented, "method RejectTrade not implemented")
+}
+func (UnimplementedTradeServiceServer) CancelTrade(context.Context, *CancelTradeRequest) (*Trade, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelTrade not implemented")
+}
+func (UnimplementedTradeServiceServer) mustEmbedUnimplementedTradeServiceServer() {}
+func (UnimplementedTradeServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeTradeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TradeServiceServer will
+// result in compilation errors.
+type UnsafeTradeServiceServer interface {
+	mustEmbedUnimplementedTradeServiceServer()
+}
+
+func RegisterTradeServiceServer(s grpc.ServiceRegistrar, srv TradeServiceServer) {
+	// If the following call panics, it indicates UnimplementedTradeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TradeService_ServiceDesc, srv)
+}
+
+func _TradeService_ProposeTrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProposeTradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradeServiceServer).ProposeTrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradeService_ProposeTrade_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradeServiceServer).ProposeTrade(ctx, req.(*ProposeTradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradeService_AcceptTrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptTradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradeServiceServer).AcceptTrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradeService_AcceptTrade_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradeServiceServer).AcceptTrade(ctx, req.(*AcceptTradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradeService_RejectTrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectTradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradeServiceServer).RejectTrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradeService_RejectTrade_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradeServiceServer).RejectTrade(ctx, req.(*RejectTradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradeService_CancelTrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradeServiceServer).CancelTrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradeService_CancelTrade_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradeServiceServer).CancelTrade(ctx, req.(*CancelTradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TradeService_ServiceDesc is the grpc.ServiceDesc for TradeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TradeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ptera.v1.TradeService",
+	HandlerType: (*TradeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ProposeTrade",
+			Handler:    _TradeService_ProposeTrade_Handler,
+		},
+		{
+			MethodName: "AcceptTrade",
+			Handler:    _TradeService_AcceptTrade_Handler,
+		},
+		{
+			MethodName: "RejectTrade",
+			Handler:    _TradeService_RejectTrade_Handler,
+		},
+		{
+			MethodName: "CancelTrade",
+			Handler:    _TradeService_CancelTrade_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ptera/v1/ptera.proto",
+}
+
+const (
+	CircleService_CreateCircle_FullMethodName       = "/ptera.v1.CircleService/CreateCircle"
+	CircleService_GetCircle_FullMethodName          = "/ptera.v1.CircleService/GetCircle"
+	CircleService_GenerateInviteCode_FullMethodName = "/ptera.v1.CircleService/GenerateInviteCode"
+	CircleService_JoinCircle_FullMethodName         = "/ptera.v1.CircleService/JoinCircle"
+	CircleService_LeaveCircle_FullMethodName        = "/ptera.v1.CircleService/LeaveCircle"
+	CircleService_ListMembers_FullMethodName        = "/ptera.v1.CircleService/ListMembers"
+)
+
+// CircleServiceClient is the client API for CircleService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CircleServiceClient interface {
+	// CreateCircle はサークルを作成し、作成者をメンバーにします。
+	CreateCircle(ctx context.Context, in *CreateCircleRequest, opts ...grpc.CallOption) (*Circle, error)
+	GetCircle(ctx context.Context, in *GetCircleRequest, opts ...grpc.CallOption) (*Circle, error)
+	// GenerateInviteCode はメンバーがサークルへの招待コードを発行します。
+	GenerateInviteCode(ctx context.Context, in *GenerateInviteCodeRequest, opts ...grpc.CallOption) (*InviteCode, error)
+	// JoinCircle は招待コードでサークルに参加します (参加中のサークルからは自動で抜けます)。
+	JoinCircle(ctx context.Context, in *JoinCircleRequest, opts ...grpc.CallOption) (*Circle, error)
+	LeaveCircle(ctx context.Context, in *LeaveCircleRequest, opts ...grpc.CallOption) (*LeaveCircleResponse, error)
+	ListMembers(ctx context.Context, in *ListMembersRequest, opts ...grpc.CallOption) (*ListMembersResponse, error)
+}
+
+type circleServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCircleServiceClient(cc grpc.ClientConnInterface) CircleServiceClient {
+	return &circleServiceClient{cc}
+}
+
+func (c *circleServiceClient) CreateCircle(ctx context.Context, in *CreateCircleRequest, opts ...grpc.CallOption) (*Circle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Circle)
+	err := c.cc.Invoke(ctx, CircleService_CreateCircle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *circleServiceClient) GetCircle(ctx context.Context, in *GetCircleRequest, opts ...grpc.CallOption) (*Circle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Circle)
+	err := c.cc.Invoke(ctx, CircleService_GetCircle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *circleServiceClient) GenerateInviteCode(ctx context.Context, in *GenerateInviteCodeRequest, opts ...grpc.CallOption) (*InviteCode, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InviteCode)
+	err := c.cc.Invoke(ctx, CircleService_GenerateInviteCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *circleServiceClient) JoinCircle(ctx context.Context, in *JoinCircleRequest, opts ...grpc.CallOption) (*Circle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Circle)
+	err := c.cc.Invoke(ctx, CircleService_JoinCircle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *circleServiceClient) LeaveCircle(ctx context.Context, in *LeaveCircleRequest, opts ...grpc.CallOption) (*LeaveCircleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaveCircleResponse)
+	err := c.cc.Invoke(ctx, CircleService_LeaveCircle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *circleServiceClient) ListMembers(ctx context.Context, in *ListMembersRequest, opts ...grpc.CallOption) (*ListMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMembersResponse)
+	err := c.cc.Invoke(ctx, CircleService_ListMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CircleServiceServer is the server API for CircleService service.
+// All implementations must embed UnimplementedCircleServiceServer
+// for forward compatibility.
+type CircleServiceServer interface {
+	// CreateCircle はサークルを作成し、作成者をメンバーにします。
+	CreateCircle(context.Context, *CreateCircleRequest) (*Circle, error)
+	GetCircle(context.Context, *GetCircleRequest) (*Circle, error)
+	// GenerateInviteCode はメンバーがサークルへの招待コードを発行します。
+	GenerateInviteCode(context.Context, *GenerateInviteCodeRequest) (*InviteCode, error)
+	// JoinCircle は招待コードでサークルに参加します (参加中のサークルからは自動で抜けます)。
+	JoinCircle(context.Context, *JoinCircleRequest) (*Circle, error)
+	LeaveCircle(context.Context, *LeaveCircleRequest) (*LeaveCircleResponse, error)
+	ListMembers(context.Context, *ListMembersRequest) (*ListMembersResponse, error)
+	mustEmbedUnimplementedCircleServiceServer()
+}
+
+// UnimplementedCircleServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCircleServiceServer struct{}
+
+func (UnimplementedCircleServiceServer) CreateCircle(context.Context, *CreateCircleRequest) (*Circle, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateCircle not implemented")
+}
+func (UnimplementedCircleServiceServer) GetCircle(context.Context, *GetCircleRequest) (*Circle, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCircle not implemented")
+}
+func (UnimplementedCircleServiceServer) GenerateInviteCode(context.Context, *GenerateInviteCodeRequest) (*InviteCode, error) {
+	return nil, status.Error(codes.Unimplemented, "method GenerateInviteCode not implemented")
+}
+func (UnimplementedCircleServiceServer) JoinCircle(context.Context, *JoinCircleRequest) (*Circle, error) {
+	return nil, status.Error(codes.Unimplemented, "method JoinCircle not implemented")
+}
+func (UnimplementedCircleServiceServer) LeaveCircle(context.Context, *LeaveCircleRequest) (*LeaveCircleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LeaveCircle not implemented")
+}
+func (UnimplementedCircleServiceServer) ListMembers(context.Context, *ListMembersRequest) (*ListMembersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListMembers not implemented")
+}
+func (UnimplementedCircleServiceServer) mustEmbedUnimplementedCircleServiceServer() {}
+func (UnimplementedCircleServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeCircleServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CircleServiceServer will
+// result in compilation errors.
+type UnsafeCircleServiceServer interface {
+	mustEmbedUnimplementedCircleServiceServer()
+}
+
+func RegisterCircleServiceServer(s grpc.ServiceRegistrar, srv CircleServiceServer) {
+	// If the following call panics, it indicates UnimplementedCircleServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CircleService_ServiceDesc, srv)
+}
+
+func _CircleService_CreateCircle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCircleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CircleServiceServer).CreateCircle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CircleService_CreateCircle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CircleServiceServer).CreateCircle(ctx, req.(*CreateCircleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CircleService_GetCircle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCircleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CircleServiceServer).GetCircle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CircleService_GetCircle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CircleServiceServer).GetCircle(ctx, req.(*GetCircleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CircleService_GenerateInviteCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateInviteCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CircleServiceServer).GenerateInviteCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CircleService_GenerateInviteCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CircleServiceServer).GenerateInviteCode(ctx, req.(*GenerateInviteCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CircleService_JoinCircle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinCircleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CircleServiceServer).JoinCircle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CircleService_JoinCircle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CircleServiceServer).JoinCircle(ctx, req.(*JoinCircleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CircleService_LeaveCircle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveCircleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CircleServiceServer).LeaveCircle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CircleService_LeaveCircle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CircleServiceServer).LeaveCircle(ctx, req.(*LeaveCircleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CircleService_ListMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CircleServiceServer).ListMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CircleService_ListMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CircleServiceServer).ListMembers(ctx, req.(*ListMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CircleService_ServiceDesc is the grpc.ServiceDesc for CircleService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CircleService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ptera.v1.CircleService",
+	HandlerType: (*CircleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateCircle",
+			Handler:    _CircleService_CreateCircle_Handler,
+		},
+		{
+			MethodName: "GetCircle",
+			Handler:    _CircleService_GetCircle_Handler,
 		},
 		{
-			MethodName: "Retreat",
-			Handler:    _BattleService_Retreat_Handler,
+			MethodName: "GenerateInviteCode",
+			Handler:    _CircleService_GenerateInviteCode_Handler,
 		},
 		{
-			MethodName: "SendBattleRequest",
-			Handler:    _BattleService_SendBattleRequest_Handler,
+			MethodName: "JoinCircle",
+			Handler:    _CircleService_JoinCircle_Handler,
 		},
 		{
-			MethodName: "AcceptBattleRequest",
-			Handler:    _BattleService_AcceptBattleRequest_Handler,
+			MethodName: "LeaveCircle",
+			Handler:    _CircleService_LeaveCircle_Handler,
 		},
 		{
-			MethodName: "RejectBattleRequest",
-			Handler:    _BattleService_RejectBattleRequest_Handler,
+			MethodName: "ListMembers",
+			Handler:    _CircleService_ListMembers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ptera/v1/ptera.proto",
+}
+
+const (
+	UserService_GetMe_FullMethodName         = "/ptera.v1.UserService/GetMe"
+	UserService_GetUser_FullMethodName       = "/ptera.v1.UserService/GetUser"
+	UserService_UpdateProfile_FullMethodName = "/ptera.v1.UserService/UpdateProfile"
+)
+
+// UserServiceClient is the client API for UserService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UserServiceClient interface {
+	// GetMe は自分のプロフィールを返します (メールアドレスを含む)。
+	// 呼び出し元は authorization メタデータの "Bearer <FirebaseのIDトークン>" で判定します。
+	GetMe(ctx context.Context, in *GetMeRequest, opts ...grpc.CallOption) (*User, error)
+	// GetUser は他のユーザーの公開プロフィールを返します。
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error)
+	// UpdateProfile は指定されたフィールドのみ更新します。
+	UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*User, error)
+}
+
+type userServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserServiceClient(cc grpc.ClientConnInterface) UserServiceClient {
+	return &userServiceClient{cc}
+}
+
+func (c *userServiceClient) GetMe(ctx context.Context, in *GetMeRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_GetMe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_GetUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_UpdateProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+// All implementations must embed UnimplementedUserServiceServer
+// for forward compatibility.
+type UserServiceServer interface {
+	// GetMe は自分のプロフィールを返します (メールアドレスを含む)。
+	// 呼び出し元は authorization メタデータの "Bearer <FirebaseのIDトークン>" で判定します。
+	GetMe(context.Context, *GetMeRequest) (*User, error)
+	// GetUser は他のユーザーの公開プロフィールを返します。
+	GetUser(context.Context, *GetUserRequest) (*User, error)
+	// UpdateProfile は指定されたフィールドのみ更新します。
+	UpdateProfile(context.Context, *UpdateProfileRequest) (*User, error)
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+// UnimplementedUserServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUserServiceServer struct{}
+
+func (UnimplementedUserServiceServer) GetMe(context.Context, *GetMeRequest) (*User, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMe not implemented")
+}
+func (UnimplementedUserServiceServer) GetUser(context.Context, *GetUserRequest) (*User, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateProfile(context.Context, *UpdateProfileRequest) (*User, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateProfile not implemented")
+}
+func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
+func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserServiceServer will
+// result in compilation errors.
+type UnsafeUserServiceServer interface {
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
+	// If the following call panics, it indicates UnimplementedUserServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UserService_ServiceDesc, srv)
+}
+
+func _UserService_GetMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetMe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetMe(ctx, req.(*GetMeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateProfile(ctx, req.(*UpdateProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ptera.v1.UserService",
+	HandlerType: (*UserServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMe",
+			Handler:    _UserService_GetMe_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _UserService_GetUser_Handler,
+		},
+		{
+			MethodName: "UpdateProfile",
+			Handler:    _UserService_UpdateProfile_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ptera/v1/ptera.proto",
+}
+
+const (
+	QuotaService_GetQuotaUsage_FullMethodName   = "/ptera.v1.QuotaService/GetQuotaUsage"
+	QuotaService_ResetQuotaUsage_FullMethodName = "/ptera.v1.QuotaService/ResetQuotaUsage"
+)
+
+// QuotaServiceClient is the client API for QuotaService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// QuotaService はAI利用量 (呼び出し回数・トークン数) の確認とリセットを行う管理者用サービスです。
+// "authorization: Bearer <Firebase IDトークン>" メタデータが必要で、トークンのユーザーが ADMIN_USER_IDS に含まれている必要があります。
+type QuotaServiceClient interface {
+	// GetQuotaUsage はユーザーまたはサークルの日次・月次の利用量を返します。
+	GetQuotaUsage(ctx context.Context, in *GetQuotaUsageRequest, opts ...grpc.CallOption) (*GetQuotaUsageResponse, error)
+	// ResetQuotaUsage は現在の期間の利用量を0に戻します。
+	ResetQuotaUsage(ctx context.Context, in *ResetQuotaUsageRequest, opts ...grpc.CallOption) (*GetQuotaUsageResponse, error)
+}
+
+type quotaServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewQuotaServiceClient(cc grpc.ClientConnInterface) QuotaServiceClient {
+	return &quotaServiceClient{cc}
+}
+
+func (c *quotaServiceClient) GetQuotaUsage(ctx context.Context, in *GetQuotaUsageRequest, opts ...grpc.CallOption) (*GetQuotaUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetQuotaUsageResponse)
+	err := c.cc.Invoke(ctx, QuotaService_GetQuotaUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quotaServiceClient) ResetQuotaUsage(ctx context.Context, in *ResetQuotaUsageRequest, opts ...grpc.CallOption) (*GetQuotaUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetQuotaUsageResponse)
+	err := c.cc.Invoke(ctx, QuotaService_ResetQuotaUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// QuotaServiceServer is the server API for QuotaService service.
+// All implementations must embed UnimplementedQuotaServiceServer
+// for forward compatibility.
+//
+// QuotaService はAI利用量 (呼び出し回数・トークン数) の確認とリセットを行う管理者用サービスです。
+// "authorization: Bearer <Firebase IDトークン>" メタデータが必要で、トークンのユーザーが ADMIN_USER_IDS に含まれている必要があります。
+type QuotaServiceServer interface {
+	// GetQuotaUsage はユーザーまたはサークルの日次・月次の利用量を返します。
+	GetQuotaUsage(context.Context, *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error)
+	// ResetQuotaUsage は現在の期間の利用量を0に戻します。
+	ResetQuotaUsage(context.Context, *ResetQuotaUsageRequest) (*GetQuotaUsageResponse, error)
+	mustEmbedUnimplementedQuotaServiceServer()
+}
+
+// UnimplementedQuotaServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedQuotaServiceServer struct{}
+
+func (UnimplementedQuotaServiceServer) GetQuotaUsage(context.Context, *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetQuotaUsage not implemented")
+}
+func (UnimplementedQuotaServiceServer) ResetQuotaUsage(context.Context, *ResetQuotaUsageRequest) (*GetQuotaUsageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResetQuotaUsage not implemented")
+}
+func (UnimplementedQuotaServiceServer) mustEmbedUnimplementedQuotaServiceServer() {}
+func (UnimplementedQuotaServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeQuotaServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to QuotaServiceServer will
+// result in compilation errors.
+type UnsafeQuotaServiceServer interface {
+	mustEmbedUnimplementedQuotaServiceServer()
+}
+
+func RegisterQuotaServiceServer(s grpc.ServiceRegistrar, srv QuotaServiceServer) {
+	// If the following call panics, it indicates UnimplementedQuotaServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&QuotaService_ServiceDesc, srv)
+}
+
+func _QuotaService_GetQuotaUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuotaUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuotaServiceServer).GetQuotaUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuotaService_GetQuotaUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuotaServiceServer).GetQuotaUsage(ctx, req.(*GetQuotaUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuotaService_ResetQuotaUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetQuotaUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuotaServiceServer).ResetQuotaUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuotaService_ResetQuotaUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuotaServiceServer).ResetQuotaUsage(ctx, req.(*ResetQuotaUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// QuotaService_ServiceDesc is the grpc.ServiceDesc for QuotaService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var QuotaService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ptera.v1.QuotaService",
+	HandlerType: (*QuotaServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetQuotaUsage",
+			Handler:    _QuotaService_GetQuotaUsage_Handler,
+		},
+		{
+			MethodName: "ResetQuotaUsage",
+			Handler:    _QuotaService_ResetQuotaUsage_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
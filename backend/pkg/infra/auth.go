@@ -0,0 +1,24 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+
+	firebase "firebase.google.com/go/v4"
+	"firebase.google.com/go/v4/auth"
+)
+
+// NewAuthClient creates the Firebase Auth client used to verify the ID tokens of callers
+func NewAuthClient(ctx context.Context) (*auth.Client, error) {
+	projectID, options := clientOptions()
+
+	app, err := firebase.NewApp(ctx, &firebase.Config{ProjectID: projectID}, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create firebase app (projectID=%s): %w", projectID, err)
+	}
+	client, err := app.Auth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create firebase auth client: %w", err)
+	}
+	return client, nil
+}
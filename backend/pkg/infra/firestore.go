@@ -11,6 +11,18 @@ import (
 )
 
 func NewFirestoreClient(ctx context.Context) (*firestore.Client, error) {
+	projectID, options := clientOptions()
+
+	client, err := firestore.NewClient(ctx, projectID, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create firestore client (projectID=%s): %w", projectID, err)
+	}
+
+	return client, nil
+}
+
+// clientOptions returns the project ID and credentials shared by the Google clients
+func clientOptions() (string, []option.ClientOption) {
 	projectID := os.Getenv("GOOGLE_CLOUD_PROJECT")
 
 	var options []option.ClientOption
@@ -37,10 +49,5 @@ func NewFirestoreClient(ctx context.Context) (*firestore.Client, error) {
 		projectID = "jyogi-cards-dev"
 	}
 
-	client, err := firestore.NewClient(ctx, projectID, options...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create firestore client (projectID=%s): %w", projectID, err)
-	}
-
-	return client, nil
+	return projectID, options
 }
@@ -0,0 +1,274 @@
+package moderation
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/ai"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+)
+
+const (
+	StageInput  = ai.SafetyStageInput
+	StageOutput = ai.SafetyStageOutput
+
+	SourceSafety    = "safety"
+	SourceBlocklist = "blocklist"
+
+	ThresholdOff = "OFF" // ignore the safety ratings (answers blocked by the model are still flagged)
+)
+
+//go:embed blocklist_ja.txt
+var defaultBlocklist string
+
+// probabilityLevels orders the safety probabilities reported by the model
+var probabilityLevels = map[string]int{
+	"NEGLIGIBLE": 1,
+	"LOW":        2,
+	"MEDIUM":     3,
+	"HIGH":       4,
+}
+
+// Policy configures what the moderation stage flags
+type Policy struct {
+	// Threshold is the lowest safety probability that flags content: LOW, MEDIUM, HIGH or OFF
+	Threshold string
+	// DefaultBlocklist enables the embedded Japanese blocklist
+	DefaultBlocklist bool
+	// Blocklist holds additional words (e.g. from MODERATION_BLOCKLIST_FILE)
+	Blocklist []string
+}
+
+func DefaultPolicy() Policy {
+	return Policy{
+		Threshold:        "MEDIUM",
+		DefaultBlocklist: true,
+	}
+}
+
+// ParseBlocklist parses one word per line, ignoring blank lines and lines starting with #
+func ParseBlocklist(s string) []string {
+	var words []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			words = append(words, line)
+		}
+	}
+	return words
+}
+
+// Reason explains why content was flagged
+type Reason struct {
+	Stage  string // StageInput or StageOutput
+	Source string // SourceSafety or SourceBlocklist
+	Field  string // empty for the whole image or answer
+	Detail string // safety category or matched word
+}
+
+func (r Reason) ToProto() *ptera.ModerationReason {
+	return &ptera.ModerationReason{
+		Stage:  r.Stage,
+		Source: r.Source,
+		Field:  r.Field,
+		Detail: r.Detail,
+	}
+}
+
+// Field is a named text value to check
+type Field struct {
+	Name  string
+	Value string
+}
+
+type blockedWord struct {
+	word       string
+	normalized string
+}
+
+// Moderator checks images and texts against a Policy, before and after generation
+type Moderator struct {
+	threshold int // 0 disables the safety rating check
+	words     []blockedWord
+}
+
+func NewModerator(policy Policy) (*Moderator, error) {
+	m := &Moderator{}
+
+	threshold := strings.ToUpper(strings.TrimSpace(policy.Threshold))
+	if threshold != ThresholdOff {
+		level, ok := probabilityLevels[threshold]
+		if !ok || level < probabilityLevels["LOW"] {
+			return nil, fmt.Errorf("invalid moderation threshold: %q", policy.Threshold)
+		}
+		m.threshold = level
+	}
+
+	words := policy.Blocklist
+	if policy.DefaultBlocklist {
+		words = append(ParseBlocklist(defaultBlocklist), words...)
+	}
+	for _, w := range words {
+		if normalized := normalize(w); normalized != "" {
+			m.words = append(m.words, blockedWord{word: w, normalized: normalized})
+		}
+	}
+	return m, nil
+}
+
+// CheckText matches the fields against the blocklist
+func (m *Moderator) CheckText(stage string, fields ...Field) []Reason {
+	var reasons []Reason
+	for _, f := range fields {
+		value := normalize(f.Value)
+		if value == "" {
+			continue
+		}
+		for _, w := range m.words {
+			if containsWord(value, w.normalized) {
+				reasons = append(reasons, Reason{Stage: stage, Source: SourceBlocklist, Field: f.Name, Detail: w.word})
+				break
+			}
+		}
+	}
+	return reasons
+}
+
+// CheckRatings flags the safety categories rated at or above the threshold, or blocked by the model
+func (m *Moderator) CheckRatings(ratings []ai.SafetyRating) []Reason {
+	var reasons []Reason
+	for _, r := range ratings {
+		if r.Blocked || (m.threshold > 0 && probabilityLevels[r.Probability] >= m.threshold) {
+			reasons = append(reasons, Reason{
+				Stage:  r.Stage,
+				Source: SourceSafety,
+				Detail: fmt.Sprintf("%s (%s)", r.Category, r.Probability),
+			})
+		}
+	}
+	return reasons
+}
+
+// CheckBlocked explains an answer the model refused to give; it is always flagged
+func (m *Moderator) CheckBlocked(err *ai.BlockedError) []Reason {
+	reasons := m.CheckRatings(err.Ratings)
+	if len(reasons) == 0 {
+		reasons = append(reasons, Reason{Stage: err.Stage, Source: SourceSafety, Detail: err.Reason})
+	}
+	return reasons
+}
+
+// CheckInput checks the fields a user filled in before asking the model
+func (m *Moderator) CheckInput(input ai.CompletionInput) []Reason {
+	return m.CheckText(StageInput,
+		Field{"name", input.Name},
+		Field{"faculty", input.Faculty},
+		Field{"department", input.Department},
+		Field{"position", input.Position},
+		Field{"hobby", input.Hobby},
+		Field{"description", input.Description},
+	)
+}
+
+// CheckSuggestions checks the safety ratings and the fields of a generated answer
+func (m *Moderator) CheckSuggestions(s *ai.CardSuggestions) []Reason {
+	reasons := m.CheckRatings(s.SafetyRatings)
+	return append(reasons, m.CheckText(StageOutput,
+		Field{"name", s.Name},
+		Field{"faculty", s.Faculty},
+		Field{"department", s.Department},
+		Field{"position", s.Position},
+		Field{"hobby", s.Hobby},
+		Field{"description", s.Description},
+		Field{"stat_profile.reason", s.StatProfile.Reason},
+	)...)
+}
+
+// CheckCard checks the texts of a card before it is saved
+func (m *Moderator) CheckCard(card *ptera.Card) []Reason {
+	return m.CheckText(StageInput,
+		Field{"name", card.Name},
+		Field{"position", card.Position},
+		Field{"hobby", card.Hobby},
+		Field{"description", card.Description},
+		Field{"affiliated_group", card.GetAffiliatedGroup()},
+	)
+}
+
+// ReasonsToProto converts reasons for a flagged response
+func ReasonsToProto(reasons []Reason) []*ptera.ModerationReason {
+	result := make([]*ptera.ModerationReason, 0, len(reasons))
+	for _, r := range reasons {
+		result = append(result, r.ToProto())
+	}
+	return result
+}
+
+// Status rejects flagged content with InvalidArgument and a BadRequest detail per reason
+func Status(reasons []Reason) error {
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(reasons))
+	for _, r := range reasons {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       r.Field,
+			Description: fmt.Sprintf("flagged by %s: %s", r.Source, r.Detail),
+		})
+	}
+
+	st := status.New(codes.InvalidArgument, "content was flagged by moderation")
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// containsWord reports whether word occurs in value as a token. Matches never span a space, and since
+// Japanese is written without spaces, a match is also rejected where it would continue a katakana or Latin word: "ブス" does not match "ラブストーリー",
+// nor "デブ" "デブサミ". Hiragana and kanji are matched as substrings, since endings follow them directly.
+func containsWord(value, word string) bool {
+	first, _ := utf8.DecodeRuneInString(word)
+	last, _ := utf8.DecodeLastRuneInString(word)
+	for i := 0; i < len(value); {
+		j := strings.Index(value[i:], word)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(word)
+
+		before, _ := utf8.DecodeLastRuneInString(value[:start])
+		after, _ := utf8.DecodeRuneInString(value[end:])
+		if (start == 0 || !continuesWord(before, first)) && (end == len(value) || !continuesWord(last, after)) {
+			return true
+		}
+		_, size := utf8.DecodeRuneInString(value[start:])
+		i = start + size
+	}
+	return false
+}
+
+// continuesWord reports whether a and b would be read as one word: both katakana, or both Latin letters or digits
+func continuesWord(a, b rune) bool {
+	katakana := func(r rune) bool { return unicode.Is(unicode.Katakana, r) || r == 'ー' }
+	latin := func(r rune) bool { return unicode.Is(unicode.Latin, r) || unicode.IsDigit(r) }
+	return katakana(a) && katakana(b) || latin(a) && latin(b)
+}
+
+// normalize folds full-width/half-width forms and case, and drops symbols, so that "ｷﾓい" and "キ・モ・い"
+// match "キモい". Spaces are kept (collapsed to one) as word boundaries.
+func normalize(s string) string {
+	s = strings.ToLower(norm.NFKC.String(s))
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			return -1
+		}
+		return r
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,124 @@
+package moderation
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/ai"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "ｷﾓい", want: "キモい"},
+		{in: "キ・モ・い", want: "キモい"},
+		{in: "Ｂａｋａ  ＹＡＲＯ!", want: "baka yaro"},
+		{in: " ラブ　ストーリー ", want: "ラブ ストーリー"},
+		{in: "  ", want: ""},
+	}
+	for _, tt := range tests {
+		if got := normalize(tt.in); got != tt.want {
+			t.Errorf("normalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCheckTextDefaultBlocklist(t *testing.T) {
+	m, err := NewModerator(DefaultPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		text string
+		want string // matched word, empty when the text must pass
+	}{
+		// Known false positives of plain substring matching
+		{text: "ラブストーリーが好き", want: ""},
+		{text: "デブサミに毎年参加", want: ""},
+		{text: "ハゲタカファンド研究会", want: ""},
+		{text: "クズハ様推し", want: ""},
+		{text: "アブ ストラクト", want: ""},
+		{text: "エロスとタナトス", want: ""},
+		{text: "ボブスレー部", want: ""},
+		// Blocked words, including spacing and width tricks
+		{text: "お前はブスだ", want: "ブス"},
+		{text: "ラブ ストーリー", want: ""},
+		{text: "お前 ブス", want: "ブス"},
+		{text: "ﾃﾞﾌﾞ!", want: "デブ"},
+		{text: "クズ野郎", want: "クズ"},
+		{text: "エロい話", want: "エロ"},
+		{text: "死ねばいいのに", want: "死ね"},
+		{text: "ｷﾓい", want: "キモい"},
+		{text: "キ・モ・い", want: "キモい"},
+	}
+	for _, tt := range tests {
+		reasons := m.CheckText(StageInput, Field{Name: "description", Value: tt.text})
+		var got string
+		if len(reasons) > 0 {
+			got = reasons[0].Detail
+		}
+		if got != tt.want {
+			t.Errorf("CheckText(%q) matched %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestCheckTextCustomBlocklist(t *testing.T) {
+	m, err := NewModerator(Policy{Threshold: ThresholdOff, Blocklist: []string{"ass", "カス", "!!!"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{text: "first class", want: false},
+		{text: "passion", want: false},
+		{text: "kiss my ass!", want: true},
+		{text: "カスタム", want: false},
+		{text: "このカスが", want: true},
+		{text: "wow!!!", want: false}, // a word of symbols only normalizes to nothing and is ignored
+	}
+	for _, tt := range tests {
+		if got := len(m.CheckText(StageInput, Field{Name: "hobby", Value: tt.text})) > 0; got != tt.want {
+			t.Errorf("CheckText(%q) = %t, want %t", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestCheckRatings(t *testing.T) {
+	ratings := []ai.SafetyRating{
+		{Stage: StageOutput, Category: "HARASSMENT", Probability: "LOW"},
+		{Stage: StageOutput, Category: "HATE_SPEECH", Probability: "MEDIUM"},
+		{Stage: StageOutput, Category: "DANGEROUS", Probability: "NEGLIGIBLE", Blocked: true},
+	}
+	tests := []struct {
+		threshold string
+		want      []string
+	}{
+		{threshold: "LOW", want: []string{"HARASSMENT (LOW)", "HATE_SPEECH (MEDIUM)", "DANGEROUS (NEGLIGIBLE)"}},
+		{threshold: "MEDIUM", want: []string{"HATE_SPEECH (MEDIUM)", "DANGEROUS (NEGLIGIBLE)"}},
+		{threshold: "OFF", want: []string{"DANGEROUS (NEGLIGIBLE)"}},
+	}
+	for _, tt := range tests {
+		m, err := NewModerator(Policy{Threshold: tt.threshold})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, r := range m.CheckRatings(ratings) {
+			got = append(got, r.Detail)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("threshold %s: got %v, want %v", tt.threshold, got, tt.want)
+		}
+	}
+
+	for _, threshold := range []string{"NEGLIGIBLE", "SEVERE", ""} {
+		if _, err := NewModerator(Policy{Threshold: threshold}); err == nil {
+			t.Errorf("NewModerator(%q) succeeded, want an error", threshold)
+		}
+	}
+}
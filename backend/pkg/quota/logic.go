@@ -0,0 +1,148 @@
+package quota
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ScopeUser   = "user"
+	ScopeCircle = "circle"
+
+	WindowDaily   = "daily"
+	WindowMonthly = "monthly"
+)
+
+// Periods are counted in Japan time, so budgets reset at midnight JST
+var jst = time.FixedZone("JST", 9*60*60)
+
+// Limit is the budget of one window. Zero means unlimited.
+type Limit struct {
+	Calls  int64
+	Tokens int64
+}
+
+// Config holds the budgets of each scope and window
+type Config struct {
+	UserDaily     Limit
+	UserMonthly   Limit
+	CircleDaily   Limit
+	CircleMonthly Limit
+}
+
+func DefaultConfig() Config {
+	return Config{
+		UserDaily:     Limit{Calls: 20, Tokens: 100_000},
+		UserMonthly:   Limit{Calls: 200, Tokens: 1_000_000},
+		CircleDaily:   Limit{Calls: 200, Tokens: 1_000_000},
+		CircleMonthly: Limit{Calls: 2_000, Tokens: 10_000_000},
+	}
+}
+
+// Limit returns the budget of the scope and window
+func (c Config) Limit(scope, window string) Limit {
+	if limit := c.limitFor(scope, window); limit != nil {
+		return *limit
+	}
+	return Limit{}
+}
+
+func (c *Config) limitFor(scope, window string) *Limit {
+	switch {
+	case scope == ScopeUser && window == WindowDaily:
+		return &c.UserDaily
+	case scope == ScopeUser && window == WindowMonthly:
+		return &c.UserMonthly
+	case scope == ScopeCircle && window == WindowDaily:
+		return &c.CircleDaily
+	case scope == ScopeCircle && window == WindowMonthly:
+		return &c.CircleMonthly
+	}
+	return nil
+}
+
+// ParseLimits overrides the budgets of base from a comma-separated list
+// such as "user.daily.calls=20,circle.monthly.tokens=5000000" (e.g. AI_QUOTA_LIMITS)
+func ParseLimits(s string, base Config) (Config, error) {
+	config := base
+	for _, entry := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return Config{}, fmt.Errorf("invalid quota limit entry: %q", entry)
+		}
+
+		parts := strings.Split(strings.ToLower(strings.TrimSpace(key)), ".")
+		if len(parts) != 3 {
+			return Config{}, fmt.Errorf("invalid quota limit key: %q", key)
+		}
+		limit := config.limitFor(parts[0], parts[1])
+		if limit == nil {
+			return Config{}, fmt.Errorf("unknown quota scope or window: %q", key)
+		}
+
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil || n < 0 {
+			return Config{}, fmt.Errorf("invalid quota limit for %s: %q", key, value)
+		}
+		switch parts[2] {
+		case "calls":
+			limit.Calls = n
+		case "tokens":
+			limit.Tokens = n
+		default:
+			return Config{}, fmt.Errorf("unknown quota unit: %q", key)
+		}
+	}
+	return config, nil
+}
+
+// period returns the key of the window containing t (e.g. "2026-10-19" or "2026-10") and when it ends
+func period(window string, t time.Time) (string, time.Time) {
+	t = t.In(jst)
+	if window == WindowMonthly {
+		start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, jst)
+		return start.Format("2006-01"), start.AddDate(0, 1, 0)
+	}
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, jst)
+	return start.Format("2006-01-02"), start.AddDate(0, 0, 1)
+}
+
+// Bucket identifies the usage counter of a subject in one period
+type Bucket struct {
+	Scope     string
+	SubjectID string
+	Window    string
+	Period    string
+	ResetsAt  time.Time
+}
+
+func (b Bucket) docID() string {
+	return b.Scope + "_" + b.SubjectID + "_" + b.Period
+}
+
+// windowBuckets returns the daily and monthly buckets of a subject at now
+func windowBuckets(scope, subjectID string, now time.Time) []Bucket {
+	var buckets []Bucket
+	for _, window := range []string{WindowDaily, WindowMonthly} {
+		key, resetsAt := period(window, now)
+		buckets = append(buckets, Bucket{
+			Scope:     scope,
+			SubjectID: subjectID,
+			Window:    window,
+			Period:    key,
+			ResetsAt:  resetsAt,
+		})
+	}
+	return buckets
+}
+
+// bucketsFor returns the buckets an AI call of the user is counted in (the circle's too, if set)
+func bucketsFor(userID, circleID string, now time.Time) []Bucket {
+	buckets := windowBuckets(ScopeUser, userID, now)
+	if circleID != "" {
+		buckets = append(buckets, windowBuckets(ScopeCircle, circleID, now)...)
+	}
+	return buckets
+}
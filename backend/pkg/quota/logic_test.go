@@ -0,0 +1,109 @@
+package quota
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLimits(t *testing.T) {
+	base := DefaultConfig()
+	tests := []struct {
+		name    string
+		in      string
+		check   func(c Config) bool
+		wantErr bool
+	}{
+		{
+			name:  "override one limit",
+			in:    "user.daily.calls=5",
+			check: func(c Config) bool { return c.UserDaily.Calls == 5 && c.UserDaily.Tokens == base.UserDaily.Tokens },
+		},
+		{
+			name: "several entries, case and spaces",
+			in:   " Circle.Monthly.Tokens = 42 ,user.monthly.calls=0",
+			check: func(c Config) bool {
+				return c.CircleMonthly.Tokens == 42 && c.UserMonthly.Calls == 0 && c.CircleDaily == base.CircleDaily
+			},
+		},
+		{name: "missing value", in: "user.daily.calls", wantErr: true},
+		{name: "negative", in: "user.daily.calls=-1", wantErr: true},
+		{name: "not a number", in: "user.daily.calls=ten", wantErr: true},
+		{name: "unknown scope", in: "team.daily.calls=1", wantErr: true},
+		{name: "unknown window", in: "user.weekly.calls=1", wantErr: true},
+		{name: "unknown unit", in: "user.daily.bytes=1", wantErr: true},
+		{name: "short key", in: "user.calls=1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLimits(tt.in, base)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLimits(%q) err = %v, wantErr %t", tt.in, err, tt.wantErr)
+			}
+			if err == nil && !tt.check(got) {
+				t.Errorf("ParseLimits(%q) = %+v", tt.in, got)
+			}
+		})
+	}
+}
+
+func TestPeriod(t *testing.T) {
+	tests := []struct {
+		name         string
+		window       string
+		t            time.Time
+		wantKey      string
+		wantResetsAt time.Time
+	}{
+		{
+			name:         "daily resets at midnight JST",
+			window:       WindowDaily,
+			t:            time.Date(2026, 10, 18, 15, 30, 0, 0, time.UTC), // 00:30 JST on the 19th
+			wantKey:      "2026-10-19",
+			wantResetsAt: time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "daily before midnight JST",
+			window:       WindowDaily,
+			t:            time.Date(2026, 10, 18, 14, 59, 0, 0, time.UTC),
+			wantKey:      "2026-10-18",
+			wantResetsAt: time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "monthly across the year",
+			window:       WindowMonthly,
+			t:            time.Date(2026, 12, 31, 16, 0, 0, 0, time.UTC), // January 1st in JST
+			wantKey:      "2027-01",
+			wantResetsAt: time.Date(2027, 1, 31, 15, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, resetsAt := period(tt.window, tt.t)
+			if key != tt.wantKey || !resetsAt.Equal(tt.wantResetsAt) {
+				t.Errorf("period() = %s, %s, want %s, %s", key, resetsAt, tt.wantKey, tt.wantResetsAt)
+			}
+		})
+	}
+}
+
+func TestBucketsFor(t *testing.T) {
+	now := time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC)
+
+	withCircle := bucketsFor("u1", "c1", now)
+	if len(withCircle) != 4 {
+		t.Fatalf("got %d buckets, want 4", len(withCircle))
+	}
+	ids := make([]string, len(withCircle))
+	for i, b := range withCircle {
+		ids[i] = b.docID()
+	}
+	want := "user_u1_2026-10-19,user_u1_2026-10,circle_c1_2026-10-19,circle_c1_2026-10"
+	if got := strings.Join(ids, ","); got != want {
+		t.Errorf("buckets = %s, want %s", got, want)
+	}
+
+	if got := bucketsFor("u1", "", now); len(got) != 2 {
+		t.Errorf("got %d buckets without a circle, want 2", len(got))
+	}
+}
@@ -0,0 +1,184 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CollectionUsage holds one usage counter per subject and period (document ID is scope_subjectId_period)
+const CollectionUsage = "ai_usage"
+
+var ErrQuotaExceeded = errors.New("ai quota exceeded")
+
+// ExceededError tells which budget was exhausted and when it resets
+type ExceededError struct {
+	Bucket Bucket
+	Unit   string // "calls" or "tokens"
+	Limit  int64
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("%s: %s %s %s limit of %d reached", ErrQuotaExceeded, e.Bucket.Scope, e.Bucket.Window, e.Unit, e.Limit)
+}
+
+func (e *ExceededError) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+// Usage is a usage counter document
+type Usage struct {
+	Scope     string    `firestore:"scope"`
+	SubjectID string    `firestore:"subjectId"`
+	Window    string    `firestore:"window"`
+	Period    string    `firestore:"period"`
+	Calls     int64     `firestore:"calls"`
+	Tokens    int64     `firestore:"tokens"`
+	ResetsAt  time.Time `firestore:"resetsAt"`
+}
+
+func (u *Usage) ToProto(limit Limit) *ptera.QuotaUsage {
+	return &ptera.QuotaUsage{
+		Scope:      u.Scope,
+		SubjectId:  u.SubjectID,
+		Window:     u.Window,
+		Period:     u.Period,
+		Calls:      u.Calls,
+		CallLimit:  limit.Calls,
+		Tokens:     u.Tokens,
+		TokenLimit: limit.Tokens,
+		ResetsAt:   timestamppb.New(u.ResetsAt),
+	}
+}
+
+type Repository struct {
+	client *firestore.Client
+}
+
+func NewRepository(client *firestore.Client) *Repository {
+	return &Repository{client: client}
+}
+
+// Reserve counts one call in every bucket, unless one of them has exhausted its calls or tokens.
+// Fails with *ExceededError (wrapping ErrQuotaExceeded) without counting anything in that case.
+func (r *Repository) Reserve(ctx context.Context, buckets []Bucket, config Config) error {
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// All reads must come before writes in a transaction
+		usages := make([]*Usage, len(buckets))
+		for i, b := range buckets {
+			usage, err := r.getUsage(tx, b)
+			if err != nil {
+				return err
+			}
+			usages[i] = usage
+		}
+
+		for i, b := range buckets {
+			limit := config.Limit(b.Scope, b.Window)
+			if limit.Calls > 0 && usages[i].Calls >= limit.Calls {
+				return &ExceededError{Bucket: b, Unit: "calls", Limit: limit.Calls}
+			}
+			if limit.Tokens > 0 && usages[i].Tokens >= limit.Tokens {
+				return &ExceededError{Bucket: b, Unit: "tokens", Limit: limit.Tokens}
+			}
+		}
+
+		for i, b := range buckets {
+			usages[i].Calls++
+			if err := tx.Set(r.client.Collection(CollectionUsage).Doc(b.docID()), usages[i]); err != nil {
+				return fmt.Errorf("failed to update usage: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// AddTokens adds the tokens consumed by a reserved call to every bucket
+func (r *Repository) AddTokens(ctx context.Context, buckets []Bucket, tokens int64) error {
+	if tokens <= 0 {
+		return nil
+	}
+
+	batch := r.client.Batch()
+	for _, b := range buckets {
+		batch.Set(r.client.Collection(CollectionUsage).Doc(b.docID()), map[string]interface{}{
+			"scope":     b.Scope,
+			"subjectId": b.SubjectID,
+			"window":    b.Window,
+			"period":    b.Period,
+			"tokens":    firestore.Increment(tokens),
+			"resetsAt":  b.ResetsAt,
+		}, firestore.MergeAll)
+	}
+	if _, err := batch.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to add token usage: %w", err)
+	}
+	return nil
+}
+
+// GetUsages returns the counters of the buckets (zero for buckets without usage)
+func (r *Repository) GetUsages(ctx context.Context, buckets []Bucket) ([]*Usage, error) {
+	refs := make([]*firestore.DocumentRef, len(buckets))
+	for i, b := range buckets {
+		refs[i] = r.client.Collection(CollectionUsage).Doc(b.docID())
+	}
+
+	docs, err := r.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage: %w", err)
+	}
+
+	usages := make([]*Usage, len(buckets))
+	for i, doc := range docs {
+		usage, err := usageFromDocument(doc, buckets[i])
+		if err != nil {
+			return nil, err
+		}
+		usages[i] = usage
+	}
+	return usages, nil
+}
+
+// ResetUsages deletes the counters of the buckets
+func (r *Repository) ResetUsages(ctx context.Context, buckets []Bucket) error {
+	batch := r.client.Batch()
+	for _, b := range buckets {
+		batch.Delete(r.client.Collection(CollectionUsage).Doc(b.docID()))
+	}
+	if _, err := batch.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to reset usage: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) getUsage(tx *firestore.Transaction, b Bucket) (*Usage, error) {
+	doc, err := tx.Get(r.client.Collection(CollectionUsage).Doc(b.docID()))
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, fmt.Errorf("failed to get usage: %w", err)
+	}
+	return usageFromDocument(doc, b)
+}
+
+func usageFromDocument(doc *firestore.DocumentSnapshot, b Bucket) (*Usage, error) {
+	usage := &Usage{
+		Scope:     b.Scope,
+		SubjectID: b.SubjectID,
+		Window:    b.Window,
+		Period:    b.Period,
+		ResetsAt:  b.ResetsAt,
+	}
+	if doc == nil || !doc.Exists() {
+		return usage, nil
+	}
+	if err := doc.DataTo(usage); err != nil {
+		return nil, fmt.Errorf("failed to parse usage: %w", err)
+	}
+	return usage, nil
+}
@@ -0,0 +1,211 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/authn"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/user"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var ErrNotAdmin = errors.New("caller is not an admin")
+
+// Service enforces the AI budgets of users and circles and implements the admin QuotaService
+type Service struct {
+	ptera.UnimplementedQuotaServiceServer
+	repo          *Repository
+	userRepo      *user.Repository
+	logger        *slog.Logger
+	config        Config
+	adminIDs      map[string]bool
+	authenticator *authn.Authenticator
+}
+
+func NewService(logger *slog.Logger, repo *Repository, userRepo *user.Repository, config Config, adminIDs []string, authenticator *authn.Authenticator) *Service {
+	admins := make(map[string]bool, len(adminIDs))
+	for _, id := range adminIDs {
+		admins[id] = true
+	}
+	return &Service{
+		repo:          repo,
+		userRepo:      userRepo,
+		logger:        logger,
+		config:        config,
+		adminIDs:      admins,
+		authenticator: authenticator,
+	}
+}
+
+// ParseAdminIDs parses a comma-separated user ID list (e.g. ADMIN_USER_IDS)
+func ParseAdminIDs(s string) []string {
+	var ids []string
+	for _, id := range strings.Split(s, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Reservation is an AI call counted against the budgets of a user and their circle
+type Reservation struct {
+	buckets []Bucket
+}
+
+// Reserve counts one AI call for the user and their circle.
+// Returns a ResourceExhausted status with RetryInfo and QuotaFailure details when a budget is exhausted.
+func (s *Service) Reserve(ctx context.Context, userID string) (*Reservation, error) {
+	u, err := s.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, s.toStatus("failed to get user", err)
+	}
+
+	buckets := bucketsFor(userID, u.GetCircleId(), time.Now())
+	if err := s.repo.Reserve(ctx, buckets, s.config); err != nil {
+		return nil, s.toStatus("failed to reserve ai quota", err)
+	}
+	return &Reservation{buckets: buckets}, nil
+}
+
+// ReserveCircle counts one AI call for the circle only, for calls no single user asked for (e.g. battle commentary)
+func (s *Service) ReserveCircle(ctx context.Context, circleID string) (*Reservation, error) {
+	buckets := windowBuckets(ScopeCircle, circleID, time.Now())
+	if err := s.repo.Reserve(ctx, buckets, s.config); err != nil {
+		return nil, s.toStatus("failed to reserve ai quota", err)
+	}
+	return &Reservation{buckets: buckets}, nil
+}
+
+// RecordTokens adds the tokens consumed by a reserved call. Failures are only logged,
+// since the call has already been made.
+func (s *Service) RecordTokens(ctx context.Context, r *Reservation, tokens int64) {
+	if err := s.repo.AddTokens(ctx, r.buckets, tokens); err != nil {
+		s.logger.Error("failed to record ai token usage", "tokens", tokens, "error", err)
+	}
+}
+
+// GetQuotaUsage returns the current daily and monthly usage of a user or circle
+func (s *Service) GetQuotaUsage(ctx context.Context, req *ptera.GetQuotaUsageRequest) (*ptera.GetQuotaUsageResponse, error) {
+	if _, err := s.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	buckets, err := s.adminBuckets(req.UserId, req.CircleId, "")
+	if err != nil {
+		return nil, err
+	}
+	return s.usageResponse(ctx, buckets)
+}
+
+// ResetQuotaUsage clears the usage of the current period and returns the reset counters
+func (s *Service) ResetQuotaUsage(ctx context.Context, req *ptera.ResetQuotaUsageRequest) (*ptera.GetQuotaUsageResponse, error) {
+	adminID, err := s.authorizeAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	buckets, err := s.adminBuckets(req.UserId, req.CircleId, req.Window)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ResetUsages(ctx, buckets); err != nil {
+		return nil, s.toStatus("failed to reset quota usage", err)
+	}
+	s.logger.Info("ai quota usage reset", "admin_user_id", adminID, "user_id", req.UserId, "circle_id", req.CircleId, "window", req.Window)
+	return s.usageResponse(ctx, buckets)
+}
+
+// authorizeAdmin returns the caller's user ID (from their ID token) if it is in ADMIN_USER_IDS
+func (s *Service) authorizeAdmin(ctx context.Context) (string, error) {
+	uid, err := s.authenticator.UserID(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !s.adminIDs[uid] {
+		return "", s.toStatus("quota usage is admin only", ErrNotAdmin)
+	}
+	return uid, nil
+}
+
+// adminBuckets returns the current buckets of the subject (both windows if window is empty)
+func (s *Service) adminBuckets(userID, circleID, window string) ([]Bucket, error) {
+	if (userID == "") == (circleID == "") {
+		return nil, status.Error(codes.InvalidArgument, "exactly one of user_id and circle_id is required")
+	}
+	if window != "" && window != WindowDaily && window != WindowMonthly {
+		return nil, status.Errorf(codes.InvalidArgument, "window must be %q or %q", WindowDaily, WindowMonthly)
+	}
+
+	scope, subjectID := ScopeUser, userID
+	if circleID != "" {
+		scope, subjectID = ScopeCircle, circleID
+	}
+	buckets := windowBuckets(scope, subjectID, time.Now())
+
+	if window == "" {
+		return buckets, nil
+	}
+	var filtered []Bucket
+	for _, b := range buckets {
+		if b.Window == window {
+			filtered = append(filtered, b)
+		}
+	}
+	return filtered, nil
+}
+
+func (s *Service) usageResponse(ctx context.Context, buckets []Bucket) (*ptera.GetQuotaUsageResponse, error) {
+	usages, err := s.repo.GetUsages(ctx, buckets)
+	if err != nil {
+		return nil, s.toStatus("failed to get quota usage", err)
+	}
+
+	resp := &ptera.GetQuotaUsageResponse{}
+	for _, u := range usages {
+		resp.Usages = append(resp.Usages, u.ToProto(s.config.Limit(u.Scope, u.Window)))
+	}
+	return resp, nil
+}
+
+func (s *Service) toStatus(msg string, err error) error {
+	var exceeded *ExceededError
+	switch {
+	case errors.As(err, &exceeded):
+		return exceededStatus(exceeded)
+	case errors.Is(err, user.ErrUserNotFound):
+		return status.Errorf(codes.NotFound, "%s: %v", msg, err)
+	case errors.Is(err, ErrNotAdmin):
+		return status.Errorf(codes.PermissionDenied, "%s: %v", msg, err)
+	default:
+		s.logger.Error(msg, "error", err)
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
+// exceededStatus builds ResourceExhausted with the time until the budget resets
+func exceededStatus(e *ExceededError) error {
+	st := status.New(codes.ResourceExhausted, e.Error())
+	detailed, err := st.WithDetails(
+		&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(time.Until(e.Bucket.ResetsAt).Round(time.Second)),
+		},
+		&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{{
+				Subject:     fmt.Sprintf("%s:%s", e.Bucket.Scope, e.Bucket.SubjectID),
+				Description: fmt.Sprintf("%s %s limit of %d reached (resets at %s)", e.Bucket.Window, e.Unit, e.Limit, e.Bucket.ResetsAt.Format(time.RFC3339)),
+			}},
+		},
+	)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
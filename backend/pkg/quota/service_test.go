@@ -0,0 +1,130 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/auth"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/authn"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeVerifier accepts the ID tokens in its map, returning the mapped user ID
+type fakeVerifier map[string]string
+
+func (f fakeVerifier) VerifyIDToken(ctx context.Context, idToken string) (*auth.Token, error) {
+	uid, ok := f[idToken]
+	if !ok {
+		return nil, errors.New("token is invalid")
+	}
+	return &auth.Token{UID: uid}, nil
+}
+
+func TestAuthorizeAdmin(t *testing.T) {
+	authenticator := authn.NewAuthenticator(fakeVerifier{"admin-token": "admin", "user-token": "user"})
+	tests := []struct {
+		name   string
+		header string
+		want   codes.Code
+	}{
+		{name: "admin", header: "Bearer admin-token", want: codes.OK},
+		{name: "not an admin", header: "Bearer user-token", want: codes.PermissionDenied},
+		{name: "invalid token", header: "Bearer forged", want: codes.Unauthenticated},
+		{name: "no token", want: codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewService(slog.New(slog.DiscardHandler), nil, nil, DefaultConfig(), []string{"admin"}, authenticator)
+			ctx := context.Background()
+			if tt.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.header))
+			}
+
+			uid, err := s.authorizeAdmin(ctx)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("authorizeAdmin() = %v, want %v", err, tt.want)
+			}
+			if err == nil && uid != "admin" {
+				t.Errorf("uid = %q, want admin", uid)
+			}
+		})
+	}
+}
+
+func TestAdminBuckets(t *testing.T) {
+	s := NewService(slog.New(slog.DiscardHandler), nil, nil, DefaultConfig(), nil, nil)
+	tests := []struct {
+		name               string
+		userID, circleID   string
+		window             string
+		wantScope          string
+		wantLen            int
+		wantInvalidRequest bool
+	}{
+		{name: "user, both windows", userID: "u1", wantScope: ScopeUser, wantLen: 2},
+		{name: "circle, monthly", circleID: "c1", window: WindowMonthly, wantScope: ScopeCircle, wantLen: 1},
+		{name: "neither", wantInvalidRequest: true},
+		{name: "both", userID: "u1", circleID: "c1", wantInvalidRequest: true},
+		{name: "unknown window", userID: "u1", window: "weekly", wantInvalidRequest: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets, err := s.adminBuckets(tt.userID, tt.circleID, tt.window)
+			if tt.wantInvalidRequest {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Fatalf("adminBuckets() = %v, want InvalidArgument", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(buckets) != tt.wantLen {
+				t.Fatalf("got %d buckets, want %d", len(buckets), tt.wantLen)
+			}
+			for _, b := range buckets {
+				if b.Scope != tt.wantScope || (tt.window != "" && b.Window != tt.window) {
+					t.Errorf("bucket %+v, want scope %s window %q", b, tt.wantScope, tt.window)
+				}
+			}
+		})
+	}
+}
+
+func TestExceededStatus(t *testing.T) {
+	e := &ExceededError{
+		Bucket: Bucket{Scope: ScopeUser, SubjectID: "u1", Window: WindowDaily, ResetsAt: time.Now().Add(time.Hour)},
+		Unit:   "calls",
+		Limit:  20,
+	}
+	if !errors.Is(e, ErrQuotaExceeded) {
+		t.Error("ExceededError does not wrap ErrQuotaExceeded")
+	}
+
+	st := status.Convert(exceededStatus(e))
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("code = %v, want ResourceExhausted", st.Code())
+	}
+	var retry *errdetails.RetryInfo
+	var failure *errdetails.QuotaFailure
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.RetryInfo:
+			retry = d
+		case *errdetails.QuotaFailure:
+			failure = d
+		}
+	}
+	if retry == nil || retry.RetryDelay.AsDuration() <= 59*time.Minute {
+		t.Errorf("RetryInfo = %v, want about an hour", retry)
+	}
+	if failure == nil || len(failure.Violations) != 1 || failure.Violations[0].Subject != "user:u1" {
+		t.Errorf("QuotaFailure = %v, want a violation of user:u1", failure)
+	}
+}
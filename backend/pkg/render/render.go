@@ -0,0 +1,283 @@
+package render
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/battle"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+)
+
+const (
+	StyleClassic = "classic"
+	StyleDark    = "dark"
+	StyleSakura  = "sakura"
+
+	// CardWidth and CardHeight are the size of the rendered PNG (the 5:7 ratio of a trading card)
+	CardWidth  = 600
+	CardHeight = 840
+
+	// rendererVersion is part of the card version; bump it when the layout changes
+	rendererVersion = "card-png-v1"
+)
+
+// ErrUnknownStyle is returned for a style that is not in Styles
+var ErrUnknownStyle = errors.New("unknown card style")
+
+// mplus-1p-regular.ttf is M+ 1p (M+ FONTS LICENSE, see fonts/LICENSE), which covers kana and kanji
+//
+//go:embed fonts/mplus-1p-regular.ttf
+var fontData []byte
+
+var cardFont = mustParseFont(fontData)
+
+// Style is the color scheme of a card frame
+type Style struct {
+	Background color.RGBA // outside of the frame
+	Frame      color.RGBA
+	Panel      color.RGBA // behind the texts
+	Accent     color.RGBA // stats and badges
+	Text       color.RGBA
+	SubText    color.RGBA
+}
+
+var Styles = map[string]Style{
+	StyleClassic: {
+		Background: color.RGBA{0x1f, 0x2a, 0x44, 0xff},
+		Frame:      color.RGBA{0xd4, 0xaf, 0x37, 0xff},
+		Panel:      color.RGBA{0xfb, 0xf6, 0xe9, 0xff},
+		Accent:     color.RGBA{0xb2, 0x3a, 0x48, 0xff},
+		Text:       color.RGBA{0x22, 0x22, 0x22, 0xff},
+		SubText:    color.RGBA{0x5c, 0x5c, 0x5c, 0xff},
+	},
+	StyleDark: {
+		Background: color.RGBA{0x0d, 0x0d, 0x12, 0xff},
+		Frame:      color.RGBA{0x3c, 0xd6, 0xe6, 0xff},
+		Panel:      color.RGBA{0x1c, 0x1f, 0x2b, 0xff},
+		Accent:     color.RGBA{0x3c, 0xd6, 0xe6, 0xff},
+		Text:       color.RGBA{0xf2, 0xf2, 0xf2, 0xff},
+		SubText:    color.RGBA{0xa8, 0xb0, 0xc0, 0xff},
+	},
+	StyleSakura: {
+		Background: color.RGBA{0xf4, 0xb6, 0xc8, 0xff},
+		Frame:      color.RGBA{0xd9, 0x5d, 0x86, 0xff},
+		Panel:      color.RGBA{0xff, 0xf5, 0xf8, 0xff},
+		Accent:     color.RGBA{0xd9, 0x5d, 0x86, 0xff},
+		Text:       color.RGBA{0x3a, 0x26, 0x2e, 0xff},
+		SubText:    color.RGBA{0x7a, 0x5a, 0x66, 0xff},
+	},
+}
+
+// ParseStyle returns the style name to use, defaulting to classic
+func ParseStyle(style string) (string, error) {
+	style = strings.ToLower(strings.TrimSpace(style))
+	if style == "" {
+		return StyleClassic, nil
+	}
+	if _, ok := Styles[style]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownStyle, style)
+	}
+	return style, nil
+}
+
+// profileLabels are the names of the stat profiles shown on the card
+var profileLabels = map[string]string{
+	battle.ProfileBalanced:  "バランス",
+	battle.ProfileTank:      "タンク",
+	battle.ProfileAttacker:  "アタッカー",
+	battle.ProfileSpeedster: "スピードスター",
+}
+
+// Render draws the card as a PNG. photo may be nil, in which case a placeholder with the initial is drawn.
+func Render(card *ptera.Card, photo image.Image, style string) ([]byte, error) {
+	s, ok := Styles[style]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStyle, style)
+	}
+
+	// Faces keep internal buffers, so each render uses its own
+	nameFace, err := newFace(34)
+	if err != nil {
+		return nil, err
+	}
+	bodyFace, err := newFace(20)
+	if err != nil {
+		return nil, err
+	}
+	smallFace, err := newFace(16)
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, CardWidth, CardHeight))
+	fill(img, img.Bounds(), s.Background)
+	fill(img, image.Rect(12, 12, CardWidth-12, CardHeight-12), s.Frame)
+	fill(img, image.Rect(22, 22, CardWidth-22, CardHeight-22), s.Panel)
+
+	// Header: name and HP
+	hp := fmt.Sprintf("HP %d", card.MaxHp)
+	hpWidth := font.MeasureString(nameFace, hp).Ceil()
+	drawText(img, nameFace, s.Accent, CardWidth-40-hpWidth, 68, hp)
+	drawText(img, nameFace, s.Text, 40, 68, truncate(nameFace, card.Name, CardWidth-80-hpWidth-20))
+
+	// Grade, position and group
+	sub := fmt.Sprintf("%d年  %s", card.Grade, card.Position)
+	if group := card.GetAffiliatedGroup(); group != "" {
+		sub += "  /  " + group
+	}
+	drawText(img, bodyFace, s.SubText, 40, 104, truncate(bodyFace, sub, CardWidth-80))
+
+	// Photo
+	photoRect := image.Rect(40, 120, CardWidth-40, 520)
+	fill(img, photoRect.Inset(-3), s.Frame)
+	if photo != nil {
+		drawCover(img, photoRect, photo)
+	} else {
+		drawPlaceholder(img, photoRect, s, card.Name)
+	}
+	if card.Graduated {
+		badge := image.Rect(photoRect.Max.X-96, photoRect.Min.Y+12, photoRect.Max.X-12, photoRect.Min.Y+44)
+		fill(img, badge, s.Accent)
+		drawText(img, bodyFace, s.Panel, badge.Min.X+14, badge.Max.Y-8, "OB/OG")
+	}
+
+	// Stats
+	statsRect := image.Rect(40, 540, CardWidth-40, 590)
+	fill(img, statsRect, s.Accent)
+	profile := profileLabels[battle.NormalizeStatProfile(card.StatProfile).GetType()]
+	if profile == "" {
+		profile = profileLabels[battle.ProfileBalanced]
+	}
+	drawText(img, bodyFace, s.Panel, 56, 572, fmt.Sprintf("ATK %d", card.Attack))
+	drawText(img, bodyFace, s.Panel, 200, 572, "タイプ: "+profile)
+
+	// Flavor and hobby
+	y := 630
+	for _, line := range wrap(bodyFace, card.Flavor, CardWidth-80, 4) {
+		drawText(img, bodyFace, s.Text, 40, y, line)
+		y += 30
+	}
+	if card.Hobby != "" {
+		drawText(img, smallFace, s.SubText, 40, 770, truncate(smallFace, "趣味: "+card.Hobby, CardWidth-80))
+	}
+	fill(img, image.Rect(40, 784, CardWidth-40, 786), s.Frame)
+	drawText(img, smallFace, s.SubText, 40, 808, "Ptera")
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode card: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func mustParseFont(data []byte) *opentype.Font {
+	f, err := opentype.Parse(data)
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse embedded font: %v", err))
+	}
+	return f
+}
+
+func newFace(size float64) (font.Face, error) {
+	face, err := opentype.NewFace(cardFont, &opentype.FaceOptions{
+		Size:    size,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create font face: %w", err)
+	}
+	return face, nil
+}
+
+func fill(img *image.RGBA, r image.Rectangle, c color.RGBA) {
+	draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+func drawText(img *image.RGBA, face font.Face, c color.RGBA, x, y int, text string) {
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(text)
+}
+
+// drawCover scales the photo to cover r and crops the overflow around the center
+func drawCover(img *image.RGBA, r image.Rectangle, photo image.Image) {
+	b := photo.Bounds()
+	scale := max(float64(r.Dx())/float64(b.Dx()), float64(r.Dy())/float64(b.Dy()))
+	srcW := int(float64(r.Dx()) / scale)
+	srcH := int(float64(r.Dy()) / scale)
+	src := image.Rect(0, 0, srcW, srcH).Add(image.Pt(b.Min.X+(b.Dx()-srcW)/2, b.Min.Y+(b.Dy()-srcH)/2))
+	draw.CatmullRom.Scale(img, r, photo, src, draw.Src, nil)
+}
+
+// drawPlaceholder fills r with the background color and draws the first letter of the name in the frame color
+func drawPlaceholder(img *image.RGBA, r image.Rectangle, s Style, name string) {
+	fill(img, r, s.Background)
+	initial, _ := utf8.DecodeRuneInString(strings.TrimSpace(name))
+	if initial == utf8.RuneError {
+		return
+	}
+	face, err := newFace(160)
+	if err != nil {
+		return
+	}
+	text := string(initial)
+	width := font.MeasureString(face, text).Ceil()
+	drawText(img, face, s.Frame, r.Min.X+(r.Dx()-width)/2, r.Min.Y+r.Dy()/2+60, text)
+}
+
+// truncate shortens text with an ellipsis so that it fits in width
+func truncate(face font.Face, text string, width int) string {
+	if font.MeasureString(face, text).Ceil() <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		if candidate := string(runes) + "…"; font.MeasureString(face, candidate).Ceil() <= width {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// wrap breaks text into at most maxLines lines of width, by character since Japanese has no spaces
+func wrap(face font.Face, text string, width, maxLines int) []string {
+	var lines []string
+	var line []rune
+	for _, r := range strings.Join(strings.Fields(text), " ") {
+		// A glyph wider than the whole line is put on a line of its own instead of leaving an empty line
+		if len(line) > 0 && font.MeasureString(face, string(append(line, r))).Ceil() > width {
+			lines = append(lines, string(line))
+			line = line[:0]
+			if r == ' ' {
+				continue
+			}
+		}
+		line = append(line, r)
+	}
+	if len(line) > 0 {
+		lines = append(lines, string(line))
+	}
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		lines[maxLines-1] = truncate(face, lines[maxLines-1]+"…", width)
+	}
+	return lines
+}
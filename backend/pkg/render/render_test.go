@@ -0,0 +1,163 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/font"
+
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+)
+
+func TestParseStyle(t *testing.T) {
+	tests := []struct {
+		style   string
+		want    string
+		wantErr bool
+	}{
+		{"", StyleClassic, false},
+		{"  ", StyleClassic, false},
+		{"classic", StyleClassic, false},
+		{"Dark", StyleDark, false},
+		{" SAKURA ", StyleSakura, false},
+		{"neon", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseStyle(tt.style)
+		if tt.wantErr {
+			if !errors.Is(err, ErrUnknownStyle) {
+				t.Errorf("ParseStyle(%q) error = %v, want %v", tt.style, err, ErrUnknownStyle)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseStyle(%q) = %q, %v, want %q", tt.style, got, err, tt.want)
+		}
+	}
+}
+
+func testFace(t *testing.T) font.Face {
+	t.Helper()
+	face, err := newFace(20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return face
+}
+
+func TestTruncate(t *testing.T) {
+	face := testFace(t)
+	glyph := font.MeasureString(face, "あ").Ceil()
+
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  string
+	}{
+		{"empty", "", 100, ""},
+		{"fits", "あいう", glyph * 3, "あいう"},
+		{"too long", "あいうえお", glyph * 3, "あい…"},
+		{"glyph wider than the width", "あ", glyph / 2, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncate(face, tt.text, tt.width)
+			if got != tt.want {
+				t.Errorf("truncate(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+			}
+			if tt.want != tt.text && font.MeasureString(face, got).Ceil() > tt.width {
+				t.Errorf("truncate(%q, %d) = %q is wider than the width", tt.text, tt.width, got)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	face := testFace(t)
+	glyph := font.MeasureString(face, "あ").Ceil()
+
+	tests := []struct {
+		name     string
+		text     string
+		width    int
+		maxLines int
+		want     []string
+	}{
+		{"empty", "", glyph * 3, 4, nil},
+		{"only spaces", "  \n ", glyph * 3, 4, nil},
+		{"one line", "あいう", glyph * 3, 4, []string{"あいう"}},
+		{"by character", "あいうえおか", glyph * 3, 4, []string{"あいう", "えおか"}},
+		{"spaces collapse", "あ  い\nう", glyph * 10, 4, []string{"あ い う"}},
+		{"glyph wider than the width", "あい", glyph / 2, 4, []string{"あ", "い"}},
+		{"max lines overflow", "あいうえおかきくけこ", glyph * 3, 2, []string{"あいう", "えお…"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wrap(face, tt.text, tt.width, tt.maxLines)
+			if len(got) != len(tt.want) {
+				t.Fatalf("wrap(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("wrap(%q) = %q, want %q", tt.text, got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	card := &ptera.Card{
+		Id:       "card1",
+		Name:     "山田 太郎",
+		Grade:    2,
+		Position: "部員",
+		Hobby:    "写真",
+		Flavor:   strings.Repeat("とても長いフレーバーテキスト", 20),
+		MaxHp:    120,
+		Attack:   30,
+	}
+	photo := image.NewRGBA(image.Rect(0, 0, 300, 200))
+
+	for style := range Styles {
+		for _, tt := range []struct {
+			name  string
+			photo image.Image
+		}{
+			{"nil photo", nil},
+			{"photo", photo},
+		} {
+			t.Run(style+"/"+tt.name, func(t *testing.T) {
+				data, err := Render(card, tt.photo, style)
+				if err != nil {
+					t.Fatalf("Render() error = %v", err)
+				}
+				img, err := png.Decode(bytes.NewReader(data))
+				if err != nil {
+					t.Fatalf("Render() is not a PNG: %v", err)
+				}
+				if img.Bounds().Dx() != CardWidth || img.Bounds().Dy() != CardHeight {
+					t.Errorf("Render() size = %v, want %dx%d", img.Bounds().Size(), CardWidth, CardHeight)
+				}
+			})
+		}
+	}
+
+	t.Run("empty card", func(t *testing.T) {
+		if _, err := Render(&ptera.Card{}, nil, StyleClassic); err != nil {
+			t.Errorf("Render() error = %v", err)
+		}
+	})
+
+	t.Run("unknown style", func(t *testing.T) {
+		if _, err := Render(card, nil, "neon"); !errors.Is(err, ErrUnknownStyle) {
+			t.Errorf("Render() error = %v, want %v", err, ErrUnknownStyle)
+		}
+	})
+}
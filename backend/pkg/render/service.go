@@ -0,0 +1,210 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/ai"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/battle"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/storage"
+)
+
+// PhotoLoader loads the member photo of a card (see ai.ImageLoader)
+type PhotoLoader interface {
+	Load(ctx context.Context, imageURL, imageID string) (*ai.Image, error)
+}
+
+// Service renders shareable card images, over gRPC (RenderCard) and HTTP (ServeHTTP)
+type Service struct {
+	cardRepo    *battle.CardRepository
+	cache       storage.Store // the last rendered PNG of each card and style (see cacheKey), not served publicly
+	photoLoader PhotoLoader
+	shareURL    string // public URL where ServeHTTP is mounted
+	logger      *slog.Logger
+}
+
+func NewService(logger *slog.Logger, cardRepo *battle.CardRepository, cache storage.Store, photoLoader PhotoLoader, shareURL string) *Service {
+	return &Service{
+		cardRepo:    cardRepo,
+		cache:       cache,
+		photoLoader: photoLoader,
+		shareURL:    strings.TrimSuffix(shareURL, "/"),
+		logger:      logger,
+	}
+}
+
+// Rendered is a card image with the version it was rendered from
+type Rendered struct {
+	PNG     []byte
+	Version string
+	Cached  bool
+}
+
+// Version identifies everything drawn on the card, so that an edited card gets a new image
+func Version(card *ptera.Card, style string) string {
+	data, _ := json.Marshal(struct {
+		Renderer, Style, ID, Name, Position, Group, Hobby, Flavor, ImageURL, Profile string
+		Grade, MaxHp, Attack                                                         int32
+		Graduated                                                                    bool
+	}{
+		rendererVersion, style, card.Id, card.Name, card.Position, card.GetAffiliatedGroup(), card.Hobby, card.Flavor, card.ImageUrl,
+		battle.NormalizeStatProfile(card.StatProfile).GetType(),
+		card.Grade, card.MaxHp, card.Attack,
+		card.Graduated,
+	})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16])
+}
+
+// Render returns the PNG of the card, from the cache if this version was already rendered
+func (s *Service) Render(ctx context.Context, cardID, style string) (*Rendered, error) {
+	card, err := s.cardRepo.GetCard(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	version := Version(card, style)
+	key := cacheKey(cardID, style)
+	obj, err := s.cache.Get(ctx, key)
+	if err == nil {
+		if data, ok := cachedPNG(obj.Data, version); ok {
+			return &Rendered{PNG: data, Version: version, Cached: true}, nil
+		}
+	} else if !errors.Is(err, storage.ErrNotFound) {
+		s.logger.Warn("failed to read rendered card", "card_id", cardID, "error", err)
+	}
+
+	data, err := Render(card, s.loadPhoto(ctx, card), style)
+	if err != nil {
+		return nil, err
+	}
+	// Overwrites the previous version, so the cache holds at most one image per card and style
+	cached := &storage.Object{Data: append([]byte(version), data...), ContentType: "application/octet-stream"}
+	if _, err := s.cache.Put(ctx, key, cached); err != nil {
+		s.logger.Warn("failed to cache rendered card", "card_id", cardID, "error", err)
+	}
+	return &Rendered{PNG: data, Version: version}, nil
+}
+
+// cacheKey is the cache entry of a card and style. The card ID is hashed since it comes from the URL.
+func cacheKey(cardID, style string) string {
+	sum := sha256.Sum256([]byte(cardID + "\x00" + style))
+	return "card-" + hex.EncodeToString(sum[:16]) + ".bin"
+}
+
+// cachedPNG returns the PNG of a cache entry (the version followed by the PNG) if it was rendered from version
+func cachedPNG(data []byte, version string) ([]byte, bool) {
+	png, ok := bytes.CutPrefix(data, []byte(version))
+	return png, ok && len(png) > 0
+}
+
+// loadPhoto returns the member photo, or nil (a placeholder is drawn) if it cannot be loaded
+func (s *Service) loadPhoto(ctx context.Context, card *ptera.Card) image.Image {
+	if card.ImageUrl == "" {
+		return nil
+	}
+	photo, err := s.photoLoader.Load(ctx, card.ImageUrl, "")
+	if err != nil {
+		s.logger.Warn("failed to load card photo", "card_id", card.Id, "error", err)
+		return nil
+	}
+	img, _, err := image.Decode(bytes.NewReader(photo.Data))
+	if err != nil {
+		s.logger.Warn("failed to decode card photo", "card_id", card.Id, "error", err)
+		return nil
+	}
+	return img
+}
+
+// ShareURL is the public URL of the card image served by ServeHTTP
+func (s *Service) ShareURL(cardID, style string) string {
+	return fmt.Sprintf("%s/%s.png?style=%s", s.shareURL, url.PathEscape(cardID), url.QueryEscape(style))
+}
+
+// RenderCard returns the card as a PNG, with a URL that can be shared outside the app
+func (s *Service) RenderCard(ctx context.Context, req *ptera.RenderCardRequest) (*ptera.RenderCardResponse, error) {
+	if req.CardId == "" {
+		return nil, status.Error(codes.InvalidArgument, "card_id is required")
+	}
+	style, err := ParseStyle(req.Style)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	rendered, err := s.Render(ctx, req.CardId, style)
+	if errors.Is(err, battle.ErrCardNotFound) {
+		return nil, status.Errorf(codes.NotFound, "failed to render card: %v", err)
+	}
+	if err != nil {
+		s.logger.Error("failed to render card", "card_id", req.CardId, "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to render card: %v", err)
+	}
+
+	return &ptera.RenderCardResponse{
+		Png:      rendered.PNG,
+		Version:  rendered.Version,
+		ImageUrl: s.ShareURL(req.CardId, style),
+		Cached:   rendered.Cached,
+	}, nil
+}
+
+// ServeHTTP serves GET /{card_id}.png?style=... Mount it with http.StripPrefix.
+// The URL stays the same when the card is edited, so responses are revalidated with the version as ETag.
+func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	cardID, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/"), ".png")
+	if !ok || cardID == "" || strings.Contains(cardID, "/") {
+		http.NotFound(w, r)
+		return
+	}
+	style, err := ParseStyle(r.URL.Query().Get("style"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rendered, err := s.Render(r.Context(), cardID, style)
+	if errors.Is(err, battle.ErrCardNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		s.logger.Error("failed to render card", "card_id", cardID, "error", err)
+		http.Error(w, "failed to render card", http.StatusInternalServerError)
+		return
+	}
+
+	writePNG(w, r, rendered)
+}
+
+// writePNG writes the rendered card, or 304 Not Modified if the client already has this version
+func writePNG(w http.ResponseWriter, r *http.Request, rendered *Rendered) {
+	etag := `"` + rendered.Version + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	w.Write(rendered.PNG)
+}
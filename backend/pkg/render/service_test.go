@@ -0,0 +1,121 @@
+package render
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+)
+
+func TestVersion(t *testing.T) {
+	card := &ptera.Card{Id: "card1", Name: "山田", Flavor: "よろしく"}
+	version := Version(card, StyleClassic)
+
+	if Version(card, StyleClassic) != version {
+		t.Error("Version() is not stable")
+	}
+	if Version(card, StyleDark) == version {
+		t.Error("Version() does not depend on the style")
+	}
+	edited := &ptera.Card{Id: "card1", Name: "山田", Flavor: "よろしくね"}
+	if Version(edited, StyleClassic) == version {
+		t.Error("Version() does not change when the card is edited")
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	key := cacheKey("card1", StyleClassic)
+	if again := cacheKey("card1", StyleClassic); again != key {
+		t.Errorf("cacheKey() is not stable: %q, %q", key, again)
+	}
+	for _, other := range []string{cacheKey("card2", StyleClassic), cacheKey("card1", StyleDark), cacheKey("../card1", StyleClassic)} {
+		if other == key {
+			t.Errorf("cacheKey() collides: %q", key)
+		}
+	}
+}
+
+func TestCachedPNG(t *testing.T) {
+	data := []byte("\x89PNG...")
+	tests := []struct {
+		name    string
+		data    []byte
+		version string
+		wantOK  bool
+	}{
+		{"same version", append([]byte("v1"), data...), "v1", true},
+		{"edited card", append([]byte("v1"), data...), "v2", false},
+		{"no image", []byte("v1"), "v1", false},
+		{"empty", nil, "v1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := cachedPNG(tt.data, tt.version)
+			if ok != tt.wantOK {
+				t.Fatalf("cachedPNG() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && string(got) != string(data) {
+				t.Errorf("cachedPNG() = %q, want %q", got, data)
+			}
+		})
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	rendered := &Rendered{PNG: []byte("png"), Version: "v1"}
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wantStatus  int
+		wantBody    string
+	}{
+		{"first request", "", http.StatusOK, "png"},
+		{"same version", `"v1"`, http.StatusNotModified, ""},
+		{"edited card", `"v0"`, http.StatusOK, "png"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/card1.png", nil)
+			if tt.ifNoneMatch != "" {
+				r.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+			writePNG(w, r, rendered)
+
+			if w.Code != tt.wantStatus || w.Body.String() != tt.wantBody {
+				t.Errorf("writePNG() = %d %q, want %d %q", w.Code, w.Body.String(), tt.wantStatus, tt.wantBody)
+			}
+			if got := w.Header().Get("ETag"); got != `"v1"` {
+				t.Errorf("ETag = %q, want %q", got, `"v1"`)
+			}
+		})
+	}
+}
+
+// TestServeHTTPRejects covers the requests answered before the card is loaded
+func TestServeHTTPRejects(t *testing.T) {
+	s := &Service{}
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		wantStatus int
+	}{
+		{"post", http.MethodPost, "/card1.png", http.StatusMethodNotAllowed},
+		{"no extension", http.MethodGet, "/card1", http.StatusNotFound},
+		{"no card id", http.MethodGet, "/.png", http.StatusNotFound},
+		{"nested path", http.MethodGet, "/a/card1.png", http.StatusNotFound},
+		{"unknown style", http.MethodGet, "/card1.png?style=neon", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, httptest.NewRequest(tt.method, tt.target, nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("ServeHTTP() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// validKey restricts keys to a flat file name, so they can never escape the directory
+var validKey = regexp.MustCompile(`^[a-zA-Z0-9_-]+\.[a-z0-9]+$`)
+
+// contentTypes maps file extensions to content types (the extension is part of the key)
+var contentTypes = map[string]string{
+	".jpg": "image/jpeg",
+	".png": "image/png",
+	".gif": "image/gif",
+}
+
+// ExtensionFor returns the file extension used for a content type ("" if it is not supported)
+func ExtensionFor(contentType string) string {
+	for ext, t := range contentTypes {
+		if t == contentType {
+			return ext
+		}
+	}
+	return ""
+}
+
+// LocalStore saves files in a directory for local development.
+// It also serves them over HTTP at publicURL (see ServeHTTP).
+type LocalStore struct {
+	dir       string
+	publicURL string
+}
+
+func NewLocalStore(dir, publicURL string) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &LocalStore{
+		dir:       dir,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+	}, nil
+}
+
+func (s *LocalStore) Put(ctx context.Context, key string, obj *Object) (string, error) {
+	if !validKey.MatchString(key) {
+		return "", fmt.Errorf("invalid key: %q", key)
+	}
+
+	// Write to a temporary file first so that readers never see a partial file
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(obj.Data); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, key)); err != nil {
+		return "", fmt.Errorf("failed to save file: %w", err)
+	}
+
+	return s.publicURL + "/" + key, nil
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) (*Object, error) {
+	if !validKey.MatchString(key) {
+		return nil, ErrNotFound
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	return &Object{
+		Data:        data,
+		ContentType: contentTypes[filepath.Ext(key)],
+	}, nil
+}
+
+func (s *LocalStore) KeyFromURL(url string) (string, bool) {
+	key, ok := strings.CutPrefix(url, s.publicURL+"/")
+	if !ok || !validKey.MatchString(key) {
+		return "", false
+	}
+	return key, true
+}
+
+// ServeHTTP serves the stored files. Mount it with http.StripPrefix so that the path is the key.
+func (s *LocalStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	obj, err := s.Get(r.Context(), strings.TrimPrefix(r.URL.Path, "/"))
+	if errors.Is(err, ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, "failed to read file", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", obj.ContentType)
+	// Uploads and member crops are keyed by the SHA-256 of their content, so a key always serves the same bytes.
+	// Do not serve a store whose keys are overwritten (such as the render cache) with this handler.
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Write(obj.Data)
+}
@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrNotFound = errors.New("object not found")
+
+// Object is a stored file
+type Object struct {
+	Data        []byte
+	ContentType string
+}
+
+// Store saves uploaded files (card images) and returns URLs that can be shown on cards
+type Store interface {
+	// Put saves the object under key and returns its public URL
+	Put(ctx context.Context, key string, obj *Object) (string, error)
+	// Get loads the object saved under key. Returns ErrNotFound if it does not exist.
+	Get(ctx context.Context, key string) (*Object, error)
+	// KeyFromURL returns the key of a URL returned by Put, so that the server can read its own files directly
+	KeyFromURL(url string) (string, bool)
+}
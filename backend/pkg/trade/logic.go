@@ -0,0 +1,96 @@
+package trade
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/collection"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// transfer moves one owned card to a new owner when a trade is accepted
+type transfer struct {
+	card       *collection.UserCard
+	newOwnerID string
+	holder     string // the trade that locked the card ("" for free cards)
+}
+
+// validateProposal checks a ProposeTrade request before any card is read
+func validateProposal(req *ptera.ProposeTradeRequest) error {
+	if req.UserId == "" || req.TargetUserId == "" {
+		return status.Error(codes.InvalidArgument, "user IDs required")
+	}
+	if req.UserId == req.TargetUserId {
+		return status.Error(codes.InvalidArgument, "cannot trade with yourself")
+	}
+	if len(req.OfferedUserCardIds) == 0 && len(req.RequestedUserCardIds) == 0 {
+		return status.Error(codes.InvalidArgument, "trade must contain at least one card")
+	}
+	if len(req.OfferedUserCardIds) > maxCardsPerSide || len(req.RequestedUserCardIds) > maxCardsPerSide {
+		return status.Errorf(codes.InvalidArgument, "at most %d cards per side", maxCardsPerSide)
+	}
+	if hasDuplicates(append(append([]string{}, req.OfferedUserCardIds...), req.RequestedUserCardIds...)) {
+		return status.Error(codes.InvalidArgument, "duplicate card in trade")
+	}
+	return nil
+}
+
+// checkProposal returns ErrCardUnavailable unless the proposer owns the offered cards
+// and the target owns the requested cards, none of them held by another trade
+func checkProposal(t *Trade, offered, requested []*collection.UserCard) error {
+	for _, c := range offered {
+		if c.OwnerID != t.ProposerID || c.IsLocked(t.CreatedAt) {
+			return fmt.Errorf("%w: %s", ErrCardUnavailable, c.ID)
+		}
+	}
+	for _, c := range requested {
+		if c.OwnerID != t.TargetID || c.IsLocked(t.CreatedAt) {
+			return fmt.Errorf("%w: %s", ErrCardUnavailable, c.ID)
+		}
+	}
+	return nil
+}
+
+// checkPending returns ErrNotPending for a settled trade and reports whether a pending trade has expired at now
+func checkPending(t *Trade, now time.Time) (expired bool, err error) {
+	if t.Status != StatusPending {
+		return false, ErrNotPending
+	}
+	return !t.ExpiresAt.After(now), nil
+}
+
+// acceptTransfers checks that userID may accept the trade and returns the swap:
+// the offered cards, still locked by the trade, go to the target and the free requested cards to the proposer
+func acceptTransfers(t *Trade, userID string, offered, requested []*collection.UserCard, now time.Time) ([]transfer, error) {
+	if t.TargetID != userID {
+		return nil, ErrNotParticipant
+	}
+
+	var transfers []transfer
+	for _, c := range offered {
+		if c.OwnerID != t.ProposerID || c.LockedBy != t.ID {
+			return nil, fmt.Errorf("%w: %s", ErrCardUnavailable, c.ID)
+		}
+		transfers = append(transfers, transfer{card: c, newOwnerID: t.TargetID, holder: t.ID})
+	}
+	for _, c := range requested {
+		if c.OwnerID != t.TargetID || c.IsLocked(now) {
+			return nil, fmt.Errorf("%w: %s", ErrCardUnavailable, c.ID)
+		}
+		transfers = append(transfers, transfer{card: c, newOwnerID: t.ProposerID})
+	}
+	return transfers, nil
+}
+
+func hasDuplicates(ids []string) bool {
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return true
+		}
+		seen[id] = true
+	}
+	return false
+}
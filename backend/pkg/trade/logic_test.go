@@ -0,0 +1,199 @@
+package trade
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/collection"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testNow = time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+func testTrade() *Trade {
+	return &Trade{
+		ID:                   "trade-1",
+		ProposerID:           "alice",
+		TargetID:             "bob",
+		OfferedUserCardIDs:   []string{"a1"},
+		RequestedUserCardIDs: []string{"b1"},
+		Status:               StatusPending,
+		CreatedAt:            testNow,
+		ExpiresAt:            testNow.Add(tradeTTL),
+	}
+}
+
+func userCard(id, ownerID, lockedBy string) *collection.UserCard {
+	c := &collection.UserCard{ID: id, OwnerID: ownerID}
+	if lockedBy != "" {
+		c.LockedBy = lockedBy
+		c.LockExpiresAt = testNow.Add(tradeTTL)
+	}
+	return c
+}
+
+func TestValidateProposal(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *ptera.ProposeTradeRequest
+		want codes.Code
+	}{
+		{
+			name: "valid",
+			req:  &ptera.ProposeTradeRequest{UserId: "alice", TargetUserId: "bob", OfferedUserCardIds: []string{"a1"}},
+			want: codes.OK,
+		},
+		{
+			name: "gift request only",
+			req:  &ptera.ProposeTradeRequest{UserId: "alice", TargetUserId: "bob", RequestedUserCardIds: []string{"b1"}},
+			want: codes.OK,
+		},
+		{
+			name: "missing target",
+			req:  &ptera.ProposeTradeRequest{UserId: "alice", OfferedUserCardIds: []string{"a1"}},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "with yourself",
+			req:  &ptera.ProposeTradeRequest{UserId: "alice", TargetUserId: "alice", OfferedUserCardIds: []string{"a1"}},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "no cards",
+			req:  &ptera.ProposeTradeRequest{UserId: "alice", TargetUserId: "bob"},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "too many cards",
+			req: &ptera.ProposeTradeRequest{UserId: "alice", TargetUserId: "bob",
+				OfferedUserCardIds: []string{"a1", "a2", "a3", "a4", "a5", "a6"}},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "same card on both sides",
+			req: &ptera.ProposeTradeRequest{UserId: "alice", TargetUserId: "bob",
+				OfferedUserCardIds: []string{"a1"}, RequestedUserCardIds: []string{"a1"}},
+			want: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(validateProposal(tt.req)); got != tt.want {
+				t.Errorf("validateProposal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckProposal(t *testing.T) {
+	tests := []struct {
+		name      string
+		offered   []*collection.UserCard
+		requested []*collection.UserCard
+		wantErr   bool
+	}{
+		{name: "available", offered: []*collection.UserCard{userCard("a1", "alice", "")}, requested: []*collection.UserCard{userCard("b1", "bob", "")}},
+		{name: "offering someone else's card", offered: []*collection.UserCard{userCard("a1", "carol", "")}, wantErr: true},
+		{name: "offering a card locked by another trade", offered: []*collection.UserCard{userCard("a1", "alice", "trade-0")}, wantErr: true},
+		{name: "requesting a card the target does not own", requested: []*collection.UserCard{userCard("b1", "carol", "")}, wantErr: true},
+		{name: "requesting a locked card", requested: []*collection.UserCard{userCard("b1", "bob", "trade-0")}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkProposal(testTrade(), tt.offered, tt.requested)
+			if tt.wantErr != errors.Is(err, ErrCardUnavailable) || (!tt.wantErr && err != nil) {
+				t.Errorf("checkProposal() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAcceptTransfers(t *testing.T) {
+	t.Run("swap", func(t *testing.T) {
+		offered := []*collection.UserCard{userCard("a1", "alice", "trade-1"), userCard("a2", "alice", "trade-1")}
+		requested := []*collection.UserCard{userCard("b1", "bob", "")}
+
+		transfers, err := acceptTransfers(testTrade(), "bob", offered, requested, testNow)
+		if err != nil {
+			t.Fatalf("acceptTransfers() error = %v", err)
+		}
+		want := map[string]transfer{
+			"a1": {newOwnerID: "bob", holder: "trade-1"},
+			"a2": {newOwnerID: "bob", holder: "trade-1"},
+			"b1": {newOwnerID: "alice"},
+		}
+		if len(transfers) != len(want) {
+			t.Fatalf("got %d transfers, want %d", len(transfers), len(want))
+		}
+		for _, tr := range transfers {
+			w := want[tr.card.ID]
+			if tr.newOwnerID != w.newOwnerID || tr.holder != w.holder {
+				t.Errorf("transfer of %s = (%s, %q), want (%s, %q)", tr.card.ID, tr.newOwnerID, tr.holder, w.newOwnerID, w.holder)
+			}
+			// The trade's own lock must not stop the transfer, any other lock must
+			if err := tr.card.CheckTransfer(tr.holder, testNow); err != nil {
+				t.Errorf("CheckTransfer(%s) error = %v", tr.card.ID, err)
+			}
+		}
+	})
+
+	tests := []struct {
+		name      string
+		userID    string
+		offered   []*collection.UserCard
+		requested []*collection.UserCard
+		want      error
+	}{
+		{name: "accepted by the proposer", userID: "alice", want: ErrNotParticipant},
+		{name: "accepted by a stranger", userID: "carol", want: ErrNotParticipant},
+		{name: "offered card no longer locked by the trade", userID: "bob",
+			offered: []*collection.UserCard{userCard("a1", "alice", "")}, want: ErrCardUnavailable},
+		{name: "offered card moved away", userID: "bob",
+			offered: []*collection.UserCard{userCard("a1", "carol", "trade-1")}, want: ErrCardUnavailable},
+		{name: "requested card locked by another trade", userID: "bob",
+			requested: []*collection.UserCard{userCard("b1", "bob", "trade-2")}, want: ErrCardUnavailable},
+		{name: "requested card traded away", userID: "bob",
+			requested: []*collection.UserCard{userCard("b1", "carol", "")}, want: ErrCardUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := acceptTransfers(testTrade(), tt.userID, tt.offered, tt.requested, testNow)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("acceptTransfers() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckPending(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      string
+		now         time.Time
+		wantExpired bool
+		wantErr     error
+	}{
+		{name: "pending", status: StatusPending, now: testNow},
+		{name: "expires now", status: StatusPending, now: testNow.Add(tradeTTL), wantExpired: true},
+		{name: "expired", status: StatusPending, now: testNow.Add(tradeTTL + time.Hour), wantExpired: true},
+		{name: "second accept", status: StatusAccepted, now: testNow, wantErr: ErrNotPending},
+		{name: "rejected", status: StatusRejected, now: testNow, wantErr: ErrNotPending},
+		{name: "already expired", status: StatusExpired, now: testNow.Add(tradeTTL + time.Hour), wantErr: ErrNotPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := testTrade()
+			tr.Status = tt.status
+			expired, err := checkPending(tr, tt.now)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("checkPending() error = %v, want %v", err, tt.wantErr)
+			}
+			if expired != tt.wantExpired {
+				t.Errorf("expired = %v, want %v", expired, tt.wantExpired)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package trade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/collection"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	CollectionTrades = "trades"
+
+	StatusPending   = "pending"
+	StatusAccepted  = "accepted"
+	StatusRejected  = "rejected"
+	StatusCancelled = "cancelled"
+	StatusExpired   = "expired"
+)
+
+var (
+	ErrTradeNotFound   = errors.New("trade not found")
+	ErrNotPending      = errors.New("trade is not pending")
+	ErrTradeExpired    = errors.New("trade has expired")
+	ErrNotParticipant  = errors.New("user is not allowed to operate this trade")
+	ErrCardUnavailable = errors.New("card is not owned by the expected user or is locked by another trade")
+)
+
+// Trade is a proposal to swap owned cards between two users
+type Trade struct {
+	ID                   string    `firestore:"-"`
+	ProposerID           string    `firestore:"proposerId"`
+	TargetID             string    `firestore:"targetId"`
+	OfferedUserCardIDs   []string  `firestore:"offeredUserCardIds"`
+	RequestedUserCardIDs []string  `firestore:"requestedUserCardIds"`
+	Status               string    `firestore:"status"`
+	CreatedAt            time.Time `firestore:"createdAt"`
+	ExpiresAt            time.Time `firestore:"expiresAt"`
+	UpdatedAt            time.Time `firestore:"updatedAt"`
+}
+
+func (t *Trade) ToProto() *ptera.Trade {
+	return &ptera.Trade{
+		TradeId:              t.ID,
+		ProposerId:           t.ProposerID,
+		TargetId:             t.TargetID,
+		OfferedUserCardIds:   t.OfferedUserCardIDs,
+		RequestedUserCardIds: t.RequestedUserCardIDs,
+		Status:               t.Status,
+		CreatedAt:            timestamppb.New(t.CreatedAt),
+		ExpiresAt:            timestamppb.New(t.ExpiresAt),
+		UpdatedAt:            timestamppb.New(t.UpdatedAt),
+	}
+}
+
+type Repository struct {
+	client    *firestore.Client
+	userCards *collection.Repository
+}
+
+func NewRepository(client *firestore.Client, userCards *collection.Repository) *Repository {
+	return &Repository{client: client, userCards: userCards}
+}
+
+// CreateTrade stores a pending trade and locks the offered cards in one transaction
+func (r *Repository) CreateTrade(ctx context.Context, t *Trade) (*Trade, error) {
+	tradeRef := r.client.Collection(CollectionTrades).NewDoc()
+	t.ID = tradeRef.ID
+
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		offered, err := r.getUserCards(tx, t.OfferedUserCardIDs)
+		if err != nil {
+			return err
+		}
+		requested, err := r.getUserCards(tx, t.RequestedUserCardIDs)
+		if err != nil {
+			return err
+		}
+
+		if err := checkProposal(t, offered, requested); err != nil {
+			return err
+		}
+
+		if err := tx.Create(tradeRef, t); err != nil {
+			return fmt.Errorf("failed to save trade: %w", err)
+		}
+
+		// Lock offered cards until the trade is settled or expires
+		for _, c := range offered {
+			if err := r.userCards.Lock(tx, c, t.ID, t.ExpiresAt, t.CreatedAt); err != nil {
+				return unavailable(err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// AcceptTrade swaps the ownership of both sides in one transaction
+func (r *Repository) AcceptTrade(ctx context.Context, tradeID, userID string, now time.Time) (*Trade, error) {
+	return r.settle(ctx, tradeID, now, func(tx *firestore.Transaction, t *Trade) error {
+		if t.TargetID != userID {
+			return ErrNotParticipant
+		}
+
+		offered, err := r.getUserCards(tx, t.OfferedUserCardIDs)
+		if err != nil {
+			return err
+		}
+		requested, err := r.getUserCards(tx, t.RequestedUserCardIDs)
+		if err != nil {
+			return err
+		}
+
+		transfers, err := acceptTransfers(t, userID, offered, requested, now)
+		if err != nil {
+			return err
+		}
+		for _, tr := range transfers {
+			if err := r.userCards.Transfer(tx, tr.card, tr.newOwnerID, collection.SourceTrade, tr.holder, now); err != nil {
+				return unavailable(err)
+			}
+		}
+
+		t.Status = StatusAccepted
+		return nil
+	})
+}
+
+// RejectTrade closes the trade by the target and unlocks the offered cards
+func (r *Repository) RejectTrade(ctx context.Context, tradeID, userID string, now time.Time) (*Trade, error) {
+	return r.settle(ctx, tradeID, now, func(tx *firestore.Transaction, t *Trade) error {
+		if t.TargetID != userID {
+			return ErrNotParticipant
+		}
+		t.Status = StatusRejected
+		return r.unlockOffered(tx, t)
+	})
+}
+
+// CancelTrade closes the trade by the proposer and unlocks the offered cards
+func (r *Repository) CancelTrade(ctx context.Context, tradeID, userID string, now time.Time) (*Trade, error) {
+	return r.settle(ctx, tradeID, now, func(tx *firestore.Transaction, t *Trade) error {
+		if t.ProposerID != userID {
+			return ErrNotParticipant
+		}
+		t.Status = StatusCancelled
+		return r.unlockOffered(tx, t)
+	})
+}
+
+// ExpireTrades marks up to limit pending trades past their expiry as expired and unlocks their cards.
+// Returns the number of trades it expired.
+func (r *Repository) ExpireTrades(ctx context.Context, now time.Time, limit int) (int, error) {
+	docs, err := r.client.Collection(CollectionTrades).
+		Where("status", "==", StatusPending).
+		Where("expiresAt", "<=", now).
+		Limit(limit).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired trades: %w", err)
+	}
+
+	expired := 0
+	for _, doc := range docs {
+		// settle expires the trade before calling fn, so fn only runs if the trade changed meanwhile
+		_, err := r.settle(ctx, doc.Ref.ID, now, func(*firestore.Transaction, *Trade) error {
+			return ErrNotPending
+		})
+		switch {
+		case errors.Is(err, ErrTradeExpired):
+			expired++
+		case errors.Is(err, ErrNotPending):
+			// Settled by a participant in the meantime
+		default:
+			return expired, err
+		}
+	}
+	return expired, nil
+}
+
+// settle runs fn on a pending trade inside a transaction and saves the new status.
+// An expired trade is marked as expired (and its cards unlocked) instead, and ErrTradeExpired is returned.
+func (r *Repository) settle(ctx context.Context, tradeID string, now time.Time, fn func(tx *firestore.Transaction, t *Trade) error) (*Trade, error) {
+	tradeRef := r.client.Collection(CollectionTrades).Doc(tradeID)
+
+	var t Trade
+	expired := false
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		expired = false
+		doc, err := tx.Get(tradeRef)
+		if status.Code(err) == codes.NotFound {
+			return ErrTradeNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get trade: %w", err)
+		}
+		t = Trade{}
+		if err := doc.DataTo(&t); err != nil {
+			return fmt.Errorf("failed to parse trade: %w", err)
+		}
+		t.ID = doc.Ref.ID
+
+		expired, err = checkPending(&t, now)
+		if err != nil {
+			return err
+		}
+		if expired {
+			t.Status = StatusExpired
+			if err := r.unlockOffered(tx, &t); err != nil {
+				return err
+			}
+		} else if err := fn(tx, &t); err != nil {
+			return err
+		}
+
+		t.UpdatedAt = now
+		return tx.Update(tradeRef, []firestore.Update{
+			{Path: "status", Value: t.Status},
+			{Path: "updatedAt", Value: now},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if expired {
+		return &t, ErrTradeExpired
+	}
+
+	return &t, nil
+}
+
+// unlockOffered releases the offered cards still locked by the trade
+func (r *Repository) unlockOffered(tx *firestore.Transaction, t *Trade) error {
+	offered, err := r.getUserCards(tx, t.OfferedUserCardIDs)
+	if err != nil {
+		return err
+	}
+	for _, c := range offered {
+		if err := r.userCards.Unlock(tx, c, t.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getUserCards reads owned cards inside a transaction
+func (r *Repository) getUserCards(tx *firestore.Transaction, ids []string) ([]*collection.UserCard, error) {
+	cards, err := r.userCards.GetUserCards(tx, ids)
+	if err != nil {
+		return nil, unavailable(err)
+	}
+	return cards, nil
+}
+
+// unavailable reports missing and locked cards as ErrCardUnavailable
+func unavailable(err error) error {
+	if errors.Is(err, collection.ErrUserCardNotFound) || errors.Is(err, collection.ErrCardLocked) {
+		return fmt.Errorf("%w: %w", ErrCardUnavailable, err)
+	}
+	return err
+}
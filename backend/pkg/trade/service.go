@@ -0,0 +1,125 @@
+package trade
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	tradeTTL        = 72 * time.Hour
+	maxCardsPerSide = 5
+
+	// ExpiryInterval is how often RunExpiry looks for expired trades
+	ExpiryInterval  = 10 * time.Minute
+	expiryBatchSize = 100
+)
+
+type Service struct {
+	ptera.UnimplementedTradeServiceServer
+	repo   *Repository
+	logger *slog.Logger
+}
+
+func NewService(logger *slog.Logger, repo *Repository) *Service {
+	return &Service{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// ProposeTrade creates a pending trade and locks the offered cards
+func (s *Service) ProposeTrade(ctx context.Context, req *ptera.ProposeTradeRequest) (*ptera.Trade, error) {
+	if err := validateProposal(req); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	t, err := s.repo.CreateTrade(ctx, &Trade{
+		ProposerID:           req.UserId,
+		TargetID:             req.TargetUserId,
+		OfferedUserCardIDs:   req.OfferedUserCardIds,
+		RequestedUserCardIDs: req.RequestedUserCardIds,
+		Status:               StatusPending,
+		CreatedAt:            now,
+		ExpiresAt:            now.Add(tradeTTL),
+		UpdatedAt:            now,
+	})
+	if err != nil {
+		return nil, s.toStatus("failed to propose trade", err)
+	}
+
+	s.logger.Info("trade proposed", "trade_id", t.ID, "proposer_id", t.ProposerID, "target_id", t.TargetID)
+	return t.ToProto(), nil
+}
+
+// AcceptTrade swaps both sides of the trade atomically
+func (s *Service) AcceptTrade(ctx context.Context, req *ptera.AcceptTradeRequest) (*ptera.Trade, error) {
+	t, err := s.repo.AcceptTrade(ctx, req.TradeId, req.UserId, time.Now())
+	if err != nil {
+		return nil, s.toStatus("failed to accept trade", err)
+	}
+
+	s.logger.Info("trade accepted", "trade_id", t.ID, "proposer_id", t.ProposerID, "target_id", t.TargetID)
+	return t.ToProto(), nil
+}
+
+// RejectTrade is called by the target to decline the trade
+func (s *Service) RejectTrade(ctx context.Context, req *ptera.RejectTradeRequest) (*ptera.Trade, error) {
+	t, err := s.repo.RejectTrade(ctx, req.TradeId, req.UserId, time.Now())
+	if err != nil {
+		return nil, s.toStatus("failed to reject trade", err)
+	}
+	return t.ToProto(), nil
+}
+
+// CancelTrade is called by the proposer to withdraw the trade
+func (s *Service) CancelTrade(ctx context.Context, req *ptera.CancelTradeRequest) (*ptera.Trade, error) {
+	t, err := s.repo.CancelTrade(ctx, req.TradeId, req.UserId, time.Now())
+	if err != nil {
+		return nil, s.toStatus("failed to cancel trade", err)
+	}
+	return t.ToProto(), nil
+}
+
+// RunExpiry expires pending trades nobody settled, so that they do not stay pending until a participant
+// touches them (their card locks already lapse at the expiry). It runs until ctx is cancelled.
+func (s *Service) RunExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		n, err := s.repo.ExpireTrades(ctx, time.Now(), expiryBatchSize)
+		if err != nil && ctx.Err() == nil {
+			s.logger.Error("failed to expire trades", "error", err)
+		}
+		if n > 0 {
+			s.logger.Info("trades expired", "count", n)
+		}
+	}
+}
+
+// toStatus maps repository errors to gRPC status codes
+func (s *Service) toStatus(msg string, err error) error {
+	switch {
+	case errors.Is(err, ErrTradeNotFound):
+		return status.Errorf(codes.NotFound, "%s: %v", msg, err)
+	case errors.Is(err, ErrNotParticipant):
+		return status.Errorf(codes.PermissionDenied, "%s: %v", msg, err)
+	case errors.Is(err, ErrNotPending), errors.Is(err, ErrTradeExpired), errors.Is(err, ErrCardUnavailable):
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", msg, err)
+	default:
+		s.logger.Error(msg, "error", err)
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
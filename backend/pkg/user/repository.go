@@ -0,0 +1,171 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	CollectionUsers = "users"
+)
+
+var ErrUserNotFound = errors.New("user not found")
+
+// ProfileUpdate holds the profile fields to change. nil fields are left as they are.
+type ProfileUpdate struct {
+	Name    *string
+	IconURL *string
+	Bio     *string
+}
+
+type Repository struct {
+	client *firestore.Client
+}
+
+func NewRepository(client *firestore.Client) *Repository {
+	return &Repository{client: client}
+}
+
+// GetCircleID retrieves the circle ID of a user from Firestore ("" if the user has not joined a circle)
+func (r *Repository) GetCircleID(ctx context.Context, userID string) (string, error) {
+	doc, err := r.client.Collection(CollectionUsers).Doc(userID).Get(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	circleID, _ := doc.Data()["circleId"].(string)
+	return circleID, nil
+}
+
+// GetUser retrieves a single user from Firestore
+func (r *Repository) GetUser(ctx context.Context, userID string) (*ptera.User, error) {
+	doc, err := r.client.Collection(CollectionUsers).Doc(userID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return userFromDocument(doc), nil
+}
+
+// UpdateProfile applies the profile update and returns the updated user
+func (r *Repository) UpdateProfile(ctx context.Context, userID string, update ProfileUpdate, now time.Time) (*ptera.User, error) {
+	ref := r.client.Collection(CollectionUsers).Doc(userID)
+
+	updates := []firestore.Update{{Path: "updatedAt", Value: now}}
+	if update.Name != nil {
+		updates = append(updates, firestore.Update{Path: "displayName", Value: *update.Name})
+	}
+	if update.IconURL != nil {
+		updates = append(updates, firestore.Update{Path: "photoURL", Value: *update.IconURL})
+	}
+	if update.Bio != nil {
+		updates = append(updates, firestore.Update{Path: "bio", Value: *update.Bio})
+	}
+
+	// Update fails with NotFound if the document does not exist
+	if _, err := ref.Update(ctx, updates); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return r.GetUser(ctx, userID)
+}
+
+// SetFavoriteCard replaces the user's 推しメン (only one is kept, as on the frontend). "" clears it.
+func (r *Repository) SetFavoriteCard(ctx context.Context, userID, cardID string, now time.Time) (*ptera.User, error) {
+	favoriteCardIDs := []string{}
+	if cardID != "" {
+		favoriteCardIDs = []string{cardID}
+	}
+
+	_, err := r.client.Collection(CollectionUsers).Doc(userID).Update(ctx, []firestore.Update{
+		{Path: "favoriteCardIds", Value: favoriteCardIDs},
+		{Path: "updatedAt", Value: now},
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to set favorite card: %w", err)
+	}
+
+	return r.GetUser(ctx, userID)
+}
+
+// GetCircleFavoriteCardIDs collects the 推しメン card IDs of all members of the circle
+func (r *Repository) GetCircleFavoriteCardIDs(ctx context.Context, circleID string) ([]string, error) {
+	docs, err := r.client.Collection(CollectionUsers).Where("circleId", "==", circleID).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circle members: %w", err)
+	}
+
+	var ids []string
+	for _, doc := range docs {
+		ids = append(ids, userFromDocument(doc).FavoriteCardIds...)
+	}
+	return ids, nil
+}
+
+// GetUsers retrieves users by ID. Users that do not exist are skipped.
+func (r *Repository) GetUsers(ctx context.Context, userIDs []string) ([]*ptera.User, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	refs := make([]*firestore.DocumentRef, len(userIDs))
+	for i, id := range userIDs {
+		refs[i] = r.client.Collection(CollectionUsers).Doc(id)
+	}
+
+	docs, err := r.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	users := make([]*ptera.User, 0, len(docs))
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
+		users = append(users, userFromDocument(doc))
+	}
+	return users, nil
+}
+
+// userFromDocument maps a Firestore user document (see FirestoreUser on the frontend) to a proto User
+func userFromDocument(doc *firestore.DocumentSnapshot) *ptera.User {
+	data := doc.Data()
+
+	u := &ptera.User{
+		Id: doc.Ref.ID,
+	}
+	u.Name, _ = data["displayName"].(string)
+	u.IconUrl, _ = data["photoURL"].(string)
+	u.Bio, _ = data["bio"].(string)
+
+	if favorites, ok := data["favoriteCardIds"].([]interface{}); ok {
+		for _, f := range favorites {
+			if id, ok := f.(string); ok && id != "" {
+				u.FavoriteCardIds = append(u.FavoriteCardIds, id)
+			}
+		}
+	}
+
+	if email, ok := data["email"].(string); ok && email != "" {
+		u.Email = &email
+	}
+	if circleID, ok := data["circleId"].(string); ok && circleID != "" {
+		u.CircleId = &circleID
+	}
+	return u
+}
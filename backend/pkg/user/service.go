@@ -0,0 +1,137 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/authn"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxNameLength = 50
+	maxBioLength  = 200
+)
+
+type Service struct {
+	ptera.UnimplementedUserServiceServer
+	repo          *Repository
+	authenticator *authn.Authenticator
+	logger        *slog.Logger
+}
+
+func NewService(logger *slog.Logger, repo *Repository, authenticator *authn.Authenticator) *Service {
+	return &Service{
+		repo:          repo,
+		authenticator: authenticator,
+		logger:        logger,
+	}
+}
+
+// GetMe returns the caller's own profile, including the email address.
+// The caller is identified by their ID token, so no one else's email can be read.
+func (s *Service) GetMe(ctx context.Context, req *ptera.GetMeRequest) (*ptera.User, error) {
+	userID, err := s.authenticator.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, s.toStatus("failed to get user", err)
+	}
+	return u, nil
+}
+
+// GetUser returns the public profile of another user (without the email address)
+func (s *Service) GetUser(ctx context.Context, req *ptera.GetUserRequest) (*ptera.User, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	u, err := s.repo.GetUser(ctx, req.UserId)
+	if err != nil {
+		return nil, s.toStatus("failed to get user", err)
+	}
+	u.Email = nil
+	return u, nil
+}
+
+// UpdateProfile updates the given profile fields of the caller
+func (s *Service) UpdateProfile(ctx context.Context, req *ptera.UpdateProfileRequest) (*ptera.User, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	update, err := sanitizeProfile(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid profile: %v", err)
+	}
+
+	u, err := s.repo.UpdateProfile(ctx, req.UserId, update, time.Now())
+	if err != nil {
+		return nil, s.toStatus("failed to update profile", err)
+	}
+
+	s.logger.Info("profile updated", "user_id", req.UserId)
+	return u, nil
+}
+
+// toStatus maps repository errors to gRPC status codes
+func (s *Service) toStatus(msg string, err error) error {
+	switch {
+	case errors.Is(err, ErrUserNotFound):
+		return status.Errorf(codes.NotFound, "%s: %v", msg, err)
+	default:
+		s.logger.Error(msg, "error", err)
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
+func sanitizeProfile(req *ptera.UpdateProfileRequest) (ProfileUpdate, error) {
+	var update ProfileUpdate
+	var errs []string
+
+	if req.Name != nil {
+		name := strings.TrimSpace(req.GetName())
+		if name == "" {
+			errs = append(errs, "name must not be empty")
+		}
+		if utf8.RuneCountInString(name) > maxNameLength {
+			errs = append(errs, fmt.Sprintf("name must be at most %d characters", maxNameLength))
+		}
+		update.Name = &name
+	}
+
+	if req.IconUrl != nil {
+		iconURL := strings.TrimSpace(req.GetIconUrl())
+		if iconURL != "" {
+			u, err := url.Parse(iconURL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs = append(errs, "icon_url must be an http(s) URL")
+			}
+		}
+		update.IconURL = &iconURL
+	}
+
+	if req.Bio != nil {
+		bio := strings.TrimSpace(req.GetBio())
+		if utf8.RuneCountInString(bio) > maxBioLength {
+			errs = append(errs, fmt.Sprintf("bio must be at most %d characters", maxBioLength))
+		}
+		update.Bio = &bio
+	}
+
+	if len(errs) > 0 {
+		return ProfileUpdate{}, fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+	return update, nil
+}
@@ -0,0 +1,151 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"firebase.google.com/go/v4/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jyogi-web/2025_Ptera/backend/pkg/authn"
+	ptera "github.com/jyogi-web/2025_Ptera/backend/pkg/grpc/ptera/v1"
+)
+
+// fakeVerifier accepts the ID tokens in its map, returning the mapped user ID
+type fakeVerifier map[string]string
+
+func (f fakeVerifier) VerifyIDToken(ctx context.Context, idToken string) (*auth.Token, error) {
+	uid, ok := f[idToken]
+	if !ok {
+		return nil, errors.New("token is invalid")
+	}
+	return &auth.Token{UID: uid}, nil
+}
+
+func TestGetMeRequiresIDToken(t *testing.T) {
+	s := NewService(slog.New(slog.DiscardHandler), nil, authn.NewAuthenticator(fakeVerifier{"user-token": "user"}))
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{name: "no token"},
+		{name: "forged token", header: "Bearer forged"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.header))
+			}
+			_, err := s.GetMe(ctx, &ptera.GetMeRequest{})
+			if status.Code(err) != codes.Unauthenticated {
+				t.Errorf("GetMe() = %v, want %v", err, codes.Unauthenticated)
+			}
+		})
+	}
+}
+
+func TestSanitizeProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *ptera.UpdateProfileRequest
+		want    ProfileUpdate
+		wantErr bool
+	}{
+		{name: "nothing to update", req: &ptera.UpdateProfileRequest{}},
+		{
+			name: "trims",
+			req:  &ptera.UpdateProfileRequest{Name: proto.String(" 山田 "), IconUrl: proto.String(" https://example.com/a.png "), Bio: proto.String(" よろしく\n")},
+			want: ProfileUpdate{Name: proto.String("山田"), IconURL: proto.String("https://example.com/a.png"), Bio: proto.String("よろしく")},
+		},
+		{
+			name: "clears icon and bio",
+			req:  &ptera.UpdateProfileRequest{IconUrl: proto.String(""), Bio: proto.String(" ")},
+			want: ProfileUpdate{IconURL: proto.String(""), Bio: proto.String("")},
+		},
+		{
+			name: "name at the limit",
+			req:  &ptera.UpdateProfileRequest{Name: proto.String(strings.Repeat("あ", maxNameLength))},
+			want: ProfileUpdate{Name: proto.String(strings.Repeat("あ", maxNameLength))},
+		},
+		{name: "blank name", req: &ptera.UpdateProfileRequest{Name: proto.String("  ")}, wantErr: true},
+		{name: "long name", req: &ptera.UpdateProfileRequest{Name: proto.String(strings.Repeat("あ", maxNameLength+1))}, wantErr: true},
+		{name: "long bio", req: &ptera.UpdateProfileRequest{Bio: proto.String(strings.Repeat("あ", maxBioLength+1))}, wantErr: true},
+		{name: "icon without scheme", req: &ptera.UpdateProfileRequest{IconUrl: proto.String("example.com/a.png")}, wantErr: true},
+		{name: "icon with javascript scheme", req: &ptera.UpdateProfileRequest{IconUrl: proto.String("javascript:alert(1)")}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sanitizeProfile(tt.req)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("sanitizeProfile() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("sanitizeProfile() error = %v", err)
+			}
+			for _, f := range []struct {
+				field     string
+				got, want *string
+			}{
+				{"Name", got.Name, tt.want.Name},
+				{"IconURL", got.IconURL, tt.want.IconURL},
+				{"Bio", got.Bio, tt.want.Bio},
+			} {
+				if (f.got == nil) != (f.want == nil) || (f.got != nil && *f.got != *f.want) {
+					t.Errorf("%s = %v, want %v", f.field, ptrString(f.got), ptrString(f.want))
+				}
+			}
+		})
+	}
+}
+
+func ptrString(s *string) string {
+	if s == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%q", *s)
+}
+
+// TestValidation covers the requests rejected before the repository is used
+func TestValidation(t *testing.T) {
+	s := NewService(slog.New(slog.DiscardHandler), nil, nil)
+	ctx := context.Background()
+
+	if _, err := s.GetUser(ctx, &ptera.GetUserRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetUser() without user_id = %v, want %v", err, codes.InvalidArgument)
+	}
+	if _, err := s.UpdateProfile(ctx, &ptera.UpdateProfileRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("UpdateProfile() without user_id = %v, want %v", err, codes.InvalidArgument)
+	}
+	req := &ptera.UpdateProfileRequest{UserId: "user1", Name: proto.String("")}
+	if _, err := s.UpdateProfile(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("UpdateProfile() with a blank name = %v, want %v", err, codes.InvalidArgument)
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	s := NewService(slog.New(slog.DiscardHandler), nil, nil)
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{ErrUserNotFound, codes.NotFound},
+		{fmt.Errorf("%w: user1", ErrUserNotFound), codes.NotFound},
+		{errors.New("firestore is down"), codes.Internal},
+	}
+	for _, tt := range tests {
+		if got := status.Code(s.toStatus("failed", tt.err)); got != tt.want {
+			t.Errorf("toStatus(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
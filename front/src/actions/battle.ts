@@ -1,6 +1,6 @@
 "use server";
 
-import { battleClient } from "@/lib/grpc";
+import { battleClient, fairnessClient } from "@/lib/grpc";
 
 // Using simple JSON serialization for passing data across serializable boundary if needed,
 // but ConnectRPC types should be serializable.
@@ -8,6 +8,22 @@ import { battleClient } from "@/lib/grpc";
 // Proto messages are classes in protobuf-es/connect-es, which might not be directly serializable by Next.js actions (React Server Actions).
 // Best practice: Convert to plain info or use `toJson` / `fromJson`.
 
+/**
+ * Commit a server seed for a battle of the circle.
+ * The client nonce is generated only after the seed hash has been published,
+ * so the server cannot pick a seed that favors a known nonce.
+ */
+async function commitBattleSeed(circleId: string) {
+  const commitment = await fairnessClient.commitSeed({
+    purpose: "battle",
+    ownerId: circleId,
+  });
+  return {
+    commitmentId: commitment.commitmentId,
+    clientNonce: crypto.randomUUID(),
+  };
+}
+
 /**
  * Start Battle
  */
@@ -16,9 +32,12 @@ export async function startBattleAction(
   opponentCircleId: string,
 ) {
   try {
+    const { commitmentId, clientNonce } = await commitBattleSeed(myCircleId);
     const response = await battleClient.startBattle({
       myCircleId,
       opponentCircleId,
+      commitmentId,
+      clientNonce,
     });
     // Serialize to plain object to avoid Next.js warnings about "Plain Object"
     return JSON.parse(JSON.stringify(response.battleState));
@@ -91,10 +110,17 @@ export async function sendBattleRequestAction(
 /**
  * Accept Battle Request
  */
-export async function acceptBattleRequestAction(requestId: string) {
+export async function acceptBattleRequestAction(
+  requestId: string,
+  myCircleId: string,
+) {
   try {
+    // The accepting circle commits the seed of the battle
+    const { commitmentId, clientNonce } = await commitBattleSeed(myCircleId);
     const response = await battleClient.acceptBattleRequest({
       requestId,
+      commitmentId,
+      clientNonce,
     });
     return JSON.parse(JSON.stringify(response));
   } catch (error) {
@@ -25,11 +25,13 @@ interface CardFormData {
 
 /**
  * AIを使用してカード情報を補完する
+ * @param idToken - ログイン中のユーザーのFirebase IDトークン (AIの利用量はこのユーザーに計上される)
  * @param imageUrl - 名刺画像のURL
  * @param currentData - 現在のフォームデータ
  * @returns 補完結果 { success: boolean, data: CardFormData | null, error: string | null }
  */
 export async function completeCardAction(
+  idToken: string,
   imageUrl: string,
   currentData: CardFormData,
 ): Promise<{
@@ -46,6 +48,15 @@ export async function completeCardAction(
     };
   }
 
+  // AIの利用量はIDトークンのユーザーに計上される (バックエンドで検証)
+  if (!idToken) {
+    return {
+      success: false,
+      data: null,
+      error: "ログインが必要です",
+    };
+  }
+
   try {
     try {
       const request = new CompleteCardRequest({
@@ -59,7 +70,18 @@ export async function completeCardAction(
         description: currentData.description,
       });
 
-      const response = await pteraClient.completeCard(request);
+      const response = await pteraClient.completeCard(request, {
+        headers: { authorization: `Bearer ${idToken}` },
+      });
+
+      // AIの失敗は success=false と errorMessage で返される
+      if (!response.success) {
+        return {
+          success: false,
+          data: null,
+          error: response.errorMessage || "AI補完に失敗しました",
+        };
+      }
 
       // クライアントに返すためにレスポンスをプレーンなオブジェクトに変換
       return {
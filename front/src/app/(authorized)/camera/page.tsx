@@ -4,6 +4,7 @@ import { useRef, useState, useTransition } from "react";
 import toast from "react-hot-toast";
 import { useAuth } from "@/context/AuthContext";
 import { calculateGraduationDate } from "@/helper/converter";
+import { auth } from "@/lib/firebase";
 import { addCard } from "@/lib/firestore";
 import { deleteImage, uploadImage } from "@/lib/storage";
 import CameraCapture from "./_components/CameraCapture";
@@ -164,7 +165,12 @@ export default function CameraPage() {
     if (!uploadedImageUrl) return;
 
     startTransition(async () => {
-      const result = await completeCardAction(uploadedImageUrl, {
+      const idToken = await auth.currentUser?.getIdToken();
+      if (!idToken) {
+        toast.error("ログインしていません。");
+        return;
+      }
+      const result = await completeCardAction(idToken, uploadedImageUrl, {
         name: form.name,
         position: form.position,
         hobby: form.hobby,
@@ -57,7 +57,7 @@ export function BattleRequestList({ myCircleId }: BattleRequestListProps) {
   }
 
   const handleAccept = async (requestId: string) => {
-    const battleId = await acceptRequest(requestId);
+    const battleId = await acceptRequest(requestId, myCircleId);
     if (battleId) {
       router.push(`/battle/${battleId}`);
     }
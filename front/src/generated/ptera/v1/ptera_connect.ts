@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { AcceptBattleRequestRequest, AttackRequest, AttackResponse, BattleRequest, BattleState, CompleteCardRequest, CompleteCardResponse, RejectBattleRequestRequest, RetreatRequest, RetreatResponse, SendBattleRequestRequest, StartBattleRequest, StartBattleResponse } from "./ptera_pb";
+import { AcceptBattleRequestRequest, AcceptTradeRequest, AttackRequest, AttackResponse, BattleRequest, BattleState, CancelTradeRequest, Card, Circle, CommitSeedRequest, CompleteCardRequest, CompleteCardResponse, CreateCardRequest, CreateCircleRequest, DeleteCardRequest, DeleteCardResponse, DetectMembersRequest, DetectMembersResponse, GenerateInviteCodeRequest, GetCardRequest, GetCircleRequest, GetCommitmentRequest, GetMeRequest, GetQuotaUsageRequest, GetQuotaUsageResponse, GetUserRequest, InviteCode, IssueCardQRRequest, IssueCardQRResponse, JoinCircleRequest, LeaveCircleRequest, LeaveCircleResponse, ListCardsRequest, ListCardsResponse, ListFavoriteCardsRequest, ListFavoriteCardsResponse, ListMembersRequest, ListMembersResponse, ProposeTradeRequest, PullRequest, PullResponse, RegenerateFieldRequest, RegenerateFieldResponse, RejectBattleRequestRequest, RejectTradeRequest, RenderCardRequest, RenderCardResponse, ResetQuotaUsageRequest, ResolveCardQRRequest, RetreatRequest, RetreatResponse, SeedCommitment, SendBattleRequestRequest, SetFavoriteCardRequest, StartBattleRequest, StartBattleResponse, StreamCompleteCardResponse, Trade, UpdateCardRequest, UpdateProfileRequest, UploadCardImageRequest, UploadCardImageResponse, User, VerifyRollsRequest, VerifyRollsResponse } from "./ptera_pb";
 import { MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -15,6 +15,8 @@ export const PteraService = {
     /**
      * CompleteCard は画像URLと任意の部分情報を受け取り、
      * AIを使用してカード情報を自動補完します。
+     * AIを使うRPC (CompleteCard, StreamCompleteCard, DetectMembers, RegenerateField) は
+     * authorization メタデータに "Bearer <FirebaseのIDトークン>" が必要で、利用量はそのユーザーに集計されます。
      *
      * @generated from rpc ptera.v1.PteraService.CompleteCard
      */
@@ -24,6 +26,159 @@ export const PteraService = {
       O: CompleteCardResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * StreamCompleteCard は CompleteCard のストリーミング版です。
+     * 生成中のフィールドを field で順次返し、最後に検証済みの result を1回返します。
+     *
+     * @generated from rpc ptera.v1.PteraService.StreamCompleteCard
+     */
+    streamCompleteCard: {
+      name: "StreamCompleteCard",
+      I: CompleteCardRequest,
+      O: StreamCompleteCardResponse,
+      kind: MethodKind.ServerStreaming,
+    },
+    /**
+     * DetectMembers は集合写真から人物を検出し、一人ずつ切り抜いた画像と
+     * カードの下書きを返します (AI利用量は検出で1回、下書き1人ごとに1回として集計され、
+     * 上限に達した後の人物は下書きなしで返されます)。
+     *
+     * @generated from rpc ptera.v1.PteraService.DetectMembers
+     */
+    detectMembers: {
+      name: "DetectMembers",
+      I: DetectMembersRequest,
+      O: DetectMembersResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * RegenerateField は他のフィールドを固定したまま、1つのフィールドの候補をn個生成します。
+     * 編集フォームで選択肢として表示するためのものです (AI利用量は1回分として集計されます)。
+     *
+     * @generated from rpc ptera.v1.PteraService.RegenerateField
+     */
+    regenerateField: {
+      name: "RegenerateField",
+      I: RegenerateFieldRequest,
+      O: RegenerateFieldResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * UploadCardImage は画像をチャンクで受け取り保存します。
+     * 最初のメッセージで metadata を、続くメッセージで chunk を送ります。
+     *
+     * @generated from rpc ptera.v1.PteraService.UploadCardImage
+     */
+    uploadCardImage: {
+      name: "UploadCardImage",
+      I: UploadCardImageRequest,
+      O: UploadCardImageResponse,
+      kind: MethodKind.ClientStreaming,
+    },
+    /**
+     * Card CRUD: サーバー側でバリデーション・所有者チェックを行い、
+     * circle_id と expiry_date を付与して保存します。
+     *
+     * @generated from rpc ptera.v1.PteraService.CreateCard
+     */
+    createCard: {
+      name: "CreateCard",
+      I: CreateCardRequest,
+      O: Card,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc ptera.v1.PteraService.GetCard
+     */
+    getCard: {
+      name: "GetCard",
+      I: GetCardRequest,
+      O: Card,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc ptera.v1.PteraService.UpdateCard
+     */
+    updateCard: {
+      name: "UpdateCard",
+      I: UpdateCardRequest,
+      O: Card,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc ptera.v1.PteraService.DeleteCard
+     */
+    deleteCard: {
+      name: "DeleteCard",
+      I: DeleteCardRequest,
+      O: DeleteCardResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc ptera.v1.PteraService.ListCards
+     */
+    listCards: {
+      name: "ListCards",
+      I: ListCardsRequest,
+      O: ListCardsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * 推しメン: ユーザーごとに1枚まで。推しメンのカードはバトルでデッキ確定枠と士気ボーナスを得ます。
+     *
+     * @generated from rpc ptera.v1.PteraService.SetFavoriteCard
+     */
+    setFavoriteCard: {
+      name: "SetFavoriteCard",
+      I: SetFavoriteCardRequest,
+      O: User,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc ptera.v1.PteraService.ListFavoriteCards
+     */
+    listFavoriteCards: {
+      name: "ListFavoriteCards",
+      I: ListFavoriteCardsRequest,
+      O: ListFavoriteCardsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * RenderCard はカードを共有用のPNG画像にします (カードの内容が変わるまでキャッシュされます)。
+     * 同じ画像は HTTP の GET /cards/{card_id}.png?style= でも取得できます。
+     *
+     * @generated from rpc ptera.v1.PteraService.RenderCard
+     */
+    renderCard: {
+      name: "RenderCard",
+      I: RenderCardRequest,
+      O: RenderCardResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * IssueCardQR はカードを表す署名付きトークンとそのQRコードを発行します (QRメンコ用)。
+     * カードの作成者か、コレクションに持っているユーザーのみ発行できます。
+     * トークンは有効期限付きで、ResolveCardQR でのみ検証できます。
+     *
+     * @generated from rpc ptera.v1.PteraService.IssueCardQR
+     */
+    issueCardQR: {
+      name: "IssueCardQR",
+      I: IssueCardQRRequest,
+      O: IssueCardQRResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ResolveCardQR は読み取ったトークンを検証し、バトルステータス付きのカードを返します。
+     *
+     * @generated from rpc ptera.v1.PteraService.ResolveCardQR
+     */
+    resolveCardQR: {
+      name: "ResolveCardQR",
+      I: ResolveCardQRRequest,
+      O: Card,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
@@ -92,3 +247,267 @@ export const BattleService = {
   }
 } as const;
 
+/**
+ * @generated from service ptera.v1.GachaService
+ */
+export const GachaService = {
+  typeName: "ptera.v1.GachaService",
+  methods: {
+    /**
+     * Pull はガチャを count 回引き、結果をユーザーのコレクションに追加します。
+     * 抽選結果はすべて監査用に記録されます。
+     *
+     * @generated from rpc ptera.v1.GachaService.Pull
+     */
+    pull: {
+      name: "Pull",
+      I: PullRequest,
+      O: PullResponse,
+      kind: MethodKind.Unary,
+    },
+  }
+} as const;
+
+/**
+ * FairnessService はバトル/ガチャの乱数を検証できるようにするコミット・リビール方式のサービスです。
+ * 乱数 i は HMAC-SHA256(server_seed, client_nonce + ":" + i) の先頭8バイト (ビッグエンディアン) です。
+ * n 通りの抽選では 2^64 を n で割り切れない上端の値を読み飛ばし (読み飛ばした分も i を進める)、
+ * 最初に残った値を n で割った余りを使うため、公開されたシードから誰でも同じ結果を再計算できます。
+ *
+ * @generated from service ptera.v1.FairnessService
+ */
+export const FairnessService = {
+  typeName: "ptera.v1.FairnessService",
+  methods: {
+    /**
+     * CommitSeed はバトル/ガチャの前にサーバーシードを生成し、そのハッシュを公開します。
+     * クライアントはハッシュを受け取った後でノンスを生成し、StartBattle / AcceptBattleRequest / Pull に渡します。
+     *
+     * @generated from rpc ptera.v1.FairnessService.CommitSeed
+     */
+    commitSeed: {
+      name: "CommitSeed",
+      I: CommitSeedRequest,
+      O: SeedCommitment,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetCommitment はコミットメントを取得します。シードは公開後のみ含まれます。
+     *
+     * @generated from rpc ptera.v1.FairnessService.GetCommitment
+     */
+    getCommitment: {
+      name: "GetCommitment",
+      I: GetCommitmentRequest,
+      O: SeedCommitment,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * VerifyRolls は公開されたシードから全ての乱数を再計算し、記録された結果と照合します。
+     *
+     * @generated from rpc ptera.v1.FairnessService.VerifyRolls
+     */
+    verifyRolls: {
+      name: "VerifyRolls",
+      I: VerifyRollsRequest,
+      O: VerifyRollsResponse,
+      kind: MethodKind.Unary,
+    },
+  }
+} as const;
+
+/**
+ * @generated from service ptera.v1.TradeService
+ */
+export const TradeService = {
+  typeName: "ptera.v1.TradeService",
+  methods: {
+    /**
+     * ProposeTrade は所持カードの交換を提案します。提示したカードは取引終了までロックされます。
+     *
+     * @generated from rpc ptera.v1.TradeService.ProposeTrade
+     */
+    proposeTrade: {
+      name: "ProposeTrade",
+      I: ProposeTradeRequest,
+      O: Trade,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * AcceptTrade は提案を承諾し、双方のカードの所有権をアトミックに移動します。
+     *
+     * @generated from rpc ptera.v1.TradeService.AcceptTrade
+     */
+    acceptTrade: {
+      name: "AcceptTrade",
+      I: AcceptTradeRequest,
+      O: Trade,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc ptera.v1.TradeService.RejectTrade
+     */
+    rejectTrade: {
+      name: "RejectTrade",
+      I: RejectTradeRequest,
+      O: Trade,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc ptera.v1.TradeService.CancelTrade
+     */
+    cancelTrade: {
+      name: "CancelTrade",
+      I: CancelTradeRequest,
+      O: Trade,
+      kind: MethodKind.Unary,
+    },
+  }
+} as const;
+
+/**
+ * @generated from service ptera.v1.CircleService
+ */
+export const CircleService = {
+  typeName: "ptera.v1.CircleService",
+  methods: {
+    /**
+     * CreateCircle はサークルを作成し、作成者をメンバーにします。
+     *
+     * @generated from rpc ptera.v1.CircleService.CreateCircle
+     */
+    createCircle: {
+      name: "CreateCircle",
+      I: CreateCircleRequest,
+      O: Circle,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc ptera.v1.CircleService.GetCircle
+     */
+    getCircle: {
+      name: "GetCircle",
+      I: GetCircleRequest,
+      O: Circle,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GenerateInviteCode はメンバーがサークルへの招待コードを発行します。
+     *
+     * @generated from rpc ptera.v1.CircleService.GenerateInviteCode
+     */
+    generateInviteCode: {
+      name: "GenerateInviteCode",
+      I: GenerateInviteCodeRequest,
+      O: InviteCode,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * JoinCircle は招待コードでサークルに参加します (参加中のサークルからは自動で抜けます)。
+     *
+     * @generated from rpc ptera.v1.CircleService.JoinCircle
+     */
+    joinCircle: {
+      name: "JoinCircle",
+      I: JoinCircleRequest,
+      O: Circle,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc ptera.v1.CircleService.LeaveCircle
+     */
+    leaveCircle: {
+      name: "LeaveCircle",
+      I: LeaveCircleRequest,
+      O: LeaveCircleResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc ptera.v1.CircleService.ListMembers
+     */
+    listMembers: {
+      name: "ListMembers",
+      I: ListMembersRequest,
+      O: ListMembersResponse,
+      kind: MethodKind.Unary,
+    },
+  }
+} as const;
+
+/**
+ * @generated from service ptera.v1.UserService
+ */
+export const UserService = {
+  typeName: "ptera.v1.UserService",
+  methods: {
+    /**
+     * GetMe は自分のプロフィールを返します (メールアドレスを含む)。
+     * 呼び出し元は authorization メタデータの "Bearer <FirebaseのIDトークン>" で判定します。
+     *
+     * @generated from rpc ptera.v1.UserService.GetMe
+     */
+    getMe: {
+      name: "GetMe",
+      I: GetMeRequest,
+      O: User,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetUser は他のユーザーの公開プロフィールを返します。
+     *
+     * @generated from rpc ptera.v1.UserService.GetUser
+     */
+    getUser: {
+      name: "GetUser",
+      I: GetUserRequest,
+      O: User,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * UpdateProfile は指定されたフィールドのみ更新します。
+     *
+     * @generated from rpc ptera.v1.UserService.UpdateProfile
+     */
+    updateProfile: {
+      name: "UpdateProfile",
+      I: UpdateProfileRequest,
+      O: User,
+      kind: MethodKind.Unary,
+    },
+  }
+} as const;
+
+/**
+ * QuotaService はAI利用量 (呼び出し回数・トークン数) の確認とリセットを行う管理者用サービスです。
+ * "authorization: Bearer <Firebase IDトークン>" メタデータが必要で、トークンのユーザーが ADMIN_USER_IDS に含まれている必要があります。
+ *
+ * @generated from service ptera.v1.QuotaService
+ */
+export const QuotaService = {
+  typeName: "ptera.v1.QuotaService",
+  methods: {
+    /**
+     * GetQuotaUsage はユーザーまたはサークルの日次・月次の利用量を返します。
+     *
+     * @generated from rpc ptera.v1.QuotaService.GetQuotaUsage
+     */
+    getQuotaUsage: {
+      name: "GetQuotaUsage",
+      I: GetQuotaUsageRequest,
+      O: GetQuotaUsageResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ResetQuotaUsage は現在の期間の利用量を0に戻します。
+     *
+     * @generated from rpc ptera.v1.QuotaService.ResetQuotaUsage
+     */
+    resetQuotaUsage: {
+      name: "ResetQuotaUsage",
+      I: ResetQuotaUsageRequest,
+      O: GetQuotaUsageResponse,
+      kind: MethodKind.Unary,
+    },
+  }
+} as const;
+
@@ -4,7 +4,7 @@
 // @ts-nocheck
 
 import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
-import { Message, proto3, Timestamp } from "@bufbuild/protobuf";
+import { Message, proto3, protoInt64, Timestamp } from "@bufbuild/protobuf";
 
 /**
  * @generated from message ptera.v1.User
@@ -35,6 +35,18 @@ export class User extends Message<User> {
    */
   circleId?: string;
 
+  /**
+   * @generated from field: string bio = 6;
+   */
+  bio = "";
+
+  /**
+   * 推しメンのカードID
+   *
+   * @generated from field: repeated string favorite_card_ids = 7;
+   */
+  favoriteCardIds: string[] = [];
+
   constructor(data?: PartialMessage<User>) {
     super();
     proto3.util.initPartial(data, this);
@@ -48,6 +60,8 @@ export class User extends Message<User> {
     { no: 3, name: "icon_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 4, name: "email", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
     { no: 5, name: "circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 6, name: "bio", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "favorite_card_ids", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): User {
@@ -150,6 +164,48 @@ export class Card extends Message<Card> {
    */
   currentHp = 0;
 
+  /**
+   * 有効期限(卒業日)
+   *
+   * @generated from field: google.protobuf.Timestamp expiry_date = 16;
+   */
+  expiryDate?: Timestamp;
+
+  /**
+   * 有効期限切れ(卒業済み = OB/OG)かどうか
+   *
+   * @generated from field: bool graduated = 17;
+   */
+  graduated = false;
+
+  /**
+   * バトル中: サークルメンバーの推しメン (士気ボーナス付き)
+   *
+   * @generated from field: bool favorite = 18;
+   */
+  favorite = false;
+
+  /**
+   * flavor がAIで生成されたものかどうか
+   *
+   * @generated from field: bool flavor_ai_generated = 19;
+   */
+  flavorAiGenerated = false;
+
+  /**
+   * バトルステータスの傾向 (省略時は balanced)
+   *
+   * @generated from field: ptera.v1.StatProfile stat_profile = 20;
+   */
+  statProfile?: StatProfile;
+
+  /**
+   * AI補完の結果を使った場合、その CompleteCardResponse.prompt_version
+   *
+   * @generated from field: string prompt_version = 21;
+   */
+  promptVersion = "";
+
   constructor(data?: PartialMessage<Card>) {
     super();
     proto3.util.initPartial(data, this);
@@ -173,6 +229,12 @@ export class Card extends Message<Card> {
     { no: 13, name: "attack", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
     { no: 14, name: "flavor", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 15, name: "current_hp", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 16, name: "expiry_date", kind: "message", T: Timestamp },
+    { no: 17, name: "graduated", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 18, name: "favorite", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 19, name: "flavor_ai_generated", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 20, name: "stat_profile", kind: "message", T: StatProfile },
+    { no: 21, name: "prompt_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Card {
@@ -192,6 +254,64 @@ export class Card extends Message<Card> {
   }
 }
 
+/**
+ * StatProfile はバトルステータスの傾向です。
+ * 種類ごとにHPと攻撃力の配分が決まっており、合計の強さはほぼ変わりません。
+ *
+ * @generated from message ptera.v1.StatProfile
+ */
+export class StatProfile extends Message<StatProfile> {
+  /**
+   * "balanced", "tank", "attacker", "speedster"
+   *
+   * @generated from field: string type = 1;
+   */
+  type = "";
+
+  /**
+   * 傾向の強さ 0.0〜1.0
+   *
+   * @generated from field: float intensity = 2;
+   */
+  intensity = 0;
+
+  /**
+   * AIが提案した理由
+   *
+   * @generated from field: string reason = 3;
+   */
+  reason = "";
+
+  constructor(data?: PartialMessage<StatProfile>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.StatProfile";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "type", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "intensity", kind: "scalar", T: 2 /* ScalarType.FLOAT */ },
+    { no: 3, name: "reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): StatProfile {
+    return new StatProfile().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): StatProfile {
+    return new StatProfile().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): StatProfile {
+    return new StatProfile().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: StatProfile | PlainMessage<StatProfile> | undefined, b: StatProfile | PlainMessage<StatProfile> | undefined): boolean {
+    return proto3.util.equals(StatProfile, a, b);
+  }
+}
+
 /**
  * @generated from message ptera.v1.Circle
  */
@@ -206,6 +326,36 @@ export class Circle extends Message<Circle> {
    */
   name = "";
 
+  /**
+   * @generated from field: string description = 3;
+   */
+  description = "";
+
+  /**
+   * @generated from field: string image_url = 4;
+   */
+  imageUrl = "";
+
+  /**
+   * @generated from field: repeated string member_ids = 5;
+   */
+  memberIds: string[] = [];
+
+  /**
+   * @generated from field: string creator_id = 6;
+   */
+  creatorId = "";
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 7;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp updated_at = 8;
+   */
+  updatedAt?: Timestamp;
+
   constructor(data?: PartialMessage<Circle>) {
     super();
     proto3.util.initPartial(data, this);
@@ -216,6 +366,12 @@ export class Circle extends Message<Circle> {
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "image_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "member_ids", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 6, name: "creator_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "created_at", kind: "message", T: Timestamp },
+    { no: 8, name: "updated_at", kind: "message", T: Timestamp },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Circle {
@@ -295,6 +451,34 @@ export class CompleteCardRequest extends Message<CompleteCardRequest> {
    */
   description?: string;
 
+  /**
+   * UploadCardImage で取得したID (指定時は image_url より優先)
+   *
+   * @generated from field: optional string image_id = 9;
+   */
+  imageId?: string;
+
+  /**
+   * trueの場合キャッシュを使わずに新しい提案を生成する
+   *
+   * @generated from field: bool force_refresh = 10;
+   */
+  forceRefresh = false;
+
+  /**
+   * 生成する文章の言語 "ja", "en" (省略時は ja)
+   *
+   * @generated from field: string locale = 12;
+   */
+  locale = "";
+
+  /**
+   * 文体 "funny", "formal", "neutral" (省略時は funny)
+   *
+   * @generated from field: string tone = 13;
+   */
+  tone = "";
+
   constructor(data?: PartialMessage<CompleteCardRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -311,6 +495,10 @@ export class CompleteCardRequest extends Message<CompleteCardRequest> {
     { no: 6, name: "position", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
     { no: 7, name: "hobby", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
     { no: 8, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 9, name: "image_id", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 10, name: "force_refresh", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 12, name: "locale", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 13, name: "tone", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CompleteCardRequest {
@@ -397,6 +585,41 @@ export class CompleteCardResponse extends Message<CompleteCardResponse> {
    */
   errorMessage?: string;
 
+  /**
+   * 提案されたバトルステータスの傾向
+   *
+   * @generated from field: ptera.v1.StatProfile stat_profile = 10;
+   */
+  statProfile?: StatProfile;
+
+  /**
+   * キャッシュされた提案を返した場合true
+   *
+   * @generated from field: bool cached = 11;
+   */
+  cached = false;
+
+  /**
+   * モデレーションで不適切と判定された場合true (提案内容は返さない)
+   *
+   * @generated from field: bool flagged = 12;
+   */
+  flagged = false;
+
+  /**
+   * flagged の理由
+   *
+   * @generated from field: repeated ptera.v1.ModerationReason moderation_reasons = 13;
+   */
+  moderationReasons: ModerationReason[] = [];
+
+  /**
+   * 生成に使ったプロンプトのバージョン (例: card-v3/ja/funny)。カード保存時に Card.prompt_version へ設定する
+   *
+   * @generated from field: string prompt_version = 14;
+   */
+  promptVersion = "";
+
   constructor(data?: PartialMessage<CompleteCardResponse>) {
     super();
     proto3.util.initPartial(data, this);
@@ -414,6 +637,11 @@ export class CompleteCardResponse extends Message<CompleteCardResponse> {
     { no: 7, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 8, name: "success", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
     { no: 9, name: "error_message", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 10, name: "stat_profile", kind: "message", T: StatProfile },
+    { no: 11, name: "cached", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 12, name: "flagged", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 13, name: "moderation_reasons", kind: "message", T: ModerationReason, repeated: true },
+    { no: 14, name: "prompt_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CompleteCardResponse {
@@ -434,596 +662,4015 @@ export class CompleteCardResponse extends Message<CompleteCardResponse> {
 }
 
 /**
- * @generated from message ptera.v1.BattleState
+ * CardFieldUpdate は生成途中で確定したフィールドです。
+ * 最終的な値は result が正です (result が flagged の場合は表示した値を破棄してください)。
+ *
+ * @generated from message ptera.v1.CardFieldUpdate
  */
-export class BattleState extends Message<BattleState> {
+export class CardFieldUpdate extends Message<CardFieldUpdate> {
   /**
-   * @generated from field: string battle_id = 1;
+   * name, faculty, department, grade, position, hobby, description, stat_profile.type, stat_profile.intensity, stat_profile.reason
+   *
+   * @generated from field: string field = 1;
    */
-  battleId = "";
+  field = "";
 
   /**
-   * リクエストしたプレイヤー（自分）
+   * 値 (数値は文字列で表現)
    *
-   * @generated from field: ptera.v1.Player player_me = 2;
+   * @generated from field: string value = 2;
    */
-  playerMe?: Player;
+  value = "";
+
+  constructor(data?: PartialMessage<CardFieldUpdate>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.CardFieldUpdate";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "field", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "value", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CardFieldUpdate {
+    return new CardFieldUpdate().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CardFieldUpdate {
+    return new CardFieldUpdate().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CardFieldUpdate {
+    return new CardFieldUpdate().fromJsonString(jsonString, options);
+  }
 
+  static equals(a: CardFieldUpdate | PlainMessage<CardFieldUpdate> | undefined, b: CardFieldUpdate | PlainMessage<CardFieldUpdate> | undefined): boolean {
+    return proto3.util.equals(CardFieldUpdate, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.StreamCompleteCardResponse
+ */
+export class StreamCompleteCardResponse extends Message<StreamCompleteCardResponse> {
   /**
-   * 対戦相手
-   *
-   * @generated from field: ptera.v1.Player player_opponent = 3;
+   * @generated from oneof ptera.v1.StreamCompleteCardResponse.event
    */
-  playerOpponent?: Player;
+  event: {
+    /**
+     * @generated from field: ptera.v1.CardFieldUpdate field = 1;
+     */
+    value: CardFieldUpdate;
+    case: "field";
+  } | {
+    /**
+     * 最後のメッセージ
+     *
+     * @generated from field: ptera.v1.CompleteCardResponse result = 2;
+     */
+    value: CompleteCardResponse;
+    case: "result";
+  } | { case: undefined; value?: undefined } = { case: undefined };
+
+  constructor(data?: PartialMessage<StreamCompleteCardResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.StreamCompleteCardResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "field", kind: "message", T: CardFieldUpdate, oneof: "event" },
+    { no: 2, name: "result", kind: "message", T: CompleteCardResponse, oneof: "event" },
+  ]);
 
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): StreamCompleteCardResponse {
+    return new StreamCompleteCardResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): StreamCompleteCardResponse {
+    return new StreamCompleteCardResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): StreamCompleteCardResponse {
+    return new StreamCompleteCardResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: StreamCompleteCardResponse | PlainMessage<StreamCompleteCardResponse> | undefined, b: StreamCompleteCardResponse | PlainMessage<StreamCompleteCardResponse> | undefined): boolean {
+    return proto3.util.equals(StreamCompleteCardResponse, a, b);
+  }
+}
+
+/**
+ * ModerationReason はモデレーションで不適切と判定された理由です。
+ *
+ * @generated from message ptera.v1.ModerationReason
+ */
+export class ModerationReason extends Message<ModerationReason> {
   /**
-   * @generated from field: int32 current_turn = 4;
+   * "input" (画像・入力) または "output" (生成結果)
+   *
+   * @generated from field: string stage = 1;
    */
-  currentTurn = 0;
+  stage = "";
 
   /**
-   * ターンプレイヤーのID
+   * "safety" (モデルの安全性評価) または "blocklist" (禁止語句)
    *
-   * @generated from field: string current_player_id = 5;
+   * @generated from field: string source = 2;
    */
-  currentPlayerId = "";
+  source = "";
 
   /**
-   * 勝者のID ("" なら対戦中)
+   * 該当するフィールド名 (画像全体の場合は空)
    *
-   * @generated from field: string winner_id = 6;
+   * @generated from field: string field = 3;
    */
-  winnerId = "";
+  field = "";
 
   /**
-   * @generated from field: repeated string logs = 7;
+   * 安全性カテゴリや一致した語句
+   *
+   * @generated from field: string detail = 4;
    */
-  logs: string[] = [];
+  detail = "";
 
-  constructor(data?: PartialMessage<BattleState>) {
+  constructor(data?: PartialMessage<ModerationReason>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "ptera.v1.BattleState";
+  static readonly typeName = "ptera.v1.ModerationReason";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "battle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 2, name: "player_me", kind: "message", T: Player },
-    { no: 3, name: "player_opponent", kind: "message", T: Player },
-    { no: 4, name: "current_turn", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
-    { no: 5, name: "current_player_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 6, name: "winner_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 7, name: "logs", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 1, name: "stage", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "source", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "field", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "detail", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): BattleState {
-    return new BattleState().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ModerationReason {
+    return new ModerationReason().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): BattleState {
-    return new BattleState().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ModerationReason {
+    return new ModerationReason().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): BattleState {
-    return new BattleState().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ModerationReason {
+    return new ModerationReason().fromJsonString(jsonString, options);
   }
 
-  static equals(a: BattleState | PlainMessage<BattleState> | undefined, b: BattleState | PlainMessage<BattleState> | undefined): boolean {
-    return proto3.util.equals(BattleState, a, b);
+  static equals(a: ModerationReason | PlainMessage<ModerationReason> | undefined, b: ModerationReason | PlainMessage<ModerationReason> | undefined): boolean {
+    return proto3.util.equals(ModerationReason, a, b);
   }
 }
 
 /**
- * @generated from message ptera.v1.Player
+ * @generated from message ptera.v1.DetectMembersRequest
  */
-export class Player extends Message<Player> {
-  /**
-   * @generated from field: string player_id = 1;
-   */
-  playerId = "";
-
+export class DetectMembersRequest extends Message<DetectMembersRequest> {
   /**
-   * @generated from field: string circle_id = 2;
+   * 集合写真のURL
+   *
+   * @generated from field: string image_url = 2;
    */
-  circleId = "";
+  imageUrl = "";
 
   /**
-   * @generated from field: string circle_name = 3;
+   * UploadCardImage で取得したID (指定時は image_url より優先)
+   *
+   * @generated from field: optional string image_id = 3;
    */
-  circleName = "";
+  imageId?: string;
 
   /**
-   * プレイヤーHP
+   * 下書きの言語 (CompleteCardRequest.locale と同じ)
    *
-   * @generated from field: int32 hp = 4;
+   * @generated from field: string locale = 4;
    */
-  hp = 0;
+  locale = "";
 
   /**
-   * [0] is active, [1..] are bench
+   * 下書きの文体 (CompleteCardRequest.tone と同じ)
    *
-   * @generated from field: repeated ptera.v1.Card deck = 5;
+   * @generated from field: string tone = 5;
    */
-  deck: Card[] = [];
+  tone = "";
 
-  constructor(data?: PartialMessage<Player>) {
+  constructor(data?: PartialMessage<DetectMembersRequest>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "ptera.v1.Player";
+  static readonly typeName = "ptera.v1.DetectMembersRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "player_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 2, name: "circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 3, name: "circle_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 4, name: "hp", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
-    { no: 5, name: "deck", kind: "message", T: Card, repeated: true },
+    { no: 2, name: "image_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "image_id", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 4, name: "locale", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "tone", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Player {
-    return new Player().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DetectMembersRequest {
+    return new DetectMembersRequest().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Player {
-    return new Player().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DetectMembersRequest {
+    return new DetectMembersRequest().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Player {
-    return new Player().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DetectMembersRequest {
+    return new DetectMembersRequest().fromJsonString(jsonString, options);
   }
 
-  static equals(a: Player | PlainMessage<Player> | undefined, b: Player | PlainMessage<Player> | undefined): boolean {
-    return proto3.util.equals(Player, a, b);
+  static equals(a: DetectMembersRequest | PlainMessage<DetectMembersRequest> | undefined, b: DetectMembersRequest | PlainMessage<DetectMembersRequest> | undefined): boolean {
+    return proto3.util.equals(DetectMembersRequest, a, b);
   }
 }
 
 /**
- * @generated from message ptera.v1.StartBattleRequest
+ * BoundingBox は画像内の領域です (座標は画像の幅・高さに対する0.0〜1.0の割合)。
+ *
+ * @generated from message ptera.v1.BoundingBox
  */
-export class StartBattleRequest extends Message<StartBattleRequest> {
+export class BoundingBox extends Message<BoundingBox> {
   /**
-   * @generated from field: string my_circle_id = 1;
+   * @generated from field: float x_min = 1;
    */
-  myCircleId = "";
+  xMin = 0;
 
   /**
-   * @generated from field: string opponent_circle_id = 2;
+   * @generated from field: float y_min = 2;
    */
-  opponentCircleId = "";
+  yMin = 0;
 
-  constructor(data?: PartialMessage<StartBattleRequest>) {
+  /**
+   * @generated from field: float x_max = 3;
+   */
+  xMax = 0;
+
+  /**
+   * @generated from field: float y_max = 4;
+   */
+  yMax = 0;
+
+  constructor(data?: PartialMessage<BoundingBox>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "ptera.v1.StartBattleRequest";
+  static readonly typeName = "ptera.v1.BoundingBox";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "my_circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 2, name: "opponent_circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 1, name: "x_min", kind: "scalar", T: 2 /* ScalarType.FLOAT */ },
+    { no: 2, name: "y_min", kind: "scalar", T: 2 /* ScalarType.FLOAT */ },
+    { no: 3, name: "x_max", kind: "scalar", T: 2 /* ScalarType.FLOAT */ },
+    { no: 4, name: "y_max", kind: "scalar", T: 2 /* ScalarType.FLOAT */ },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): StartBattleRequest {
-    return new StartBattleRequest().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): BoundingBox {
+    return new BoundingBox().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): StartBattleRequest {
-    return new StartBattleRequest().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): BoundingBox {
+    return new BoundingBox().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): StartBattleRequest {
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): BoundingBox {
+    return new BoundingBox().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: BoundingBox | PlainMessage<BoundingBox> | undefined, b: BoundingBox | PlainMessage<BoundingBox> | undefined): boolean {
+    return proto3.util.equals(BoundingBox, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.MemberDraft
+ */
+export class MemberDraft extends Message<MemberDraft> {
+  /**
+   * 元画像で検出された顔の位置
+   *
+   * @generated from field: ptera.v1.BoundingBox box = 1;
+   */
+  box?: BoundingBox;
+
+  /**
+   * 切り抜いた画像のID (カード作成時の画像として使用可能)
+   *
+   * @generated from field: string image_id = 2;
+   */
+  imageId = "";
+
+  /**
+   * 切り抜いた画像のURL
+   *
+   * @generated from field: string image_url = 3;
+   */
+  imageUrl = "";
+
+  /**
+   * カードの下書き (失敗した場合は success=false)
+   *
+   * @generated from field: ptera.v1.CompleteCardResponse draft = 4;
+   */
+  draft?: CompleteCardResponse;
+
+  constructor(data?: PartialMessage<MemberDraft>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.MemberDraft";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "box", kind: "message", T: BoundingBox },
+    { no: 2, name: "image_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "image_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "draft", kind: "message", T: CompleteCardResponse },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): MemberDraft {
+    return new MemberDraft().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): MemberDraft {
+    return new MemberDraft().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): MemberDraft {
+    return new MemberDraft().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: MemberDraft | PlainMessage<MemberDraft> | undefined, b: MemberDraft | PlainMessage<MemberDraft> | undefined): boolean {
+    return proto3.util.equals(MemberDraft, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.DetectMembersResponse
+ */
+export class DetectMembersResponse extends Message<DetectMembersResponse> {
+  /**
+   * 左から順に並ぶ
+   *
+   * @generated from field: repeated ptera.v1.MemberDraft members = 1;
+   */
+  members: MemberDraft[] = [];
+
+  /**
+   * @generated from field: bool success = 2;
+   */
+  success = false;
+
+  /**
+   * @generated from field: optional string error_message = 3;
+   */
+  errorMessage?: string;
+
+  constructor(data?: PartialMessage<DetectMembersResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.DetectMembersResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "members", kind: "message", T: MemberDraft, repeated: true },
+    { no: 2, name: "success", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 3, name: "error_message", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DetectMembersResponse {
+    return new DetectMembersResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DetectMembersResponse {
+    return new DetectMembersResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DetectMembersResponse {
+    return new DetectMembersResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DetectMembersResponse | PlainMessage<DetectMembersResponse> | undefined, b: DetectMembersResponse | PlainMessage<DetectMembersResponse> | undefined): boolean {
+    return proto3.util.equals(DetectMembersResponse, a, b);
+  }
+}
+
+/**
+ * CardFields は編集中のカードのプロフィール項目です (未入力は空)。
+ *
+ * @generated from message ptera.v1.CardFields
+ */
+export class CardFields extends Message<CardFields> {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name = "";
+
+  /**
+   * @generated from field: string faculty = 2;
+   */
+  faculty = "";
+
+  /**
+   * @generated from field: string department = 3;
+   */
+  department = "";
+
+  /**
+   * @generated from field: int32 grade = 4;
+   */
+  grade = 0;
+
+  /**
+   * @generated from field: string position = 5;
+   */
+  position = "";
+
+  /**
+   * @generated from field: string hobby = 6;
+   */
+  hobby = "";
+
+  /**
+   * @generated from field: string description = 7;
+   */
+  description = "";
+
+  constructor(data?: PartialMessage<CardFields>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.CardFields";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "faculty", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "department", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "grade", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 5, name: "position", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "hobby", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CardFields {
+    return new CardFields().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CardFields {
+    return new CardFields().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CardFields {
+    return new CardFields().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CardFields | PlainMessage<CardFields> | undefined, b: CardFields | PlainMessage<CardFields> | undefined): boolean {
+    return proto3.util.equals(CardFields, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.RegenerateFieldRequest
+ */
+export class RegenerateFieldRequest extends Message<RegenerateFieldRequest> {
+  /**
+   * 現在の入力内容 (field_name 以外は文脈として固定される)
+   *
+   * @generated from field: ptera.v1.CardFields fields = 2;
+   */
+  fields?: CardFields;
+
+  /**
+   * "name", "faculty", "department", "position", "hobby", "description"
+   *
+   * @generated from field: string field_name = 3;
+   */
+  fieldName = "";
+
+  /**
+   * 文体 "funny", "formal", "neutral" (省略時は funny)
+   *
+   * @generated from field: string style = 4;
+   */
+  style = "";
+
+  /**
+   * 候補の数 1〜5 (省略時は3)
+   *
+   * @generated from field: int32 n = 5;
+   */
+  n = 0;
+
+  /**
+   * 候補の言語 "ja", "en" (省略時は ja)
+   *
+   * @generated from field: string locale = 6;
+   */
+  locale = "";
+
+  constructor(data?: PartialMessage<RegenerateFieldRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.RegenerateFieldRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 2, name: "fields", kind: "message", T: CardFields },
+    { no: 3, name: "field_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "style", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "n", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 6, name: "locale", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RegenerateFieldRequest {
+    return new RegenerateFieldRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RegenerateFieldRequest {
+    return new RegenerateFieldRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RegenerateFieldRequest {
+    return new RegenerateFieldRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RegenerateFieldRequest | PlainMessage<RegenerateFieldRequest> | undefined, b: RegenerateFieldRequest | PlainMessage<RegenerateFieldRequest> | undefined): boolean {
+    return proto3.util.equals(RegenerateFieldRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.RegenerateFieldResponse
+ */
+export class RegenerateFieldResponse extends Message<RegenerateFieldResponse> {
+  /**
+   * 候補 (現在の値とは異なる。不適切と判定された候補は除かれるため n より少ない場合がある)
+   *
+   * @generated from field: repeated string values = 1;
+   */
+  values: string[] = [];
+
+  /**
+   * @generated from field: bool success = 2;
+   */
+  success = false;
+
+  /**
+   * @generated from field: optional string error_message = 3;
+   */
+  errorMessage?: string;
+
+  /**
+   * 入力または全ての候補が不適切と判定された場合true
+   *
+   * @generated from field: bool flagged = 4;
+   */
+  flagged = false;
+
+  /**
+   * flagged の理由
+   *
+   * @generated from field: repeated ptera.v1.ModerationReason moderation_reasons = 5;
+   */
+  moderationReasons: ModerationReason[] = [];
+
+  /**
+   * 生成に使ったプロンプトのバージョン
+   *
+   * @generated from field: string prompt_version = 6;
+   */
+  promptVersion = "";
+
+  constructor(data?: PartialMessage<RegenerateFieldResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.RegenerateFieldResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "values", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 2, name: "success", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 3, name: "error_message", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 4, name: "flagged", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 5, name: "moderation_reasons", kind: "message", T: ModerationReason, repeated: true },
+    { no: 6, name: "prompt_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RegenerateFieldResponse {
+    return new RegenerateFieldResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RegenerateFieldResponse {
+    return new RegenerateFieldResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RegenerateFieldResponse {
+    return new RegenerateFieldResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RegenerateFieldResponse | PlainMessage<RegenerateFieldResponse> | undefined, b: RegenerateFieldResponse | PlainMessage<RegenerateFieldResponse> | undefined): boolean {
+    return proto3.util.equals(RegenerateFieldResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.CreateCardRequest
+ */
+export class CreateCardRequest extends Message<CreateCardRequest> {
+  /**
+   * 認証情報から取るべきだが、一旦IDを送る (creator_id として保存)
+   *
+   * @generated from field: string user_id = 1;
+   */
+  userId = "";
+
+  /**
+   * id, creator_id, circle_id, 及びバトルステータスはサーバー側で設定
+   *
+   * @generated from field: ptera.v1.Card card = 2;
+   */
+  card?: Card;
+
+  constructor(data?: PartialMessage<CreateCardRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.CreateCardRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "card", kind: "message", T: Card },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateCardRequest {
+    return new CreateCardRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateCardRequest {
+    return new CreateCardRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateCardRequest {
+    return new CreateCardRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateCardRequest | PlainMessage<CreateCardRequest> | undefined, b: CreateCardRequest | PlainMessage<CreateCardRequest> | undefined): boolean {
+    return proto3.util.equals(CreateCardRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.GetCardRequest
+ */
+export class GetCardRequest extends Message<GetCardRequest> {
+  /**
+   * @generated from field: string card_id = 1;
+   */
+  cardId = "";
+
+  constructor(data?: PartialMessage<GetCardRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.GetCardRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "card_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetCardRequest {
+    return new GetCardRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetCardRequest {
+    return new GetCardRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetCardRequest {
+    return new GetCardRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetCardRequest | PlainMessage<GetCardRequest> | undefined, b: GetCardRequest | PlainMessage<GetCardRequest> | undefined): boolean {
+    return proto3.util.equals(GetCardRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.UpdateCardRequest
+ */
+export class UpdateCardRequest extends Message<UpdateCardRequest> {
+  /**
+   * 認証情報から取るべきだが、一旦IDを送る
+   *
+   * @generated from field: string user_id = 1;
+   */
+  userId = "";
+
+  /**
+   * card.id のカードの編集可能な項目を置き換える
+   *
+   * @generated from field: ptera.v1.Card card = 2;
+   */
+  card?: Card;
+
+  constructor(data?: PartialMessage<UpdateCardRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.UpdateCardRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "card", kind: "message", T: Card },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UpdateCardRequest {
+    return new UpdateCardRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UpdateCardRequest {
+    return new UpdateCardRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UpdateCardRequest {
+    return new UpdateCardRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: UpdateCardRequest | PlainMessage<UpdateCardRequest> | undefined, b: UpdateCardRequest | PlainMessage<UpdateCardRequest> | undefined): boolean {
+    return proto3.util.equals(UpdateCardRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.DeleteCardRequest
+ */
+export class DeleteCardRequest extends Message<DeleteCardRequest> {
+  /**
+   * 認証情報から取るべきだが、一旦IDを送る
+   *
+   * @generated from field: string user_id = 1;
+   */
+  userId = "";
+
+  /**
+   * @generated from field: string card_id = 2;
+   */
+  cardId = "";
+
+  constructor(data?: PartialMessage<DeleteCardRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.DeleteCardRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "card_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteCardRequest {
+    return new DeleteCardRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteCardRequest {
+    return new DeleteCardRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteCardRequest {
+    return new DeleteCardRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DeleteCardRequest | PlainMessage<DeleteCardRequest> | undefined, b: DeleteCardRequest | PlainMessage<DeleteCardRequest> | undefined): boolean {
+    return proto3.util.equals(DeleteCardRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.DeleteCardResponse
+ */
+export class DeleteCardResponse extends Message<DeleteCardResponse> {
+  /**
+   * @generated from field: string card_id = 1;
+   */
+  cardId = "";
+
+  constructor(data?: PartialMessage<DeleteCardResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.DeleteCardResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "card_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteCardResponse {
+    return new DeleteCardResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteCardResponse {
+    return new DeleteCardResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteCardResponse {
+    return new DeleteCardResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DeleteCardResponse | PlainMessage<DeleteCardResponse> | undefined, b: DeleteCardResponse | PlainMessage<DeleteCardResponse> | undefined): boolean {
+    return proto3.util.equals(DeleteCardResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.CardFilter
+ */
+export class CardFilter extends Message<CardFilter> {
+  /**
+   * @generated from field: optional string circle_id = 1;
+   */
+  circleId?: string;
+
+  /**
+   * @generated from field: optional string creator_id = 2;
+   */
+  creatorId?: string;
+
+  /**
+   * 卒業済み(OB/OG)カードも含める
+   *
+   * @generated from field: bool include_graduated = 3;
+   */
+  includeGraduated = false;
+
+  constructor(data?: PartialMessage<CardFilter>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.CardFilter";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 2, name: "creator_id", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 3, name: "include_graduated", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CardFilter {
+    return new CardFilter().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CardFilter {
+    return new CardFilter().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CardFilter {
+    return new CardFilter().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CardFilter | PlainMessage<CardFilter> | undefined, b: CardFilter | PlainMessage<CardFilter> | undefined): boolean {
+    return proto3.util.equals(CardFilter, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.ListCardsRequest
+ */
+export class ListCardsRequest extends Message<ListCardsRequest> {
+  /**
+   * @generated from field: ptera.v1.CardFilter filter = 1;
+   */
+  filter?: CardFilter;
+
+  /**
+   * 省略時は20、最大100
+   *
+   * @generated from field: int32 page_size = 2;
+   */
+  pageSize = 0;
+
+  /**
+   * 前回のレスポンスの next_page_token
+   *
+   * @generated from field: string page_token = 3;
+   */
+  pageToken = "";
+
+  constructor(data?: PartialMessage<ListCardsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.ListCardsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "filter", kind: "message", T: CardFilter },
+    { no: 2, name: "page_size", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListCardsRequest {
+    return new ListCardsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListCardsRequest {
+    return new ListCardsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListCardsRequest {
+    return new ListCardsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListCardsRequest | PlainMessage<ListCardsRequest> | undefined, b: ListCardsRequest | PlainMessage<ListCardsRequest> | undefined): boolean {
+    return proto3.util.equals(ListCardsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.ListCardsResponse
+ */
+export class ListCardsResponse extends Message<ListCardsResponse> {
+  /**
+   * @generated from field: repeated ptera.v1.Card cards = 1;
+   */
+  cards: Card[] = [];
+
+  /**
+   * 空なら最後のページ
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken = "";
+
+  constructor(data?: PartialMessage<ListCardsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.ListCardsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "cards", kind: "message", T: Card, repeated: true },
+    { no: 2, name: "next_page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListCardsResponse {
+    return new ListCardsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListCardsResponse {
+    return new ListCardsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListCardsResponse {
+    return new ListCardsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListCardsResponse | PlainMessage<ListCardsResponse> | undefined, b: ListCardsResponse | PlainMessage<ListCardsResponse> | undefined): boolean {
+    return proto3.util.equals(ListCardsResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.SetFavoriteCardRequest
+ */
+export class SetFavoriteCardRequest extends Message<SetFavoriteCardRequest> {
+  /**
+   * 認証情報から取るべきだが、一旦IDを送る
+   *
+   * @generated from field: string user_id = 1;
+   */
+  userId = "";
+
+  /**
+   * 空文字で推しメンを解除
+   *
+   * @generated from field: string card_id = 2;
+   */
+  cardId = "";
+
+  constructor(data?: PartialMessage<SetFavoriteCardRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.SetFavoriteCardRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "card_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SetFavoriteCardRequest {
+    return new SetFavoriteCardRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SetFavoriteCardRequest {
+    return new SetFavoriteCardRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SetFavoriteCardRequest {
+    return new SetFavoriteCardRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SetFavoriteCardRequest | PlainMessage<SetFavoriteCardRequest> | undefined, b: SetFavoriteCardRequest | PlainMessage<SetFavoriteCardRequest> | undefined): boolean {
+    return proto3.util.equals(SetFavoriteCardRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.ListFavoriteCardsRequest
+ */
+export class ListFavoriteCardsRequest extends Message<ListFavoriteCardsRequest> {
+  /**
+   * @generated from field: string user_id = 1;
+   */
+  userId = "";
+
+  constructor(data?: PartialMessage<ListFavoriteCardsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.ListFavoriteCardsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListFavoriteCardsRequest {
+    return new ListFavoriteCardsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListFavoriteCardsRequest {
+    return new ListFavoriteCardsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListFavoriteCardsRequest {
+    return new ListFavoriteCardsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListFavoriteCardsRequest | PlainMessage<ListFavoriteCardsRequest> | undefined, b: ListFavoriteCardsRequest | PlainMessage<ListFavoriteCardsRequest> | undefined): boolean {
+    return proto3.util.equals(ListFavoriteCardsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.ListFavoriteCardsResponse
+ */
+export class ListFavoriteCardsResponse extends Message<ListFavoriteCardsResponse> {
+  /**
+   * @generated from field: repeated ptera.v1.Card cards = 1;
+   */
+  cards: Card[] = [];
+
+  constructor(data?: PartialMessage<ListFavoriteCardsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.ListFavoriteCardsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "cards", kind: "message", T: Card, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListFavoriteCardsResponse {
+    return new ListFavoriteCardsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListFavoriteCardsResponse {
+    return new ListFavoriteCardsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListFavoriteCardsResponse {
+    return new ListFavoriteCardsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListFavoriteCardsResponse | PlainMessage<ListFavoriteCardsResponse> | undefined, b: ListFavoriteCardsResponse | PlainMessage<ListFavoriteCardsResponse> | undefined): boolean {
+    return proto3.util.equals(ListFavoriteCardsResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.RenderCardRequest
+ */
+export class RenderCardRequest extends Message<RenderCardRequest> {
+  /**
+   * @generated from field: string card_id = 1;
+   */
+  cardId = "";
+
+  /**
+   * "classic", "dark", "sakura" (省略時は classic)
+   *
+   * @generated from field: string style = 2;
+   */
+  style = "";
+
+  constructor(data?: PartialMessage<RenderCardRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.RenderCardRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "card_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "style", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RenderCardRequest {
+    return new RenderCardRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RenderCardRequest {
+    return new RenderCardRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RenderCardRequest {
+    return new RenderCardRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RenderCardRequest | PlainMessage<RenderCardRequest> | undefined, b: RenderCardRequest | PlainMessage<RenderCardRequest> | undefined): boolean {
+    return proto3.util.equals(RenderCardRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.RenderCardResponse
+ */
+export class RenderCardResponse extends Message<RenderCardResponse> {
+  /**
+   * 600x840のPNG
+   *
+   * @generated from field: bytes png = 1;
+   */
+  png = new Uint8Array(0);
+
+  /**
+   * 描画したカードのバージョン (内容かスタイルが変わると変わる)
+   *
+   * @generated from field: string version = 2;
+   */
+  version = "";
+
+  /**
+   * 共有用のURL
+   *
+   * @generated from field: string image_url = 3;
+   */
+  imageUrl = "";
+
+  /**
+   * キャッシュされた画像を返した場合true
+   *
+   * @generated from field: bool cached = 4;
+   */
+  cached = false;
+
+  constructor(data?: PartialMessage<RenderCardResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.RenderCardResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "png", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+    { no: 2, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "image_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "cached", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RenderCardResponse {
+    return new RenderCardResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RenderCardResponse {
+    return new RenderCardResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RenderCardResponse {
+    return new RenderCardResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RenderCardResponse | PlainMessage<RenderCardResponse> | undefined, b: RenderCardResponse | PlainMessage<RenderCardResponse> | undefined): boolean {
+    return proto3.util.equals(RenderCardResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.IssueCardQRRequest
+ */
+export class IssueCardQRRequest extends Message<IssueCardQRRequest> {
+  /**
+   * @generated from field: string card_id = 1;
+   */
+  cardId = "";
+
+  /**
+   * 認証情報から取るべきだが、一旦IDを送る
+   *
+   * @generated from field: string user_id = 2;
+   */
+  userId = "";
+
+  constructor(data?: PartialMessage<IssueCardQRRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.IssueCardQRRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "card_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): IssueCardQRRequest {
+    return new IssueCardQRRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): IssueCardQRRequest {
+    return new IssueCardQRRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): IssueCardQRRequest {
+    return new IssueCardQRRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: IssueCardQRRequest | PlainMessage<IssueCardQRRequest> | undefined, b: IssueCardQRRequest | PlainMessage<IssueCardQRRequest> | undefined): boolean {
+    return proto3.util.equals(IssueCardQRRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.IssueCardQRResponse
+ */
+export class IssueCardQRResponse extends Message<IssueCardQRResponse> {
+  /**
+   * QRコードに埋め込まれたトークン
+   *
+   * @generated from field: string token = 1;
+   */
+  token = "";
+
+  /**
+   * トークンの有効期限
+   *
+   * @generated from field: google.protobuf.Timestamp expires_at = 2;
+   */
+  expiresAt?: Timestamp;
+
+  /**
+   * token のQRコード (PNG)
+   *
+   * @generated from field: bytes qr_png = 3;
+   */
+  qrPng = new Uint8Array(0);
+
+  constructor(data?: PartialMessage<IssueCardQRResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.IssueCardQRResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "expires_at", kind: "message", T: Timestamp },
+    { no: 3, name: "qr_png", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): IssueCardQRResponse {
+    return new IssueCardQRResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): IssueCardQRResponse {
+    return new IssueCardQRResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): IssueCardQRResponse {
+    return new IssueCardQRResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: IssueCardQRResponse | PlainMessage<IssueCardQRResponse> | undefined, b: IssueCardQRResponse | PlainMessage<IssueCardQRResponse> | undefined): boolean {
+    return proto3.util.equals(IssueCardQRResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.ResolveCardQRRequest
+ */
+export class ResolveCardQRRequest extends Message<ResolveCardQRRequest> {
+  /**
+   * QRコードから読み取ったトークン
+   *
+   * @generated from field: string token = 1;
+   */
+  token = "";
+
+  constructor(data?: PartialMessage<ResolveCardQRRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.ResolveCardQRRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ResolveCardQRRequest {
+    return new ResolveCardQRRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ResolveCardQRRequest {
+    return new ResolveCardQRRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ResolveCardQRRequest {
+    return new ResolveCardQRRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ResolveCardQRRequest | PlainMessage<ResolveCardQRRequest> | undefined, b: ResolveCardQRRequest | PlainMessage<ResolveCardQRRequest> | undefined): boolean {
+    return proto3.util.equals(ResolveCardQRRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.ImageUploadMetadata
+ */
+export class ImageUploadMetadata extends Message<ImageUploadMetadata> {
+  /**
+   * 認証情報から取るべきだが、一旦IDを送る
+   *
+   * @generated from field: string user_id = 1;
+   */
+  userId = "";
+
+  /**
+   * 画像全体のバイト数 (最大10MB)
+   *
+   * @generated from field: int64 size = 2;
+   */
+  size = protoInt64.zero;
+
+  /**
+   * 画像全体のSHA-256 (16進数)
+   *
+   * @generated from field: string sha256 = 3;
+   */
+  sha256 = "";
+
+  constructor(data?: PartialMessage<ImageUploadMetadata>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.ImageUploadMetadata";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "size", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "sha256", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ImageUploadMetadata {
+    return new ImageUploadMetadata().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ImageUploadMetadata {
+    return new ImageUploadMetadata().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ImageUploadMetadata {
+    return new ImageUploadMetadata().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ImageUploadMetadata | PlainMessage<ImageUploadMetadata> | undefined, b: ImageUploadMetadata | PlainMessage<ImageUploadMetadata> | undefined): boolean {
+    return proto3.util.equals(ImageUploadMetadata, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.UploadCardImageRequest
+ */
+export class UploadCardImageRequest extends Message<UploadCardImageRequest> {
+  /**
+   * @generated from oneof ptera.v1.UploadCardImageRequest.payload
+   */
+  payload: {
+    /**
+     * @generated from field: ptera.v1.ImageUploadMetadata metadata = 1;
+     */
+    value: ImageUploadMetadata;
+    case: "metadata";
+  } | {
+    /**
+     * 最大1MB
+     *
+     * @generated from field: bytes chunk = 2;
+     */
+    value: Uint8Array;
+    case: "chunk";
+  } | { case: undefined; value?: undefined } = { case: undefined };
+
+  constructor(data?: PartialMessage<UploadCardImageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.UploadCardImageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "metadata", kind: "message", T: ImageUploadMetadata, oneof: "payload" },
+    { no: 2, name: "chunk", kind: "scalar", T: 12 /* ScalarType.BYTES */, oneof: "payload" },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UploadCardImageRequest {
+    return new UploadCardImageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UploadCardImageRequest {
+    return new UploadCardImageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UploadCardImageRequest {
+    return new UploadCardImageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: UploadCardImageRequest | PlainMessage<UploadCardImageRequest> | undefined, b: UploadCardImageRequest | PlainMessage<UploadCardImageRequest> | undefined): boolean {
+    return proto3.util.equals(UploadCardImageRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.UploadCardImageResponse
+ */
+export class UploadCardImageResponse extends Message<UploadCardImageResponse> {
+  /**
+   * CompleteCard の image_id に指定できるID
+   *
+   * @generated from field: string image_id = 1;
+   */
+  imageId = "";
+
+  /**
+   * カードの image_url に指定できるURL
+   *
+   * @generated from field: string image_url = 2;
+   */
+  imageUrl = "";
+
+  /**
+   * @generated from field: string content_type = 3;
+   */
+  contentType = "";
+
+  /**
+   * @generated from field: int64 size = 4;
+   */
+  size = protoInt64.zero;
+
+  constructor(data?: PartialMessage<UploadCardImageResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.UploadCardImageResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "image_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "image_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "content_type", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "size", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UploadCardImageResponse {
+    return new UploadCardImageResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UploadCardImageResponse {
+    return new UploadCardImageResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UploadCardImageResponse {
+    return new UploadCardImageResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: UploadCardImageResponse | PlainMessage<UploadCardImageResponse> | undefined, b: UploadCardImageResponse | PlainMessage<UploadCardImageResponse> | undefined): boolean {
+    return proto3.util.equals(UploadCardImageResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.BattleState
+ */
+export class BattleState extends Message<BattleState> {
+  /**
+   * @generated from field: string battle_id = 1;
+   */
+  battleId = "";
+
+  /**
+   * リクエストしたプレイヤー（自分）
+   *
+   * @generated from field: ptera.v1.Player player_me = 2;
+   */
+  playerMe?: Player;
+
+  /**
+   * 対戦相手
+   *
+   * @generated from field: ptera.v1.Player player_opponent = 3;
+   */
+  playerOpponent?: Player;
+
+  /**
+   * @generated from field: int32 current_turn = 4;
+   */
+  currentTurn = 0;
+
+  /**
+   * ターンプレイヤーのID
+   *
+   * @generated from field: string current_player_id = 5;
+   */
+  currentPlayerId = "";
+
+  /**
+   * 勝者のID ("" なら対戦中)
+   *
+   * @generated from field: string winner_id = 6;
+   */
+  winnerId = "";
+
+  /**
+   * @generated from field: repeated string logs = 7;
+   */
+  logs: string[] = [];
+
+  /**
+   * 乱数のコミットメント情報
+   *
+   * @generated from field: ptera.v1.FairnessInfo fairness = 8;
+   */
+  fairness?: FairnessInfo;
+
+  /**
+   * 検証用のダメージ乱数の記録
+   *
+   * @generated from field: repeated ptera.v1.DamageRoll damage_rolls = 9;
+   */
+  damageRolls: DamageRoll[] = [];
+
+  /**
+   * 実況モード
+   *
+   * @generated from field: bool commentary_enabled = 10;
+   */
+  commentaryEnabled = false;
+
+  /**
+   * 実況 (logs と同じく新しい順)
+   *
+   * @generated from field: repeated ptera.v1.BattleCommentary commentary = 11;
+   */
+  commentary: BattleCommentary[] = [];
+
+  constructor(data?: PartialMessage<BattleState>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.BattleState";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "battle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "player_me", kind: "message", T: Player },
+    { no: 3, name: "player_opponent", kind: "message", T: Player },
+    { no: 4, name: "current_turn", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 5, name: "current_player_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "winner_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "logs", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 8, name: "fairness", kind: "message", T: FairnessInfo },
+    { no: 9, name: "damage_rolls", kind: "message", T: DamageRoll, repeated: true },
+    { no: 10, name: "commentary_enabled", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 11, name: "commentary", kind: "message", T: BattleCommentary, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): BattleState {
+    return new BattleState().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): BattleState {
+    return new BattleState().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): BattleState {
+    return new BattleState().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: BattleState | PlainMessage<BattleState> | undefined, b: BattleState | PlainMessage<BattleState> | undefined): boolean {
+    return proto3.util.equals(BattleState, a, b);
+  }
+}
+
+/**
+ * BattleCommentary は1ターン分の実況です。
+ * まずテンプレートの実況が保存され、AI実況が有効な場合は生成でき次第 text が置き換わります。
+ *
+ * @generated from message ptera.v1.BattleCommentary
+ */
+export class BattleCommentary extends Message<BattleCommentary> {
+  /**
+   * 実況の通し番号 (0 は試合開始)
+   *
+   * @generated from field: int32 index = 1;
+   */
+  index = 0;
+
+  /**
+   * @generated from field: string text = 2;
+   */
+  text = "";
+
+  /**
+   * text がAIで生成されたものかどうか
+   *
+   * @generated from field: bool ai_generated = 3;
+   */
+  aiGenerated = false;
+
+  constructor(data?: PartialMessage<BattleCommentary>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.BattleCommentary";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "index", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 2, name: "text", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "ai_generated", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): BattleCommentary {
+    return new BattleCommentary().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): BattleCommentary {
+    return new BattleCommentary().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): BattleCommentary {
+    return new BattleCommentary().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: BattleCommentary | PlainMessage<BattleCommentary> | undefined, b: BattleCommentary | PlainMessage<BattleCommentary> | undefined): boolean {
+    return proto3.util.equals(BattleCommentary, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.Player
+ */
+export class Player extends Message<Player> {
+  /**
+   * @generated from field: string player_id = 1;
+   */
+  playerId = "";
+
+  /**
+   * @generated from field: string circle_id = 2;
+   */
+  circleId = "";
+
+  /**
+   * @generated from field: string circle_name = 3;
+   */
+  circleName = "";
+
+  /**
+   * プレイヤーHP
+   *
+   * @generated from field: int32 hp = 4;
+   */
+  hp = 0;
+
+  /**
+   * [0] is active, [1..] are bench
+   *
+   * @generated from field: repeated ptera.v1.Card deck = 5;
+   */
+  deck: Card[] = [];
+
+  constructor(data?: PartialMessage<Player>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.Player";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "player_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "circle_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "hp", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 5, name: "deck", kind: "message", T: Card, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Player {
+    return new Player().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Player {
+    return new Player().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Player {
+    return new Player().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Player | PlainMessage<Player> | undefined, b: Player | PlainMessage<Player> | undefined): boolean {
+    return proto3.util.equals(Player, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.StartBattleRequest
+ */
+export class StartBattleRequest extends Message<StartBattleRequest> {
+  /**
+   * @generated from field: string my_circle_id = 1;
+   */
+  myCircleId = "";
+
+  /**
+   * @generated from field: string opponent_circle_id = 2;
+   */
+  opponentCircleId = "";
+
+  /**
+   * OB/OGカード(卒業済み)もデッキに含める特別モード
+   *
+   * @generated from field: bool include_graduated = 3;
+   */
+  includeGraduated = false;
+
+  /**
+   * 事前に CommitSeed で取得したコミットメントID (必須)
+   *
+   * @generated from field: optional string commitment_id = 4;
+   */
+  commitmentId?: string;
+
+  /**
+   * 乱数の導出に使うクライアントノンス (必須、コミットメント取得後にクライアントで生成)
+   *
+   * @generated from field: string client_nonce = 5;
+   */
+  clientNonce = "";
+
+  /**
+   * 実況モード (カード名・趣味・フレーバーを使った日本語の実況を追加)
+   *
+   * @generated from field: bool commentary = 6;
+   */
+  commentary = false;
+
+  constructor(data?: PartialMessage<StartBattleRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.StartBattleRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "my_circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "opponent_circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "include_graduated", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 4, name: "commitment_id", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 5, name: "client_nonce", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "commentary", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): StartBattleRequest {
+    return new StartBattleRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): StartBattleRequest {
+    return new StartBattleRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): StartBattleRequest {
     return new StartBattleRequest().fromJsonString(jsonString, options);
   }
 
-  static equals(a: StartBattleRequest | PlainMessage<StartBattleRequest> | undefined, b: StartBattleRequest | PlainMessage<StartBattleRequest> | undefined): boolean {
-    return proto3.util.equals(StartBattleRequest, a, b);
+  static equals(a: StartBattleRequest | PlainMessage<StartBattleRequest> | undefined, b: StartBattleRequest | PlainMessage<StartBattleRequest> | undefined): boolean {
+    return proto3.util.equals(StartBattleRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.StartBattleResponse
+ */
+export class StartBattleResponse extends Message<StartBattleResponse> {
+  /**
+   * @generated from field: ptera.v1.BattleState battle_state = 1;
+   */
+  battleState?: BattleState;
+
+  constructor(data?: PartialMessage<StartBattleResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.StartBattleResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "battle_state", kind: "message", T: BattleState },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): StartBattleResponse {
+    return new StartBattleResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): StartBattleResponse {
+    return new StartBattleResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): StartBattleResponse {
+    return new StartBattleResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: StartBattleResponse | PlainMessage<StartBattleResponse> | undefined, b: StartBattleResponse | PlainMessage<StartBattleResponse> | undefined): boolean {
+    return proto3.util.equals(StartBattleResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.AttackRequest
+ */
+export class AttackRequest extends Message<AttackRequest> {
+  /**
+   * @generated from field: string battle_id = 1;
+   */
+  battleId = "";
+
+  /**
+   * 認証情報から取るべきだが、一旦IDを送る
+   *
+   * @generated from field: string player_id = 2;
+   */
+  playerId = "";
+
+  constructor(data?: PartialMessage<AttackRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.AttackRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "battle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "player_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AttackRequest {
+    return new AttackRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AttackRequest {
+    return new AttackRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AttackRequest {
+    return new AttackRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: AttackRequest | PlainMessage<AttackRequest> | undefined, b: AttackRequest | PlainMessage<AttackRequest> | undefined): boolean {
+    return proto3.util.equals(AttackRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.AttackResponse
+ */
+export class AttackResponse extends Message<AttackResponse> {
+  /**
+   * @generated from field: ptera.v1.BattleState battle_state = 1;
+   */
+  battleState?: BattleState;
+
+  constructor(data?: PartialMessage<AttackResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.AttackResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "battle_state", kind: "message", T: BattleState },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AttackResponse {
+    return new AttackResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AttackResponse {
+    return new AttackResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AttackResponse {
+    return new AttackResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: AttackResponse | PlainMessage<AttackResponse> | undefined, b: AttackResponse | PlainMessage<AttackResponse> | undefined): boolean {
+    return proto3.util.equals(AttackResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.RetreatRequest
+ */
+export class RetreatRequest extends Message<RetreatRequest> {
+  /**
+   * @generated from field: string battle_id = 1;
+   */
+  battleId = "";
+
+  /**
+   * @generated from field: string player_id = 2;
+   */
+  playerId = "";
+
+  /**
+   * @generated from field: int32 bench_index = 3;
+   */
+  benchIndex = 0;
+
+  constructor(data?: PartialMessage<RetreatRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.RetreatRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "battle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "player_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "bench_index", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RetreatRequest {
+    return new RetreatRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RetreatRequest {
+    return new RetreatRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RetreatRequest {
+    return new RetreatRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RetreatRequest | PlainMessage<RetreatRequest> | undefined, b: RetreatRequest | PlainMessage<RetreatRequest> | undefined): boolean {
+    return proto3.util.equals(RetreatRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.RetreatResponse
+ */
+export class RetreatResponse extends Message<RetreatResponse> {
+  /**
+   * @generated from field: ptera.v1.BattleState battle_state = 1;
+   */
+  battleState?: BattleState;
+
+  constructor(data?: PartialMessage<RetreatResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.RetreatResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "battle_state", kind: "message", T: BattleState },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RetreatResponse {
+    return new RetreatResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RetreatResponse {
+    return new RetreatResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RetreatResponse {
+    return new RetreatResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RetreatResponse | PlainMessage<RetreatResponse> | undefined, b: RetreatResponse | PlainMessage<RetreatResponse> | undefined): boolean {
+    return proto3.util.equals(RetreatResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.BattleRequest
+ */
+export class BattleRequest extends Message<BattleRequest> {
+  /**
+   * @generated from field: string request_id = 1;
+   */
+  requestId = "";
+
+  /**
+   * @generated from field: string from_circle_id = 2;
+   */
+  fromCircleId = "";
+
+  /**
+   * @generated from field: string to_circle_id = 3;
+   */
+  toCircleId = "";
+
+  /**
+   * @generated from field: string from_circle_name = 4;
+   */
+  fromCircleName = "";
+
+  /**
+   * @generated from field: string to_circle_name = 5;
+   */
+  toCircleName = "";
+
+  /**
+   * "pending", "accepted", "rejected"
+   *
+   * @generated from field: string status = 6;
+   */
+  status = "";
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 7;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * Set after acceptance
+   *
+   * @generated from field: optional string battle_id = 8;
+   */
+  battleId?: string;
+
+  /**
+   * OB/OGカード(卒業済み)もデッキに含める特別モード
+   *
+   * @generated from field: bool include_graduated = 9;
+   */
+  includeGraduated = false;
+
+  /**
+   * 実況モード
+   *
+   * @generated from field: bool commentary = 10;
+   */
+  commentary = false;
+
+  constructor(data?: PartialMessage<BattleRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.BattleRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "request_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "from_circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "to_circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "from_circle_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "to_circle_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "status", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "created_at", kind: "message", T: Timestamp },
+    { no: 8, name: "battle_id", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 9, name: "include_graduated", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 10, name: "commentary", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): BattleRequest {
+    return new BattleRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): BattleRequest {
+    return new BattleRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): BattleRequest {
+    return new BattleRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: BattleRequest | PlainMessage<BattleRequest> | undefined, b: BattleRequest | PlainMessage<BattleRequest> | undefined): boolean {
+    return proto3.util.equals(BattleRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.SendBattleRequestRequest
+ */
+export class SendBattleRequestRequest extends Message<SendBattleRequestRequest> {
+  /**
+   * @generated from field: string from_circle_id = 1;
+   */
+  fromCircleId = "";
+
+  /**
+   * @generated from field: string to_circle_id = 2;
+   */
+  toCircleId = "";
+
+  /**
+   * OB/OGカード(卒業済み)もデッキに含める特別モード
+   *
+   * @generated from field: bool include_graduated = 3;
+   */
+  includeGraduated = false;
+
+  /**
+   * 実況モード
+   *
+   * @generated from field: bool commentary = 4;
+   */
+  commentary = false;
+
+  constructor(data?: PartialMessage<SendBattleRequestRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.SendBattleRequestRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "from_circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "to_circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "include_graduated", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 4, name: "commentary", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SendBattleRequestRequest {
+    return new SendBattleRequestRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SendBattleRequestRequest {
+    return new SendBattleRequestRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SendBattleRequestRequest {
+    return new SendBattleRequestRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SendBattleRequestRequest | PlainMessage<SendBattleRequestRequest> | undefined, b: SendBattleRequestRequest | PlainMessage<SendBattleRequestRequest> | undefined): boolean {
+    return proto3.util.equals(SendBattleRequestRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.AcceptBattleRequestRequest
+ */
+export class AcceptBattleRequestRequest extends Message<AcceptBattleRequestRequest> {
+  /**
+   * @generated from field: string request_id = 1;
+   */
+  requestId = "";
+
+  /**
+   * 承認するサークル (to_circle_id) が CommitSeed で取得したコミットメントID (必須)
+   *
+   * @generated from field: string commitment_id = 2;
+   */
+  commitmentId = "";
+
+  /**
+   * 乱数の導出に使うクライアントノンス (必須、コミットメント取得後にクライアントで生成)
+   *
+   * @generated from field: string client_nonce = 3;
+   */
+  clientNonce = "";
+
+  constructor(data?: PartialMessage<AcceptBattleRequestRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.AcceptBattleRequestRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "request_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "commitment_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "client_nonce", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AcceptBattleRequestRequest {
+    return new AcceptBattleRequestRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AcceptBattleRequestRequest {
+    return new AcceptBattleRequestRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AcceptBattleRequestRequest {
+    return new AcceptBattleRequestRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: AcceptBattleRequestRequest | PlainMessage<AcceptBattleRequestRequest> | undefined, b: AcceptBattleRequestRequest | PlainMessage<AcceptBattleRequestRequest> | undefined): boolean {
+    return proto3.util.equals(AcceptBattleRequestRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.RejectBattleRequestRequest
+ */
+export class RejectBattleRequestRequest extends Message<RejectBattleRequestRequest> {
+  /**
+   * @generated from field: string request_id = 1;
+   */
+  requestId = "";
+
+  constructor(data?: PartialMessage<RejectBattleRequestRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.RejectBattleRequestRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "request_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RejectBattleRequestRequest {
+    return new RejectBattleRequestRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RejectBattleRequestRequest {
+    return new RejectBattleRequestRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RejectBattleRequestRequest {
+    return new RejectBattleRequestRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RejectBattleRequestRequest | PlainMessage<RejectBattleRequestRequest> | undefined, b: RejectBattleRequestRequest | PlainMessage<RejectBattleRequestRequest> | undefined): boolean {
+    return proto3.util.equals(RejectBattleRequestRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.PullRequest
+ */
+export class PullRequest extends Message<PullRequest> {
+  /**
+   * 認証情報から取るべきだが、一旦IDを送る
+   *
+   * @generated from field: string user_id = 1;
+   */
+  userId = "";
+
+  /**
+   * 引く回数 (1〜10)
+   *
+   * @generated from field: int32 count = 2;
+   */
+  count = 0;
+
+  /**
+   * "circle" (自サークルのカード) or "public" (全公開カード)
+   *
+   * @generated from field: string pool = 3;
+   */
+  pool = "";
+
+  /**
+   * 事前に CommitSeed で取得したコミットメントID (必須)
+   *
+   * @generated from field: optional string commitment_id = 4;
+   */
+  commitmentId?: string;
+
+  /**
+   * 乱数の導出に使うクライアントノンス (必須、コミットメント取得後にクライアントで生成)
+   *
+   * @generated from field: string client_nonce = 5;
+   */
+  clientNonce = "";
+
+  constructor(data?: PartialMessage<PullRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.PullRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "count", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "pool", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "commitment_id", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 5, name: "client_nonce", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PullRequest {
+    return new PullRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PullRequest {
+    return new PullRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PullRequest {
+    return new PullRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: PullRequest | PlainMessage<PullRequest> | undefined, b: PullRequest | PlainMessage<PullRequest> | undefined): boolean {
+    return proto3.util.equals(PullRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.PullResult
+ */
+export class PullResult extends Message<PullResult> {
+  /**
+   * @generated from field: ptera.v1.Card card = 1;
+   */
+  card?: Card;
+
+  /**
+   * "N", "R", "SR", "SSR"
+   *
+   * @generated from field: string rarity = 2;
+   */
+  rarity = "";
+
+  /**
+   * 天井で確定した排出かどうか
+   *
+   * @generated from field: bool pity = 3;
+   */
+  pity = false;
+
+  /**
+   * コレクションに追加された所持カードのID
+   *
+   * @generated from field: string user_card_id = 4;
+   */
+  userCardId = "";
+
+  constructor(data?: PartialMessage<PullResult>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.PullResult";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "card", kind: "message", T: Card },
+    { no: 2, name: "rarity", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "pity", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 4, name: "user_card_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PullResult {
+    return new PullResult().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PullResult {
+    return new PullResult().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PullResult {
+    return new PullResult().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: PullResult | PlainMessage<PullResult> | undefined, b: PullResult | PlainMessage<PullResult> | undefined): boolean {
+    return proto3.util.equals(PullResult, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.PullResponse
+ */
+export class PullResponse extends Message<PullResponse> {
+  /**
+   * 監査用の抽選記録ID
+   *
+   * @generated from field: string pull_id = 1;
+   */
+  pullId = "";
+
+  /**
+   * @generated from field: repeated ptera.v1.PullResult results = 2;
+   */
+  results: PullResult[] = [];
+
+  /**
+   * 最高レアが出ていない連続回数
+   *
+   * @generated from field: int32 pity_count = 3;
+   */
+  pityCount = 0;
+
+  /**
+   * 天井の回数
+   *
+   * @generated from field: int32 pity_threshold = 4;
+   */
+  pityThreshold = 0;
+
+  /**
+   * 抽選に使用したコミットメント (シード公開済み)
+   *
+   * @generated from field: ptera.v1.SeedCommitment commitment = 5;
+   */
+  commitment?: SeedCommitment;
+
+  constructor(data?: PartialMessage<PullResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.PullResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "pull_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "results", kind: "message", T: PullResult, repeated: true },
+    { no: 3, name: "pity_count", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 4, name: "pity_threshold", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 5, name: "commitment", kind: "message", T: SeedCommitment },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PullResponse {
+    return new PullResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PullResponse {
+    return new PullResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PullResponse {
+    return new PullResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: PullResponse | PlainMessage<PullResponse> | undefined, b: PullResponse | PlainMessage<PullResponse> | undefined): boolean {
+    return proto3.util.equals(PullResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.FairnessInfo
+ */
+export class FairnessInfo extends Message<FairnessInfo> {
+  /**
+   * @generated from field: string commitment_id = 1;
+   */
+  commitmentId = "";
+
+  /**
+   * SHA-256(server_seed) の16進文字列
+   *
+   * @generated from field: string server_seed_hash = 2;
+   */
+  serverSeedHash = "";
+
+  /**
+   * @generated from field: string client_nonce = 3;
+   */
+  clientNonce = "";
+
+  constructor(data?: PartialMessage<FairnessInfo>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.FairnessInfo";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "commitment_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "server_seed_hash", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "client_nonce", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): FairnessInfo {
+    return new FairnessInfo().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): FairnessInfo {
+    return new FairnessInfo().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): FairnessInfo {
+    return new FairnessInfo().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: FairnessInfo | PlainMessage<FairnessInfo> | undefined, b: FairnessInfo | PlainMessage<FairnessInfo> | undefined): boolean {
+    return proto3.util.equals(FairnessInfo, a, b);
+  }
+}
+
+/**
+ * ダメージ1回分の乱数。roll_index から順に通し番号を使い (棄却サンプリングで複数使うことがあります)、
+ * 次のロールは next_roll_index から始まります
+ *
+ * @generated from message ptera.v1.DamageRoll
+ */
+export class DamageRoll extends Message<DamageRoll> {
+  /**
+   * このロールで最初に使った乱数の通し番号
+   *
+   * @generated from field: int64 roll_index = 1;
+   */
+  rollIndex = protoInt64.zero;
+
+  /**
+   * @generated from field: string attacker_card_id = 2;
+   */
+  attackerCardId = "";
+
+  /**
+   * 攻撃時の攻撃力
+   *
+   * @generated from field: int32 attack = 3;
+   */
+  attack = 0;
+
+  /**
+   * 算出されたダメージ
+   *
+   * @generated from field: int32 damage = 4;
+   */
+  damage = 0;
+
+  /**
+   * 次のロールの最初の通し番号 (0 なら roll_index + 1)
+   *
+   * @generated from field: int64 next_roll_index = 5;
+   */
+  nextRollIndex = protoInt64.zero;
+
+  constructor(data?: PartialMessage<DamageRoll>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.DamageRoll";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "roll_index", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "attacker_card_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "attack", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 4, name: "damage", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 5, name: "next_roll_index", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DamageRoll {
+    return new DamageRoll().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DamageRoll {
+    return new DamageRoll().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DamageRoll {
+    return new DamageRoll().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DamageRoll | PlainMessage<DamageRoll> | undefined, b: DamageRoll | PlainMessage<DamageRoll> | undefined): boolean {
+    return proto3.util.equals(DamageRoll, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.SeedCommitment
+ */
+export class SeedCommitment extends Message<SeedCommitment> {
+  /**
+   * @generated from field: string commitment_id = 1;
+   */
+  commitmentId = "";
+
+  /**
+   * "battle" or "gacha"
+   *
+   * @generated from field: string purpose = 2;
+   */
+  purpose = "";
+
+  /**
+   * コミットメントを要求したユーザー/サークルのID
+   *
+   * @generated from field: string owner_id = 3;
+   */
+  ownerId = "";
+
+  /**
+   * SHA-256(server_seed) の16進文字列
+   *
+   * @generated from field: string server_seed_hash = 4;
+   */
+  serverSeedHash = "";
+
+  /**
+   * 公開後のみ設定される16進文字列
+   *
+   * @generated from field: optional string server_seed = 5;
+   */
+  serverSeed?: string;
+
+  /**
+   * @generated from field: string client_nonce = 6;
+   */
+  clientNonce = "";
+
+  /**
+   * 紐付いたバトルID/抽選ID
+   *
+   * @generated from field: string subject_id = 7;
+   */
+  subjectId = "";
+
+  /**
+   * "committed", "bound", "revealed"
+   *
+   * @generated from field: string status = 8;
+   */
+  status = "";
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 9;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp revealed_at = 10;
+   */
+  revealedAt?: Timestamp;
+
+  constructor(data?: PartialMessage<SeedCommitment>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.SeedCommitment";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "commitment_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "purpose", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "owner_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "server_seed_hash", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "server_seed", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 6, name: "client_nonce", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "subject_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "status", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 9, name: "created_at", kind: "message", T: Timestamp },
+    { no: 10, name: "revealed_at", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SeedCommitment {
+    return new SeedCommitment().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SeedCommitment {
+    return new SeedCommitment().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SeedCommitment {
+    return new SeedCommitment().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SeedCommitment | PlainMessage<SeedCommitment> | undefined, b: SeedCommitment | PlainMessage<SeedCommitment> | undefined): boolean {
+    return proto3.util.equals(SeedCommitment, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.CommitSeedRequest
+ */
+export class CommitSeedRequest extends Message<CommitSeedRequest> {
+  /**
+   * "battle" or "gacha"
+   *
+   * @generated from field: string purpose = 1;
+   */
+  purpose = "";
+
+  /**
+   * バトルならサークルID、ガチャならユーザーID
+   *
+   * @generated from field: string owner_id = 2;
+   */
+  ownerId = "";
+
+  constructor(data?: PartialMessage<CommitSeedRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.CommitSeedRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "purpose", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "owner_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CommitSeedRequest {
+    return new CommitSeedRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CommitSeedRequest {
+    return new CommitSeedRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CommitSeedRequest {
+    return new CommitSeedRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CommitSeedRequest | PlainMessage<CommitSeedRequest> | undefined, b: CommitSeedRequest | PlainMessage<CommitSeedRequest> | undefined): boolean {
+    return proto3.util.equals(CommitSeedRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.GetCommitmentRequest
+ */
+export class GetCommitmentRequest extends Message<GetCommitmentRequest> {
+  /**
+   * @generated from field: string commitment_id = 1;
+   */
+  commitmentId = "";
+
+  constructor(data?: PartialMessage<GetCommitmentRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.GetCommitmentRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "commitment_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetCommitmentRequest {
+    return new GetCommitmentRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetCommitmentRequest {
+    return new GetCommitmentRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetCommitmentRequest {
+    return new GetCommitmentRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetCommitmentRequest | PlainMessage<GetCommitmentRequest> | undefined, b: GetCommitmentRequest | PlainMessage<GetCommitmentRequest> | undefined): boolean {
+    return proto3.util.equals(GetCommitmentRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.VerifyRollsRequest
+ */
+export class VerifyRollsRequest extends Message<VerifyRollsRequest> {
+  /**
+   * @generated from field: string commitment_id = 1;
+   */
+  commitmentId = "";
+
+  constructor(data?: PartialMessage<VerifyRollsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.VerifyRollsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "commitment_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): VerifyRollsRequest {
+    return new VerifyRollsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): VerifyRollsRequest {
+    return new VerifyRollsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): VerifyRollsRequest {
+    return new VerifyRollsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: VerifyRollsRequest | PlainMessage<VerifyRollsRequest> | undefined, b: VerifyRollsRequest | PlainMessage<VerifyRollsRequest> | undefined): boolean {
+    return proto3.util.equals(VerifyRollsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.VerifiedRoll
+ */
+export class VerifiedRoll extends Message<VerifiedRoll> {
+  /**
+   * 結果の通し番号
+   *
+   * @generated from field: int64 index = 1;
+   */
+  index = protoInt64.zero;
+
+  /**
+   * "damage" or "pull"
+   *
+   * @generated from field: string kind = 2;
+   */
+  kind = "";
+
+  /**
+   * 記録されている結果
+   *
+   * @generated from field: string recorded = 3;
+   */
+  recorded = "";
+
+  /**
+   * シードから再計算した結果
+   *
+   * @generated from field: string recomputed = 4;
+   */
+  recomputed = "";
+
+  /**
+   * @generated from field: bool match = 5;
+   */
+  match = false;
+
+  constructor(data?: PartialMessage<VerifiedRoll>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.VerifiedRoll";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "index", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "kind", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "recorded", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "recomputed", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "match", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): VerifiedRoll {
+    return new VerifiedRoll().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): VerifiedRoll {
+    return new VerifiedRoll().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): VerifiedRoll {
+    return new VerifiedRoll().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: VerifiedRoll | PlainMessage<VerifiedRoll> | undefined, b: VerifiedRoll | PlainMessage<VerifiedRoll> | undefined): boolean {
+    return proto3.util.equals(VerifiedRoll, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.VerifyRollsResponse
+ */
+export class VerifyRollsResponse extends Message<VerifyRollsResponse> {
+  /**
+   * @generated from field: ptera.v1.SeedCommitment commitment = 1;
+   */
+  commitment?: SeedCommitment;
+
+  /**
+   * 全ての結果が一致したかどうか
+   *
+   * @generated from field: bool valid = 2;
+   */
+  valid = false;
+
+  /**
+   * @generated from field: repeated ptera.v1.VerifiedRoll rolls = 3;
+   */
+  rolls: VerifiedRoll[] = [];
+
+  /**
+   * @generated from field: optional string error_message = 4;
+   */
+  errorMessage?: string;
+
+  constructor(data?: PartialMessage<VerifyRollsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.VerifyRollsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "commitment", kind: "message", T: SeedCommitment },
+    { no: 2, name: "valid", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 3, name: "rolls", kind: "message", T: VerifiedRoll, repeated: true },
+    { no: 4, name: "error_message", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): VerifyRollsResponse {
+    return new VerifyRollsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): VerifyRollsResponse {
+    return new VerifyRollsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): VerifyRollsResponse {
+    return new VerifyRollsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: VerifyRollsResponse | PlainMessage<VerifyRollsResponse> | undefined, b: VerifyRollsResponse | PlainMessage<VerifyRollsResponse> | undefined): boolean {
+    return proto3.util.equals(VerifyRollsResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.Trade
+ */
+export class Trade extends Message<Trade> {
+  /**
+   * @generated from field: string trade_id = 1;
+   */
+  tradeId = "";
+
+  /**
+   * 提案したユーザーのID
+   *
+   * @generated from field: string proposer_id = 2;
+   */
+  proposerId = "";
+
+  /**
+   * 提案されたユーザーのID
+   *
+   * @generated from field: string target_id = 3;
+   */
+  targetId = "";
+
+  /**
+   * 提案者が差し出す所持カード
+   *
+   * @generated from field: repeated string offered_user_card_ids = 4;
+   */
+  offeredUserCardIds: string[] = [];
+
+  /**
+   * 提案者が求める相手の所持カード
+   *
+   * @generated from field: repeated string requested_user_card_ids = 5;
+   */
+  requestedUserCardIds: string[] = [];
+
+  /**
+   * "pending", "accepted", "rejected", "cancelled", "expired"
+   *
+   * @generated from field: string status = 6;
+   */
+  status = "";
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 7;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp expires_at = 8;
+   */
+  expiresAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp updated_at = 9;
+   */
+  updatedAt?: Timestamp;
+
+  constructor(data?: PartialMessage<Trade>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.Trade";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "trade_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "proposer_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "target_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "offered_user_card_ids", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 5, name: "requested_user_card_ids", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 6, name: "status", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "created_at", kind: "message", T: Timestamp },
+    { no: 8, name: "expires_at", kind: "message", T: Timestamp },
+    { no: 9, name: "updated_at", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Trade {
+    return new Trade().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Trade {
+    return new Trade().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Trade {
+    return new Trade().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Trade | PlainMessage<Trade> | undefined, b: Trade | PlainMessage<Trade> | undefined): boolean {
+    return proto3.util.equals(Trade, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.ProposeTradeRequest
+ */
+export class ProposeTradeRequest extends Message<ProposeTradeRequest> {
+  /**
+   * 認証情報から取るべきだが、一旦IDを送る
+   *
+   * @generated from field: string user_id = 1;
+   */
+  userId = "";
+
+  /**
+   * @generated from field: string target_user_id = 2;
+   */
+  targetUserId = "";
+
+  /**
+   * @generated from field: repeated string offered_user_card_ids = 3;
+   */
+  offeredUserCardIds: string[] = [];
+
+  /**
+   * @generated from field: repeated string requested_user_card_ids = 4;
+   */
+  requestedUserCardIds: string[] = [];
+
+  constructor(data?: PartialMessage<ProposeTradeRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.ProposeTradeRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "target_user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "offered_user_card_ids", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 4, name: "requested_user_card_ids", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ProposeTradeRequest {
+    return new ProposeTradeRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ProposeTradeRequest {
+    return new ProposeTradeRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ProposeTradeRequest {
+    return new ProposeTradeRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ProposeTradeRequest | PlainMessage<ProposeTradeRequest> | undefined, b: ProposeTradeRequest | PlainMessage<ProposeTradeRequest> | undefined): boolean {
+    return proto3.util.equals(ProposeTradeRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.AcceptTradeRequest
+ */
+export class AcceptTradeRequest extends Message<AcceptTradeRequest> {
+  /**
+   * @generated from field: string trade_id = 1;
+   */
+  tradeId = "";
+
+  /**
+   * 提案された側のユーザーID
+   *
+   * @generated from field: string user_id = 2;
+   */
+  userId = "";
+
+  constructor(data?: PartialMessage<AcceptTradeRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.AcceptTradeRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "trade_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AcceptTradeRequest {
+    return new AcceptTradeRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AcceptTradeRequest {
+    return new AcceptTradeRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AcceptTradeRequest {
+    return new AcceptTradeRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: AcceptTradeRequest | PlainMessage<AcceptTradeRequest> | undefined, b: AcceptTradeRequest | PlainMessage<AcceptTradeRequest> | undefined): boolean {
+    return proto3.util.equals(AcceptTradeRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.RejectTradeRequest
+ */
+export class RejectTradeRequest extends Message<RejectTradeRequest> {
+  /**
+   * @generated from field: string trade_id = 1;
+   */
+  tradeId = "";
+
+  /**
+   * 提案された側のユーザーID
+   *
+   * @generated from field: string user_id = 2;
+   */
+  userId = "";
+
+  constructor(data?: PartialMessage<RejectTradeRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.RejectTradeRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "trade_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RejectTradeRequest {
+    return new RejectTradeRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RejectTradeRequest {
+    return new RejectTradeRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RejectTradeRequest {
+    return new RejectTradeRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RejectTradeRequest | PlainMessage<RejectTradeRequest> | undefined, b: RejectTradeRequest | PlainMessage<RejectTradeRequest> | undefined): boolean {
+    return proto3.util.equals(RejectTradeRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.CancelTradeRequest
+ */
+export class CancelTradeRequest extends Message<CancelTradeRequest> {
+  /**
+   * @generated from field: string trade_id = 1;
+   */
+  tradeId = "";
+
+  /**
+   * 提案した側のユーザーID
+   *
+   * @generated from field: string user_id = 2;
+   */
+  userId = "";
+
+  constructor(data?: PartialMessage<CancelTradeRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.CancelTradeRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "trade_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CancelTradeRequest {
+    return new CancelTradeRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CancelTradeRequest {
+    return new CancelTradeRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CancelTradeRequest {
+    return new CancelTradeRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CancelTradeRequest | PlainMessage<CancelTradeRequest> | undefined, b: CancelTradeRequest | PlainMessage<CancelTradeRequest> | undefined): boolean {
+    return proto3.util.equals(CancelTradeRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.InviteCode
+ */
+export class InviteCode extends Message<InviteCode> {
+  /**
+   * @generated from field: string code = 1;
+   */
+  code = "";
+
+  /**
+   * @generated from field: string circle_id = 2;
+   */
+  circleId = "";
+
+  /**
+   * @generated from field: string created_by = 3;
+   */
+  createdBy = "";
+
+  /**
+   * @generated from field: google.protobuf.Timestamp expires_at = 4;
+   */
+  expiresAt?: Timestamp;
+
+  /**
+   * 0 なら無制限
+   *
+   * @generated from field: int32 max_uses = 5;
+   */
+  maxUses = 0;
+
+  /**
+   * @generated from field: int32 uses = 6;
+   */
+  uses = 0;
+
+  constructor(data?: PartialMessage<InviteCode>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.InviteCode";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "code", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "created_by", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "expires_at", kind: "message", T: Timestamp },
+    { no: 5, name: "max_uses", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 6, name: "uses", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): InviteCode {
+    return new InviteCode().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): InviteCode {
+    return new InviteCode().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): InviteCode {
+    return new InviteCode().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: InviteCode | PlainMessage<InviteCode> | undefined, b: InviteCode | PlainMessage<InviteCode> | undefined): boolean {
+    return proto3.util.equals(InviteCode, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.CreateCircleRequest
+ */
+export class CreateCircleRequest extends Message<CreateCircleRequest> {
+  /**
+   * 認証情報から取るべきだが、一旦IDを送る
+   *
+   * @generated from field: string user_id = 1;
+   */
+  userId = "";
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name = "";
+
+  /**
+   * @generated from field: string description = 3;
+   */
+  description = "";
+
+  /**
+   * @generated from field: string image_url = 4;
+   */
+  imageUrl = "";
+
+  constructor(data?: PartialMessage<CreateCircleRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.CreateCircleRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "image_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateCircleRequest {
+    return new CreateCircleRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateCircleRequest {
+    return new CreateCircleRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateCircleRequest {
+    return new CreateCircleRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateCircleRequest | PlainMessage<CreateCircleRequest> | undefined, b: CreateCircleRequest | PlainMessage<CreateCircleRequest> | undefined): boolean {
+    return proto3.util.equals(CreateCircleRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.GetCircleRequest
+ */
+export class GetCircleRequest extends Message<GetCircleRequest> {
+  /**
+   * @generated from field: string circle_id = 1;
+   */
+  circleId = "";
+
+  constructor(data?: PartialMessage<GetCircleRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.GetCircleRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetCircleRequest {
+    return new GetCircleRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetCircleRequest {
+    return new GetCircleRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetCircleRequest {
+    return new GetCircleRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetCircleRequest | PlainMessage<GetCircleRequest> | undefined, b: GetCircleRequest | PlainMessage<GetCircleRequest> | undefined): boolean {
+    return proto3.util.equals(GetCircleRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.GenerateInviteCodeRequest
+ */
+export class GenerateInviteCodeRequest extends Message<GenerateInviteCodeRequest> {
+  /**
+   * 認証情報から取るべきだが、一旦IDを送る
+   *
+   * @generated from field: string user_id = 1;
+   */
+  userId = "";
+
+  /**
+   * @generated from field: string circle_id = 2;
+   */
+  circleId = "";
+
+  /**
+   * 0 なら無制限
+   *
+   * @generated from field: int32 max_uses = 3;
+   */
+  maxUses = 0;
+
+  constructor(data?: PartialMessage<GenerateInviteCodeRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.GenerateInviteCodeRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "max_uses", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GenerateInviteCodeRequest {
+    return new GenerateInviteCodeRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GenerateInviteCodeRequest {
+    return new GenerateInviteCodeRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GenerateInviteCodeRequest {
+    return new GenerateInviteCodeRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GenerateInviteCodeRequest | PlainMessage<GenerateInviteCodeRequest> | undefined, b: GenerateInviteCodeRequest | PlainMessage<GenerateInviteCodeRequest> | undefined): boolean {
+    return proto3.util.equals(GenerateInviteCodeRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.JoinCircleRequest
+ */
+export class JoinCircleRequest extends Message<JoinCircleRequest> {
+  /**
+   * 認証情報から取るべきだが、一旦IDを送る
+   *
+   * @generated from field: string user_id = 1;
+   */
+  userId = "";
+
+  /**
+   * 招待コード
+   *
+   * @generated from field: string code = 2;
+   */
+  code = "";
+
+  constructor(data?: PartialMessage<JoinCircleRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.JoinCircleRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "code", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): JoinCircleRequest {
+    return new JoinCircleRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): JoinCircleRequest {
+    return new JoinCircleRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): JoinCircleRequest {
+    return new JoinCircleRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: JoinCircleRequest | PlainMessage<JoinCircleRequest> | undefined, b: JoinCircleRequest | PlainMessage<JoinCircleRequest> | undefined): boolean {
+    return proto3.util.equals(JoinCircleRequest, a, b);
   }
 }
 
 /**
- * @generated from message ptera.v1.StartBattleResponse
+ * @generated from message ptera.v1.LeaveCircleRequest
  */
-export class StartBattleResponse extends Message<StartBattleResponse> {
+export class LeaveCircleRequest extends Message<LeaveCircleRequest> {
   /**
-   * @generated from field: ptera.v1.BattleState battle_state = 1;
+   * 認証情報から取るべきだが、一旦IDを送る
+   *
+   * @generated from field: string user_id = 1;
    */
-  battleState?: BattleState;
+  userId = "";
 
-  constructor(data?: PartialMessage<StartBattleResponse>) {
+  constructor(data?: PartialMessage<LeaveCircleRequest>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "ptera.v1.StartBattleResponse";
+  static readonly typeName = "ptera.v1.LeaveCircleRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "battle_state", kind: "message", T: BattleState },
+    { no: 1, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): StartBattleResponse {
-    return new StartBattleResponse().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): LeaveCircleRequest {
+    return new LeaveCircleRequest().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): StartBattleResponse {
-    return new StartBattleResponse().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): LeaveCircleRequest {
+    return new LeaveCircleRequest().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): StartBattleResponse {
-    return new StartBattleResponse().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): LeaveCircleRequest {
+    return new LeaveCircleRequest().fromJsonString(jsonString, options);
   }
 
-  static equals(a: StartBattleResponse | PlainMessage<StartBattleResponse> | undefined, b: StartBattleResponse | PlainMessage<StartBattleResponse> | undefined): boolean {
-    return proto3.util.equals(StartBattleResponse, a, b);
+  static equals(a: LeaveCircleRequest | PlainMessage<LeaveCircleRequest> | undefined, b: LeaveCircleRequest | PlainMessage<LeaveCircleRequest> | undefined): boolean {
+    return proto3.util.equals(LeaveCircleRequest, a, b);
   }
 }
 
 /**
- * @generated from message ptera.v1.AttackRequest
+ * @generated from message ptera.v1.LeaveCircleResponse
  */
-export class AttackRequest extends Message<AttackRequest> {
-  /**
-   * @generated from field: string battle_id = 1;
-   */
-  battleId = "";
-
+export class LeaveCircleResponse extends Message<LeaveCircleResponse> {
   /**
-   * 認証情報から取るべきだが、一旦IDを送る
+   * 抜けたサークルのID
    *
-   * @generated from field: string player_id = 2;
+   * @generated from field: string circle_id = 1;
    */
-  playerId = "";
+  circleId = "";
 
-  constructor(data?: PartialMessage<AttackRequest>) {
+  constructor(data?: PartialMessage<LeaveCircleResponse>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "ptera.v1.AttackRequest";
+  static readonly typeName = "ptera.v1.LeaveCircleResponse";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "battle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 2, name: "player_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 1, name: "circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AttackRequest {
-    return new AttackRequest().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): LeaveCircleResponse {
+    return new LeaveCircleResponse().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AttackRequest {
-    return new AttackRequest().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): LeaveCircleResponse {
+    return new LeaveCircleResponse().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AttackRequest {
-    return new AttackRequest().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): LeaveCircleResponse {
+    return new LeaveCircleResponse().fromJsonString(jsonString, options);
   }
 
-  static equals(a: AttackRequest | PlainMessage<AttackRequest> | undefined, b: AttackRequest | PlainMessage<AttackRequest> | undefined): boolean {
-    return proto3.util.equals(AttackRequest, a, b);
+  static equals(a: LeaveCircleResponse | PlainMessage<LeaveCircleResponse> | undefined, b: LeaveCircleResponse | PlainMessage<LeaveCircleResponse> | undefined): boolean {
+    return proto3.util.equals(LeaveCircleResponse, a, b);
   }
 }
 
 /**
- * @generated from message ptera.v1.AttackResponse
+ * @generated from message ptera.v1.ListMembersRequest
  */
-export class AttackResponse extends Message<AttackResponse> {
+export class ListMembersRequest extends Message<ListMembersRequest> {
   /**
-   * @generated from field: ptera.v1.BattleState battle_state = 1;
+   * @generated from field: string circle_id = 1;
    */
-  battleState?: BattleState;
+  circleId = "";
 
-  constructor(data?: PartialMessage<AttackResponse>) {
+  constructor(data?: PartialMessage<ListMembersRequest>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "ptera.v1.AttackResponse";
+  static readonly typeName = "ptera.v1.ListMembersRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "battle_state", kind: "message", T: BattleState },
+    { no: 1, name: "circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AttackResponse {
-    return new AttackResponse().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListMembersRequest {
+    return new ListMembersRequest().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AttackResponse {
-    return new AttackResponse().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListMembersRequest {
+    return new ListMembersRequest().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AttackResponse {
-    return new AttackResponse().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListMembersRequest {
+    return new ListMembersRequest().fromJsonString(jsonString, options);
   }
 
-  static equals(a: AttackResponse | PlainMessage<AttackResponse> | undefined, b: AttackResponse | PlainMessage<AttackResponse> | undefined): boolean {
-    return proto3.util.equals(AttackResponse, a, b);
+  static equals(a: ListMembersRequest | PlainMessage<ListMembersRequest> | undefined, b: ListMembersRequest | PlainMessage<ListMembersRequest> | undefined): boolean {
+    return proto3.util.equals(ListMembersRequest, a, b);
   }
 }
 
 /**
- * @generated from message ptera.v1.RetreatRequest
+ * @generated from message ptera.v1.ListMembersResponse
  */
-export class RetreatRequest extends Message<RetreatRequest> {
+export class ListMembersResponse extends Message<ListMembersResponse> {
   /**
-   * @generated from field: string battle_id = 1;
+   * @generated from field: repeated ptera.v1.User members = 1;
    */
-  battleId = "";
+  members: User[] = [];
 
-  /**
-   * @generated from field: string player_id = 2;
-   */
-  playerId = "";
+  constructor(data?: PartialMessage<ListMembersResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.ListMembersResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "members", kind: "message", T: User, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListMembersResponse {
+    return new ListMembersResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListMembersResponse {
+    return new ListMembersResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListMembersResponse {
+    return new ListMembersResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListMembersResponse | PlainMessage<ListMembersResponse> | undefined, b: ListMembersResponse | PlainMessage<ListMembersResponse> | undefined): boolean {
+    return proto3.util.equals(ListMembersResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message ptera.v1.GetMeRequest
+ */
+export class GetMeRequest extends Message<GetMeRequest> {
+  constructor(data?: PartialMessage<GetMeRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ptera.v1.GetMeRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetMeRequest {
+    return new GetMeRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetMeRequest {
+    return new GetMeRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetMeRequest {
+    return new GetMeRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetMeRequest | PlainMessage<GetMeRequest> | undefined, b: GetMeRequest | PlainMessage<GetMeRequest> | undefined): boolean {
+    return proto3.util.equals(GetMeRequest, a, b);
+  }
+}
 
+/**
+ * @generated from message ptera.v1.GetUserRequest
+ */
+export class GetUserRequest extends Message<GetUserRequest> {
   /**
-   * @generated from field: int32 bench_index = 3;
+   * @generated from field: string user_id = 1;
    */
-  benchIndex = 0;
+  userId = "";
 
-  constructor(data?: PartialMessage<RetreatRequest>) {
+  constructor(data?: PartialMessage<GetUserRequest>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "ptera.v1.RetreatRequest";
+  static readonly typeName = "ptera.v1.GetUserRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "battle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 2, name: "player_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 3, name: "bench_index", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 1, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RetreatRequest {
-    return new RetreatRequest().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetUserRequest {
+    return new GetUserRequest().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RetreatRequest {
-    return new RetreatRequest().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetUserRequest {
+    return new GetUserRequest().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RetreatRequest {
-    return new RetreatRequest().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetUserRequest {
+    return new GetUserRequest().fromJsonString(jsonString, options);
   }
 
-  static equals(a: RetreatRequest | PlainMessage<RetreatRequest> | undefined, b: RetreatRequest | PlainMessage<RetreatRequest> | undefined): boolean {
-    return proto3.util.equals(RetreatRequest, a, b);
+  static equals(a: GetUserRequest | PlainMessage<GetUserRequest> | undefined, b: GetUserRequest | PlainMessage<GetUserRequest> | undefined): boolean {
+    return proto3.util.equals(GetUserRequest, a, b);
   }
 }
 
 /**
- * @generated from message ptera.v1.RetreatResponse
+ * @generated from message ptera.v1.UpdateProfileRequest
  */
-export class RetreatResponse extends Message<RetreatResponse> {
+export class UpdateProfileRequest extends Message<UpdateProfileRequest> {
   /**
-   * @generated from field: ptera.v1.BattleState battle_state = 1;
+   * 認証情報から取るべきだが、一旦IDを送る
+   *
+   * @generated from field: string user_id = 1;
    */
-  battleState?: BattleState;
+  userId = "";
 
-  constructor(data?: PartialMessage<RetreatResponse>) {
+  /**
+   * @generated from field: optional string name = 2;
+   */
+  name?: string;
+
+  /**
+   * @generated from field: optional string icon_url = 3;
+   */
+  iconUrl?: string;
+
+  /**
+   * @generated from field: optional string bio = 4;
+   */
+  bio?: string;
+
+  constructor(data?: PartialMessage<UpdateProfileRequest>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "ptera.v1.RetreatResponse";
+  static readonly typeName = "ptera.v1.UpdateProfileRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "battle_state", kind: "message", T: BattleState },
+    { no: 1, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 3, name: "icon_url", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 4, name: "bio", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RetreatResponse {
-    return new RetreatResponse().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UpdateProfileRequest {
+    return new UpdateProfileRequest().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RetreatResponse {
-    return new RetreatResponse().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UpdateProfileRequest {
+    return new UpdateProfileRequest().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RetreatResponse {
-    return new RetreatResponse().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UpdateProfileRequest {
+    return new UpdateProfileRequest().fromJsonString(jsonString, options);
   }
 
-  static equals(a: RetreatResponse | PlainMessage<RetreatResponse> | undefined, b: RetreatResponse | PlainMessage<RetreatResponse> | undefined): boolean {
-    return proto3.util.equals(RetreatResponse, a, b);
+  static equals(a: UpdateProfileRequest | PlainMessage<UpdateProfileRequest> | undefined, b: UpdateProfileRequest | PlainMessage<UpdateProfileRequest> | undefined): boolean {
+    return proto3.util.equals(UpdateProfileRequest, a, b);
   }
 }
 
 /**
- * @generated from message ptera.v1.BattleRequest
+ * @generated from message ptera.v1.QuotaUsage
  */
-export class BattleRequest extends Message<BattleRequest> {
+export class QuotaUsage extends Message<QuotaUsage> {
   /**
-   * @generated from field: string request_id = 1;
+   * "user" または "circle"
+   *
+   * @generated from field: string scope = 1;
    */
-  requestId = "";
+  scope = "";
 
   /**
-   * @generated from field: string from_circle_id = 2;
+   * ユーザーIDまたはサークルID
+   *
+   * @generated from field: string subject_id = 2;
    */
-  fromCircleId = "";
+  subjectId = "";
 
   /**
-   * @generated from field: string to_circle_id = 3;
+   * "daily" または "monthly"
+   *
+   * @generated from field: string window = 3;
    */
-  toCircleId = "";
+  window = "";
 
   /**
-   * @generated from field: string from_circle_name = 4;
+   * 集計期間 (例: "2026-10-19", "2026-10")
+   *
+   * @generated from field: string period = 4;
    */
-  fromCircleName = "";
+  period = "";
 
   /**
-   * @generated from field: string to_circle_name = 5;
+   * @generated from field: int64 calls = 5;
    */
-  toCircleName = "";
+  calls = protoInt64.zero;
 
   /**
-   * "pending", "accepted", "rejected"
+   * 0 なら無制限
    *
-   * @generated from field: string status = 6;
+   * @generated from field: int64 call_limit = 6;
    */
-  status = "";
+  callLimit = protoInt64.zero;
 
   /**
-   * @generated from field: google.protobuf.Timestamp created_at = 7;
+   * @generated from field: int64 tokens = 7;
    */
-  createdAt?: Timestamp;
+  tokens = protoInt64.zero;
 
   /**
-   * Set after acceptance
+   * 0 なら無制限
    *
-   * @generated from field: optional string battle_id = 8;
+   * @generated from field: int64 token_limit = 8;
    */
-  battleId?: string;
+  tokenLimit = protoInt64.zero;
 
-  constructor(data?: PartialMessage<BattleRequest>) {
+  /**
+   * @generated from field: google.protobuf.Timestamp resets_at = 9;
+   */
+  resetsAt?: Timestamp;
+
+  constructor(data?: PartialMessage<QuotaUsage>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "ptera.v1.BattleRequest";
+  static readonly typeName = "ptera.v1.QuotaUsage";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "request_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 2, name: "from_circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 3, name: "to_circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 4, name: "from_circle_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 5, name: "to_circle_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 6, name: "status", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 7, name: "created_at", kind: "message", T: Timestamp },
-    { no: 8, name: "battle_id", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 1, name: "scope", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "subject_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "window", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "period", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "calls", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 6, name: "call_limit", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 7, name: "tokens", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 8, name: "token_limit", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 9, name: "resets_at", kind: "message", T: Timestamp },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): BattleRequest {
-    return new BattleRequest().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): QuotaUsage {
+    return new QuotaUsage().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): BattleRequest {
-    return new BattleRequest().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): QuotaUsage {
+    return new QuotaUsage().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): BattleRequest {
-    return new BattleRequest().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): QuotaUsage {
+    return new QuotaUsage().fromJsonString(jsonString, options);
   }
 
-  static equals(a: BattleRequest | PlainMessage<BattleRequest> | undefined, b: BattleRequest | PlainMessage<BattleRequest> | undefined): boolean {
-    return proto3.util.equals(BattleRequest, a, b);
+  static equals(a: QuotaUsage | PlainMessage<QuotaUsage> | undefined, b: QuotaUsage | PlainMessage<QuotaUsage> | undefined): boolean {
+    return proto3.util.equals(QuotaUsage, a, b);
   }
 }
 
 /**
- * @generated from message ptera.v1.SendBattleRequestRequest
+ * @generated from message ptera.v1.GetQuotaUsageRequest
  */
-export class SendBattleRequestRequest extends Message<SendBattleRequestRequest> {
+export class GetQuotaUsageRequest extends Message<GetQuotaUsageRequest> {
   /**
-   * @generated from field: string from_circle_id = 1;
+   * user_id と circle_id のどちらか一方を指定
+   *
+   * @generated from field: string user_id = 2;
    */
-  fromCircleId = "";
+  userId = "";
 
   /**
-   * @generated from field: string to_circle_id = 2;
+   * @generated from field: string circle_id = 3;
    */
-  toCircleId = "";
+  circleId = "";
 
-  constructor(data?: PartialMessage<SendBattleRequestRequest>) {
+  constructor(data?: PartialMessage<GetQuotaUsageRequest>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "ptera.v1.SendBattleRequestRequest";
+  static readonly typeName = "ptera.v1.GetQuotaUsageRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "from_circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 2, name: "to_circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SendBattleRequestRequest {
-    return new SendBattleRequestRequest().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetQuotaUsageRequest {
+    return new GetQuotaUsageRequest().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SendBattleRequestRequest {
-    return new SendBattleRequestRequest().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetQuotaUsageRequest {
+    return new GetQuotaUsageRequest().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SendBattleRequestRequest {
-    return new SendBattleRequestRequest().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetQuotaUsageRequest {
+    return new GetQuotaUsageRequest().fromJsonString(jsonString, options);
   }
 
-  static equals(a: SendBattleRequestRequest | PlainMessage<SendBattleRequestRequest> | undefined, b: SendBattleRequestRequest | PlainMessage<SendBattleRequestRequest> | undefined): boolean {
-    return proto3.util.equals(SendBattleRequestRequest, a, b);
+  static equals(a: GetQuotaUsageRequest | PlainMessage<GetQuotaUsageRequest> | undefined, b: GetQuotaUsageRequest | PlainMessage<GetQuotaUsageRequest> | undefined): boolean {
+    return proto3.util.equals(GetQuotaUsageRequest, a, b);
   }
 }
 
 /**
- * @generated from message ptera.v1.AcceptBattleRequestRequest
+ * @generated from message ptera.v1.GetQuotaUsageResponse
  */
-export class AcceptBattleRequestRequest extends Message<AcceptBattleRequestRequest> {
+export class GetQuotaUsageResponse extends Message<GetQuotaUsageResponse> {
   /**
-   * @generated from field: string request_id = 1;
+   * @generated from field: repeated ptera.v1.QuotaUsage usages = 1;
    */
-  requestId = "";
+  usages: QuotaUsage[] = [];
 
-  constructor(data?: PartialMessage<AcceptBattleRequestRequest>) {
+  constructor(data?: PartialMessage<GetQuotaUsageResponse>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "ptera.v1.AcceptBattleRequestRequest";
+  static readonly typeName = "ptera.v1.GetQuotaUsageResponse";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "request_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 1, name: "usages", kind: "message", T: QuotaUsage, repeated: true },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AcceptBattleRequestRequest {
-    return new AcceptBattleRequestRequest().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetQuotaUsageResponse {
+    return new GetQuotaUsageResponse().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AcceptBattleRequestRequest {
-    return new AcceptBattleRequestRequest().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetQuotaUsageResponse {
+    return new GetQuotaUsageResponse().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AcceptBattleRequestRequest {
-    return new AcceptBattleRequestRequest().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetQuotaUsageResponse {
+    return new GetQuotaUsageResponse().fromJsonString(jsonString, options);
   }
 
-  static equals(a: AcceptBattleRequestRequest | PlainMessage<AcceptBattleRequestRequest> | undefined, b: AcceptBattleRequestRequest | PlainMessage<AcceptBattleRequestRequest> | undefined): boolean {
-    return proto3.util.equals(AcceptBattleRequestRequest, a, b);
+  static equals(a: GetQuotaUsageResponse | PlainMessage<GetQuotaUsageResponse> | undefined, b: GetQuotaUsageResponse | PlainMessage<GetQuotaUsageResponse> | undefined): boolean {
+    return proto3.util.equals(GetQuotaUsageResponse, a, b);
   }
 }
 
 /**
- * @generated from message ptera.v1.RejectBattleRequestRequest
+ * @generated from message ptera.v1.ResetQuotaUsageRequest
  */
-export class RejectBattleRequestRequest extends Message<RejectBattleRequestRequest> {
+export class ResetQuotaUsageRequest extends Message<ResetQuotaUsageRequest> {
   /**
-   * @generated from field: string request_id = 1;
+   * user_id と circle_id のどちらか一方を指定
+   *
+   * @generated from field: string user_id = 2;
    */
-  requestId = "";
+  userId = "";
 
-  constructor(data?: PartialMessage<RejectBattleRequestRequest>) {
+  /**
+   * @generated from field: string circle_id = 3;
+   */
+  circleId = "";
+
+  /**
+   * "daily" または "monthly" (空の場合は両方)
+   *
+   * @generated from field: string window = 4;
+   */
+  window = "";
+
+  constructor(data?: PartialMessage<ResetQuotaUsageRequest>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "ptera.v1.RejectBattleRequestRequest";
+  static readonly typeName = "ptera.v1.ResetQuotaUsageRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "request_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "circle_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "window", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RejectBattleRequestRequest {
-    return new RejectBattleRequestRequest().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ResetQuotaUsageRequest {
+    return new ResetQuotaUsageRequest().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RejectBattleRequestRequest {
-    return new RejectBattleRequestRequest().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ResetQuotaUsageRequest {
+    return new ResetQuotaUsageRequest().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RejectBattleRequestRequest {
-    return new RejectBattleRequestRequest().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ResetQuotaUsageRequest {
+    return new ResetQuotaUsageRequest().fromJsonString(jsonString, options);
   }
 
-  static equals(a: RejectBattleRequestRequest | PlainMessage<RejectBattleRequestRequest> | undefined, b: RejectBattleRequestRequest | PlainMessage<RejectBattleRequestRequest> | undefined): boolean {
-    return proto3.util.equals(RejectBattleRequestRequest, a, b);
+  static equals(a: ResetQuotaUsageRequest | PlainMessage<ResetQuotaUsageRequest> | undefined, b: ResetQuotaUsageRequest | PlainMessage<ResetQuotaUsageRequest> | undefined): boolean {
+    return proto3.util.equals(ResetQuotaUsageRequest, a, b);
   }
 }
 
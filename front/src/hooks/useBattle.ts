@@ -101,23 +101,26 @@ export const useBattle = () => {
   );
 
   // バトル申請承認
-  const acceptRequest = useCallback(async (requestId: string) => {
-    if (processingRef.current) return null;
-    processingRef.current = true;
-    setLoading(true);
-    setError(null);
-    try {
-      const result = await acceptBattleRequestAction(requestId);
-      return result.battleId;
-    } catch (e) {
-      console.error("Failed to accept request:", e);
-      setError("承認に失敗しました");
-      return null;
-    } finally {
-      processingRef.current = false;
-      setLoading(false);
-    }
-  }, []);
+  const acceptRequest = useCallback(
+    async (requestId: string, myCircleId: string) => {
+      if (processingRef.current) return null;
+      processingRef.current = true;
+      setLoading(true);
+      setError(null);
+      try {
+        const result = await acceptBattleRequestAction(requestId, myCircleId);
+        return result.battleId;
+      } catch (e) {
+        console.error("Failed to accept request:", e);
+        setError("承認に失敗しました");
+        return null;
+      } finally {
+        processingRef.current = false;
+        setLoading(false);
+      }
+    },
+    [],
+  );
 
   // バトル申請拒否
   const rejectRequest = useCallback(async (requestId: string) => {
@@ -2,6 +2,7 @@ import { createPromiseClient } from "@connectrpc/connect";
 import { createGrpcTransport } from "@connectrpc/connect-node";
 import {
   BattleService,
+  FairnessService,
   PteraService,
 } from "@/generated/ptera/v1/ptera_connect";
 
@@ -18,3 +19,4 @@ const transport = createGrpcTransport({
 // Create the client
 export const pteraClient = createPromiseClient(PteraService, transport);
 export const battleClient = createPromiseClient(BattleService, transport);
+export const fairnessClient = createPromiseClient(FairnessService, transport);